@@ -0,0 +1,192 @@
+package appsec
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/networklists"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+)
+
+// Values of the pre_activation_checks setting of akamai_appsec_activations.
+const (
+	activationChecksNone  = "none"
+	activationChecksWarn  = "warn"
+	activationChecksError = "error"
+)
+
+const (
+	// networkListActive is the status of a network list whose latest sync point is active on a network
+	networkListActive = "ACTIVE"
+
+	// networkListActivated is the status of a completed network list activation
+	networkListActivated = "ACTIVATED"
+)
+
+var (
+	// networkListActivationFailures are the statuses of network list activations which ended without activating the list
+	networkListActivationFailures = map[string]bool{
+		"FAILED":      true,
+		"ABORTED":     true,
+		"DEACTIVATED": true,
+	}
+)
+
+var (
+	// EvalExpiryWarningPeriod is how long before its evaluation expires a security policy is reported by pre-activation checks
+	EvalExpiryWarningPeriod = 7 * 24 * time.Hour
+)
+
+// activationChecker verifies that a security configuration version is ready to be activated on a network.
+type activationChecker struct {
+	client             appsec.APPSEC
+	networkListsClient networklists.NTWRKLISTS
+	configID           int
+	version            int
+	network            string
+
+	// activateNetworkLists activates the referenced network lists that aren't active instead of reporting them
+	activateNetworkLists bool
+	comments             string
+	notificationEmails   []string
+
+	// networkListsOnly skips the checks of security policies
+	networkListsOnly bool
+}
+
+// check returns the issues found in the configuration version, one message each.
+func (c activationChecker) check(ctx context.Context) ([]string, error) {
+	export, err := c.client.GetExportConfiguration(ctx, appsec.GetExportConfigurationRequest{ConfigID: c.configID, Version: c.version})
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []string
+	for _, id := range activationNetworkLists(export) {
+		status, err := c.networkListsClient.GetActivations(ctx, networklists.GetActivationsRequest{UniqueID: id, Network: c.network})
+		if err != nil {
+			return nil, err
+		}
+		if status.ActivationStatus == networkListActive {
+			continue
+		}
+		if !c.activateNetworkLists {
+			issues = append(issues, fmt.Sprintf("network list %s is %s on %s", id, status.ActivationStatus, c.network))
+			continue
+		}
+		if err := c.activateNetworkList(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.networkListsOnly {
+		return issues, nil
+	}
+	for _, p := range export.SecurityPolicies {
+		wafMode, err := c.client.GetWAFMode(ctx, appsec.GetWAFModeRequest{ConfigID: c.configID, Version: c.version, PolicyID: p.ID})
+		if err != nil {
+			return nil, err
+		}
+		if wafMode.Expires != "" {
+			expires, err := time.Parse(time.RFC3339, wafMode.Expires)
+			if err == nil && time.Until(expires) < EvalExpiryWarningPeriod {
+				issues = append(issues, fmt.Sprintf("evaluation in security policy %s expires at %s", p.ID, wafMode.Expires))
+			}
+		}
+		if wafMode.Mode != KRS {
+			continue
+		}
+		upgrade, err := c.client.GetRuleUpgrade(ctx, appsec.GetRuleUpgradeRequest{ConfigID: c.configID, Version: c.version, PolicyID: p.ID})
+		if err != nil {
+			return nil, err
+		}
+		if upgrade.Latest != "" && upgrade.Current != upgrade.Latest {
+			issues = append(issues, fmt.Sprintf("rule upgrade from %s to %s is pending in security policy %s", upgrade.Current, upgrade.Latest, p.ID))
+		}
+	}
+
+	return issues, nil
+}
+
+// activateNetworkList activates a network list on the checked network and waits for the activation to complete.
+func (c activationChecker) activateNetworkList(ctx context.Context, id string) error {
+	response, err := c.networkListsClient.CreateActivations(ctx, networklists.CreateActivationsRequest{
+		UniqueID:               id,
+		Network:                c.network,
+		Action:                 "ACTIVATE",
+		Comments:               c.comments,
+		NotificationRecipients: c.notificationEmails,
+	})
+	if err != nil {
+		return err
+	}
+
+	request := networklists.GetActivationRequest{ActivationID: response.ActivationID}
+	activation, err := c.networkListsClient.GetActivation(ctx, request)
+	if err != nil {
+		return err
+	}
+	for activation.ActivationStatus != networkListActivated {
+		if networkListActivationFailures[activation.ActivationStatus] {
+			return fmt.Errorf("network list %s activation %d ended with status %s", id, activation.ActivationID, activation.ActivationStatus)
+		}
+		select {
+		case <-time.After(tools.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
+			act, err := c.networkListsClient.GetActivation(ctx, request)
+			if err != nil {
+				return err
+			}
+			activation = act
+
+		case <-ctx.Done():
+			return fmt.Errorf("network list %s activation context terminated: %s", id, ctx.Err())
+		}
+	}
+	return nil
+}
+
+// activationNetworkLists returns the IDs of the network lists referenced by IP/Geo firewalls and match target bypass lists.
+func activationNetworkLists(export *appsec.GetExportConfigurationResponse) []string {
+	ids := map[string]struct{}{}
+	add := func(lists *appsec.IPGeoNetworkLists) {
+		if lists == nil {
+			return
+		}
+		for _, id := range lists.NetworkList {
+			ids[id] = struct{}{}
+		}
+	}
+
+	for _, p := range export.SecurityPolicies {
+		if p.IPGeoFirewall == nil {
+			continue
+		}
+		if p.IPGeoFirewall.GeoControls != nil {
+			add(p.IPGeoFirewall.GeoControls.BlockedIPNetworkLists)
+		}
+		if p.IPGeoFirewall.IPControls != nil {
+			add(p.IPGeoFirewall.IPControls.AllowedIPNetworkLists)
+			add(p.IPGeoFirewall.IPControls.BlockedIPNetworkLists)
+		}
+	}
+	for _, t := range export.MatchTargets.WebsiteTargets {
+		for _, l := range t.BypassNetworkLists {
+			ids[l.ID] = struct{}{}
+		}
+	}
+	for _, t := range export.MatchTargets.APITargets {
+		for _, l := range t.BypassNetworkLists {
+			ids[l.ID] = struct{}{}
+		}
+	}
+
+	result := make([]string, 0, len(ids))
+	for id := range ids {
+		result = append(result, id)
+	}
+	sort.Strings(result)
+	return result
+}
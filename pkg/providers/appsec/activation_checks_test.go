@@ -0,0 +1,153 @@
+package appsec
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/networklists"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func mockActivationChecksExport(t *testing.T, client *appsec.Mock) {
+	export := appsec.GetExportConfigurationResponse{}
+	err := json.Unmarshal(loadFixtureBytes("testdata/TestResActivations/ExportConfiguration.json"), &export)
+	require.NoError(t, err)
+
+	client.On("GetExportConfiguration",
+		mock.Anything,
+		appsec.GetExportConfigurationRequest{ConfigID: 43253, Version: 7},
+	).Return(&export, nil)
+}
+
+func mockNetworkListStatus(client *networklists.Mock, id, status string) {
+	client.On("GetActivations",
+		mock.Anything,
+		networklists.GetActivationsRequest{UniqueID: id, Network: "STAGING"},
+	).Return(&networklists.GetActivationsResponse{UniqueID: id, ActivationStatus: status}, nil)
+}
+
+func TestActivationNetworkLists(t *testing.T) {
+	export := appsec.GetExportConfigurationResponse{}
+	err := json.Unmarshal(loadFixtureBytes("testdata/TestResActivations/ExportConfiguration.json"), &export)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"1024_BLOCK", "1304_GEO", "1410_BYPASS"}, activationNetworkLists(&export))
+}
+
+func TestActivationChecker(t *testing.T) {
+	expires := time.Now().Add(48 * time.Hour).UTC().Format(time.RFC3339)
+
+	t.Run("report issues", func(t *testing.T) {
+		client := &appsec.Mock{}
+		networkListsClient := &networklists.Mock{}
+		mockActivationChecksExport(t, client)
+		mockNetworkListStatus(networkListsClient, "1024_BLOCK", "ACTIVE")
+		mockNetworkListStatus(networkListsClient, "1304_GEO", "INACTIVE")
+		mockNetworkListStatus(networkListsClient, "1410_BYPASS", "MODIFIED")
+
+		client.On("GetWAFMode",
+			mock.Anything,
+			appsec.GetWAFModeRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230"},
+		).Return(&appsec.GetWAFModeResponse{Mode: KRS, Expires: expires}, nil)
+		client.On("GetRuleUpgrade",
+			mock.Anything,
+			appsec.GetRuleUpgradeRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230"},
+		).Return(&appsec.GetRuleUpgradeResponse{Current: "3.0", Latest: "3.1"}, nil)
+		client.On("GetWAFMode",
+			mock.Anything,
+			appsec.GetWAFModeRequest{ConfigID: 43253, Version: 7, PolicyID: "BBBB_1"},
+		).Return(&appsec.GetWAFModeResponse{Mode: AseAuto}, nil)
+
+		checker := activationChecker{client: client, networkListsClient: networkListsClient, configID: 43253, version: 7, network: "STAGING"}
+		issues, err := checker.check(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"network list 1304_GEO is INACTIVE on STAGING",
+			"network list 1410_BYPASS is MODIFIED on STAGING",
+			"evaluation in security policy AAAA_81230 expires at " + expires,
+			"rule upgrade from 3.0 to 3.1 is pending in security policy AAAA_81230",
+		}, issues)
+
+		client.AssertExpectations(t)
+		networkListsClient.AssertExpectations(t)
+	})
+
+	t.Run("activate network lists", func(t *testing.T) {
+		client := &appsec.Mock{}
+		networkListsClient := &networklists.Mock{}
+		mockActivationChecksExport(t, client)
+		mockNetworkListStatus(networkListsClient, "1024_BLOCK", "ACTIVE")
+		mockNetworkListStatus(networkListsClient, "1304_GEO", "INACTIVE")
+		mockNetworkListStatus(networkListsClient, "1410_BYPASS", "ACTIVE")
+
+		networkListsClient.On("CreateActivations",
+			mock.Anything,
+			networklists.CreateActivationsRequest{
+				UniqueID:               "1304_GEO",
+				Network:                "STAGING",
+				Action:                 "ACTIVATE",
+				Comments:               "TEST Notes",
+				NotificationRecipients: []string{"martin@email.io"},
+			},
+		).Return(&networklists.CreateActivationsResponse{ActivationID: 12345}, nil)
+		networkListsClient.On("GetActivation",
+			mock.Anything,
+			networklists.GetActivationRequest{ActivationID: 12345},
+		).Return(&networklists.GetActivationResponse{ActivationID: 12345, ActivationStatus: "ACTIVATED"}, nil)
+
+		checker := activationChecker{
+			client:               client,
+			networkListsClient:   networkListsClient,
+			configID:             43253,
+			version:              7,
+			network:              "STAGING",
+			activateNetworkLists: true,
+			comments:             "TEST Notes",
+			notificationEmails:   []string{"martin@email.io"},
+			networkListsOnly:     true,
+		}
+		issues, err := checker.check(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, issues)
+
+		client.AssertExpectations(t)
+		networkListsClient.AssertExpectations(t)
+	})
+
+	t.Run("failed network list activation", func(t *testing.T) {
+		client := &appsec.Mock{}
+		networkListsClient := &networklists.Mock{}
+		mockActivationChecksExport(t, client)
+		mockNetworkListStatus(networkListsClient, "1024_BLOCK", "ACTIVE")
+		mockNetworkListStatus(networkListsClient, "1304_GEO", "INACTIVE")
+
+		networkListsClient.On("CreateActivations",
+			mock.Anything,
+			networklists.CreateActivationsRequest{UniqueID: "1304_GEO", Network: "STAGING", Action: "ACTIVATE"},
+		).Return(&networklists.CreateActivationsResponse{ActivationID: 12345}, nil)
+		networkListsClient.On("GetActivation",
+			mock.Anything,
+			networklists.GetActivationRequest{ActivationID: 12345},
+		).Return(&networklists.GetActivationResponse{ActivationID: 12345, ActivationStatus: "FAILED"}, nil).Once()
+
+		checker := activationChecker{
+			client:               client,
+			networkListsClient:   networkListsClient,
+			configID:             43253,
+			version:              7,
+			network:              "STAGING",
+			activateNetworkLists: true,
+			networkListsOnly:     true,
+		}
+		_, err := checker.check(context.Background())
+		assert.EqualError(t, err, "network list 1304_GEO activation 12345 ended with status FAILED")
+
+		client.AssertExpectations(t)
+		networkListsClient.AssertExpectations(t)
+	})
+}
@@ -4,110 +4,216 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"sync"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
 	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/apex/log"
 )
 
 // Utility functions for determining current and latest versions of a security
 // configuration, and for identifying a modifiable (editable) version.
 
 var (
-	configCloneMutex   sync.Mutex
 	latestVersionMutex sync.Mutex
 	// GetModifiableConfigVersion returns the number of the latest editable version
 	// of the given security configuration. If the most recent version is not editable
 	// (because it is active in staging or production) a new version is cloned and the
 	// new version's number is returned. API calls are made using the supplied context
-	// and the API client obtained from m. Log messages are written to m's logger. A
-	// mutex prevents calls made by multiple resources from creating unnecessary clones.
+	// and the API client obtained from m. Log messages are written to m's logger. The
+	// version is resolved once per configuration and provider run, so that calls made
+	// by multiple resources don't create unnecessary clones.
 	GetModifiableConfigVersion = getModifiableConfigVersion
 	// GetLatestConfigVersion returns the latest version number of the given security
 	// configuration. API calls are made using the supplied context and the API client
 	// obtained from m. Log messages are written to m's logger.
 	GetLatestConfigVersion = getLatestConfigVersion
+
+	// ConfigVersionConflictRetries is how many times a version conflict is retried
+	ConfigVersionConflictRetries = 3
+	// ConfigVersionConflictRetryInterval is the time waited before retrying a version conflict
+	ConfigVersionConflictRetryInterval = 5 * time.Second
+
+	configVersions = newConfigVersionCoordinator()
 )
 
-// getModifiableConfigVersion returns the number of the latest editable version
-// of the given security configuration. If the most recent version is not editable
-// (because it is active in staging or production) a new version is cloned and the
-// new version's number is returned. API calls are made using the supplied context
-// and the API client obtained from m. Log messages are written to m's logger. A
-// mutex prevents calls made by multiple resources from creating unnecessary clones.
-func getModifiableConfigVersion(ctx context.Context, configID int, resource string, m interface{}) (int, error) {
-	meta := akamai.Meta(m)
-	client := inst.Client(meta)
-	logger := meta.Log("APPSEC", "getModifiableConfigVersion")
+// configVersionRetention is how long the editable version of a configuration is kept after it was last
+// used. The provider isn't told when a run ends, so the versions of other runs are dropped once they're
+// unused for that long.
+const configVersionRetention = time.Hour
 
-	// If the version info is in the cache, return it immediately.
-	cacheKey := fmt.Sprintf("%s:%d", "getModifiableConfigVersion", configID)
-	configuration := &appsec.GetConfigurationResponse{}
-	if err := meta.CacheGet(inst, cacheKey, configuration); err == nil {
-		logger.Debugf("Resource %s returning modifiable version %d from cache", resource, configuration.LatestVersion)
-		return configuration.LatestVersion, nil
+type (
+	// configVersionCoordinator resolves the editable version of security configurations for
+	// concurrent resources. Each configuration has its own lock, so that resources of different
+	// configurations don't wait for each other, and its editable version is kept in memory for
+	// the provider run, independently of the provider cache.
+	configVersionCoordinator struct {
+		mu       sync.Mutex
+		versions map[configVersionKey]*configVersionState
+		now      func() time.Time
 	}
 
-	logger.Debugf("Resource %s requesting mutex lock", resource)
-	configCloneMutex.Lock()
-	defer func() {
-		logger.Debugf("Resource %s releasing mutex lock", resource)
-		configCloneMutex.Unlock()
-	}()
+	configVersionKey struct {
+		run      string
+		configID int
+	}
 
-	// If the version info is in the cache, return it immediately.
-	err := meta.CacheGet(inst, cacheKey, configuration)
-	if err == nil {
-		logger.Debugf("Resource %s returning modifiable version %d from cache", resource, configuration.LatestVersion)
-		return configuration.LatestVersion, nil
+	configVersionState struct {
+		sync.Mutex
+		// version is the editable version, or 0 if it isn't resolved yet
+		version int
+		// used is when the state was last returned, guarded by the coordinator's lock
+		used time.Time
 	}
-	// Any error response other than 'not found' or 'cache disabled' is a problem.
-	if !akamai.IsNotFoundError(err) && !errors.Is(err, akamai.ErrCacheDisabled) {
-		logger.Errorf("error reading from cache: %s", err.Error())
-		return 0, err
+)
+
+func newConfigVersionCoordinator() *configVersionCoordinator {
+	return &configVersionCoordinator{versions: map[configVersionKey]*configVersionState{}, now: time.Now}
+}
+
+// state returns the state of a configuration in the given provider run. The states of other runs
+// unused for longer than configVersionRetention are dropped.
+func (c *configVersionCoordinator) state(run string, configID int) *configVersionState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for key, s := range c.versions {
+		if key.run != run && now.Sub(s.used) > configVersionRetention {
+			delete(c.versions, key)
+		}
 	}
 
-	// Check whether the latest version is active in staging or production
-	logger.Debugf("Resource %s calling GetConfigurations", resource)
-	configuration, err = client.GetConfiguration(ctx, appsec.GetConfigurationRequest{
-		ConfigID: configID,
-	})
-	if err != nil {
-		logger.Errorf("error calling 'getConfiguration': %s", err.Error())
-		return 0, err
+	key := configVersionKey{run: run, configID: configID}
+	s, ok := c.versions[key]
+	if !ok {
+		s = &configVersionState{}
+		c.versions[key] = s
+	}
+	s.used = now
+	return s
+}
+
+// modifiableVersion returns the editable version of the configuration, cloning the latest version
+// if it's active. Only the first caller for a configuration makes API calls; concurrent callers wait
+// for it and get the same version. A clone that conflicts with a version created concurrently by
+// another client is retried.
+func (c *configVersionCoordinator) modifiableVersion(ctx context.Context, client appsec.APPSEC, logger log.Interface, run string, configID int, resource string) (int, error) {
+	s := c.state(run, configID)
+
+	logger.Debugf("Resource %s waiting for configuration %d", resource, configID)
+	s.Lock()
+	defer s.Unlock()
+
+	if s.version != 0 {
+		logger.Debugf("Resource %s returning modifiable version %d", resource, s.version)
+		return s.version, nil
 	}
-	latestVersion := configuration.LatestVersion
-	stagingVersion := configuration.StagingVersion
-	productionVersion := configuration.ProductionVersion
-	if latestVersion != stagingVersion && latestVersion != productionVersion {
-		if err := meta.CacheSet(inst, cacheKey, configuration); err != nil {
-			if !errors.Is(err, akamai.ErrCacheDisabled) {
-				logger.Errorf("unable to set latestVersion %d into cache")
-			}
+
+	for attempt := 0; ; attempt++ {
+		logger.Debugf("Resource %s calling GetConfiguration", resource)
+		configuration, err := client.GetConfiguration(ctx, appsec.GetConfigurationRequest{ConfigID: configID})
+		if err != nil {
+			logger.Errorf("error calling 'getConfiguration': %s", err.Error())
+			return 0, err
+		}
+		latestVersion := configuration.LatestVersion
+		if latestVersion != configuration.StagingVersion && latestVersion != configuration.ProductionVersion {
+			logger.Debugf("Resource %s returning latestVersion %d (staging version %d, production version %d)",
+				resource, latestVersion, configuration.StagingVersion, configuration.ProductionVersion)
+			s.version = latestVersion
+			return latestVersion, nil
+		}
+
+		// Latest version is active, so need to clone a new version
+		logger.Debugf("Resource %s cloning configuration version %d", resource, latestVersion)
+		ccr, err := client.CreateConfigurationVersionClone(ctx, appsec.CreateConfigurationVersionCloneRequest{
+			ConfigID:          configID,
+			CreateFromVersion: latestVersion,
+		})
+		if err == nil {
+			logger.Debugf("Resource %s returning new cloned version %d as modifiable version", resource, ccr.Version)
+			s.version = ccr.Version
+			return ccr.Version, nil
+		}
+		if !isConfigVersionConflict(err) || attempt >= ConfigVersionConflictRetries {
+			logger.Errorf("error calling 'createConfigurationVersionClone': %s", err.Error())
+			return 0, err
+		}
+		logger.Warnf("Resource %s cloning version %d of configuration %d conflicted, retrying: %s", resource, latestVersion, configID, err.Error())
+		if err := waitConfigVersionConflict(ctx); err != nil {
+			return 0, err
 		}
-		logger.Debugf("Resource %s caching and returning latestVersion %d (staging version %d, production version %d)",
-			resource, latestVersion, stagingVersion, productionVersion)
-		return latestVersion, nil
 	}
+}
 
-	// Latest version is active, so need to clone a new version
-	logger.Debugf("Resource %s cloning configuration version %d", resource, latestVersion)
-	ccr, err := client.CreateConfigurationVersionClone(ctx, appsec.CreateConfigurationVersionCloneRequest{
-		ConfigID:          configID,
-		CreateFromVersion: latestVersion,
-	})
-	if err != nil {
-		logger.Errorf("error calling 'createConfigurationVersionClone': %s", err.Error())
-		return 0, err
+// forget drops the editable version of the configuration, so that the next caller resolves it again.
+// It's called when the version may no longer be editable, such as after it's activated.
+func (c *configVersionCoordinator) forget(run string, configID int) {
+	s := c.state(run, configID)
+	s.Lock()
+	defer s.Unlock()
+	s.version = 0
+}
+
+// isConfigVersionConflict returns whether err reports that a configuration version was changed
+// concurrently or is no longer editable.
+func isConfigVersionConflict(err error) bool {
+	var apiErr *appsec.Error
+	if !errors.As(err, &apiErr) {
+		return false
 	}
+	return apiErr.StatusCode == http.StatusConflict || apiErr.StatusCode == http.StatusPreconditionFailed
+}
 
-	configuration.LatestVersion = ccr.Version
-	if err := meta.CacheSet(inst, cacheKey, configuration); err != nil && !errors.Is(err, akamai.ErrCacheDisabled) {
-		logger.Errorf("unable to set latestVersion %d into cache: %s", err.Error())
+func waitConfigVersionConflict(ctx context.Context) error {
+	select {
+	case <-time.After(ConfigVersionConflictRetryInterval):
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("retrying version conflict: %w", ctx.Err())
 	}
+}
 
-	logger.Debugf("Resource %s caching and returning new cloned version %d as modifiable version", ccr.Version)
-	return ccr.Version, nil
+// getModifiableConfigVersion returns the number of the latest editable version
+// of the given security configuration. If the most recent version is not editable
+// (because it is active in staging or production) a new version is cloned and the
+// new version's number is returned. API calls are made using the supplied context
+// and the API client obtained from m. Log messages are written to m's logger. The
+// version is resolved once per configuration and provider run, so that calls made
+// by multiple resources don't create unnecessary clones.
+func getModifiableConfigVersion(ctx context.Context, configID int, resource string, m interface{}) (int, error) {
+	meta := akamai.Meta(m)
+	client := inst.Client(meta)
+	logger := meta.Log("APPSEC", "getModifiableConfigVersion")
+
+	return configVersions.modifiableVersion(ctx, client, logger, meta.OperationID(), configID, resource)
+}
+
+// updateModifiableConfigVersion calls update with the editable version of the given security
+// configuration. If update fails with a version conflict, the editable version is resolved
+// again and update is retried, so update must be safe to repeat.
+func updateModifiableConfigVersion(ctx context.Context, configID int, resource string, m interface{}, update func(version int) error) error {
+	meta := akamai.Meta(m)
+	logger := meta.Log("APPSEC", "updateModifiableConfigVersion")
+
+	for attempt := 0; ; attempt++ {
+		version, err := getModifiableConfigVersion(ctx, configID, resource, m)
+		if err != nil {
+			return err
+		}
+		err = update(version)
+		if err == nil || !isConfigVersionConflict(err) || attempt >= ConfigVersionConflictRetries {
+			return err
+		}
+		logger.Warnf("Resource %s updating version %d of configuration %d conflicted, retrying: %s", resource, version, configID, err.Error())
+		configVersions.forget(meta.OperationID(), configID)
+		if err := waitConfigVersionConflict(ctx); err != nil {
+			return err
+		}
+	}
 }
 
 // getLatestConfigVersion returns the latest version number of the given security
@@ -0,0 +1,159 @@
+package appsec
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigVersionCoordinator(t *testing.T) {
+	logger := akamai.Log("APPSEC", "TestConfigVersionCoordinator")
+	conflict := &appsec.Error{Type: "conflict", Title: "Version conflict", StatusCode: http.StatusConflict}
+
+	defer func(interval time.Duration) { ConfigVersionConflictRetryInterval = interval }(ConfigVersionConflictRetryInterval)
+	ConfigVersionConflictRetryInterval = time.Millisecond
+
+	t.Run("concurrent resources clone each configuration once", func(t *testing.T) {
+		client := &appsec.Mock{}
+		client.On("GetConfiguration", mock.Anything, appsec.GetConfigurationRequest{ConfigID: 1}).
+			Return(&appsec.GetConfigurationResponse{ID: 1, LatestVersion: 3, StagingVersion: 3}, nil).Once()
+		client.On("CreateConfigurationVersionClone", mock.Anything, appsec.CreateConfigurationVersionCloneRequest{ConfigID: 1, CreateFromVersion: 3}).
+			Return(&appsec.CreateConfigurationVersionCloneResponse{ConfigID: 1, Version: 4}, nil).Once()
+		client.On("GetConfiguration", mock.Anything, appsec.GetConfigurationRequest{ConfigID: 2}).
+			Return(&appsec.GetConfigurationResponse{ID: 2, LatestVersion: 8, ProductionVersion: 7}, nil).Once()
+
+		coordinator := newConfigVersionCoordinator()
+		versions := make([]int, 20)
+		var wg sync.WaitGroup
+		for i := range versions {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				version, err := coordinator.modifiableVersion(context.Background(), client, logger, "run", 1+i%2, "resource")
+				assert.NoError(t, err)
+				versions[i] = version
+			}(i)
+		}
+		wg.Wait()
+
+		for i, version := range versions {
+			if i%2 == 0 {
+				assert.Equal(t, 4, version)
+			} else {
+				assert.Equal(t, 8, version)
+			}
+		}
+		client.AssertExpectations(t)
+	})
+
+	t.Run("provider runs don't share versions", func(t *testing.T) {
+		client := &appsec.Mock{}
+		client.On("GetConfiguration", mock.Anything, appsec.GetConfigurationRequest{ConfigID: 1}).
+			Return(&appsec.GetConfigurationResponse{ID: 1, LatestVersion: 5}, nil).Twice()
+
+		coordinator := newConfigVersionCoordinator()
+		for _, run := range []string{"first", "second", "first"} {
+			version, err := coordinator.modifiableVersion(context.Background(), client, logger, run, 1, "resource")
+			require.NoError(t, err)
+			assert.Equal(t, 5, version)
+		}
+		client.AssertExpectations(t)
+	})
+
+	t.Run("conflicting clone is retried", func(t *testing.T) {
+		client := &appsec.Mock{}
+		client.On("GetConfiguration", mock.Anything, appsec.GetConfigurationRequest{ConfigID: 1}).
+			Return(&appsec.GetConfigurationResponse{ID: 1, LatestVersion: 3, StagingVersion: 3}, nil).Once()
+		client.On("CreateConfigurationVersionClone", mock.Anything, appsec.CreateConfigurationVersionCloneRequest{ConfigID: 1, CreateFromVersion: 3}).
+			Return(nil, conflict).Once()
+		client.On("GetConfiguration", mock.Anything, appsec.GetConfigurationRequest{ConfigID: 1}).
+			Return(&appsec.GetConfigurationResponse{ID: 1, LatestVersion: 4, StagingVersion: 3}, nil).Once()
+
+		version, err := newConfigVersionCoordinator().modifiableVersion(context.Background(), client, logger, "run", 1, "resource")
+		require.NoError(t, err)
+		assert.Equal(t, 4, version)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("conflicts stop after the retries", func(t *testing.T) {
+		client := &appsec.Mock{}
+		client.On("GetConfiguration", mock.Anything, appsec.GetConfigurationRequest{ConfigID: 1}).
+			Return(&appsec.GetConfigurationResponse{ID: 1, LatestVersion: 3, ProductionVersion: 3}, nil)
+		client.On("CreateConfigurationVersionClone", mock.Anything, appsec.CreateConfigurationVersionCloneRequest{ConfigID: 1, CreateFromVersion: 3}).
+			Return(nil, conflict).Times(ConfigVersionConflictRetries + 1)
+
+		_, err := newConfigVersionCoordinator().modifiableVersion(context.Background(), client, logger, "run", 1, "resource")
+		assert.ErrorIs(t, err, conflict)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("other errors aren't retried", func(t *testing.T) {
+		client := &appsec.Mock{}
+		client.On("GetConfiguration", mock.Anything, appsec.GetConfigurationRequest{ConfigID: 1}).
+			Return(&appsec.GetConfigurationResponse{ID: 1, LatestVersion: 3, ProductionVersion: 3}, nil).Once()
+		client.On("CreateConfigurationVersionClone", mock.Anything, appsec.CreateConfigurationVersionCloneRequest{ConfigID: 1, CreateFromVersion: 3}).
+			Return(nil, &appsec.Error{Title: "Forbidden", StatusCode: http.StatusForbidden}).Once()
+
+		_, err := newConfigVersionCoordinator().modifiableVersion(context.Background(), client, logger, "run", 1, "resource")
+		assert.Error(t, err)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("forgotten version is resolved again", func(t *testing.T) {
+		client := &appsec.Mock{}
+		client.On("GetConfiguration", mock.Anything, appsec.GetConfigurationRequest{ConfigID: 1}).
+			Return(&appsec.GetConfigurationResponse{ID: 1, LatestVersion: 5}, nil).Once()
+		client.On("GetConfiguration", mock.Anything, appsec.GetConfigurationRequest{ConfigID: 1}).
+			Return(&appsec.GetConfigurationResponse{ID: 1, LatestVersion: 5, StagingVersion: 5}, nil).Once()
+		client.On("CreateConfigurationVersionClone", mock.Anything, appsec.CreateConfigurationVersionCloneRequest{ConfigID: 1, CreateFromVersion: 5}).
+			Return(&appsec.CreateConfigurationVersionCloneResponse{ConfigID: 1, Version: 6}, nil).Once()
+
+		coordinator := newConfigVersionCoordinator()
+		version, err := coordinator.modifiableVersion(context.Background(), client, logger, "run", 1, "resource")
+		require.NoError(t, err)
+		assert.Equal(t, 5, version)
+
+		coordinator.forget("run", 1)
+		version, err = coordinator.modifiableVersion(context.Background(), client, logger, "run", 1, "resource")
+		require.NoError(t, err)
+		assert.Equal(t, 6, version)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("unused versions of other runs are dropped", func(t *testing.T) {
+		now := time.Now()
+		coordinator := newConfigVersionCoordinator()
+		coordinator.now = func() time.Time { return now }
+
+		coordinator.state("first", 1)
+		coordinator.state("first", 2)
+		now = now.Add(configVersionRetention / 2)
+		coordinator.state("first", 2)
+		now = now.Add(configVersionRetention)
+		coordinator.state("second", 1)
+
+		assert.Equal(t, map[configVersionKey]*configVersionState{
+			{run: "first", configID: 2}:  {used: now.Add(-configVersionRetention)},
+			{run: "second", configID: 1}: {used: now},
+		}, coordinator.versions)
+
+		now = now.Add(configVersionRetention * 2)
+		coordinator.state("second", 1)
+		assert.Len(t, coordinator.versions, 1)
+	})
+}
+
+func TestIsConfigVersionConflict(t *testing.T) {
+	assert.True(t, isConfigVersionConflict(&appsec.Error{StatusCode: http.StatusConflict}))
+	assert.True(t, isConfigVersionConflict(&appsec.Error{StatusCode: http.StatusPreconditionFailed}))
+	assert.False(t, isConfigVersionConflict(&appsec.Error{StatusCode: http.StatusBadRequest}))
+	assert.False(t, isConfigVersionConflict(context.Canceled))
+}
@@ -0,0 +1,712 @@
+package appsec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+)
+
+// Kinds of configuration objects managed by akamai_appsec_configuration_document.
+const (
+	docSelectedHosts           = "selected_hosts"
+	docSecurityPolicy          = "security_policy"
+	docCustomRule              = "custom_rule"
+	docRatePolicy              = "rate_policy"
+	docReputationProfile       = "reputation_profile"
+	docMatchTarget             = "match_target"
+	docCustomRuleAction        = "custom_rule_action"
+	docRatePolicyAction        = "rate_policy_action"
+	docReputationProfileAction = "reputation_profile_action"
+	docIPGeo                   = "ip_geo"
+	docLogging                 = "logging"
+	docAttackPayloadLogging    = "attack_payload_logging"
+	docPragmaHeader            = "pragma_header"
+	docEvasivePathMatch        = "evasive_path_match"
+	docPrefetch                = "prefetch"
+)
+
+// Actions of a documentChange.
+const (
+	docCreate = "create"
+	docUpdate = "update"
+	docRemove = "remove"
+)
+
+var (
+	// documentKindOrder is the order objects are created and updated in, so that objects exist
+	// before others refer to them. Removals are applied first, in the reverse order.
+	documentKindOrder = []string{
+		docSelectedHosts,
+		docSecurityPolicy,
+		docCustomRule,
+		docRatePolicy,
+		docReputationProfile,
+		docMatchTarget,
+		docCustomRuleAction,
+		docRatePolicyAction,
+		docReputationProfileAction,
+		docIPGeo,
+		docLogging,
+		docAttackPayloadLogging,
+		docPragmaHeader,
+		docEvasivePathMatch,
+		docPrefetch,
+	}
+
+	// documentRemovableKinds lists the kinds removed when they are missing from the desired
+	// document. Settings of other kinds are left as they are.
+	documentRemovableKinds = map[string]bool{
+		docSecurityPolicy:          true,
+		docCustomRule:              true,
+		docRatePolicy:              true,
+		docReputationProfile:       true,
+		docMatchTarget:             true,
+		docCustomRuleAction:        true,
+		docRatePolicyAction:        true,
+		docReputationProfileAction: true,
+	}
+)
+
+type (
+	// documentObject is a single object of a security configuration. Objects are identified by
+	// kind, owning security policy and name rather than by ID, since IDs are assigned by the API
+	// when objects are created.
+	documentObject struct {
+		Kind   string
+		Policy string
+		Name   string
+		Body   string
+	}
+
+	// configurationDocument holds the objects of an exported security configuration version.
+	configurationDocument struct {
+		objects            map[string]documentObject
+		policies           map[string]string
+		customRules        map[string]int
+		ratePolicies       map[string]int
+		reputationProfiles map[string]int
+	}
+
+	// documentChange is a change to a single object needed to turn one document into another.
+	documentChange struct {
+		Action string
+		Object documentObject
+	}
+)
+
+func (o documentObject) key() string {
+	return o.Kind + "/" + o.Policy + "/" + o.Name
+}
+
+func (o documentObject) String() string {
+	s := o.Kind
+	if o.Name != "" {
+		s += fmt.Sprintf(" %q", o.Name)
+	}
+	if o.Policy != "" {
+		s += fmt.Sprintf(" in security policy %q", o.Policy)
+	}
+	return s
+}
+
+func (c documentChange) String() string {
+	return c.Action + " " + c.Object.String()
+}
+
+// parseConfigurationDocument parses the export JSON of a security configuration version.
+func parseConfigurationDocument(document string) (*configurationDocument, error) {
+	var export appsec.GetExportConfigurationResponse
+	if err := json.Unmarshal([]byte(document), &export); err != nil {
+		return nil, fmt.Errorf("invalid configuration document: %w", err)
+	}
+	return newConfigurationDocument(&export)
+}
+
+// newConfigurationDocument splits an exported security configuration version into objects.
+func newConfigurationDocument(export *appsec.GetExportConfigurationResponse) (*configurationDocument, error) {
+	doc := &configurationDocument{
+		objects:            map[string]documentObject{},
+		policies:           map[string]string{},
+		customRules:        map[string]int{},
+		ratePolicies:       map[string]int{},
+		reputationProfiles: map[string]int{},
+	}
+	add := doc.add
+
+	hosts := append([]string{}, export.SelectedHosts...)
+	sort.Strings(hosts)
+	if err := add(docSelectedHosts, "", "", hosts); err != nil {
+		return nil, err
+	}
+
+	policyNames := map[string]string{}
+	for _, p := range export.SecurityPolicies {
+		_, taken := doc.policies[p.Name]
+		name := uniqueDocumentName(p.Name, p.ID, taken)
+		doc.policies[name] = p.ID
+		policyNames[p.ID] = name
+		if err := add(docSecurityPolicy, "", name, struct{}{}); err != nil {
+			return nil, err
+		}
+	}
+
+	customRuleNames := map[string]string{}
+	for _, r := range export.CustomRules {
+		_, taken := doc.customRules[r.Name]
+		name := uniqueDocumentName(r.Name, strconv.Itoa(r.ID), taken)
+		doc.customRules[name] = r.ID
+		customRuleNames[strconv.Itoa(r.ID)] = name
+		if err := add(docCustomRule, "", name, r, "id"); err != nil {
+			return nil, err
+		}
+	}
+	ratePolicyNames := map[string]string{}
+	for _, r := range export.RatePolicies {
+		_, taken := doc.ratePolicies[r.Name]
+		name := uniqueDocumentName(r.Name, strconv.Itoa(r.ID), taken)
+		doc.ratePolicies[name] = r.ID
+		ratePolicyNames[strconv.Itoa(r.ID)] = name
+		if err := add(docRatePolicy, "", name, r, "id"); err != nil {
+			return nil, err
+		}
+	}
+	reputationProfileNames := map[string]string{}
+	for _, r := range export.ReputationProfiles {
+		_, taken := doc.reputationProfiles[r.Name]
+		name := uniqueDocumentName(r.Name, strconv.Itoa(r.ID), taken)
+		doc.reputationProfiles[name] = r.ID
+		reputationProfileNames[strconv.Itoa(r.ID)] = name
+		if err := add(docReputationProfile, "", name, r, "id"); err != nil {
+			return nil, err
+		}
+	}
+
+	// Match targets have no name, so they are identified by ID; see planConfigurationDocument. The
+	// owning security policy is kept outside of the body since its ID may differ between documents.
+	for _, t := range export.MatchTargets.WebsiteTargets {
+		if err := add(docMatchTarget, documentName(policyNames, t.SecurityPolicy.PolicyID), strconv.Itoa(t.ID), t, "id", "securityPolicy"); err != nil {
+			return nil, err
+		}
+	}
+	for _, t := range export.MatchTargets.APITargets {
+		if err := add(docMatchTarget, documentName(policyNames, t.SecurityPolicy.PolicyID), strconv.Itoa(t.TargetID), t, "id", "targetId", "securityPolicy"); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, p := range export.SecurityPolicies {
+		policy := policyNames[p.ID]
+		for _, a := range p.CustomRuleActions {
+			if err := add(docCustomRuleAction, policy, documentName(customRuleNames, strconv.Itoa(a.ID)), map[string]string{"action": a.Action}); err != nil {
+				return nil, err
+			}
+		}
+		if p.RatePolicyActions != nil {
+			for _, a := range *p.RatePolicyActions {
+				if err := add(docRatePolicyAction, policy, documentName(ratePolicyNames, strconv.Itoa(a.ID)), a, "id"); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if p.ClientReputation.ReputationProfileActions != nil {
+			for _, a := range *p.ClientReputation.ReputationProfileActions {
+				if err := add(docReputationProfileAction, policy, documentName(reputationProfileNames, strconv.Itoa(a.ID)), map[string]string{"action": a.Action}); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		settings := []struct {
+			kind  string
+			value interface{}
+			set   bool
+		}{
+			{docIPGeo, p.IPGeoFirewall, p.IPGeoFirewall != nil},
+			{docLogging, p.LoggingOverrides, p.LoggingOverrides != nil},
+			{docAttackPayloadLogging, p.AttackPayloadLoggingOverrides, p.AttackPayloadLoggingOverrides != nil},
+			{docPragmaHeader, p.PragmaHeader, p.PragmaHeader != nil},
+			{docEvasivePathMatch, p.EvasivePathMatch, p.EvasivePathMatch != nil},
+		}
+		for _, s := range settings {
+			if !s.set {
+				continue
+			}
+			if err := add(s.kind, policy, "", s.value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if o := export.AdvancedOptions; o != nil {
+		settings := []struct {
+			kind  string
+			value interface{}
+			set   bool
+		}{
+			{docLogging, o.Logging, o.Logging != nil},
+			{docAttackPayloadLogging, o.AttackPayloadLogging, o.AttackPayloadLogging != nil},
+			{docPragmaHeader, o.PragmaHeader, o.PragmaHeader != nil},
+			{docEvasivePathMatch, o.EvasivePathMatch, o.EvasivePathMatch != nil},
+			{docPrefetch, o.Prefetch, true},
+		}
+		for _, s := range settings {
+			if !s.set {
+				continue
+			}
+			if err := add(s.kind, "", "", s.value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return doc, nil
+}
+
+// add adds an object with the canonical JSON of v as body, dropping the given top-level fields.
+func (doc *configurationDocument) add(kind, policy, name string, v interface{}, drop ...string) error {
+	body, err := canonicalDocumentJSON(v, drop...)
+	if err != nil {
+		return fmt.Errorf("%s %q: %w", kind, name, err)
+	}
+	o := documentObject{Kind: kind, Policy: policy, Name: name, Body: body}
+	doc.objects[o.key()] = o
+	return nil
+}
+
+// uniqueDocumentName returns name, or name qualified with id when name is empty or already taken.
+func uniqueDocumentName(name, id string, taken bool) string {
+	if taken || name == "" {
+		return fmt.Sprintf("%s#%s", name, id)
+	}
+	return name
+}
+
+// documentName returns the name of the object with the given ID, or the ID prefixed with '#'
+// when the document doesn't contain such an object.
+func documentName(names map[string]string, id string) string {
+	if name, ok := names[id]; ok {
+		return name
+	}
+	return "#" + id
+}
+
+// canonicalDocumentJSON marshals v with sorted keys, dropping the given top-level fields.
+func canonicalDocumentJSON(v interface{}, drop ...string) (string, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return "", err
+	}
+	if m, ok := generic.(map[string]interface{}); ok {
+		for _, field := range drop {
+			delete(m, field)
+		}
+	}
+	canonical, err := json.Marshal(generic)
+	if err != nil {
+		return "", err
+	}
+	return string(canonical), nil
+}
+
+// diffConfigurationDocuments returns the changes turning current into desired, in the order they
+// have to be applied.
+func diffConfigurationDocuments(current, desired *configurationDocument) []documentChange {
+	rank := make(map[string]int, len(documentKindOrder))
+	for i, kind := range documentKindOrder {
+		rank[kind] = i
+	}
+
+	var removals, upserts []documentChange
+	for key, o := range current.objects {
+		if _, ok := desired.objects[key]; ok || !documentRemovableKinds[o.Kind] {
+			continue
+		}
+		// Objects owned by a removed security policy go away with it. Match targets only refer
+		// to their policy, so they are removed explicitly.
+		if o.Policy != "" && o.Kind != docMatchTarget {
+			if _, ok := desired.policies[o.Policy]; !ok {
+				if _, ok := current.policies[o.Policy]; ok {
+					continue
+				}
+			}
+		}
+		removals = append(removals, documentChange{Action: docRemove, Object: o})
+	}
+	for key, o := range desired.objects {
+		existing, ok := current.objects[key]
+		switch {
+		case !ok:
+			upserts = append(upserts, documentChange{Action: docCreate, Object: o})
+		case existing.Body != o.Body:
+			upserts = append(upserts, documentChange{Action: docUpdate, Object: o})
+		}
+	}
+
+	sort.Slice(removals, func(i, j int) bool {
+		a, b := removals[i].Object, removals[j].Object
+		if rank[a.Kind] != rank[b.Kind] {
+			return rank[a.Kind] > rank[b.Kind]
+		}
+		return a.key() < b.key()
+	})
+	sort.Slice(upserts, func(i, j int) bool {
+		a, b := upserts[i].Object, upserts[j].Object
+		if rank[a.Kind] != rank[b.Kind] {
+			return rank[a.Kind] < rank[b.Kind]
+		}
+		return a.key() < b.key()
+	})
+	return append(removals, upserts...)
+}
+
+// planConfigurationDocument returns the changes turning current into desired. The API assigns match
+// targets a new ID when they're created, so the match targets of desired are first paired with the
+// identical match targets of current; otherwise a created match target would never match the document.
+func planConfigurationDocument(current, desired *configurationDocument) []documentChange {
+	reuseMatchTargets(current, desired)
+	return diffConfigurationDocuments(current, desired)
+}
+
+// reuseMatchTargets renames the match targets of desired after identical match targets of current, so that
+// match targets whose definition didn't change are kept instead of being recreated with a new ID.
+func reuseMatchTargets(current, desired *configurationDocument) {
+	claimed := map[string]bool{}
+	for key, o := range desired.objects {
+		if o.Kind != docMatchTarget {
+			continue
+		}
+		if _, ok := current.objects[key]; ok {
+			claimed[key] = true
+		}
+	}
+
+	keys := make([]string, 0, len(desired.objects))
+	for key := range desired.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		o := desired.objects[key]
+		if o.Kind != docMatchTarget || claimed[key] {
+			continue
+		}
+		candidates := make([]string, 0)
+		for currentKey, c := range current.objects {
+			if c.Kind == docMatchTarget && c.Policy == o.Policy && c.Body == o.Body && !claimed[currentKey] {
+				candidates = append(candidates, currentKey)
+			}
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+		sort.Strings(candidates)
+		delete(desired.objects, key)
+		o.Name = current.objects[candidates[0]].Name
+		desired.objects[o.key()] = o
+		claimed[o.key()] = true
+	}
+}
+
+// documentChangeStrings returns the human-readable descriptions of changes.
+func documentChangeStrings(changes []documentChange) []string {
+	result := make([]string, 0, len(changes))
+	for _, c := range changes {
+		result = append(result, c.String())
+	}
+	return result
+}
+
+// documentApplier applies documentChanges to an editable version of a security configuration.
+// It tracks the IDs of named objects, so that objects created from the desired document can be
+// referred to by the objects applied after them.
+type documentApplier struct {
+	client             appsec.APPSEC
+	configID           int
+	version            int
+	desired            *configurationDocument
+	policies           map[string]string
+	customRules        map[string]int
+	ratePolicies       map[string]int
+	reputationProfiles map[string]int
+}
+
+func newDocumentApplier(client appsec.APPSEC, configID, version int, current, desired *configurationDocument) *documentApplier {
+	a := &documentApplier{
+		client:             client,
+		configID:           configID,
+		version:            version,
+		desired:            desired,
+		policies:           map[string]string{},
+		customRules:        map[string]int{},
+		ratePolicies:       map[string]int{},
+		reputationProfiles: map[string]int{},
+	}
+	for k, v := range current.policies {
+		a.policies[k] = v
+	}
+	for _, m := range []struct{ from, to map[string]int }{
+		{current.customRules, a.customRules},
+		{current.ratePolicies, a.ratePolicies},
+		{current.reputationProfiles, a.reputationProfiles},
+	} {
+		for k, v := range m.from {
+			m.to[k] = v
+		}
+	}
+	return a
+}
+
+func (a *documentApplier) apply(ctx context.Context, changes []documentChange) error {
+	for _, c := range changes {
+		if err := a.applyChange(ctx, c); err != nil {
+			return fmt.Errorf("%s: %w", c, err)
+		}
+	}
+	return nil
+}
+
+func (a *documentApplier) applyChange(ctx context.Context, c documentChange) error {
+	o := c.Object
+	body := json.RawMessage(o.Body)
+
+	var policyID string
+	if o.Policy != "" {
+		id, err := a.policyID(o.Policy)
+		if err != nil {
+			return err
+		}
+		policyID = id
+	}
+
+	switch o.Kind {
+	case docSelectedHosts:
+		var hosts []string
+		if err := json.Unmarshal(body, &hosts); err != nil {
+			return err
+		}
+		request := appsec.UpdateSelectedHostnamesRequest{ConfigID: a.configID, Version: a.version, HostnameList: []appsec.Hostname{}}
+		for _, h := range hosts {
+			request.HostnameList = append(request.HostnameList, appsec.Hostname{Hostname: h})
+		}
+		_, err := a.client.UpdateSelectedHostnames(ctx, request)
+		return err
+
+	case docSecurityPolicy:
+		switch c.Action {
+		case docCreate:
+			prefix := strings.SplitN(a.desired.policies[o.Name], "_", 2)[0]
+			policy, err := a.client.CreateSecurityPolicy(ctx, appsec.CreateSecurityPolicyRequest{
+				ConfigID:        a.configID,
+				Version:         a.version,
+				PolicyName:      o.Name,
+				PolicyPrefix:    prefix,
+				DefaultSettings: true,
+			})
+			if err != nil {
+				return err
+			}
+			a.policies[o.Name] = policy.PolicyID
+			return nil
+		case docRemove:
+			_, err := a.client.RemoveSecurityPolicy(ctx, appsec.RemoveSecurityPolicyRequest{ConfigID: a.configID, Version: a.version, PolicyID: a.policies[o.Name]})
+			return err
+		}
+		return nil
+
+	case docCustomRule:
+		switch c.Action {
+		case docCreate:
+			rule, err := a.client.CreateCustomRule(ctx, appsec.CreateCustomRuleRequest{ConfigID: a.configID, JsonPayloadRaw: body})
+			if err != nil {
+				return err
+			}
+			a.customRules[o.Name] = rule.ID
+			return nil
+		case docUpdate:
+			_, err := a.client.UpdateCustomRule(ctx, appsec.UpdateCustomRuleRequest{ConfigID: a.configID, ID: a.customRules[o.Name], JsonPayloadRaw: body})
+			return err
+		default:
+			_, err := a.client.RemoveCustomRule(ctx, appsec.RemoveCustomRuleRequest{ConfigID: a.configID, ID: a.customRules[o.Name]})
+			return err
+		}
+
+	case docRatePolicy:
+		switch c.Action {
+		case docCreate:
+			policy, err := a.client.CreateRatePolicy(ctx, appsec.CreateRatePolicyRequest{ConfigID: a.configID, ConfigVersion: a.version, JsonPayloadRaw: body})
+			if err != nil {
+				return err
+			}
+			a.ratePolicies[o.Name] = policy.ID
+			return nil
+		case docUpdate:
+			_, err := a.client.UpdateRatePolicy(ctx, appsec.UpdateRatePolicyRequest{ConfigID: a.configID, ConfigVersion: a.version, RatePolicyID: a.ratePolicies[o.Name], JsonPayloadRaw: body})
+			return err
+		default:
+			_, err := a.client.RemoveRatePolicy(ctx, appsec.RemoveRatePolicyRequest{ConfigID: a.configID, ConfigVersion: a.version, RatePolicyID: a.ratePolicies[o.Name]})
+			return err
+		}
+
+	case docReputationProfile:
+		switch c.Action {
+		case docCreate:
+			profile, err := a.client.CreateReputationProfile(ctx, appsec.CreateReputationProfileRequest{ConfigID: a.configID, ConfigVersion: a.version, JsonPayloadRaw: body})
+			if err != nil {
+				return err
+			}
+			a.reputationProfiles[o.Name] = profile.ID
+			return nil
+		case docUpdate:
+			_, err := a.client.UpdateReputationProfile(ctx, appsec.UpdateReputationProfileRequest{ConfigID: a.configID, ConfigVersion: a.version, ReputationProfileId: a.reputationProfiles[o.Name], JsonPayloadRaw: body})
+			return err
+		default:
+			_, err := a.client.RemoveReputationProfile(ctx, appsec.RemoveReputationProfileRequest{ConfigID: a.configID, ConfigVersion: a.version, ReputationProfileId: a.reputationProfiles[o.Name]})
+			return err
+		}
+
+	case docMatchTarget:
+		targetID, err := strconv.Atoi(o.Name)
+		if err != nil {
+			return fmt.Errorf("invalid match target ID %q", o.Name)
+		}
+		if c.Action == docRemove {
+			_, err := a.client.RemoveMatchTarget(ctx, appsec.RemoveMatchTargetRequest{ConfigID: a.configID, ConfigVersion: a.version, TargetID: targetID})
+			return err
+		}
+		var target map[string]interface{}
+		if err := json.Unmarshal(body, &target); err != nil {
+			return err
+		}
+		target["securityPolicy"] = map[string]string{"policyId": policyID}
+		payload, err := json.Marshal(target)
+		if err != nil {
+			return err
+		}
+		if c.Action == docCreate {
+			targetType, _ := target["type"].(string)
+			_, err = a.client.CreateMatchTarget(ctx, appsec.CreateMatchTargetRequest{Type: targetType, ConfigID: a.configID, ConfigVersion: a.version, JsonPayloadRaw: payload})
+			return err
+		}
+		_, err = a.client.UpdateMatchTarget(ctx, appsec.UpdateMatchTargetRequest{ConfigID: a.configID, ConfigVersion: a.version, TargetID: targetID, JsonPayloadRaw: payload})
+		return err
+
+	case docCustomRuleAction:
+		ruleID, err := documentID(a.customRules, o.Name)
+		if err != nil {
+			return err
+		}
+		var action struct {
+			Action string `json:"action"`
+		}
+		if err := json.Unmarshal(body, &action); err != nil {
+			return err
+		}
+		if c.Action == docRemove {
+			action.Action = None
+		}
+		_, err = a.client.UpdateCustomRuleAction(ctx, appsec.UpdateCustomRuleActionRequest{ConfigID: a.configID, Version: a.version, PolicyID: policyID, RuleID: ruleID, Action: action.Action})
+		return err
+
+	case docRatePolicyAction:
+		ratePolicyID, err := documentID(a.ratePolicies, o.Name)
+		if err != nil {
+			return err
+		}
+		request := appsec.UpdateRatePolicyActionRequest{ConfigID: a.configID, Version: a.version, PolicyID: policyID, RatePolicyID: ratePolicyID}
+		if err := json.Unmarshal(body, &request); err != nil {
+			return err
+		}
+		if c.Action == docRemove {
+			request.Ipv4Action, request.Ipv6Action = None, None
+		}
+		_, err = a.client.UpdateRatePolicyAction(ctx, request)
+		return err
+
+	case docReputationProfileAction:
+		profileID, err := documentID(a.reputationProfiles, o.Name)
+		if err != nil {
+			return err
+		}
+		request := appsec.UpdateReputationProfileActionRequest{ConfigID: a.configID, Version: a.version, PolicyID: policyID, ReputationProfileID: profileID}
+		if err := json.Unmarshal(body, &request); err != nil {
+			return err
+		}
+		if c.Action == docRemove {
+			request.Action = None
+		}
+		_, err = a.client.UpdateReputationProfileAction(ctx, request)
+		return err
+
+	case docIPGeo:
+		request := appsec.UpdateIPGeoRequest{ConfigID: a.configID, Version: a.version, PolicyID: policyID}
+		if err := json.Unmarshal(body, &request); err != nil {
+			return err
+		}
+		_, err := a.client.UpdateIPGeo(ctx, request)
+		return err
+
+	case docLogging:
+		_, err := a.client.UpdateAdvancedSettingsLogging(ctx, appsec.UpdateAdvancedSettingsLoggingRequest{ConfigID: a.configID, Version: a.version, PolicyID: policyID, JsonPayloadRaw: body})
+		return err
+
+	case docAttackPayloadLogging:
+		_, err := a.client.UpdateAdvancedSettingsAttackPayloadLogging(ctx, appsec.UpdateAdvancedSettingsAttackPayloadLoggingRequest{ConfigID: a.configID, Version: a.version, PolicyID: policyID, JSONPayloadRaw: body})
+		return err
+
+	case docPragmaHeader:
+		_, err := a.client.UpdateAdvancedSettingsPragma(ctx, appsec.UpdateAdvancedSettingsPragmaRequest{ConfigID: a.configID, Version: a.version, PolicyID: policyID, JsonPayloadRaw: body})
+		return err
+
+	case docEvasivePathMatch:
+		var setting appsec.EvasivePathMatchexp
+		if err := json.Unmarshal(body, &setting); err != nil {
+			return err
+		}
+		_, err := a.client.UpdateAdvancedSettingsEvasivePathMatch(ctx, appsec.UpdateAdvancedSettingsEvasivePathMatchRequest{ConfigID: a.configID, Version: a.version, PolicyID: policyID, EnablePathMatch: setting.EnablePathMatch})
+		return err
+
+	case docPrefetch:
+		request := appsec.UpdateAdvancedSettingsPrefetchRequest{ConfigID: a.configID, Version: a.version}
+		if err := json.Unmarshal(body, &request); err != nil {
+			return err
+		}
+		_, err := a.client.UpdateAdvancedSettingsPrefetch(ctx, request)
+		return err
+	}
+
+	return fmt.Errorf("unsupported object kind %q", o.Kind)
+}
+
+func (a *documentApplier) policyID(name string) (string, error) {
+	if id, ok := a.policies[name]; ok {
+		return id, nil
+	}
+	if strings.HasPrefix(name, "#") {
+		return strings.TrimPrefix(name, "#"), nil
+	}
+	return "", fmt.Errorf("unknown security policy %q", name)
+}
+
+// documentID returns the ID of the named object, resolving names of objects missing from the
+// document, which have the form '#<id>'.
+func documentID(ids map[string]int, name string) (int, error) {
+	if id, ok := ids[name]; ok {
+		return id, nil
+	}
+	if strings.HasPrefix(name, "#") {
+		if id, err := strconv.Atoi(strings.TrimPrefix(name, "#")); err == nil {
+			return id, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown object %q", name)
+}
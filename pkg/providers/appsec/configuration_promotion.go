@@ -0,0 +1,193 @@
+package appsec
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+)
+
+// configurationPromotion rewrites an exported security configuration version so that it can be applied to a
+// configuration of another account or contract, where hostnames, network lists and custom rules differ.
+type configurationPromotion struct {
+	// hostnames maps hostnames of the source configuration to hostnames of the target configuration
+	hostnames map[string]string
+
+	// networkLists maps network list IDs of the source account to network list IDs of the target account
+	networkLists map[string]string
+
+	// customRules maps custom rule IDs of the source configuration to existing custom rules of the target configuration
+	customRules map[int]int
+
+	skipped map[string]struct{}
+}
+
+// remap returns the document describing source in terms of target, along with the objects that couldn't be
+// carried over, sorted. Match targets keep their source IDs; see reuseMatchTargets.
+func (p *configurationPromotion) remap(source, target *appsec.GetExportConfigurationResponse) (*configurationDocument, []string, error) {
+	p.skipped = map[string]struct{}{}
+
+	available := map[string]bool{}
+	for _, h := range target.SelectableHosts {
+		available[h] = true
+	}
+	for _, h := range target.SelectedHosts {
+		available[h] = true
+	}
+	hosts := func(from []string) []string {
+		to := make([]string, 0, len(from))
+		for _, h := range from {
+			mapped, ok := p.hostnames[h]
+			if !ok {
+				mapped = h
+			}
+			if !available[mapped] {
+				p.skip("hostname %s isn't selectable in the target configuration", mapped)
+				continue
+			}
+			to = append(to, mapped)
+		}
+		return to
+	}
+
+	source.SelectedHosts = hosts(source.SelectedHosts)
+
+	// Bypass lists are named after the network lists of the target account when they're known.
+	listNames := map[string]string{}
+	for _, t := range target.MatchTargets.WebsiteTargets {
+		for _, l := range t.BypassNetworkLists {
+			listNames[l.ID] = l.Name
+		}
+	}
+
+	websiteTargets := source.MatchTargets.WebsiteTargets[:0]
+	for _, t := range source.MatchTargets.WebsiteTargets {
+		if len(t.Hostnames) > 0 {
+			t.Hostnames = hosts(t.Hostnames)
+			if len(t.Hostnames) == 0 {
+				p.skip("match target %d has no hostnames left", t.ID)
+				continue
+			}
+		}
+		bypass := t.BypassNetworkLists[:0]
+		for _, l := range t.BypassNetworkLists {
+			if id, ok := p.networkList(l.ID); ok {
+				l.ID = id
+				if name, ok := listNames[id]; ok {
+					l.Name = name
+				}
+				bypass = append(bypass, l)
+			}
+		}
+		t.BypassNetworkLists = bypass
+		websiteTargets = append(websiteTargets, t)
+	}
+	source.MatchTargets.WebsiteTargets = websiteTargets
+
+	// API match targets refer to API definitions, which belong to the source account.
+	for _, t := range source.MatchTargets.APITargets {
+		p.skip("API match target %d", t.TargetID)
+	}
+	source.MatchTargets.APITargets = source.MatchTargets.APITargets[:0]
+
+	for i := range source.RatePolicies {
+		r := &source.RatePolicies[i]
+		if len(r.Hostnames) > 0 {
+			r.Hostnames = hosts(r.Hostnames)
+		}
+		if r.Hosts != nil && r.Hosts.Values != nil {
+			values := hosts(*r.Hosts.Values)
+			r.Hosts.Values = &values
+		}
+	}
+
+	targetRules := make(map[int]string, len(target.CustomRules))
+	for _, r := range target.CustomRules {
+		targetRules[r.ID] = r.Name
+	}
+	for i := range source.CustomRules {
+		r := &source.CustomRules[i]
+		targetID, ok := p.customRules[r.ID]
+		if !ok {
+			continue
+		}
+		name, ok := targetRules[targetID]
+		if !ok {
+			return nil, nil, fmt.Errorf("custom rule %d mapped from custom rule %d doesn't exist in the target configuration", targetID, r.ID)
+		}
+		r.Name = name
+	}
+
+	for i := range source.SecurityPolicies {
+		policy := &source.SecurityPolicies[i]
+		if firewall := policy.IPGeoFirewall; firewall != nil {
+			if firewall.GeoControls != nil {
+				p.networkListIDs(firewall.GeoControls.BlockedIPNetworkLists)
+			}
+			if firewall.IPControls != nil {
+				p.networkListIDs(firewall.IPControls.AllowedIPNetworkLists)
+				p.networkListIDs(firewall.IPControls.BlockedIPNetworkLists)
+			}
+		}
+		p.skipUnsupported(policy.Name, policy.WebApplicationFirewall.RuleActions != nil, "rule actions")
+		p.skipUnsupported(policy.Name, policy.WebApplicationFirewall.AttackGroupActions != nil, "attack group actions")
+		p.skipUnsupported(policy.Name, policy.APIRequestConstraints != nil, "API request constraints")
+		p.skipUnsupported(policy.Name, policy.PenaltyBox != nil, "penalty box")
+		p.skipUnsupported(policy.Name, policy.SlowPost != nil, "slow POST protection")
+		p.skipUnsupported(policy.Name, policy.BotManagement != nil, "bot management")
+		p.skipUnsupported(policy.Name, len(policy.MalwarePolicyActions) > 0, "malware policy actions")
+	}
+	p.skipUnsupported("", len(source.MalwarePolicies) > 0, "malware policies")
+	p.skipUnsupported("", source.Siem != nil, "SIEM settings")
+	p.skipUnsupported("", source.CustomDenyList != nil, "custom deny actions")
+	p.skipUnsupported("", len(source.CustomBotCategories)+len(source.CustomDefinedBots)+len(source.CustomClients) > 0, "custom bots")
+
+	desired, err := newConfigurationDocument(source)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	skipped := make([]string, 0, len(p.skipped))
+	for s := range p.skipped {
+		skipped = append(skipped, s)
+	}
+	sort.Strings(skipped)
+	return desired, skipped, nil
+}
+
+// networkList returns the target ID of a network list, or false if it has no mapping.
+func (p *configurationPromotion) networkList(id string) (string, bool) {
+	mapped, ok := p.networkLists[id]
+	if !ok {
+		p.skip("network list %s has no mapping", id)
+	}
+	return mapped, ok
+}
+
+// networkListIDs replaces the IDs of the lists by their target IDs, removing the ones without a mapping.
+func (p *configurationPromotion) networkListIDs(lists *appsec.IPGeoNetworkLists) {
+	if lists == nil {
+		return
+	}
+	ids := make([]string, 0, len(lists.NetworkList))
+	for _, id := range lists.NetworkList {
+		if mapped, ok := p.networkList(id); ok {
+			ids = append(ids, mapped)
+		}
+	}
+	lists.NetworkList = ids
+}
+
+func (p *configurationPromotion) skipUnsupported(policy string, present bool, what string) {
+	switch {
+	case !present:
+	case policy == "":
+		p.skip("%s", what)
+	default:
+		p.skip("%s of security policy %q", what, policy)
+	}
+}
+
+func (p *configurationPromotion) skip(format string, args ...interface{}) {
+	p.skipped[fmt.Sprintf(format, args...)] = struct{}{}
+}
@@ -0,0 +1,55 @@
+package appsec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadPromotionExport(t *testing.T, path string) *appsec.GetExportConfigurationResponse {
+	export := appsec.GetExportConfigurationResponse{}
+	err := json.Unmarshal(loadFixtureBytes(path), &export)
+	require.NoError(t, err)
+	return &export
+}
+
+func TestConfigurationPromotionRemap(t *testing.T) {
+	target := loadPromotionExport(t, "testdata/TestResConfigurationPromotion/Target.json")
+	promotion := configurationPromotion{
+		hostnames:    map[string]string{"dev.example.com": "www.example.com"},
+		networkLists: map[string]string{"1_DEV": "1_PROD", "2_DEV": "2_PROD"},
+		customRules:  map[int]int{101: 700},
+	}
+
+	desired, skipped, err := promotion.remap(loadPromotionExport(t, "testdata/TestResConfigurationPromotion/Source.json"), target)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"API match target 501",
+		"hostname legacy.example.com isn't selectable in the target configuration",
+		"network list 3_DEV has no mapping",
+		`rule actions of security policy "Main"`,
+	}, skipped)
+
+	current, err := newConfigurationDocument(target)
+	require.NoError(t, err)
+	reuseMatchTargets(current, desired)
+	assert.Equal(t, []string{
+		"update selected_hosts",
+		`create custom_rule "Block admin"`,
+		`create custom_rule_action "Block admin" in security policy "Main"`,
+		`create ip_geo in security policy "Main"`,
+	}, documentChangeStrings(diffConfigurationDocuments(current, desired)))
+}
+
+func TestConfigurationPromotionRemapUnknownCustomRule(t *testing.T) {
+	promotion := configurationPromotion{customRules: map[int]int{101: 701}}
+
+	_, _, err := promotion.remap(
+		loadPromotionExport(t, "testdata/TestResConfigurationPromotion/Source.json"),
+		loadPromotionExport(t, "testdata/TestResConfigurationPromotion/Target.json"),
+	)
+	assert.EqualError(t, err, "custom rule 701 mapped from custom rule 101 doesn't exist in the target configuration")
+}
@@ -0,0 +1,341 @@
+package appsec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// customRuleStructuredKeys are the attributes of akamai_appsec_custom_rule describing the rule as nested blocks
+// instead of the custom_rule JSON.
+var customRuleStructuredKeys = []string{
+	"name",
+	"description",
+	"tag",
+	"operation",
+	"condition",
+	"effective_time_period",
+	"sampling_rate",
+}
+
+// customRuleConditionFlags maps the boolean attributes of a condition block to their custom rule JSON members.
+var customRuleConditionFlags = []struct {
+	key    string
+	member string
+}{
+	{"name_case", "nameCase"},
+	{"name_wildcard", "nameWildcard"},
+	{"value_case", "valueCase"},
+	{"value_exact_match", "valueExactMatch"},
+	{"value_ignore_segment", "valueIgnoreSegment"},
+	{"value_normalize", "valueNormalize"},
+	{"value_recursive", "valueRecursive"},
+	{"value_wildcard", "valueWildcard"},
+	{"use_x_forward_for_headers", "useXForwardForHeaders"},
+}
+
+// customRuleStructuredSchema returns the nested block form of the custom rule attributes.
+func customRuleStructuredSchema() map[string]*schema.Schema {
+	conditionSchema := map[string]*schema.Schema{
+		"type": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Type of the condition, such as pathMatch or requestMethodMatch",
+		},
+		"positive_match": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Whether the condition matches when the values are found (true) or not found (false)",
+		},
+		"value": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Values matched by the condition",
+		},
+		"name": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Names of the headers, cookies or arguments matched by the condition",
+		},
+	}
+	for _, flag := range customRuleConditionFlags {
+		conditionSchema[flag.key] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: fmt.Sprintf("Sets %s of the condition", flag.member),
+		}
+	}
+
+	structured := map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Name of the custom rule",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Description of the custom rule",
+		},
+		"tag": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Tags of the custom rule",
+		},
+		"operation": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Whether all (AND) or any (OR) of the conditions must match",
+		},
+		"condition": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Computed:    true,
+			Elem:        &schema.Resource{Schema: conditionSchema},
+			Description: "Conditions of the custom rule",
+		},
+		"effective_time_period": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Computed: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"start_date": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Date and time from which the custom rule is active",
+					},
+					"end_date": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Date and time until which the custom rule is active",
+					},
+				},
+			},
+			Description: "Period during which the custom rule is active",
+		},
+		"sampling_rate": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Computed:    true,
+			Description: "Percentage of requests the custom rule is evaluated on",
+		},
+	}
+	for key, s := range structured {
+		s.ConflictsWith = []string{"custom_rule"}
+		structured[key] = s
+	}
+	return structured
+}
+
+// customRuleStructuredJSON returns the custom rule JSON described by the nested block attributes.
+func customRuleStructuredJSON(get func(string) interface{}) (string, error) {
+	rule := map[string]interface{}{
+		"name":       get("name"),
+		"conditions": []interface{}{},
+	}
+	if description := get("description").(string); description != "" {
+		rule["description"] = description
+	}
+	if tags := get("tag").([]interface{}); len(tags) > 0 {
+		rule["tag"] = tags
+	}
+	if operation := get("operation").(string); operation != "" {
+		rule["operation"] = operation
+	}
+	if samplingRate := get("sampling_rate").(int); samplingRate != 0 {
+		rule["samplingRate"] = samplingRate
+	}
+	if periods := get("effective_time_period").([]interface{}); len(periods) > 0 && periods[0] != nil {
+		period := periods[0].(map[string]interface{})
+		rule["effectiveTimePeriod"] = map[string]interface{}{
+			"startDate": period["start_date"],
+			"endDate":   period["end_date"],
+		}
+	}
+
+	conditions := make([]interface{}, 0)
+	for _, c := range get("condition").([]interface{}) {
+		block, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		condition := map[string]interface{}{
+			"type":          block["type"],
+			"positiveMatch": block["positive_match"],
+		}
+		if values := block["value"].([]interface{}); len(values) > 0 {
+			condition["value"] = values
+		}
+		if names := block["name"].([]interface{}); len(names) > 0 {
+			condition["name"] = names
+		}
+		for _, flag := range customRuleConditionFlags {
+			if block[flag.key].(bool) {
+				condition[flag.member] = true
+			}
+		}
+		conditions = append(conditions, condition)
+	}
+	rule["conditions"] = conditions
+
+	body, err := json.Marshal(rule)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// customRuleStructuredValues returns the nested block attributes describing a custom rule JSON, or an error
+// if the rule has conditions that can't be described by them.
+func customRuleStructuredValues(customRule string) (map[string]interface{}, error) {
+	var rule struct {
+		Name                string                       `json:"name"`
+		Description         string                       `json:"description"`
+		Tag                 []string                     `json:"tag"`
+		Operation           string                       `json:"operation"`
+		Conditions          []map[string]json.RawMessage `json:"conditions"`
+		EffectiveTimePeriod *struct {
+			StartDate string `json:"startDate"`
+			EndDate   string `json:"endDate"`
+		} `json:"effectiveTimePeriod"`
+		SamplingRate int `json:"samplingRate"`
+	}
+	if err := json.Unmarshal([]byte(customRule), &rule); err != nil {
+		return nil, err
+	}
+
+	conditions := make([]interface{}, 0, len(rule.Conditions))
+	for i, c := range rule.Conditions {
+		condition := map[string]interface{}{"positive_match": false}
+		for member, raw := range c {
+			var err error
+			switch member {
+			case "type":
+				var conditionType string
+				err = json.Unmarshal(raw, &conditionType)
+				condition["type"] = conditionType
+			case "positiveMatch":
+				var positiveMatch bool
+				err = json.Unmarshal(raw, &positiveMatch)
+				condition["positive_match"] = positiveMatch
+			case "value", "name":
+				var values []string
+				values, err = customRuleConditionStrings(raw)
+				condition[member] = values
+			default:
+				key := ""
+				for _, flag := range customRuleConditionFlags {
+					if flag.member == member {
+						key = flag.key
+					}
+				}
+				if key == "" {
+					return nil, fmt.Errorf("condition %d: %s can only be set in custom_rule", i, member)
+				}
+				var flag bool
+				err = json.Unmarshal(raw, &flag)
+				condition[key] = flag
+			}
+			if err != nil {
+				return nil, fmt.Errorf("condition %d: %s: %s", i, member, err)
+			}
+		}
+		conditions = append(conditions, condition)
+	}
+
+	periods := make([]interface{}, 0, 1)
+	if rule.EffectiveTimePeriod != nil {
+		periods = append(periods, map[string]interface{}{
+			"start_date": rule.EffectiveTimePeriod.StartDate,
+			"end_date":   rule.EffectiveTimePeriod.EndDate,
+		})
+	}
+	tags := rule.Tag
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return map[string]interface{}{
+		"name":                  rule.Name,
+		"description":           rule.Description,
+		"tag":                   tags,
+		"operation":             rule.Operation,
+		"condition":             conditions,
+		"effective_time_period": periods,
+		"sampling_rate":         rule.SamplingRate,
+	}, nil
+}
+
+// customRuleConditionStrings reads condition values given either as a list or a single string.
+func customRuleConditionStrings(raw json.RawMessage) ([]string, error) {
+	var values []string
+	if err := json.Unmarshal(raw, &values); err == nil {
+		return values, nil
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, err
+	}
+	return []string{value}, nil
+}
+
+// customRuleStructuredDiff keeps the custom_rule JSON and the nested block attributes in sync in the plan, so that
+// changing either form shows which conditions of the rule change.
+func customRuleStructuredDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	if config.GetAttr("custom_rule").IsNull() {
+		if d.Id() != "" && !d.HasChanges(customRuleStructuredKeys...) {
+			return nil
+		}
+		for _, key := range customRuleStructuredKeys {
+			if !d.NewValueKnown(key) {
+				return d.SetNewComputed("custom_rule")
+			}
+		}
+		customRule, err := customRuleStructuredJSON(d.Get)
+		if err != nil {
+			return err
+		}
+		old, _ := d.GetChange("custom_rule")
+		if suppressEquivalentJSONDiffsGeneric("custom_rule", old.(string), customRule, nil) {
+			return nil
+		}
+		return d.SetNew("custom_rule", customRule)
+	}
+
+	if !d.HasChange("custom_rule") {
+		return nil
+	}
+	var values map[string]interface{}
+	if d.NewValueKnown("custom_rule") {
+		values, _ = customRuleStructuredValues(d.Get("custom_rule").(string))
+	}
+	for _, key := range customRuleStructuredKeys {
+		if values == nil {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := d.SetNew(key, values[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package appsec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomRuleStructuredValues(t *testing.T) {
+	values, err := customRuleStructuredValues(loadFixtureString("testdata/TestResCustomRule/CustomRule.json"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Rule Test New", values["name"])
+	assert.Equal(t, []string{"test"}, values["tag"])
+	assert.Equal(t, 5, values["sampling_rate"])
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"start_date": "2022-05-03T18:19:55Z",
+		"end_date":   "2022-06-02T18:19:55Z",
+	}}, values["effective_time_period"])
+
+	conditions := values["condition"].([]interface{})
+	require.Len(t, conditions, 3)
+	assert.Equal(t, map[string]interface{}{
+		"type":           "extensionMatch",
+		"positive_match": true,
+		"value":          []string{"Li", "He", "H"},
+		"value_wildcard": true,
+		"value_case":     true,
+	}, conditions[2])
+}
+
+func TestCustomRuleStructuredValuesSingleValue(t *testing.T) {
+	values, err := customRuleStructuredValues(`{"name":"r","conditions":[{"type":"hostMatch","positiveMatch":false,"value":"example.com"}]}`)
+	require.NoError(t, err)
+
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"type":           "hostMatch",
+		"positive_match": false,
+		"value":          []string{"example.com"},
+	}}, values["condition"])
+}
+
+func TestCustomRuleStructuredValuesUnsupported(t *testing.T) {
+	_, err := customRuleStructuredValues(`{"name":"r","conditions":[{"type":"uriQueryMatch","positiveMatch":true,"valueRegex":"a+"}]}`)
+	assert.EqualError(t, err, "condition 0: valueRegex can only be set in custom_rule")
+}
+
+func TestCustomRuleStructuredJSON(t *testing.T) {
+	expected := loadFixtureString("testdata/TestResCustomRule/CreateCustomRule.json")
+	values, err := customRuleStructuredValues(expected)
+	require.NoError(t, err)
+
+	// condition blocks are read with every attribute set, as ResourceData.Get returns them
+	conditions := values["condition"].([]interface{})
+	for i, c := range conditions {
+		block := map[string]interface{}{"name": []interface{}{}, "value": []interface{}{}}
+		for _, flag := range customRuleConditionFlags {
+			block[flag.key] = false
+		}
+		for key, v := range c.(map[string]interface{}) {
+			if list, ok := v.([]string); ok {
+				items := make([]interface{}, 0, len(list))
+				for _, item := range list {
+					items = append(items, item)
+				}
+				v = items
+			}
+			block[key] = v
+		}
+		conditions[i] = block
+	}
+	values["tag"] = []interface{}{"test"}
+
+	customRule, err := customRuleStructuredJSON(func(key string) interface{} { return values[key] })
+	require.NoError(t, err)
+
+	var want, got interface{}
+	require.NoError(t, json.Unmarshal([]byte(expected), &want))
+	require.NoError(t, json.Unmarshal([]byte(customRule), &got))
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,98 @@
+package appsec
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/providers/appsec/hclgen"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceConfigurationHCL() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceConfigurationHCLRead,
+		Schema: map[string]*schema.Schema{
+			"config_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "Unique identifier of the security configuration",
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "Version number of the security configuration to be converted",
+			},
+			"json_directory": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     hclgen.DefaultJSONDirectory,
+				Description: "Directory, relative to the generated module, holding the files referenced by JSON attributes",
+			},
+			"files": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Contents of the generated module keyed by file path relative to the module root",
+			},
+			"hcl": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Resource blocks of the generated module",
+			},
+			"imports": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Import blocks mapping the generated resources onto the existing configuration",
+			},
+		},
+	}
+}
+
+func dataSourceConfigurationHCLRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	client := inst.Client(meta)
+	logger := meta.Log("APPSEC", "dataSourceConfigurationHCLRead")
+
+	configID, err := tools.GetIntValue("config_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	version, err := tools.GetIntValue("version", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	jsonDirectory, err := tools.GetStringValue("json_directory", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	exportconfiguration, err := client.GetExportConfiguration(ctx, appsec.GetExportConfigurationRequest{ConfigID: configID, Version: version})
+	if err != nil {
+		logger.Errorf("calling 'getExportConfiguration': %s", err.Error())
+		return diag.FromErr(err)
+	}
+
+	files, err := hclgen.Generate(exportconfiguration, hclgen.Options{JSONDirectory: jsonDirectory})
+	if err != nil {
+		logger.Errorf("generating configuration: %s", err.Error())
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("files", files); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("hcl", files[hclgen.ResourcesFile]); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("imports", files[hclgen.ImportsFile]); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	d.SetId(strconv.Itoa(exportconfiguration.ConfigID))
+
+	return nil
+}
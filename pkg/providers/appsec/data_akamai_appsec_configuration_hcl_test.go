@@ -0,0 +1,50 @@
+package appsec
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAkamaiConfigurationHCL_data_basic(t *testing.T) {
+	t.Run("match by ConfigurationHCL ID", func(t *testing.T) {
+		client := &appsec.Mock{}
+
+		getExportConfigurationResponse := appsec.GetExportConfigurationResponse{}
+		err := json.Unmarshal(loadFixtureBytes("testdata/TestDSExportConfiguration/ExportConfiguration.json"), &getExportConfigurationResponse)
+		require.NoError(t, err)
+
+		client.On("GetExportConfiguration",
+			mock.Anything,
+			appsec.GetExportConfigurationRequest{ConfigID: 43253, Version: 7},
+		).Return(&getExportConfigurationResponse, nil)
+
+		useClient(client, func() {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:        true,
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestDSConfigurationHCL/match_by_id.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("data.akamai_appsec_configuration_hcl.test", "id", "43253"),
+							resource.TestCheckResourceAttrSet("data.akamai_appsec_configuration_hcl.test", "files.variables.tf"),
+							resource.TestCheckResourceAttrSet("data.akamai_appsec_configuration_hcl.test", "files.settings/logging.json"),
+							resource.TestMatchResourceAttr("data.akamai_appsec_configuration_hcl.test", "hcl",
+								regexp.MustCompile(`resource "akamai_appsec_security_policy" "aaaa_81230"`)),
+							resource.TestMatchResourceAttr("data.akamai_appsec_configuration_hcl.test", "imports",
+								regexp.MustCompile(`id = "43253:AAAA_81230"`)),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,272 @@
+package appsec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePolicySimulation() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePolicySimulationRead,
+		Schema: map[string]*schema.Schema{
+			"config_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "Unique identifier of the security configuration",
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Version of the security configuration to simulate. Defaults to the latest version",
+			},
+			"request": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Description: "Sample requests to evaluate",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name identifying the request in the results",
+						},
+						"method": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "GET",
+							Description: "HTTP method of the request",
+						},
+						"host": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Hostname the request is sent to",
+						},
+						"path": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "/",
+							Description: "Path of the request",
+						},
+						"query": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Query string arguments of the request",
+						},
+						"headers": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Headers of the request",
+						},
+						"client_ip": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "IP address of the client",
+						},
+						"client_country": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Two-letter code of the country the client is located in",
+						},
+					},
+				},
+			},
+			"network_list": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Contents of the network lists referenced by the configuration",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Unique identifier of the network list",
+						},
+						"elements": {
+							Type:        schema.TypeList,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "IP addresses, CIDR blocks or country codes of the network list",
+						},
+					},
+				},
+			},
+			"results": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Outcome of each sample request",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"request": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the request",
+						},
+						"match_target_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of the website match target applying to the request, or 0 if none does",
+						},
+						"security_policy_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the security policy protecting the request",
+						},
+						"bypassed": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the client is in a bypass network list of the match target",
+						},
+						"ip_geo_action": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Whether the IP/Geo firewall allows, denies or doesn't act on the request",
+						},
+						"custom_rules": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Custom rules matching the request",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": {
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "Unique identifier of the custom rule",
+									},
+									"name": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Name of the custom rule",
+									},
+									"action": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Action of the custom rule in the security policy",
+									},
+								},
+							},
+						},
+						"skipped_custom_rules": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Description: "IDs of the custom rules with conditions that can't be evaluated locally",
+						},
+						"action": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Action that would apply to the request",
+						},
+					},
+				},
+			},
+			"output_text": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Text representation",
+			},
+		},
+	}
+}
+
+func dataSourcePolicySimulationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	client := inst.Client(meta)
+	logger := meta.Log("APPSEC", "dataSourcePolicySimulationRead")
+
+	configID, err := tools.GetIntValue("config_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	version, err := tools.GetIntValue("version", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	if version == 0 {
+		if version, err = getLatestConfigVersion(ctx, configID, m); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	exportconfiguration, err := client.GetExportConfiguration(ctx, appsec.GetExportConfigurationRequest{ConfigID: configID, Version: version})
+	if err != nil {
+		logger.Errorf("calling 'getExportConfiguration': %s", err.Error())
+		return diag.FromErr(err)
+	}
+
+	simulator := policySimulator{export: exportconfiguration, networkLists: map[string][]string{}, now: time.Now()}
+	for _, l := range d.Get("network_list").([]interface{}) {
+		list := l.(map[string]interface{})
+		id := list["id"].(string)
+		for _, element := range list["elements"].([]interface{}) {
+			simulator.networkLists[id] = append(simulator.networkLists[id], element.(string))
+		}
+	}
+
+	results := make([]simulationResult, 0)
+	resultList := make([]map[string]interface{}, 0)
+	for _, r := range d.Get("request").([]interface{}) {
+		request := r.(map[string]interface{})
+		result := simulator.simulate(simulationRequest{
+			Name:          request["name"].(string),
+			Method:        request["method"].(string),
+			Host:          request["host"].(string),
+			Path:          request["path"].(string),
+			Query:         simulationStringMap(request["query"].(map[string]interface{})),
+			Headers:       simulationStringMap(request["headers"].(map[string]interface{})),
+			ClientIP:      request["client_ip"].(string),
+			ClientCountry: request["client_country"].(string),
+		})
+		results = append(results, result)
+
+		customRules := make([]map[string]interface{}, 0, len(result.CustomRules))
+		for _, c := range result.CustomRules {
+			customRules = append(customRules, map[string]interface{}{
+				"id":     c.ID,
+				"name":   c.Name,
+				"action": c.Action,
+			})
+		}
+		resultList = append(resultList, map[string]interface{}{
+			"request":              result.Request,
+			"match_target_id":      result.MatchTargetID,
+			"security_policy_id":   result.PolicyID,
+			"bypassed":             result.Bypassed,
+			"ip_geo_action":        result.IPGeoAction,
+			"custom_rules":         customRules,
+			"skipped_custom_rules": result.Skipped,
+			"action":               result.Action,
+		})
+	}
+
+	ots := OutputTemplates{}
+	InitTemplates(ots)
+	outputtext, err := RenderTemplates(ots, "policySimulationDS", results)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("version", version); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("results", resultList); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("output_text", outputtext); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+
+	d.SetId(fmt.Sprintf("%d:%d", configID, version))
+
+	return nil
+}
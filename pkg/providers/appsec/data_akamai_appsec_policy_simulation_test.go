@@ -0,0 +1,171 @@
+package appsec
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicySimulator(t *testing.T) {
+	export := appsec.GetExportConfigurationResponse{}
+	err := json.Unmarshal(loadFixtureBytes("testdata/TestDSPolicySimulation/ExportConfiguration.json"), &export)
+	require.NoError(t, err)
+
+	simulator := policySimulator{
+		export: &export,
+		networkLists: map[string][]string{
+			"1024_BLOCK":  {"198.51.100.0/24"},
+			"1304_GEO":    {"XX"},
+			"1305_ALLOW":  {"198.51.100.7"},
+			"1410_BYPASS": {"203.0.113.5"},
+		},
+		now: time.Now(),
+	}
+
+	tests := map[string]struct {
+		request  simulationRequest
+		expected simulationResult
+	}{
+		"custom rules": {
+			request: simulationRequest{Name: "r", Method: "POST", Host: "www.example.com", Path: "/admin/users", Headers: map[string]string{"x-debug": "1"}},
+			expected: simulationResult{
+				Request:       "r",
+				MatchTargetID: 2971337,
+				PolicyID:      "BBBB_81231",
+				IPGeoAction:   simulationNone,
+				CustomRules: []simulationCustomRule{
+					{ID: 661699, Name: "Block admin POST", Action: "deny"},
+					{ID: 661700, Name: "Debug header", Action: "alert"},
+				},
+				Skipped: []int{661701},
+				Action:  "deny",
+			},
+		},
+		"no custom rule": {
+			request: simulationRequest{Name: "r", Method: "GET", Host: "www.example.com", Path: "/admin/users"},
+			expected: simulationResult{
+				Request:       "r",
+				MatchTargetID: 2971337,
+				PolicyID:      "BBBB_81231",
+				IPGeoAction:   simulationNone,
+				Skipped:       []int{661701},
+				Action:        simulationNone,
+			},
+		},
+		"blocked IP": {
+			request: simulationRequest{Name: "r", Method: "GET", Host: "shop.example.com", Path: "/", ClientIP: "198.51.100.20"},
+			expected: simulationResult{
+				Request:       "r",
+				MatchTargetID: 2971337,
+				PolicyID:      "BBBB_81231",
+				IPGeoAction:   simulationDeny,
+				Skipped:       []int{661701},
+				Action:        simulationDeny,
+			},
+		},
+		"allowed IP": {
+			request: simulationRequest{Name: "r", Method: "GET", Host: "shop.example.com", Path: "/", ClientIP: "198.51.100.7", ClientCountry: "XX"},
+			expected: simulationResult{
+				Request:       "r",
+				MatchTargetID: 2971337,
+				PolicyID:      "BBBB_81231",
+				IPGeoAction:   simulationAllow,
+				Skipped:       []int{661701},
+				Action:        simulationNone,
+			},
+		},
+		"bypassed": {
+			request: simulationRequest{Name: "r", Method: "GET", Host: "www.example.com", Path: "/static/app.js", ClientIP: "203.0.113.5"},
+			expected: simulationResult{
+				Request:       "r",
+				MatchTargetID: 2971336,
+				Bypassed:      true,
+				IPGeoAction:   simulationNone,
+				Action:        simulationNone,
+			},
+		},
+		"no match target": {
+			request: simulationRequest{Name: "r", Method: "GET", Host: "www.example.org", Path: "/"},
+			expected: simulationResult{
+				Request:     "r",
+				IPGeoAction: simulationNone,
+				Action:      simulationNone,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, simulator.simulate(test.request))
+		})
+	}
+}
+
+func TestPolicySimulationTemplate(t *testing.T) {
+	results := []simulationResult{{
+		Request:       "admin, post",
+		MatchTargetID: 2971337,
+		PolicyID:      "BBBB_81231",
+		IPGeoAction:   simulationNone,
+		CustomRules:   []simulationCustomRule{{ID: 661699, Action: "deny"}, {ID: 661700, Action: "alert"}},
+		Action:        "deny",
+	}}
+
+	ots := OutputTemplates{}
+	InitTemplates(ots)
+	outputtext, err := RenderTemplates(ots, "policySimulationDS", results)
+	require.NoError(t, err)
+	assert.Contains(t, outputtext, "661699:deny 661700:alert")
+	assert.Contains(t, outputtext, "admin post")
+}
+
+func TestSimulationValueMatches(t *testing.T) {
+	assert.True(t, simulationValueMatches([]string{"/Admin/x"}, []string{"/admin/*"}, false, true))
+	assert.False(t, simulationValueMatches([]string{"/Admin/x"}, []string{"/admin/*"}, true, true))
+	assert.True(t, simulationValueMatches([]string{"GET"}, []string{"get"}, false, false))
+	assert.False(t, simulationValueMatches([]string{"/admin/x"}, []string{"/admin/*"}, false, false))
+}
+
+func TestAkamaiPolicySimulation_data_basic(t *testing.T) {
+	t.Run("match by PolicySimulation ID", func(t *testing.T) {
+		client := &appsec.Mock{}
+
+		export := appsec.GetExportConfigurationResponse{}
+		err := json.Unmarshal(loadFixtureBytes("testdata/TestDSPolicySimulation/ExportConfiguration.json"), &export)
+		require.NoError(t, err)
+
+		client.On("GetExportConfiguration",
+			mock.Anything,
+			appsec.GetExportConfigurationRequest{ConfigID: 43253, Version: 7},
+		).Return(&export, nil)
+
+		useClient(client, func() {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:        true,
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestDSPolicySimulation/match_by_id.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("data.akamai_appsec_policy_simulation.test", "id", "43253:7"),
+							resource.TestCheckResourceAttr("data.akamai_appsec_policy_simulation.test", "results.0.security_policy_id", "BBBB_81231"),
+							resource.TestCheckResourceAttr("data.akamai_appsec_policy_simulation.test", "results.0.custom_rules.#", "2"),
+							resource.TestCheckResourceAttr("data.akamai_appsec_policy_simulation.test", "results.0.skipped_custom_rules.0", "661701"),
+							resource.TestCheckResourceAttr("data.akamai_appsec_policy_simulation.test", "results.0.action", "deny"),
+							resource.TestCheckResourceAttr("data.akamai_appsec_policy_simulation.test", "results.1.ip_geo_action", "deny"),
+							resource.TestCheckResourceAttr("data.akamai_appsec_policy_simulation.test", "results.1.action", "deny"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
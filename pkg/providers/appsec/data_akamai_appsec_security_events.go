@@ -0,0 +1,358 @@
+package appsec
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceSecurityEvents() *schema.Resource {
+	securityEventCountSchema := func(key, keyDescription, message, messageDescription string) *schema.Resource {
+		return &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				key: {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: keyDescription,
+				},
+				message: {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: messageDescription,
+				},
+				"events": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Number of security events",
+				},
+				"denied": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Number of security events whose request was denied",
+				},
+			},
+		}
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceSecurityEventsRead,
+		Schema: map[string]*schema.Schema{
+			"config_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "Unique identifier of the security configuration",
+			},
+			"offset": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"from", "to"},
+				Description:   "Offset returned by a previous read, from which to fetch the next security events",
+			},
+			"from": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Start of the time window, in seconds since the epoch",
+			},
+			"to": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"from"},
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "End of the time window, in seconds since the epoch",
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10000,
+				ValidateFunc: validation.IntBetween(1, 600000),
+				Description:  "Maximum number of security events to fetch",
+			},
+			"security_policy_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the security events of this security policy",
+			},
+			"rule_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the security events that triggered this rule",
+			},
+			"top": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Number of rules and clients in top_rules and top_clients",
+			},
+			"events": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Security events, with their rule fields and headers decoded",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"request_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Unique identifier of the request",
+						},
+						"time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time the request started, in RFC 3339 format",
+						},
+						"security_policy_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the security policy that protected the request",
+						},
+						"client_ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "IP address of the client",
+						},
+						"country": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Country the client is located in",
+						},
+						"asn": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Autonomous system number of the client",
+						},
+						"method": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "HTTP method of the request",
+						},
+						"host": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Hostname of the request",
+						},
+						"path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Path of the request",
+						},
+						"query": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Query string of the request",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "HTTP status code of the response",
+						},
+						"request_headers": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Headers of the request",
+						},
+						"action": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Most severe action taken by the rules triggered by the request",
+						},
+						"rules": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Rules triggered by the request",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Unique identifier of the rule",
+									},
+									"version": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Version of the rule",
+									},
+									"message": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Message of the rule",
+									},
+									"tag": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Tag of the rule",
+									},
+									"data": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Part of the request that triggered the rule",
+									},
+									"selector": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Location of the data in the request",
+									},
+									"action": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Action taken by the rule",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"top_rules": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Rules triggered by the most security events",
+				Elem:        securityEventCountSchema("id", "Unique identifier of the rule", "message", "Message of the rule"),
+			},
+			"top_clients": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Clients sending the requests of the most security events",
+				Elem:        securityEventCountSchema("client_ip", "IP address of the client", "country", "Country the client is located in"),
+			},
+			"total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of security events returned by the SIEM API, before filtering",
+			},
+			"next_offset": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Offset from which to fetch the security events following these",
+			},
+			"output_text": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Text representation",
+			},
+		},
+	}
+}
+
+func dataSourceSecurityEventsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	client := inst.SIEMClient(meta)
+	logger := meta.Log("APPSEC", "dataSourceSecurityEventsRead")
+
+	configID, err := tools.GetIntValue("config_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	request := GetSecurityEventsRequest{
+		ConfigID: configID,
+		Offset:   d.Get("offset").(string),
+		From:     int64(d.Get("from").(int)),
+		To:       int64(d.Get("to").(int)),
+		Limit:    d.Get("limit").(int),
+	}
+	policyID := d.Get("security_policy_id").(string)
+	ruleID := d.Get("rule_id").(string)
+
+	securityevents, err := client.GetSecurityEvents(ctx, request)
+	if err != nil {
+		logger.Errorf("calling 'getSecurityEvents': %s", err.Error())
+		return diag.FromErr(err)
+	}
+
+	records := make([]securityEventRecord, 0, len(securityevents.Events))
+	for _, event := range securityevents.Events {
+		record, err := decodeSecurityEvent(event)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if record.matches(policyID, ruleID) {
+			records = append(records, record)
+		}
+	}
+	summary := summarizeSecurityEvents(records, d.Get("top").(int))
+
+	eventList := make([]map[string]interface{}, 0, len(records))
+	for _, record := range records {
+		rules := make([]map[string]interface{}, 0, len(record.Rules))
+		for _, r := range record.Rules {
+			rules = append(rules, map[string]interface{}{
+				"id":       r.ID,
+				"version":  r.Version,
+				"message":  r.Message,
+				"tag":      r.Tag,
+				"data":     r.Data,
+				"selector": r.Selector,
+				"action":   r.Action,
+			})
+		}
+		eventList = append(eventList, map[string]interface{}{
+			"request_id":         record.RequestID,
+			"time":               record.Time,
+			"security_policy_id": record.PolicyID,
+			"client_ip":          record.ClientIP,
+			"country":            record.Country,
+			"asn":                record.ASN,
+			"method":             record.Method,
+			"host":               record.Host,
+			"path":               record.Path,
+			"query":              record.Query,
+			"status":             record.Status,
+			"request_headers":    record.RequestHeaders,
+			"action":             record.Action,
+			"rules":              rules,
+		})
+	}
+	topRules := make([]map[string]interface{}, 0, len(summary.TopRules))
+	for _, c := range summary.TopRules {
+		topRules = append(topRules, map[string]interface{}{
+			"id":      c.Key,
+			"message": c.Message,
+			"events":  c.Events,
+			"denied":  c.Denied,
+		})
+	}
+	topClients := make([]map[string]interface{}, 0, len(summary.TopClients))
+	for _, c := range summary.TopClients {
+		topClients = append(topClients, map[string]interface{}{
+			"client_ip": c.Key,
+			"country":   c.Message,
+			"events":    c.Events,
+			"denied":    c.Denied,
+		})
+	}
+
+	ots := OutputTemplates{}
+	InitTemplates(ots)
+	outputtext, err := RenderTemplates(ots, "securityEventsDS", summary)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("events", eventList); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("top_rules", topRules); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("top_clients", topClients); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("total", securityevents.Total); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("next_offset", securityevents.Offset); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("output_text", outputtext); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+
+	d.SetId(strconv.Itoa(configID))
+
+	return nil
+}
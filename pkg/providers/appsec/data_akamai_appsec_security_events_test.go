@@ -0,0 +1,83 @@
+package appsec
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAkamaiSecurityEvents_data_basic(t *testing.T) {
+	response, err := parseSecurityEvents(loadFixtureBytes("testdata/TestDSSecurityEvents/SecurityEvents.ndjson"))
+	require.NoError(t, err)
+
+	t.Run("match by time window and rule", func(t *testing.T) {
+		client := &appsec.Mock{}
+		siemClient := &mockSIEM{}
+		siemClient.On("GetSecurityEvents",
+			mock.Anything,
+			GetSecurityEventsRequest{ConfigID: 43253, From: 1678888800, To: 1678892400, Limit: 10000},
+		).Return(response, nil)
+
+		useClient(client, func() {
+			useSIEMClient(siemClient, func() {
+				resource.Test(t, resource.TestCase{
+					IsUnitTest:        true,
+					ProviderFactories: testAccProviders,
+					Steps: []resource.TestStep{
+						{
+							Config: loadFixtureString("testdata/TestDSSecurityEvents/match_by_id.tf"),
+							Check: resource.ComposeAggregateTestCheckFunc(
+								resource.TestCheckResourceAttr("data.akamai_appsec_security_events.test", "id", "43253"),
+								resource.TestCheckResourceAttr("data.akamai_appsec_security_events.test", "events.#", "2"),
+								resource.TestCheckResourceAttr("data.akamai_appsec_security_events.test", "events.0.rules.0.message", "System Command Access"),
+								resource.TestCheckResourceAttr("data.akamai_appsec_security_events.test", "events.0.action", "deny"),
+								resource.TestCheckResourceAttr("data.akamai_appsec_security_events.test", "events.1.security_policy_id", "BBBB_81231"),
+								resource.TestCheckResourceAttr("data.akamai_appsec_security_events.test", "top_rules.0.id", "950002"),
+								resource.TestCheckResourceAttr("data.akamai_appsec_security_events.test", "top_rules.0.events", "2"),
+								resource.TestCheckResourceAttr("data.akamai_appsec_security_events.test", "top_rules.0.denied", "1"),
+								resource.TestCheckResourceAttr("data.akamai_appsec_security_events.test", "top_clients.#", "1"),
+								resource.TestCheckResourceAttr("data.akamai_appsec_security_events.test", "total", "3"),
+								resource.TestCheckResourceAttr("data.akamai_appsec_security_events.test", "next_offset", "faf3-2b6f-aa00-1d4b"),
+							),
+						},
+					},
+				})
+			})
+		})
+
+		siemClient.AssertExpectations(t)
+	})
+
+	t.Run("match by offset", func(t *testing.T) {
+		client := &appsec.Mock{}
+		siemClient := &mockSIEM{}
+		siemClient.On("GetSecurityEvents",
+			mock.Anything,
+			GetSecurityEventsRequest{ConfigID: 43253, Offset: "faf3-2b6f-aa00-1d4b", Limit: 100},
+		).Return(response, nil)
+
+		useClient(client, func() {
+			useSIEMClient(siemClient, func() {
+				resource.Test(t, resource.TestCase{
+					IsUnitTest:        true,
+					ProviderFactories: testAccProviders,
+					Steps: []resource.TestStep{
+						{
+							Config: loadFixtureString("testdata/TestDSSecurityEvents/offset.tf"),
+							Check: resource.ComposeAggregateTestCheckFunc(
+								resource.TestCheckResourceAttr("data.akamai_appsec_security_events.test", "events.#", "3"),
+								resource.TestCheckResourceAttr("data.akamai_appsec_security_events.test", "top_clients.0.client_ip", "198.51.100.7"),
+								resource.TestCheckResourceAttr("data.akamai_appsec_security_events.test", "top_clients.1.denied", "1"),
+							),
+						},
+					},
+				})
+			})
+		})
+
+		siemClient.AssertExpectations(t)
+	})
+}
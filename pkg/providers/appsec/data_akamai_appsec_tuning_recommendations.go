@@ -54,6 +54,12 @@ func dataSourceTuningRecommendations() *schema.Resource {
 				Computed:    true,
 				Description: "JSON-formatted list of the tuning recommendations for the security policy, attack group or rule",
 			},
+			"recommendation_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the tuning recommendations, to be applied with the akamai_appsec_applied_tuning_recommendations resource",
+			},
 		},
 	}
 }
@@ -100,6 +106,7 @@ func dataSourceTuningRecommendationsRead(ctx context.Context, d *schema.Resource
 	}
 
 	var jsonBody []byte
+	var recommendationIDs []string
 
 	version, err := getLatestConfigVersion(ctx, configID, m)
 	if err != nil {
@@ -121,6 +128,13 @@ func dataSourceTuningRecommendationsRead(ctx context.Context, d *schema.Resource
 			return diag.FromErr(err)
 		}
 
+		recommendationIDs, err = tuningRecommendationIDs(&appsec.GetTuningRecommendationsResponse{
+			AttackGroupRecommendations: []appsec.AttackGroupRecommendation{appsec.AttackGroupRecommendation(*response)},
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
 		jsonBody, err = json.Marshal(response)
 		if err != nil {
 			return diag.FromErr(err)
@@ -139,6 +153,13 @@ func dataSourceTuningRecommendationsRead(ctx context.Context, d *schema.Resource
 			return diag.FromErr(err)
 		}
 
+		recommendationIDs, err = tuningRecommendationIDs(&appsec.GetTuningRecommendationsResponse{
+			RuleRecommendations: []appsec.RuleRecommendation{appsec.RuleRecommendation(*response)},
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
 		jsonBody, err = json.Marshal(response)
 		if err != nil {
 			return diag.FromErr(err)
@@ -158,6 +179,11 @@ func dataSourceTuningRecommendationsRead(ctx context.Context, d *schema.Resource
 			return diag.FromErr(err)
 		}
 
+		recommendationIDs, err = tuningRecommendationIDs(response)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
 		jsonBody, err = json.Marshal(response)
 		if err != nil {
 			return diag.FromErr(err)
@@ -167,6 +193,9 @@ func dataSourceTuningRecommendationsRead(ctx context.Context, d *schema.Resource
 	if err := d.Set("json", string(jsonBody)); err != nil {
 		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
 	}
+	if err := d.Set("recommendation_ids", recommendationIDs); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
 
 	d.SetId(strconv.Itoa(configID))
 
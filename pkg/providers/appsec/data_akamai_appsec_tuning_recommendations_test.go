@@ -61,6 +61,7 @@ func TestAkamaiTuningRecommendationsDataBasic(t *testing.T) {
 						Config: loadFixtureString("testdata/TestDSTuningRecommendations/match_by_id.tf"),
 						Check: resource.ComposeAggregateTestCheckFunc(
 							resource.TestCheckResourceAttr("data.akamai_appsec_tuning_recommendations.recommendations", "id", "43253"),
+							resource.TestCheckResourceAttr("data.akamai_appsec_tuning_recommendations.recommendations", "recommendation_ids.0", "attack_group:XSS:86417fbf"),
 						),
 					},
 				},
@@ -0,0 +1,331 @@
+package appsec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Kinds of configuration objects only compared by akamai_appsec_version_diff.
+const (
+	docRuleAction             = "rule_action"
+	docRuleException          = "rule_exception"
+	docAttackGroupAction      = "attack_group_action"
+	docAttackGroupException   = "attack_group_exception"
+	versionDiffDefaultNetwork = "PRODUCTION"
+)
+
+// versionDiffKindOrder is the order changes are reported in.
+var versionDiffKindOrder = append(append([]string{}, documentKindOrder...),
+	docRuleAction,
+	docRuleException,
+	docAttackGroupAction,
+	docAttackGroupException,
+)
+
+// versionDiffActions maps the action of a documentChange to the way it's reported.
+var versionDiffActions = map[string]string{
+	docCreate: "added",
+	docUpdate: "changed",
+	docRemove: "removed",
+}
+
+// versionDiffChange is a difference between two versions as rendered by the versionDiffDS template.
+type versionDiffChange struct {
+	Action string
+	Kind   string
+	Policy string
+	Name   string
+	From   string
+	To     string
+}
+
+func dataSourceVersionDiff() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceVersionDiffRead,
+		Schema: map[string]*schema.Schema{
+			"config_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "Unique identifier of the security configuration",
+			},
+			"from_version": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"from_network"},
+				Description:   "Version to compare from. Defaults to the version active on from_network",
+			},
+			"from_network": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ConflictsWith:    []string{"from_version"},
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"STAGING", "PRODUCTION"}, false)),
+				Description:      "Network whose active version is compared from (STAGING or PRODUCTION). Defaults to PRODUCTION",
+			},
+			"to_version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Version to compare to. Defaults to the latest version",
+			},
+			"changes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Objects that differ between the two versions",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Whether the object was added, changed or removed",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the object, such as custom_rule or rule_action",
+						},
+						"security_policy": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the security policy the object belongs to, if any",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the object, or its ID for objects without a name",
+						},
+						"from": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "JSON-formatted object in from_version",
+						},
+						"to": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "JSON-formatted object in to_version",
+						},
+					},
+				},
+			},
+			"summary": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "Number of changed objects by type",
+			},
+			"output_text": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Text representation",
+			},
+		},
+	}
+}
+
+func dataSourceVersionDiffRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	client := inst.Client(meta)
+	logger := meta.Log("APPSEC", "dataSourceVersionDiffRead")
+
+	configID, err := tools.GetIntValue("config_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	fromVersion, err := tools.GetIntValue("from_version", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	if fromVersion == 0 {
+		network, err := tools.GetStringValue("from_network", d)
+		if err != nil && !errors.Is(err, tools.ErrNotFound) {
+			return diag.FromErr(err)
+		}
+		if network == "" {
+			network = versionDiffDefaultNetwork
+		}
+		stagingVersion, productionVersion, err := getActiveConfigVersions(ctx, configID, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		fromVersion = productionVersion
+		if network == "STAGING" {
+			fromVersion = stagingVersion
+		}
+		if fromVersion == 0 {
+			return diag.Errorf("no version of security configuration %d is active on %s", configID, network)
+		}
+	}
+
+	toVersion, err := tools.GetIntValue("to_version", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	if toVersion == 0 {
+		if toVersion, err = getLatestConfigVersion(ctx, configID, m); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	documents := make([]*configurationDocument, 0, 2)
+	for _, version := range []int{fromVersion, toVersion} {
+		exportconfiguration, err := client.GetExportConfiguration(ctx, appsec.GetExportConfigurationRequest{ConfigID: configID, Version: version})
+		if err != nil {
+			logger.Errorf("calling 'getExportConfiguration': %s", err.Error())
+			return diag.FromErr(err)
+		}
+		document, err := newVersionDiffDocument(exportconfiguration)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		documents = append(documents, document)
+	}
+
+	changes := compareConfigurationDocuments(documents[0], documents[1])
+	changeList := make([]map[string]interface{}, 0, len(changes))
+	summary := map[string]int{}
+	for _, c := range changes {
+		changeList = append(changeList, map[string]interface{}{
+			"action":          c.Action,
+			"type":            c.Kind,
+			"security_policy": c.Policy,
+			"name":            c.Name,
+			"from":            c.From,
+			"to":              c.To,
+		})
+		summary[c.Kind]++
+	}
+
+	ots := OutputTemplates{}
+	InitTemplates(ots)
+	outputtext, err := RenderTemplates(ots, "versionDiffDS", changes)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("from_version", fromVersion); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("to_version", toVersion); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("changes", changeList); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("summary", summary); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("output_text", outputtext); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+
+	d.SetId(fmt.Sprintf("%d:%d:%d", configID, fromVersion, toVersion))
+
+	return nil
+}
+
+// newVersionDiffDocument splits an exported security configuration version into the objects
+// managed by akamai_appsec_configuration_document, plus the rule and attack group actions and
+// exceptions of each security policy.
+func newVersionDiffDocument(export *appsec.GetExportConfigurationResponse) (*configurationDocument, error) {
+	doc, err := newConfigurationDocument(export)
+	if err != nil {
+		return nil, err
+	}
+	policyNames := make(map[string]string, len(doc.policies))
+	for name, id := range doc.policies {
+		policyNames[id] = name
+	}
+
+	for _, p := range export.SecurityPolicies {
+		policy := policyNames[p.ID]
+		for _, r := range p.WebApplicationFirewall.RuleActions {
+			name := strconv.Itoa(r.ID)
+			if err := doc.add(docRuleAction, policy, name, map[string]string{"action": r.Action}); err != nil {
+				return nil, err
+			}
+			if r.Conditions == nil && r.Exception == nil && r.AdvancedExceptionsList == nil {
+				continue
+			}
+			exception := map[string]interface{}{
+				"conditions":         r.Conditions,
+				"exception":          r.Exception,
+				"advancedExceptions": r.AdvancedExceptionsList,
+			}
+			if err := doc.add(docRuleException, policy, name, exception); err != nil {
+				return nil, err
+			}
+		}
+		for _, g := range p.WebApplicationFirewall.AttackGroupActions {
+			if err := doc.add(docAttackGroupAction, policy, g.Group, map[string]string{"action": g.Action}); err != nil {
+				return nil, err
+			}
+			if g.Exception == nil && g.AdvancedExceptionsList == nil {
+				continue
+			}
+			exception := map[string]interface{}{
+				"exception":          g.Exception,
+				"advancedExceptions": g.AdvancedExceptionsList,
+			}
+			if err := doc.add(docAttackGroupException, policy, g.Group, exception); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return doc, nil
+}
+
+// compareConfigurationDocuments returns every object that differs between from and to, ordered by type.
+func compareConfigurationDocuments(from, to *configurationDocument) []versionDiffChange {
+	rank := make(map[string]int, len(versionDiffKindOrder))
+	for i, kind := range versionDiffKindOrder {
+		rank[kind] = i
+	}
+
+	var changes []documentChange
+	for key, o := range from.objects {
+		if _, ok := to.objects[key]; !ok {
+			changes = append(changes, documentChange{Action: docRemove, Object: o})
+		}
+	}
+	for key, o := range to.objects {
+		previous, ok := from.objects[key]
+		switch {
+		case !ok:
+			changes = append(changes, documentChange{Action: docCreate, Object: o})
+		case previous.Body != o.Body:
+			changes = append(changes, documentChange{Action: docUpdate, Object: o})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := changes[i].Object, changes[j].Object
+		if rank[a.Kind] != rank[b.Kind] {
+			return rank[a.Kind] < rank[b.Kind]
+		}
+		return a.key() < b.key()
+	})
+
+	result := make([]versionDiffChange, 0, len(changes))
+	for _, c := range changes {
+		o := c.Object
+		result = append(result, versionDiffChange{
+			Action: versionDiffActions[c.Action],
+			Kind:   o.Kind,
+			Policy: o.Policy,
+			Name:   o.Name,
+			From:   from.objects[o.key()].Body,
+			To:     to.objects[o.key()].Body,
+		})
+	}
+	return result
+}
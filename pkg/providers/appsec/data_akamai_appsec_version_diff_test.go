@@ -0,0 +1,97 @@
+package appsec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func loadVersionDiffExport(t *testing.T, path string) *appsec.GetExportConfigurationResponse {
+	export := appsec.GetExportConfigurationResponse{}
+	err := json.Unmarshal(loadFixtureBytes(path), &export)
+	require.NoError(t, err)
+	return &export
+}
+
+func TestCompareConfigurationDocuments(t *testing.T) {
+	from, err := newVersionDiffDocument(loadVersionDiffExport(t, "testdata/TestDSVersionDiff/From.json"))
+	require.NoError(t, err)
+	to, err := newVersionDiffDocument(loadVersionDiffExport(t, "testdata/TestDSVersionDiff/To.json"))
+	require.NoError(t, err)
+
+	changes := compareConfigurationDocuments(from, to)
+	var report []string
+	for _, c := range changes {
+		report = append(report, c.Action+" "+documentObject{Kind: c.Kind, Policy: c.Policy, Name: c.Name}.String())
+	}
+	assert.Equal(t, []string{
+		`changed custom_rule "Bad bots"`,
+		`added rate_policy "Page views"`,
+		`changed match_target "2001" in security policy "Main"`,
+		`changed rule_action "950002" in security policy "Main"`,
+		`added rule_exception "950006" in security policy "Main"`,
+		`removed attack_group_action "XSS" in security policy "Main"`,
+	}, report)
+
+	assert.JSONEq(t, `{"action":"alert"}`, changes[3].From)
+	assert.JSONEq(t, `{"action":"deny"}`, changes[3].To)
+	assert.Empty(t, changes[5].To)
+	assert.Empty(t, compareConfigurationDocuments(to, to))
+
+	ots := OutputTemplates{}
+	InitTemplates(ots)
+	outputtext, err := RenderTemplates(ots, "versionDiffDS", changes)
+	require.NoError(t, err)
+	assert.Contains(t, outputtext, "| changed | rule_action         | Main            | 950002     |")
+}
+
+func TestAkamaiVersionDiff_data_basic(t *testing.T) {
+	t.Run("match by VersionDiff ID", func(t *testing.T) {
+		client := &appsec.Mock{}
+
+		config := appsec.GetConfigurationResponse{}
+		err := json.Unmarshal(loadFixtureBytes("testdata/TestResConfiguration/LatestConfiguration.json"), &config)
+		require.NoError(t, err)
+
+		client.On("GetConfiguration",
+			mock.Anything,
+			appsec.GetConfigurationRequest{ConfigID: 43253},
+		).Return(&config, nil)
+
+		client.On("GetExportConfiguration",
+			mock.Anything,
+			appsec.GetExportConfigurationRequest{ConfigID: 43253, Version: 5},
+		).Return(loadVersionDiffExport(t, "testdata/TestDSVersionDiff/From.json"), nil)
+
+		client.On("GetExportConfiguration",
+			mock.Anything,
+			appsec.GetExportConfigurationRequest{ConfigID: 43253, Version: 7},
+		).Return(loadVersionDiffExport(t, "testdata/TestDSVersionDiff/To.json"), nil)
+
+		useClient(client, func() {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:        true,
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestDSVersionDiff/match_by_id.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("data.akamai_appsec_version_diff.test", "id", "43253:5:7"),
+							resource.TestCheckResourceAttr("data.akamai_appsec_version_diff.test", "to_version", "7"),
+							resource.TestCheckResourceAttr("data.akamai_appsec_version_diff.test", "changes.#", "6"),
+							resource.TestCheckResourceAttr("data.akamai_appsec_version_diff.test", "changes.1.action", "added"),
+							resource.TestCheckResourceAttr("data.akamai_appsec_version_diff.test", "summary.rule_action", "1"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
@@ -351,3 +351,15 @@ func compareMatchTargets(oldTarget, newTarget *appsec.CreateMatchTargetResponse)
 	})
 	return reflect.DeepEqual(oldTarget, newTarget)
 }
+
+func suppressEquivalentConfigurationDocuments(_, oldString, newString string, _ *schema.ResourceData) bool {
+	oldDocument, err := parseConfigurationDocument(oldString)
+	if err != nil {
+		return false
+	}
+	newDocument, err := parseConfigurationDocument(newString)
+	if err != nil {
+		return false
+	}
+	return len(planConfigurationDocument(oldDocument, newDocument)) == 0
+}
@@ -0,0 +1,493 @@
+// Package hclgen renders an exported Application Security configuration as a complete Terraform module.
+package hclgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	// VariablesFile holds the input variables of the generated module.
+	VariablesFile = "variables.tf"
+	// ResourcesFile holds the resource blocks of the generated module.
+	ResourcesFile = "appsec.tf"
+	// ImportsFile holds the import blocks mapping every resource onto the existing configuration.
+	ImportsFile = "imports.tf"
+
+	// DefaultJSONDirectory is the directory, relative to the module, receiving the JSON attribute files.
+	DefaultJSONDirectory = "json"
+
+	configLabel = "config"
+)
+
+// Options controls the layout of the generated module.
+type Options struct {
+	// JSONDirectory is the directory, relative to the module, the JSON attribute files are written to.
+	JSONDirectory string
+}
+
+type generator struct {
+	export  *appsec.GetExportConfigurationResponse
+	opts    Options
+	main    *hclwrite.File
+	imports *hclwrite.File
+	files   map[string]string
+	labels  map[string]map[string]struct{}
+
+	policies     map[string]string
+	customRules  map[int]string
+	ratePolicies map[int]string
+	profiles     map[int]string
+}
+
+var invalidLabelChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Generate renders the given export as a set of files keyed by their path relative to the module root.
+// The result contains VariablesFile, ResourcesFile, ImportsFile and one JSON file per JSON attribute.
+func Generate(export *appsec.GetExportConfigurationResponse, opts Options) (map[string]string, error) {
+	if export == nil {
+		return nil, fmt.Errorf("export configuration is required")
+	}
+	if opts.JSONDirectory == "" {
+		opts.JSONDirectory = DefaultJSONDirectory
+	}
+	g := &generator{
+		export:       export,
+		opts:         opts,
+		main:         hclwrite.NewEmptyFile(),
+		imports:      hclwrite.NewEmptyFile(),
+		files:        map[string]string{},
+		labels:       map[string]map[string]struct{}{},
+		policies:     map[string]string{},
+		customRules:  map[int]string{},
+		ratePolicies: map[int]string{},
+		profiles:     map[int]string{},
+	}
+
+	steps := []func() error{
+		g.addConfiguration,
+		g.addSecurityPolicies,
+		g.addCustomRules,
+		g.addRateAndReputation,
+		g.addMatchTargets,
+		g.addPolicyControls,
+		g.addAdvancedSettings,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return nil, err
+		}
+	}
+
+	g.files[VariablesFile] = string(variables().Bytes())
+	g.files[ResourcesFile] = string(hclwrite.Format(g.main.Bytes()))
+	g.files[ImportsFile] = string(hclwrite.Format(g.imports.Bytes()))
+	return g.files, nil
+}
+
+func variables() *hclwrite.File {
+	f := hclwrite.NewEmptyFile()
+	for i, v := range []struct{ name, description string }{
+		{"contract_id", "Contract the security configuration belongs to"},
+		{"group_id", "Group the security configuration belongs to"},
+		{"description", "Description of the security configuration"},
+	} {
+		if i > 0 {
+			f.Body().AppendNewline()
+		}
+		b := f.Body().AppendNewBlock("variable", []string{v.name}).Body()
+		b.SetAttributeTraversal("type", hcl.Traversal{hcl.TraverseRoot{Name: "string"}})
+		b.SetAttributeValue("description", cty.StringVal(v.description))
+	}
+	return f
+}
+
+// label returns a unique resource name for the given resource type built from the name parts.
+func (g *generator) label(resourceType string, parts ...string) string {
+	base := strings.Trim(invalidLabelChars.ReplaceAllString(strings.ToLower(strings.Join(parts, "_")), "_"), "_")
+	if base == "" || (base[0] >= '0' && base[0] <= '9') {
+		base = "r_" + base
+	}
+	used, ok := g.labels[resourceType]
+	if !ok {
+		used = map[string]struct{}{}
+		g.labels[resourceType] = used
+	}
+	label := base
+	for i := 2; ; i++ {
+		if _, ok := used[label]; !ok {
+			break
+		}
+		label = fmt.Sprintf("%s_%d", base, i)
+	}
+	used[label] = struct{}{}
+	return label
+}
+
+// resource appends a resource block and its matching import block.
+func (g *generator) resource(resourceType, label, importID string) *hclwrite.Body {
+	if len(g.main.Body().Blocks()) > 0 {
+		g.main.Body().AppendNewline()
+	}
+	body := g.main.Body().AppendNewBlock("resource", []string{resourceType, label}).Body()
+
+	if len(g.imports.Body().Blocks()) > 0 {
+		g.imports.Body().AppendNewline()
+	}
+	imp := g.imports.Body().AppendNewBlock("import", nil).Body()
+	imp.SetAttributeTraversal("to", traversal(resourceType, label))
+	imp.SetAttributeValue("id", cty.StringVal(importID))
+
+	body.SetAttributeTraversal("config_id", traversal("akamai_appsec_configuration", configLabel, "config_id"))
+	return body
+}
+
+// jsonFile writes v to the JSON directory and returns the expression loading it.
+func (g *generator) jsonFile(name string, v interface{}, withoutID bool) (hclwrite.Tokens, error) {
+	body, err := marshalJSON(v, withoutID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	rel := path.Join(g.opts.JSONDirectory, name+".json")
+	g.files[rel] = body
+
+	return hclwrite.TokensForFunctionCall("file", hclwrite.Tokens{
+		{Type: hclsyntax.TokenOQuote, Bytes: []byte(`"`)},
+		{Type: hclsyntax.TokenTemplateInterp, Bytes: []byte("${")},
+		{Type: hclsyntax.TokenIdent, Bytes: []byte("path")},
+		{Type: hclsyntax.TokenDot, Bytes: []byte(".")},
+		{Type: hclsyntax.TokenIdent, Bytes: []byte("module")},
+		{Type: hclsyntax.TokenTemplateSeqEnd, Bytes: []byte("}")},
+		{Type: hclsyntax.TokenQuotedLit, Bytes: []byte("/" + strings.ReplaceAll(rel, "$", "$$"))},
+		{Type: hclsyntax.TokenCQuote, Bytes: []byte(`"`)},
+	}), nil
+}
+
+func (g *generator) policyReference(body *hclwrite.Body, policyID string) {
+	if label, ok := g.policies[policyID]; ok {
+		body.SetAttributeTraversal("security_policy_id", traversal("akamai_appsec_security_policy", label, "security_policy_id"))
+		return
+	}
+	body.SetAttributeValue("security_policy_id", cty.StringVal(policyID))
+}
+
+func objectReference(body *hclwrite.Body, attribute string, labels map[int]string, resourceType string, id int) {
+	if label, ok := labels[id]; ok {
+		body.SetAttributeTraversal(attribute, traversal(resourceType, label, attribute))
+		return
+	}
+	body.SetAttributeValue(attribute, cty.NumberIntVal(int64(id)))
+}
+
+func (g *generator) addConfiguration() error {
+	e := g.export
+	body := g.main.Body().AppendNewBlock("resource", []string{"akamai_appsec_configuration", configLabel}).Body()
+	body.SetAttributeValue("name", cty.StringVal(e.ConfigName))
+	body.SetAttributeTraversal("description", traversal("var", "description"))
+	body.SetAttributeTraversal("contract_id", traversal("var", "contract_id"))
+	body.SetAttributeTraversal("group_id", traversal("var", "group_id"))
+	body.SetAttributeValue("host_names", stringList(e.SelectedHosts))
+	g.labels["akamai_appsec_configuration"] = map[string]struct{}{configLabel: {}}
+
+	imp := g.imports.Body().AppendNewBlock("import", nil).Body()
+	imp.SetAttributeTraversal("to", traversal("akamai_appsec_configuration", configLabel))
+	imp.SetAttributeValue("id", cty.StringVal(strconv.Itoa(e.ConfigID)))
+	return nil
+}
+
+func (g *generator) addSecurityPolicies() error {
+	for _, p := range g.export.SecurityPolicies {
+		label := g.label("akamai_appsec_security_policy", p.ID)
+		g.policies[p.ID] = label
+
+		body := g.resource("akamai_appsec_security_policy", label, fmt.Sprintf("%d:%s", g.export.ConfigID, p.ID))
+		body.SetAttributeValue("security_policy_name", cty.StringVal(p.Name))
+		body.SetAttributeValue("security_policy_prefix", cty.StringVal(strings.SplitN(p.ID, "_", 2)[0]))
+		body.SetAttributeValue("default_settings", cty.True)
+	}
+	return nil
+}
+
+func (g *generator) addCustomRules() error {
+	for _, r := range g.export.CustomRules {
+		label := g.label("akamai_appsec_custom_rule", r.Name, strconv.Itoa(r.ID))
+		g.customRules[r.ID] = label
+
+		expr, err := g.jsonFile(fmt.Sprintf("custom_rule_%d", r.ID), r, true)
+		if err != nil {
+			return err
+		}
+		body := g.resource("akamai_appsec_custom_rule", label, fmt.Sprintf("%d:%d", g.export.ConfigID, r.ID))
+		body.SetAttributeRaw("custom_rule", expr)
+	}
+	return nil
+}
+
+func (g *generator) addRateAndReputation() error {
+	for _, r := range g.export.RatePolicies {
+		label := g.label("akamai_appsec_rate_policy", r.Name, strconv.Itoa(r.ID))
+		g.ratePolicies[r.ID] = label
+
+		expr, err := g.jsonFile(fmt.Sprintf("rate_policy_%d", r.ID), r, true)
+		if err != nil {
+			return err
+		}
+		body := g.resource("akamai_appsec_rate_policy", label, fmt.Sprintf("%d:%d", g.export.ConfigID, r.ID))
+		body.SetAttributeRaw("rate_policy", expr)
+	}
+	for _, r := range g.export.ReputationProfiles {
+		label := g.label("akamai_appsec_reputation_profile", r.Name, strconv.Itoa(r.ID))
+		g.profiles[r.ID] = label
+
+		expr, err := g.jsonFile(fmt.Sprintf("reputation_profile_%d", r.ID), r, true)
+		if err != nil {
+			return err
+		}
+		body := g.resource("akamai_appsec_reputation_profile", label, fmt.Sprintf("%d:%d", g.export.ConfigID, r.ID))
+		body.SetAttributeRaw("reputation_profile", expr)
+	}
+	return nil
+}
+
+func (g *generator) addMatchTargets() error {
+	type target struct {
+		id       int
+		policyID string
+		value    interface{}
+	}
+	var targets []target
+	for _, t := range g.export.MatchTargets.WebsiteTargets {
+		targets = append(targets, target{t.ID, t.SecurityPolicy.PolicyID, t})
+	}
+	for _, t := range g.export.MatchTargets.APITargets {
+		id := t.TargetID
+		if id == 0 {
+			id = t.ID
+		}
+		targets = append(targets, target{id, t.SecurityPolicy.PolicyID, t})
+	}
+
+	for _, t := range targets {
+		label := g.label("akamai_appsec_match_target", "match_target", strconv.Itoa(t.id))
+		expr, err := g.jsonFile(fmt.Sprintf("match_target_%d", t.id), t.value, true)
+		if err != nil {
+			return err
+		}
+		body := g.resource("akamai_appsec_match_target", label, fmt.Sprintf("%d:%d", g.export.ConfigID, t.id))
+		body.SetAttributeRaw("match_target", expr)
+		// The policy is referenced from within the JSON document, so the ordering has to be explicit.
+		if policy, ok := g.policies[t.policyID]; ok {
+			body.SetAttributeRaw("depends_on", hclwrite.TokensForTuple([]hclwrite.Tokens{
+				hclwrite.TokensForTraversal(traversal("akamai_appsec_security_policy", policy)),
+			}))
+		}
+	}
+	return nil
+}
+
+func (g *generator) addPolicyControls() error {
+	cfg := g.export.ConfigID
+	for _, p := range g.export.SecurityPolicies {
+		policy := g.policies[p.ID]
+
+		for _, a := range p.CustomRuleActions {
+			body := g.resource("akamai_appsec_custom_rule_action",
+				g.label("akamai_appsec_custom_rule_action", policy, labelOr(g.customRules, a.ID)),
+				fmt.Sprintf("%d:%s:%d", cfg, p.ID, a.ID))
+			g.policyReference(body, p.ID)
+			objectReference(body, "custom_rule_id", g.customRules, "akamai_appsec_custom_rule", a.ID)
+			body.SetAttributeValue("custom_rule_action", cty.StringVal(a.Action))
+		}
+
+		if p.RatePolicyActions != nil {
+			for _, a := range *p.RatePolicyActions {
+				body := g.resource("akamai_appsec_rate_policy_action",
+					g.label("akamai_appsec_rate_policy_action", policy, labelOr(g.ratePolicies, a.ID)),
+					fmt.Sprintf("%d:%s:%d", cfg, p.ID, a.ID))
+				g.policyReference(body, p.ID)
+				objectReference(body, "rate_policy_id", g.ratePolicies, "akamai_appsec_rate_policy", a.ID)
+				body.SetAttributeValue("ipv4_action", cty.StringVal(a.Ipv4Action))
+				body.SetAttributeValue("ipv6_action", cty.StringVal(a.Ipv6Action))
+			}
+		}
+
+		if p.ClientReputation.ReputationProfileActions != nil {
+			for _, a := range *p.ClientReputation.ReputationProfileActions {
+				body := g.resource("akamai_appsec_reputation_profile_action",
+					g.label("akamai_appsec_reputation_profile_action", policy, labelOr(g.profiles, a.ID)),
+					fmt.Sprintf("%d:%s:%d", cfg, p.ID, a.ID))
+				g.policyReference(body, p.ID)
+				objectReference(body, "reputation_profile_id", g.profiles, "akamai_appsec_reputation_profile", a.ID)
+				body.SetAttributeValue("action", cty.StringVal(a.Action))
+			}
+		}
+
+		if fw := p.IPGeoFirewall; fw != nil {
+			body := g.resource("akamai_appsec_ip_geo", g.label("akamai_appsec_ip_geo", policy), fmt.Sprintf("%d:%s", cfg, p.ID))
+			g.policyReference(body, p.ID)
+			mode := "allow"
+			if fw.Block == "blockSpecificIPGeo" {
+				mode = "block"
+			}
+			body.SetAttributeValue("mode", cty.StringVal(mode))
+			if fw.GeoControls != nil {
+				setNetworkLists(body, "geo_network_lists", fw.GeoControls.BlockedIPNetworkLists)
+			}
+			if fw.IPControls != nil {
+				setNetworkLists(body, "ip_network_lists", fw.IPControls.BlockedIPNetworkLists)
+				setNetworkLists(body, "exception_ip_network_lists", fw.IPControls.AllowedIPNetworkLists)
+			}
+		}
+	}
+	return nil
+}
+
+func (g *generator) addAdvancedSettings() error {
+	cfg := g.export.ConfigID
+	type setting struct {
+		resourceType string
+		attribute    string
+		value        interface{}
+	}
+	emit := func(policyID string, s setting) error {
+		label, name, importID := configLabel, s.attribute, strconv.Itoa(cfg)
+		if policyID != "" {
+			label = g.policies[policyID]
+			name = s.attribute + "_" + label
+			importID = fmt.Sprintf("%d:%s", cfg, policyID)
+		}
+		body := g.resource(s.resourceType, g.label(s.resourceType, label), importID)
+		if policyID != "" {
+			g.policyReference(body, policyID)
+		}
+		if epm, ok := s.value.(*appsec.EvasivePathMatchexp); ok {
+			body.SetAttributeValue("enable_path_match", cty.BoolVal(epm.EnablePathMatch))
+			return nil
+		}
+		expr, err := g.jsonFile(name, s.value, false)
+		if err != nil {
+			return err
+		}
+		body.SetAttributeRaw(s.attribute, expr)
+		return nil
+	}
+
+	if o := g.export.AdvancedOptions; o != nil {
+		var settings []setting
+		if o.Logging != nil {
+			settings = append(settings, setting{"akamai_appsec_advanced_settings_logging", "logging", o.Logging})
+		}
+		if o.AttackPayloadLogging != nil {
+			settings = append(settings, setting{"akamai_appsec_advanced_settings_attack_payload_logging", "attack_payload_logging", o.AttackPayloadLogging})
+		}
+		if o.PragmaHeader != nil {
+			settings = append(settings, setting{"akamai_appsec_advanced_settings_pragma_header", "pragma_header", o.PragmaHeader})
+		}
+		if o.EvasivePathMatch != nil {
+			settings = append(settings, setting{"akamai_appsec_advanced_settings_evasive_path_match", "enable_path_match", o.EvasivePathMatch})
+		}
+		for _, s := range settings {
+			if err := emit("", s); err != nil {
+				return err
+			}
+		}
+
+		body := g.resource("akamai_appsec_advanced_settings_prefetch",
+			g.label("akamai_appsec_advanced_settings_prefetch", configLabel), strconv.Itoa(cfg))
+		body.SetAttributeValue("enable_app_layer", cty.BoolVal(o.Prefetch.EnableAppLayer))
+		body.SetAttributeValue("all_extensions", cty.BoolVal(o.Prefetch.AllExtensions))
+		body.SetAttributeValue("enable_rate_controls", cty.BoolVal(o.Prefetch.EnableRateControls))
+		body.SetAttributeValue("extensions", stringList(o.Prefetch.Extensions))
+	}
+
+	for _, p := range g.export.SecurityPolicies {
+		var settings []setting
+		if p.LoggingOverrides != nil {
+			settings = append(settings, setting{"akamai_appsec_advanced_settings_logging", "logging", p.LoggingOverrides})
+		}
+		if p.AttackPayloadLoggingOverrides != nil {
+			settings = append(settings, setting{"akamai_appsec_advanced_settings_attack_payload_logging", "attack_payload_logging", p.AttackPayloadLoggingOverrides})
+		}
+		if p.PragmaHeader != nil {
+			settings = append(settings, setting{"akamai_appsec_advanced_settings_pragma_header", "pragma_header", p.PragmaHeader})
+		}
+		if p.EvasivePathMatch != nil {
+			settings = append(settings, setting{"akamai_appsec_advanced_settings_evasive_path_match", "enable_path_match", p.EvasivePathMatch})
+		}
+		for _, s := range settings {
+			if err := emit(p.ID, s); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func labelOr(labels map[int]string, id int) string {
+	if label, ok := labels[id]; ok {
+		return label
+	}
+	return strconv.Itoa(id)
+}
+
+func setNetworkLists(body *hclwrite.Body, attribute string, lists *appsec.IPGeoNetworkLists) {
+	if lists == nil || len(lists.NetworkList) == 0 {
+		return
+	}
+	body.SetAttributeValue(attribute, stringList(lists.NetworkList))
+}
+
+func stringList(values []string) cty.Value {
+	if len(values) == 0 {
+		return cty.ListValEmpty(cty.String)
+	}
+	vals := make([]cty.Value, 0, len(values))
+	for _, v := range values {
+		vals = append(vals, cty.StringVal(v))
+	}
+	return cty.ListVal(vals)
+}
+
+func traversal(root string, attrs ...string) hcl.Traversal {
+	t := hcl.Traversal{hcl.TraverseRoot{Name: root}}
+	for _, a := range attrs {
+		t = append(t, hcl.TraverseAttr{Name: a})
+	}
+	return t
+}
+
+// marshalJSON renders v as indented JSON, dropping the top-level "id" when requested
+// since the API assigns it on creation.
+func marshalJSON(v interface{}, withoutID bool) (string, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return "", err
+	}
+	if m, ok := doc.(map[string]interface{}); ok && withoutID {
+		delete(m, "id")
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,116 @@
+package hclgen
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadExport(t *testing.T) *appsec.GetExportConfigurationResponse {
+	body, err := os.ReadFile("../testdata/TestDSExportConfiguration/ExportConfiguration.json")
+	require.NoError(t, err)
+	var export appsec.GetExportConfigurationResponse
+	require.NoError(t, json.Unmarshal(body, &export))
+	return &export
+}
+
+func parseBlocks(t *testing.T, name, src string) hclsyntax.Blocks {
+	file, diags := hclsyntax.ParseConfig([]byte(src), name, hcl.InitialPos)
+	require.False(t, diags.HasErrors(), diags.Error())
+	return file.Body.(*hclsyntax.Body).Blocks
+}
+
+func TestGenerate(t *testing.T) {
+	export := loadExport(t)
+
+	files, err := Generate(export, Options{})
+	require.NoError(t, err)
+
+	resources := parseBlocks(t, ResourcesFile, files[ResourcesFile])
+	imports := parseBlocks(t, ImportsFile, files[ImportsFile])
+	parseBlocks(t, VariablesFile, files[VariablesFile])
+	require.Len(t, imports, len(resources))
+
+	byType := map[string]int{}
+	addresses := map[string]bool{}
+	for _, b := range resources {
+		require.Equal(t, "resource", b.Type)
+		byType[b.Labels[0]]++
+		address := b.Labels[0] + "." + b.Labels[1]
+		assert.False(t, addresses[address], "duplicate resource %s", address)
+		addresses[address] = true
+	}
+	for _, b := range imports {
+		to := b.Body.Attributes["to"].Expr.(*hclsyntax.ScopeTraversalExpr)
+		address := to.Traversal.RootName() + "." + to.Traversal[1].(hcl.TraverseAttr).Name
+		assert.True(t, addresses[address], "import targets unknown resource %s", address)
+	}
+
+	var customRuleActions, reputationActions, ipGeo int
+	for _, p := range export.SecurityPolicies {
+		customRuleActions += len(p.CustomRuleActions)
+		if p.ClientReputation.ReputationProfileActions != nil {
+			reputationActions += len(*p.ClientReputation.ReputationProfileActions)
+		}
+		if p.IPGeoFirewall != nil {
+			ipGeo++
+		}
+	}
+	assert.Equal(t, 1, byType["akamai_appsec_configuration"])
+	assert.Equal(t, len(export.SecurityPolicies), byType["akamai_appsec_security_policy"])
+	assert.Equal(t, len(export.CustomRules), byType["akamai_appsec_custom_rule"])
+	assert.Equal(t, customRuleActions, byType["akamai_appsec_custom_rule_action"])
+	assert.Equal(t, len(export.ReputationProfiles), byType["akamai_appsec_reputation_profile"])
+	assert.Equal(t, reputationActions, byType["akamai_appsec_reputation_profile_action"])
+	assert.Equal(t, len(export.MatchTargets.WebsiteTargets)+len(export.MatchTargets.APITargets), byType["akamai_appsec_match_target"])
+	assert.Equal(t, ipGeo, byType["akamai_appsec_ip_geo"])
+	assert.Equal(t, 1, byType["akamai_appsec_advanced_settings_prefetch"])
+	assert.Equal(t, 1, byType["akamai_appsec_advanced_settings_logging"])
+
+	assert.Contains(t, files[ResourcesFile], `security_policy_id = akamai_appsec_security_policy.aaaa_81230.security_policy_id`)
+	assert.Contains(t, files[ResourcesFile], `custom_rule = file("${path.module}/json/custom_rule_60036362.json")`)
+	assert.Contains(t, files[ImportsFile], `id = "43253:AAAA_81230"`)
+
+	var jsonFiles int
+	for name, body := range files {
+		if !strings.HasPrefix(name, DefaultJSONDirectory+"/") {
+			continue
+		}
+		jsonFiles++
+		var doc map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(body), &doc), name)
+		assert.NotContains(t, doc, "id", name)
+		assert.Contains(t, files[ResourcesFile], `"${path.module}/`+name+`"`)
+	}
+	assert.Equal(t, len(files)-3, jsonFiles)
+}
+
+func TestGenerateJSONDirectory(t *testing.T) {
+	files, err := Generate(loadExport(t), Options{JSONDirectory: "settings/appsec"})
+	require.NoError(t, err)
+
+	assert.Contains(t, files, "settings/appsec/logging.json")
+	assert.Contains(t, files[ResourcesFile], `logging   = file("${path.module}/settings/appsec/logging.json")`)
+}
+
+func TestGenerateNilExport(t *testing.T) {
+	_, err := Generate(nil, Options{})
+	assert.Error(t, err)
+}
+
+func TestLabel(t *testing.T) {
+	g := &generator{labels: map[string]map[string]struct{}{}}
+
+	assert.Equal(t, "block_bad_bots_12", g.label("t", "Block bad-bots!", "12"))
+	assert.Equal(t, "block_bad_bots_12_2", g.label("t", "block bad bots", "12"))
+	assert.Equal(t, "block_bad_bots_12", g.label("other", "Block bad-bots!", "12"))
+	assert.Equal(t, "r_0001_1234", g.label("t", "0001_1234"))
+	assert.Equal(t, "r_", g.label("t", "***"))
+}
@@ -0,0 +1,391 @@
+package appsec
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+)
+
+// Values of IPGeoFirewall.Block
+const (
+	ipGeoBlockSpecific  = "blockSpecificIPGeo"
+	ipGeoBlockAllExcept = "blockAllTrafficExceptAllowedIPs"
+)
+
+// Outcomes of the IP/Geo firewall reported by the simulation.
+const (
+	simulationAllow = "allow"
+	simulationDeny  = "deny"
+	simulationNone  = "none"
+)
+
+// simulationRequest is a sample HTTP request evaluated by policySimulator.
+type simulationRequest struct {
+	Name          string
+	Method        string
+	Host          string
+	Path          string
+	Query         map[string]string
+	Headers       map[string]string
+	ClientIP      string
+	ClientCountry string
+}
+
+// simulationCustomRule is a custom rule matching a simulated request.
+type simulationCustomRule struct {
+	ID     int
+	Name   string
+	Action string
+}
+
+// simulationResult is the outcome of a simulated request as rendered by the policySimulationDS template.
+type simulationResult struct {
+	Request       string
+	MatchTargetID int
+	PolicyID      string
+	Bypassed      bool
+	IPGeoAction   string
+	CustomRules   []simulationCustomRule
+	Skipped       []int
+	Action        string
+}
+
+// policySimulator evaluates sample requests against the website match targets, IP/Geo firewalls and
+// custom rules of an exported security configuration version.
+type policySimulator struct {
+	export *appsec.GetExportConfigurationResponse
+
+	// networkLists holds the IPs, CIDR blocks or country codes of the network lists, by ID, as
+	// the export only references them
+	networkLists map[string][]string
+
+	// now is the time effective time periods of custom rules are compared to
+	now time.Time
+}
+
+// simulate returns which match target, IP/Geo firewall and custom rules apply to the request, and the resulting action.
+func (s policySimulator) simulate(r simulationRequest) simulationResult {
+	result := simulationResult{Request: r.Name, IPGeoAction: simulationNone, Action: simulationNone}
+
+	var policyID string
+	for _, t := range s.export.MatchTargets.WebsiteTargets {
+		if !simulationTargetMatches(t.Hostnames, t.FilePaths, t.FileExtensions, t.IsNegativePathMatch, t.IsNegativeFileExtensionMatch, r) {
+			continue
+		}
+		result.MatchTargetID = t.ID
+		policyID = t.SecurityPolicy.PolicyID
+		for _, l := range t.BypassNetworkLists {
+			if s.networkListContains([]string{l.ID}, r.ClientIP, "") {
+				result.Bypassed = true
+			}
+		}
+		break
+	}
+	if policyID == "" || result.Bypassed {
+		return result
+	}
+	result.PolicyID = policyID
+
+	for _, p := range s.export.SecurityPolicies {
+		if p.ID != policyID {
+			continue
+		}
+		if p.SecurityControls.ApplyNetworkLayerControls && p.IPGeoFirewall != nil {
+			result.IPGeoAction = s.ipGeoAction(p.IPGeoFirewall, r)
+		}
+		if !p.SecurityControls.ApplyApplicationLayerControls {
+			break
+		}
+		for _, a := range p.CustomRuleActions {
+			if a.Action == "" || a.Action == simulationNone {
+				continue
+			}
+			matched, name, err := s.customRuleMatches(a.ID, r)
+			if err != nil {
+				result.Skipped = append(result.Skipped, a.ID)
+				continue
+			}
+			if matched {
+				result.CustomRules = append(result.CustomRules, simulationCustomRule{ID: a.ID, Name: name, Action: a.Action})
+			}
+		}
+		break
+	}
+
+	if result.IPGeoAction == simulationDeny {
+		result.Action = simulationDeny
+		return result
+	}
+	for _, c := range result.CustomRules {
+		if simulationActionSeverity(c.Action) > simulationActionSeverity(result.Action) {
+			result.Action = c.Action
+		}
+	}
+	return result
+}
+
+// ipGeoAction returns whether the IP/Geo firewall allows, denies or doesn't act on the request.
+func (s policySimulator) ipGeoAction(firewall *appsec.IPGeoFirewall, r simulationRequest) string {
+	var allowed, blocked, geoBlocked []string
+	if firewall.IPControls != nil {
+		if firewall.IPControls.AllowedIPNetworkLists != nil {
+			allowed = firewall.IPControls.AllowedIPNetworkLists.NetworkList
+		}
+		if firewall.IPControls.BlockedIPNetworkLists != nil {
+			blocked = firewall.IPControls.BlockedIPNetworkLists.NetworkList
+		}
+	}
+	if firewall.GeoControls != nil && firewall.GeoControls.BlockedIPNetworkLists != nil {
+		geoBlocked = firewall.GeoControls.BlockedIPNetworkLists.NetworkList
+	}
+
+	if s.networkListContains(allowed, r.ClientIP, "") {
+		return simulationAllow
+	}
+	switch firewall.Block {
+	case ipGeoBlockAllExcept:
+		return simulationDeny
+	case ipGeoBlockSpecific:
+		if s.networkListContains(blocked, r.ClientIP, "") || s.networkListContains(geoBlocked, r.ClientIP, r.ClientCountry) {
+			return simulationDeny
+		}
+	}
+	return simulationNone
+}
+
+// networkListContains returns whether any of the network lists contains the IP or the country code.
+func (s policySimulator) networkListContains(ids []string, ip, country string) bool {
+	for _, id := range ids {
+		for _, element := range s.networkLists[id] {
+			if country != "" && strings.EqualFold(element, country) {
+				return true
+			}
+			if ip != "" && simulationIPMatches(ip, element) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// customRuleMatches returns whether the custom rule matches the request and its name, or an error if
+// the rule can't be evaluated locally.
+func (s policySimulator) customRuleMatches(id int, r simulationRequest) (bool, string, error) {
+	for _, rule := range s.export.CustomRules {
+		if rule.ID != id {
+			continue
+		}
+		if period := rule.EffectiveTimePeriod; period != nil {
+			start, errStart := time.Parse(time.RFC3339, period.StartDate)
+			end, errEnd := time.Parse(time.RFC3339, period.EndDate)
+			if errStart == nil && errEnd == nil && (s.now.Before(start) || s.now.After(end)) {
+				return false, rule.Name, nil
+			}
+		}
+		if len(rule.Conditions) == 0 {
+			return false, rule.Name, nil
+		}
+
+		matchAny := strings.EqualFold(rule.Operation, "OR")
+		matched := !matchAny
+		for _, c := range rule.Conditions {
+			conditionJSON, err := json.Marshal(c)
+			if err != nil {
+				return false, rule.Name, err
+			}
+			var condition simulationCondition
+			if err := json.Unmarshal(conditionJSON, &condition); err != nil {
+				return false, rule.Name, err
+			}
+			m, err := condition.matches(r)
+			if err != nil {
+				return false, rule.Name, err
+			}
+			if matchAny {
+				matched = matched || m
+			} else {
+				matched = matched && m
+			}
+		}
+		return matched, rule.Name, nil
+	}
+	return false, "", fmt.Errorf("custom rule %d not found", id)
+}
+
+// simulationCondition is a custom rule condition evaluated against a simulated request.
+type simulationCondition struct {
+	Type                  string          `json:"type"`
+	PositiveMatch         bool            `json:"positiveMatch"`
+	Name                  json.RawMessage `json:"name"`
+	Value                 json.RawMessage `json:"value"`
+	NameCase              bool            `json:"nameCase"`
+	NameWildcard          bool            `json:"nameWildcard"`
+	ValueCase             bool            `json:"valueCase"`
+	ValueWildcard         bool            `json:"valueWildcard"`
+	UseXForwardForHeaders bool            `json:"useXForwardForHeaders"`
+}
+
+// matches returns whether the request matches the condition, or an error if the condition type can't be evaluated locally.
+func (c simulationCondition) matches(r simulationRequest) (bool, error) {
+	values, err := c.strings(c.Value)
+	if err != nil {
+		return false, err
+	}
+	names, err := c.strings(c.Name)
+	if err != nil {
+		return false, err
+	}
+
+	var matched bool
+	switch c.Type {
+	case "requestMethodMatch":
+		matched = simulationValueMatches([]string{r.Method}, values, false, c.ValueWildcard)
+	case "hostMatch":
+		matched = simulationValueMatches([]string{r.Host}, values, false, c.ValueWildcard)
+	case "pathMatch":
+		matched = simulationValueMatches([]string{r.Path}, values, c.ValueCase, c.ValueWildcard)
+	case "extensionMatch":
+		matched = simulationValueMatches([]string{strings.TrimPrefix(path.Ext(r.Path), ".")}, values, c.ValueCase, c.ValueWildcard)
+	case "filenameMatch":
+		matched = simulationValueMatches([]string{path.Base(r.Path)}, values, c.ValueCase, c.ValueWildcard)
+	case "requestHeaderMatch":
+		matched = c.namedValuesMatch(r.Headers, names, values)
+	case "uriQueryMatch":
+		matched = c.namedValuesMatch(r.Query, names, values)
+	case "cookieMatch":
+		cookies := map[string]string{}
+		header := http.Header{}
+		for name, value := range r.Headers {
+			header.Add(name, value)
+		}
+		for _, cookie := range (&http.Request{Header: header}).Cookies() {
+			cookies[cookie.Name] = cookie.Value
+		}
+		matched = c.namedValuesMatch(cookies, names, values)
+	case "ipMatch":
+		ip := r.ClientIP
+		if c.UseXForwardForHeaders {
+			for name, value := range r.Headers {
+				if strings.EqualFold(name, "X-Forwarded-For") {
+					ip = strings.TrimSpace(strings.Split(value, ",")[0])
+				}
+			}
+		}
+		for _, v := range values {
+			matched = matched || simulationIPMatches(ip, v)
+		}
+	case "geoMatch":
+		matched = simulationValueMatches([]string{r.ClientCountry}, values, false, false)
+	default:
+		return false, fmt.Errorf("condition type %s can't be simulated", c.Type)
+	}
+	return matched == c.PositiveMatch, nil
+}
+
+// namedValuesMatch returns whether any of the named items has one of the values, or exists when there are no values.
+func (c simulationCondition) namedValuesMatch(items map[string]string, names, values []string) bool {
+	var candidates []string
+	for name, value := range items {
+		if len(names) == 0 || simulationValueMatches([]string{name}, names, c.NameCase, c.NameWildcard) {
+			candidates = append(candidates, value)
+		}
+	}
+	if len(values) == 0 {
+		return len(candidates) > 0
+	}
+	return simulationValueMatches(candidates, values, c.ValueCase, c.ValueWildcard)
+}
+
+func (c simulationCondition) strings(raw json.RawMessage) ([]string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	values, err := customRuleConditionStrings(raw)
+	if err != nil {
+		return nil, fmt.Errorf("condition type %s can't be simulated: %s", c.Type, err)
+	}
+	return values, nil
+}
+
+// simulationTargetMatches returns whether a website match target applies to the request.
+func simulationTargetMatches(hostnames, filePaths, fileExtensions []string, negativePath, negativeExtension bool, r simulationRequest) bool {
+	if len(hostnames) > 0 && !simulationValueMatches([]string{r.Host}, hostnames, false, true) {
+		return false
+	}
+	if len(filePaths) > 0 && simulationValueMatches([]string{r.Path}, filePaths, true, true) == negativePath {
+		return false
+	}
+	if len(fileExtensions) > 0 {
+		extension := strings.TrimPrefix(path.Ext(r.Path), ".")
+		if simulationValueMatches([]string{extension}, fileExtensions, false, false) == negativeExtension {
+			return false
+		}
+	}
+	return true
+}
+
+// simulationValueMatches returns whether any of the candidates matches any of the patterns.
+func simulationValueMatches(candidates, patterns []string, caseSensitive, wildcard bool) bool {
+	for _, pattern := range patterns {
+		var re *regexp.Regexp
+		if wildcard {
+			expr := regexp.QuoteMeta(pattern)
+			expr = strings.NewReplacer(`\*`, `.*`, `\?`, `.`).Replace(expr)
+			if !caseSensitive {
+				expr = "(?i)" + expr
+			}
+			re = regexp.MustCompile("^" + expr + "$")
+		}
+		for _, candidate := range candidates {
+			switch {
+			case re != nil && re.MatchString(candidate):
+				return true
+			case re == nil && caseSensitive && candidate == pattern:
+				return true
+			case re == nil && !caseSensitive && strings.EqualFold(candidate, pattern):
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// simulationIPMatches returns whether the IP equals the address or belongs to the CIDR block.
+func simulationIPMatches(ip, element string) bool {
+	address := net.ParseIP(ip)
+	if address == nil {
+		return false
+	}
+	if _, block, err := net.ParseCIDR(element); err == nil {
+		return block.Contains(address)
+	}
+	other := net.ParseIP(element)
+	return other != nil && other.Equal(address)
+}
+
+// simulationActionSeverity orders actions from none to alert to deny, including custom deny actions.
+func simulationActionSeverity(action string) int {
+	switch {
+	case strings.HasPrefix(action, "deny"):
+		return 2
+	case action == "alert":
+		return 1
+	}
+	return 0
+}
+
+// simulationStringMap converts a TypeMap attribute of a request block.
+func simulationStringMap(m map[string]interface{}) map[string]string {
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[k] = v.(string)
+	}
+	return result
+}
@@ -0,0 +1,658 @@
+package appsec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+)
+
+// Types of the variables of a security policy template.
+const (
+	templateVariableString = "string"
+	templateVariableNumber = "number"
+	templateVariableBool   = "bool"
+	templateVariableJSON   = "json"
+)
+
+var (
+	// policyTemplateVariablePattern matches references to template variables, including references
+	// escaped as '$${name}'.
+	policyTemplateVariablePattern = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+	// policyTemplateProtections lists the protections a template can turn on or off.
+	policyTemplateProtections = map[string]bool{
+		"applyApiConstraints":           true,
+		"applyApplicationLayerControls": true,
+		"applyBotmanControls":           true,
+		"applyMalwareControls":          true,
+		"applyNetworkLayerControls":     true,
+		"applyRateControls":             true,
+		"applyReputationControls":       true,
+		"applySlowPostControls":         true,
+	}
+)
+
+type (
+	// policyTemplate describes a security policy: the protections it applies, the rate policies and
+	// reputation profiles it uses along with their actions, and the actions and exceptions of its
+	// rules. Rate policies and reputation profiles are identified by name.
+	policyTemplate struct {
+		Name               string                            `json:"name"`
+		Version            string                            `json:"version"`
+		Description        string                            `json:"description,omitempty"`
+		Variables          map[string]policyTemplateVariable `json:"variables,omitempty"`
+		Protections        map[string]bool                   `json:"protections,omitempty"`
+		RatePolicies       []map[string]interface{}          `json:"ratePolicies,omitempty"`
+		ReputationProfiles []map[string]interface{}          `json:"reputationProfiles,omitempty"`
+		Rules              map[string]policyTemplateRule     `json:"rules,omitempty"`
+	}
+
+	// policyTemplateVariable is a variable a template is parameterized with.
+	policyTemplateVariable struct {
+		Type        string          `json:"type,omitempty"`
+		Default     json.RawMessage `json:"default,omitempty"`
+		Description string          `json:"description,omitempty"`
+	}
+
+	// policyTemplateRule is the action and the condition and exception a template gives a rule.
+	policyTemplateRule struct {
+		Action             string          `json:"action,omitempty"`
+		ConditionException json.RawMessage `json:"conditionException,omitempty"`
+	}
+
+	// policyTemplateValue is the value of a template variable, as text for references within a
+	// string and as JSON value for references making up a whole string.
+	policyTemplateValue struct {
+		text  string
+		value interface{}
+	}
+
+	// policyTemplatePlan holds the drift of a security policy from its template, and the changes
+	// bringing the policy back to the template.
+	policyTemplatePlan struct {
+		Drift               []string
+		PolicyName          string
+		Protections         *appsec.UpdatePolicyProtectionsRequest
+		Changes             []documentChange
+		RulesDrift          bool
+		RuleActions         map[int]string
+		ConditionExceptions map[int]string
+		current             *configurationDocument
+	}
+)
+
+// renderPolicyTemplate parses a JSON-formatted template and replaces the references to its
+// variables with the given values, or with the defaults of the variables.
+func renderPolicyTemplate(template string, values map[string]string) (*policyTemplate, error) {
+	var header struct {
+		Name      string                            `json:"name"`
+		Version   string                            `json:"version"`
+		Variables map[string]policyTemplateVariable `json:"variables"`
+	}
+	if err := json.Unmarshal([]byte(template), &header); err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	if header.Name == "" || header.Version == "" {
+		return nil, fmt.Errorf("invalid template: name and version are required")
+	}
+	variables, err := resolvePolicyTemplateVariables(header.Variables, values)
+	if err != nil {
+		return nil, fmt.Errorf("template %s version %s: %w", header.Name, header.Version, err)
+	}
+
+	var document map[string]interface{}
+	decoder := json.NewDecoder(strings.NewReader(template))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	delete(document, "variables")
+	rendered, err := renderPolicyTemplateValue(document, variables)
+	if err != nil {
+		return nil, fmt.Errorf("template %s version %s: %w", header.Name, header.Version, err)
+	}
+	raw, err := json.Marshal(rendered)
+	if err != nil {
+		return nil, fmt.Errorf("template %s version %s: %w", header.Name, header.Version, err)
+	}
+
+	var t policyTemplate
+	decoder = json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&t); err != nil {
+		return nil, fmt.Errorf("template %s version %s: %w", header.Name, header.Version, err)
+	}
+	t.Variables = header.Variables
+	if err := t.validate(); err != nil {
+		return nil, fmt.Errorf("template %s version %s: %w", t.Name, t.Version, err)
+	}
+	return &t, nil
+}
+
+// resolvePolicyTemplateVariables returns the values of the declared variables. Values must be given
+// for the variables without default, and only for declared variables.
+func resolvePolicyTemplateVariables(declared map[string]policyTemplateVariable, values map[string]string) (map[string]policyTemplateValue, error) {
+	for _, name := range sortedStringKeys(values) {
+		if _, ok := declared[name]; !ok {
+			return nil, fmt.Errorf("variable %q isn't declared", name)
+		}
+	}
+
+	names := make([]string, 0, len(declared))
+	for name := range declared {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	resolved := make(map[string]policyTemplateValue, len(declared))
+	for _, name := range names {
+		variable := declared[name]
+		text, ok := values[name]
+		if !ok {
+			if len(variable.Default) == 0 {
+				return nil, fmt.Errorf("variable %q has no value", name)
+			}
+			if err := json.Unmarshal(variable.Default, &text); err != nil {
+				text = string(variable.Default)
+			}
+		}
+		value, err := variable.parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("variable %q: %w", name, err)
+		}
+		resolved[name] = policyTemplateValue{text: text, value: value}
+	}
+	return resolved, nil
+}
+
+// parse converts the text of a variable to the JSON value of its type.
+func (v policyTemplateVariable) parse(text string) (interface{}, error) {
+	switch v.Type {
+	case "", templateVariableString:
+		return text, nil
+	case templateVariableNumber:
+		var number float64
+		if err := json.Unmarshal([]byte(text), &number); err != nil {
+			return nil, fmt.Errorf("%q isn't a number", text)
+		}
+		return json.Number(text), nil
+	case templateVariableBool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return nil, fmt.Errorf("%q isn't a bool", text)
+		}
+		return b, nil
+	case templateVariableJSON:
+		var value interface{}
+		if err := json.Unmarshal([]byte(text), &value); err != nil {
+			return nil, fmt.Errorf("invalid JSON value: %w", err)
+		}
+		return value, nil
+	}
+	return nil, fmt.Errorf("unsupported type %q", v.Type)
+}
+
+func renderPolicyTemplateValue(v interface{}, variables map[string]policyTemplateValue) (interface{}, error) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			rendered, err := renderPolicyTemplateValue(item, variables)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = rendered
+		}
+		return v, nil
+	case []interface{}:
+		for i, item := range v {
+			rendered, err := renderPolicyTemplateValue(item, variables)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = rendered
+		}
+		return v, nil
+	case string:
+		return renderPolicyTemplateString(v, variables)
+	}
+	return v, nil
+}
+
+// renderPolicyTemplateString replaces a string made up of a single variable reference with the
+// value of the variable, and references within a string with the text of the variables.
+func renderPolicyTemplateString(s string, variables map[string]policyTemplateValue) (interface{}, error) {
+	if match := policyTemplateVariablePattern.FindStringSubmatch(s); match != nil && match[0] == s && !strings.HasPrefix(s, "$$") {
+		variable, ok := variables[match[1]]
+		if !ok {
+			return nil, fmt.Errorf("undeclared variable %q", match[1])
+		}
+		return variable.value, nil
+	}
+
+	var err error
+	rendered := policyTemplateVariablePattern.ReplaceAllStringFunc(s, func(reference string) string {
+		if strings.HasPrefix(reference, "$$") {
+			return reference[1:]
+		}
+		name := reference[2 : len(reference)-1]
+		variable, ok := variables[name]
+		if !ok {
+			if err == nil {
+				err = fmt.Errorf("undeclared variable %q", name)
+			}
+			return reference
+		}
+		return variable.text
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rendered, nil
+}
+
+func (t *policyTemplate) validate() error {
+	var unknown []string
+	for key := range t.Protections {
+		if !policyTemplateProtections[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown protections %s", strings.Join(unknown, ", "))
+	}
+
+	for _, objects := range []struct {
+		kind    string
+		objects []map[string]interface{}
+		action  func(interface{}) (map[string]interface{}, error)
+	}{
+		{"rate policy", t.RatePolicies, ratePolicyTemplateAction},
+		{"reputation profile", t.ReputationProfiles, reputationProfileTemplateAction},
+	} {
+		names := map[string]bool{}
+		for _, o := range objects.objects {
+			name, _, action := policyTemplateObject(o)
+			if name == "" {
+				return fmt.Errorf("every %s needs a name", objects.kind)
+			}
+			if names[name] {
+				return fmt.Errorf("%s %q is defined more than once", objects.kind, name)
+			}
+			names[name] = true
+			if action == nil {
+				continue
+			}
+			if _, err := objects.action(action); err != nil {
+				return fmt.Errorf("%s %q: %w", objects.kind, name, err)
+			}
+		}
+	}
+
+	for key := range t.Rules {
+		if _, err := strconv.Atoi(key); err != nil {
+			return fmt.Errorf("%q isn't a rule ID", key)
+		}
+	}
+	return nil
+}
+
+// conditionException returns the JSON-formatted condition and exception of the rule, or an empty
+// string if the template doesn't give the rule any.
+func (r policyTemplateRule) conditionException() string {
+	if len(r.ConditionException) == 0 || string(r.ConditionException) == "null" {
+		return ""
+	}
+	return string(r.ConditionException)
+}
+
+// policyTemplateObject splits a rate policy or reputation profile of a template into its name, its
+// body and the action taken by the security policy.
+func policyTemplateObject(o map[string]interface{}) (string, map[string]interface{}, interface{}) {
+	body := make(map[string]interface{}, len(o))
+	for key, value := range o {
+		if key != "action" {
+			body[key] = value
+		}
+	}
+	name, _ := o["name"].(string)
+	return name, body, o["action"]
+}
+
+// ratePolicyTemplateAction returns the IPv4 and IPv6 actions of a rate policy. A single action
+// applies to both.
+func ratePolicyTemplateAction(action interface{}) (map[string]interface{}, error) {
+	switch action := action.(type) {
+	case string:
+		return map[string]interface{}{"ipv4Action": action, "ipv6Action": action}, nil
+	case map[string]interface{}:
+		result := map[string]interface{}{}
+		for key, value := range action {
+			s, ok := value.(string)
+			if !ok || (key != "ipv4Action" && key != "ipv6Action") {
+				return nil, fmt.Errorf("action must be a string, or an object with ipv4Action and ipv6Action strings")
+			}
+			result[key] = s
+		}
+		if _, ok := result["ipv4Action"]; !ok {
+			return nil, fmt.Errorf("action must have an ipv4Action")
+		}
+		if _, ok := result["ipv6Action"]; !ok {
+			result["ipv6Action"] = result["ipv4Action"]
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("action must be a string, or an object with ipv4Action and ipv6Action strings")
+}
+
+// reputationProfileTemplateAction returns the action of a reputation profile.
+func reputationProfileTemplateAction(action interface{}) (map[string]interface{}, error) {
+	s, ok := action.(string)
+	if !ok {
+		return nil, fmt.Errorf("action must be a string")
+	}
+	return map[string]interface{}{"action": s}, nil
+}
+
+// planPolicyTemplate compares a security policy of an exported configuration version with its
+// template, and returns the drift of the policy along with the changes bringing it back to the
+// template. Only the fields the template sets are compared.
+func planPolicyTemplate(t *policyTemplate, export *appsec.GetExportConfigurationResponse, policyID string) (*policyTemplatePlan, error) {
+	current, err := newConfigurationDocument(export)
+	if err != nil {
+		return nil, err
+	}
+	plan := &policyTemplatePlan{
+		RuleActions:         map[int]string{},
+		ConditionExceptions: map[int]string{},
+		current:             current,
+	}
+
+	index := -1
+	for i := range export.SecurityPolicies {
+		if export.SecurityPolicies[i].ID == policyID {
+			index = i
+		}
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("security policy %s doesn't exist in configuration %d version %d", policyID, export.ConfigID, export.Version)
+	}
+	policy := export.SecurityPolicies[index]
+	plan.PolicyName = policy.Name
+	var policyName string
+	for name, id := range current.policies {
+		if id == policyID {
+			policyName = name
+		}
+	}
+
+	if len(t.Protections) > 0 {
+		var controls map[string]interface{}
+		if err := remarshalPolicyTemplate(policy.SecurityControls, &controls); err != nil {
+			return nil, err
+		}
+		desired := make(map[string]interface{}, len(t.Protections))
+		for key, value := range t.Protections {
+			desired[key] = value
+		}
+		if drift := templateDrift("protections", "", desired, controls); len(drift) > 0 {
+			plan.Drift = append(plan.Drift, drift...)
+			plan.Protections = &appsec.UpdatePolicyProtectionsRequest{}
+			if err := remarshalPolicyTemplate(mergeTemplateFields(desired, controls), plan.Protections); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, objects := range []struct {
+		kind, actionKind, label string
+		objects                 []map[string]interface{}
+		action                  func(interface{}) (map[string]interface{}, error)
+	}{
+		{docRatePolicy, docRatePolicyAction, "rate policy", t.RatePolicies, ratePolicyTemplateAction},
+		{docReputationProfile, docReputationProfileAction, "reputation profile", t.ReputationProfiles, reputationProfileTemplateAction},
+	} {
+		for _, o := range objects.objects {
+			name, body, action := policyTemplateObject(o)
+			label := fmt.Sprintf("%s %q", objects.label, name)
+			if err := plan.compare(label, label+" doesn't exist", objects.kind, "", name, body); err != nil {
+				return nil, err
+			}
+			if action == nil {
+				continue
+			}
+			desired, err := objects.action(action)
+			if err != nil {
+				return nil, err
+			}
+			if err := plan.compare(label+" action", label+" action isn't set", objects.actionKind, policyName, name, desired); err != nil {
+				return nil, err
+			}
+		}
+	}
+	rank := make(map[string]int, len(documentKindOrder))
+	for i, kind := range documentKindOrder {
+		rank[kind] = i
+	}
+	sort.SliceStable(plan.Changes, func(i, j int) bool {
+		return rank[plan.Changes[i].Object.Kind] < rank[plan.Changes[j].Object.Kind]
+	})
+
+	rules := make(map[int]ruleSetting, len(policy.WebApplicationFirewall.RuleActions))
+	for _, r := range policy.WebApplicationFirewall.RuleActions {
+		setting := ruleSetting{Action: r.Action}
+		if r.Conditions != nil || r.Exception != nil || r.AdvancedExceptionsList != nil {
+			jsonBody, err := json.Marshal(appsec.RuleConditionException{
+				Conditions:             r.Conditions,
+				Exception:              r.Exception,
+				AdvancedExceptionsList: r.AdvancedExceptionsList,
+			})
+			if err != nil {
+				return nil, err
+			}
+			setting.ConditionException = string(jsonBody)
+		}
+		rules[r.ID] = setting
+	}
+	ruleIDs := make([]int, 0, len(t.Rules))
+	for key, rule := range t.Rules {
+		ruleID, _ := strconv.Atoi(key)
+		ruleIDs = append(ruleIDs, ruleID)
+		if rule.Action != "" {
+			plan.RuleActions[ruleID] = rule.Action
+		}
+		if ce := rule.conditionException(); ce != "" {
+			plan.ConditionExceptions[ruleID] = ce
+		}
+	}
+	sort.Ints(ruleIDs)
+	for _, ruleID := range ruleIDs {
+		rule := t.Rules[strconv.Itoa(ruleID)]
+		setting, ok := rules[ruleID]
+		if !ok {
+			plan.Drift = append(plan.Drift, fmt.Sprintf("rule %d has no action in the security policy", ruleID))
+			plan.RulesDrift = true
+			continue
+		}
+		if rule.Action != "" && rule.Action != setting.Action {
+			plan.Drift = append(plan.Drift, fmt.Sprintf("rule %d: action is %q, template sets %q", ruleID, setting.Action, rule.Action))
+			plan.RulesDrift = true
+		}
+		if !ruleConditionExceptionsEqual(rule.conditionException(), setting.ConditionException) {
+			plan.Drift = append(plan.Drift, fmt.Sprintf("rule %d: condition and exception differs from the template", ruleID))
+			plan.RulesDrift = true
+		}
+	}
+
+	return plan, nil
+}
+
+// existingPolicyTemplateObjects describes the rate policies and reputation profiles of the template
+// that already exist in the configuration, other than the ones in created, which holds the names
+// of the objects created for the security policy by kind.
+func existingPolicyTemplateObjects(t *policyTemplate, current *configurationDocument, created map[string]map[string]bool) []string {
+	var existing []string
+	for _, objects := range []struct {
+		kind, label string
+		objects     []map[string]interface{}
+	}{
+		{docRatePolicy, "rate policy", t.RatePolicies},
+		{docReputationProfile, "reputation profile", t.ReputationProfiles},
+	} {
+		for _, o := range objects.objects {
+			name, _, _ := policyTemplateObject(o)
+			if _, ok := current.objects[documentObject{Kind: objects.kind, Name: name}.key()]; ok && !created[objects.kind][name] {
+				existing = append(existing, fmt.Sprintf("%s %q", objects.label, name))
+			}
+		}
+	}
+	return existing
+}
+
+// policyTemplateRemovals returns the changes removing the rate policies and reputation profiles
+// created for a security policy along with the policy. Objects that no longer exist, or that other
+// security policies still take an action for, are kept.
+func policyTemplateRemovals(current *configurationDocument, policyName string, created map[string]map[string]bool) []documentChange {
+	used := map[string]bool{}
+	for _, o := range current.objects {
+		if o.Policy != policyName {
+			used[o.Kind+"/"+o.Name] = true
+		}
+	}
+
+	var changes []documentChange
+	for _, kinds := range []struct{ kind, actionKind string }{
+		{docReputationProfile, docReputationProfileAction},
+		{docRatePolicy, docRatePolicyAction},
+	} {
+		names := make([]string, 0, len(created[kinds.kind]))
+		for name := range created[kinds.kind] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			o, ok := current.objects[documentObject{Kind: kinds.kind, Name: name}.key()]
+			if ok && !used[kinds.actionKind+"/"+name] {
+				changes = append(changes, documentChange{Action: docRemove, Object: o})
+			}
+		}
+	}
+	return changes
+}
+
+// compare records the drift of an object from the template, and the change creating the object or
+// setting the fields the template sets.
+func (p *policyTemplatePlan) compare(label, missing, kind, policy, name string, desired map[string]interface{}) error {
+	existing, ok := p.current.objects[documentObject{Kind: kind, Policy: policy, Name: name}.key()]
+	if !ok {
+		p.Drift = append(p.Drift, missing)
+		return p.change(docCreate, kind, policy, name, desired)
+	}
+	var current interface{}
+	if err := json.Unmarshal([]byte(existing.Body), &current); err != nil {
+		return err
+	}
+	drift := templateDrift(label, "", desired, current)
+	if len(drift) == 0 {
+		return nil
+	}
+	p.Drift = append(p.Drift, drift...)
+	return p.change(docUpdate, kind, policy, name, mergeTemplateFields(desired, current))
+}
+
+func (p *policyTemplatePlan) change(action, kind, policy, name string, body interface{}) error {
+	canonical, err := canonicalDocumentJSON(body)
+	if err != nil {
+		return fmt.Errorf("%s %q: %w", kind, name, err)
+	}
+	p.Changes = append(p.Changes, documentChange{
+		Action: action,
+		Object: documentObject{Kind: kind, Policy: policy, Name: name, Body: canonical},
+	})
+	return nil
+}
+
+// templateDrift describes the fields of current differing from desired, which only contains the
+// fields set by a template. Objects are compared field by field, other values as a whole.
+func templateDrift(label, path string, desired, current interface{}) []string {
+	desiredFields, ok := desired.(map[string]interface{})
+	currentFields, isObject := current.(map[string]interface{})
+	if !ok || !isObject {
+		if reflect.DeepEqual(desired, current) {
+			return nil
+		}
+		return []string{fmt.Sprintf("%s: %s is %s, template sets %s", label, path, templateJSON(current), templateJSON(desired))}
+	}
+
+	var drift []string
+	keys := make([]string, 0, len(desiredFields))
+	for key := range desiredFields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		field := key
+		if path != "" {
+			field = path + "." + key
+		}
+		value, ok := currentFields[key]
+		if !ok {
+			if desiredFields[key] != nil {
+				drift = append(drift, fmt.Sprintf("%s: %s isn't set, template sets %s", label, field, templateJSON(desiredFields[key])))
+			}
+			continue
+		}
+		drift = append(drift, templateDrift(label, field, desiredFields[key], value)...)
+	}
+	return drift
+}
+
+// mergeTemplateFields returns current with the fields set by desired replaced, merging objects
+// field by field.
+func mergeTemplateFields(desired, current interface{}) interface{} {
+	desiredFields, ok := desired.(map[string]interface{})
+	currentFields, isObject := current.(map[string]interface{})
+	if !ok || !isObject {
+		return desired
+	}
+	merged := make(map[string]interface{}, len(currentFields))
+	for key, value := range currentFields {
+		merged[key] = value
+	}
+	for key, value := range desiredFields {
+		merged[key] = mergeTemplateFields(value, currentFields[key])
+	}
+	return merged
+}
+
+func templateJSON(v interface{}) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(raw)
+}
+
+func remarshalPolicyTemplate(from, to interface{}) error {
+	raw, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, to)
+}
+
+func sortedStringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,177 @@
+package appsec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadPolicyTemplateExport(t *testing.T, path string) *appsec.GetExportConfigurationResponse {
+	export := appsec.GetExportConfigurationResponse{}
+	require.NoError(t, json.Unmarshal(loadFixtureBytes(path), &export))
+	return &export
+}
+
+func TestRenderPolicyTemplate(t *testing.T) {
+	template := loadFixtureString("testdata/TestResSecurityPolicyFromTemplate/Template.json")
+
+	t.Run("variables and defaults", func(t *testing.T) {
+		rendered, err := renderPolicyTemplate(template, map[string]string{"excluded_cookie": "session", "rate_threshold": "20"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "standard-web", rendered.Name)
+		assert.Equal(t, "1.2.0", rendered.Version)
+		assert.Equal(t, float64(20), rendered.RatePolicies[0]["averageThreshold"])
+		assert.Equal(t, "alert", rendered.ReputationProfiles[0]["action"])
+		assert.JSONEq(t, `{"exception":{"headerCookieOrParamValues":["session"]}}`, rendered.Rules["950002"].conditionException())
+	})
+
+	tests := map[string]struct {
+		template  string
+		variables map[string]string
+		expected  string
+		withError string
+	}{
+		"reference within a string": {
+			template:  `{"name":"t","version":"1","variables":{"host":{}},"ratePolicies":[{"name":"Burst ${host} $${host}"}]}`,
+			variables: map[string]string{"host": "www"},
+			expected:  "Burst www ${host}",
+		},
+		"typed variable": {
+			template:  `{"name":"t","version":"1","variables":{"on":{"type":"bool"}},"protections":{"applyRateControls":"${on}"}}`,
+			variables: map[string]string{"on": "true"},
+		},
+		"missing value": {
+			template:  `{"name":"t","version":"1","variables":{"host":{}}}`,
+			withError: `template t version 1: variable "host" has no value`,
+		},
+		"undeclared variable": {
+			template:  `{"name":"t","version":"1"}`,
+			variables: map[string]string{"host": "www"},
+			withError: `template t version 1: variable "host" isn't declared`,
+		},
+		"undeclared reference": {
+			template:  `{"name":"t","version":"1","ratePolicies":[{"name":"${host}"}]}`,
+			withError: `template t version 1: undeclared variable "host"`,
+		},
+		"invalid number": {
+			template:  `{"name":"t","version":"1","variables":{"n":{"type":"number"}}}`,
+			variables: map[string]string{"n": "ten"},
+			withError: `template t version 1: variable "n": "ten" isn't a number`,
+		},
+		"unknown protection": {
+			template:  `{"name":"t","version":"1","protections":{"applyEverything":true}}`,
+			withError: `template t version 1: unknown protections applyEverything`,
+		},
+		"duplicate rate policy": {
+			template:  `{"name":"t","version":"1","ratePolicies":[{"name":"a"},{"name":"a"}]}`,
+			withError: `template t version 1: rate policy "a" is defined more than once`,
+		},
+		"invalid action": {
+			template:  `{"name":"t","version":"1","reputationProfiles":[{"name":"a","action":{"ipv4Action":"deny"}}]}`,
+			withError: `template t version 1: reputation profile "a": action must be a string`,
+		},
+		"unknown field": {
+			template:  `{"name":"t","version":"1","ratePolicy":[]}`,
+			withError: `template t version 1: json: unknown field "ratePolicy"`,
+		},
+		"no version": {
+			template:  `{"name":"t"}`,
+			withError: `invalid template: name and version are required`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rendered, err := renderPolicyTemplate(test.template, test.variables)
+			if test.withError != "" {
+				assert.EqualError(t, err, test.withError)
+				return
+			}
+			require.NoError(t, err)
+			if test.expected != "" {
+				assert.Equal(t, test.expected, rendered.RatePolicies[0]["name"])
+			}
+		})
+	}
+}
+
+func TestPlanPolicyTemplate(t *testing.T) {
+	template, err := renderPolicyTemplate(loadFixtureString("testdata/TestResSecurityPolicyFromTemplate/Template.json"), map[string]string{"excluded_cookie": "session"})
+	require.NoError(t, err)
+
+	created := loadPolicyTemplateExport(t, "testdata/TestResSecurityPolicyFromTemplate/Created.json")
+	plan, err := planPolicyTemplate(template, created, "AAAA_81230")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		`protections: applyRateControls is false, template sets true`,
+		`protections: applyReputationControls is false, template sets true`,
+		`rate policy "Origin Burst" doesn't exist`,
+		`rate policy "Origin Burst" action isn't set`,
+		`reputation profile "Scrapers": threshold is 5, template sets 7`,
+		`reputation profile "Scrapers" action isn't set`,
+		`rule 950001: action is "alert", template sets "deny"`,
+		`rule 950002: action is "none", template sets "alert"`,
+		`rule 950002: condition and exception differs from the template`,
+	}, plan.Drift)
+	assert.Equal(t, "Web", plan.PolicyName)
+	require.NotNil(t, plan.Protections)
+	assert.Equal(t, appsec.UpdatePolicyProtectionsRequest{
+		ApplyApplicationLayerControls: true,
+		ApplyRateControls:             true,
+		ApplyReputationControls:       true,
+	}, *plan.Protections)
+	assert.Equal(t, []string{
+		`create rate_policy "Origin Burst"`,
+		`update reputation_profile "Scrapers"`,
+		`create rate_policy_action "Origin Burst" in security policy "Web"`,
+		`create reputation_profile_action "Scrapers" in security policy "Web"`,
+	}, documentChangeStrings(plan.Changes))
+	assert.Equal(t, `{"context":"WEBSCRP","name":"Scrapers","sharedIpHandling":"NON_SHARED","threshold":7}`, plan.Changes[1].Object.Body)
+	assert.True(t, plan.RulesDrift)
+	assert.Equal(t, map[int]string{950001: "deny", 950002: "alert"}, plan.RuleActions)
+
+	applied := loadPolicyTemplateExport(t, "testdata/TestResSecurityPolicyFromTemplate/Applied.json")
+	plan, err = planPolicyTemplate(template, applied, "AAAA_81230")
+	require.NoError(t, err)
+	assert.Empty(t, plan.Drift)
+	assert.Nil(t, plan.Protections)
+	assert.Empty(t, plan.Changes)
+	assert.False(t, plan.RulesDrift)
+
+	// Changing a variable only changes the fields that depend on it.
+	template, err = renderPolicyTemplate(loadFixtureString("testdata/TestResSecurityPolicyFromTemplate/Template.json"), map[string]string{"excluded_cookie": "session", "rate_threshold": "40"})
+	require.NoError(t, err)
+	plan, err = planPolicyTemplate(template, applied, "AAAA_81230")
+	require.NoError(t, err)
+	assert.Equal(t, []string{`rate policy "Origin Burst": averageThreshold is 50, template sets 40`}, plan.Drift)
+	require.Len(t, plan.Changes, 1)
+	assert.Contains(t, plan.Changes[0].Object.Body, `"averageThreshold":40`)
+	assert.Contains(t, plan.Changes[0].Object.Body, `"useXForwardForHeaders":false`)
+
+	_, err = planPolicyTemplate(template, applied, "BBBB_1")
+	assert.EqualError(t, err, "security policy BBBB_1 doesn't exist in configuration 43253 version 7")
+}
+
+func TestPolicyTemplateObjectOwnership(t *testing.T) {
+	template, err := renderPolicyTemplate(loadFixtureString("testdata/TestResSecurityPolicyFromTemplate/Template.json"), map[string]string{"excluded_cookie": "session"})
+	require.NoError(t, err)
+	applied, err := newConfigurationDocument(loadPolicyTemplateExport(t, "testdata/TestResSecurityPolicyFromTemplate/Applied.json"))
+	require.NoError(t, err)
+	created := map[string]map[string]bool{docRatePolicy: {"Origin Burst": true, "Removed": true}, docReputationProfile: {}}
+
+	assert.Equal(t, []string{`rate policy "Origin Burst"`, `reputation profile "Scrapers"`},
+		existingPolicyTemplateObjects(template, applied, map[string]map[string]bool{}))
+	assert.Equal(t, []string{`reputation profile "Scrapers"`}, existingPolicyTemplateObjects(template, applied, created))
+
+	assert.Equal(t, []string{`remove rate_policy "Origin Burst"`}, documentChangeStrings(policyTemplateRemovals(applied, "Web", created)))
+
+	// Objects other security policies take an action for are kept.
+	action := documentObject{Kind: docRatePolicyAction, Policy: "Blog", Name: "Origin Burst"}
+	applied.objects[action.key()] = action
+	assert.Empty(t, policyTemplateRemovals(applied, "Web", created))
+}
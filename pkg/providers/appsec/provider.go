@@ -8,6 +8,7 @@ import (
 	"github.com/apex/log"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/networklists"
 	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
 	"github.com/akamai/terraform-provider-akamai/v3/pkg/config"
 	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
@@ -21,6 +22,10 @@ type (
 		*schema.Provider
 
 		client appsec.APPSEC
+
+		networkListsClient networklists.NTWRKLISTS
+
+		siemClient SIEM
 	}
 	// Option is a appsec provider option
 	Option func(p *provider)
@@ -73,6 +78,7 @@ func Provider() *schema.Provider {
 			"akamai_appsec_attack_groups":                            dataSourceAttackGroups(),
 			"akamai_appsec_bypass_network_lists":                     dataSourceBypassNetworkLists(),
 			"akamai_appsec_configuration":                            dataSourceConfiguration(),
+			"akamai_appsec_configuration_hcl":                        dataSourceConfigurationHCL(),
 			"akamai_appsec_configuration_version":                    dataSourceConfigurationVersion(),
 			"akamai_appsec_contracts_groups":                         dataSourceContractsGroups(),
 			"akamai_appsec_custom_deny":                              dataSourceCustomDeny(),
@@ -93,6 +99,7 @@ func Provider() *schema.Provider {
 			"akamai_appsec_malware_policy_actions":                   dataSourceMalwarePolicyActions(),
 			"akamai_appsec_match_targets":                            dataSourceMatchTargets(),
 			"akamai_appsec_penalty_box":                              dataSourcePenaltyBox(),
+			"akamai_appsec_policy_simulation":                        dataSourcePolicySimulation(),
 			"akamai_appsec_rate_policies":                            dataSourceRatePolicies(),
 			"akamai_appsec_rate_policy_actions":                      dataSourceRatePolicyActions(),
 			"akamai_appsec_reputation_profile_actions":               dataSourceReputationProfileActions(),
@@ -100,6 +107,7 @@ func Provider() *schema.Provider {
 			"akamai_appsec_reputation_profiles":                      dataSourceReputationProfiles(),
 			"akamai_appsec_rule_upgrade_details":                     dataSourceRuleUpgrade(),
 			"akamai_appsec_rules":                                    dataSourceRules(),
+			"akamai_appsec_security_events":                          dataSourceSecurityEvents(),
 			"akamai_appsec_security_policy":                          dataSourceSecurityPolicy(),
 			"akamai_appsec_security_policy_protections":              dataSourcePolicyProtections(),
 			"akamai_appsec_selectable_hostnames":                     dataSourceSelectableHostnames(),
@@ -109,6 +117,7 @@ func Provider() *schema.Provider {
 			"akamai_appsec_slow_post":                                dataSourceSlowPostProtectionSettings(),
 			"akamai_appsec_threat_intel":                             dataSourceThreatIntel(),
 			"akamai_appsec_tuning_recommendations":                   dataSourceTuningRecommendations(),
+			"akamai_appsec_version_diff":                             dataSourceVersionDiff(),
 			"akamai_appsec_version_notes":                            dataSourceVersionNotes(),
 			"akamai_appsec_waf_mode":                                 dataSourceWAFMode(),
 			"akamai_appsec_wap_selected_hostnames":                   dataSourceWAPSelectedHostnames(),
@@ -122,9 +131,12 @@ func Provider() *schema.Provider {
 			"akamai_appsec_advanced_settings_prefetch":               resourceAdvancedSettingsPrefetch(),
 			"akamai_appsec_api_constraints_protection":               resourceAPIConstraintsProtection(),
 			"akamai_appsec_api_request_constraints":                  resourceAPIRequestConstraints(),
+			"akamai_appsec_applied_tuning_recommendations":           resourceAppliedTuningRecommendations(),
 			"akamai_appsec_attack_group":                             resourceAttackGroup(),
 			"akamai_appsec_bypass_network_lists":                     resourceBypassNetworkLists(),
 			"akamai_appsec_configuration":                            resourceConfiguration(),
+			"akamai_appsec_configuration_document":                   resourceConfigurationDocument(),
+			"akamai_appsec_configuration_promotion":                  resourceConfigurationPromotion(),
 			"akamai_appsec_configuration_rename":                     resourceConfigurationRename(),
 			"akamai_appsec_custom_deny":                              resourceCustomDeny(),
 			"akamai_appsec_custom_rule":                              resourceCustomRule(),
@@ -151,7 +163,9 @@ func Provider() *schema.Provider {
 			"akamai_appsec_reputation_protection":                    resourceReputationProtection(),
 			"akamai_appsec_rule":                                     resourceRule(),
 			"akamai_appsec_rule_upgrade":                             resourceRuleUpgrade(),
+			"akamai_appsec_rules":                                    resourceRules(),
 			"akamai_appsec_security_policy":                          resourceSecurityPolicy(),
+			"akamai_appsec_security_policy_from_template":            resourceSecurityPolicyFromTemplate(),
 			"akamai_appsec_security_policy_rename":                   resourceSecurityPolicyRename(),
 			"akamai_appsec_selected_hostnames":                       resourceSelectedHostname(),
 			"akamai_appsec_siem_settings":                            resourceSiemSettings(),
@@ -182,6 +196,36 @@ func (p *provider) Client(meta akamai.OperationMeta) appsec.APPSEC {
 	return appsec.Client(meta.Session())
 }
 
+// WithNetworkListsClient sets the network lists client interface function, used for mocking and testing
+func WithNetworkListsClient(c networklists.NTWRKLISTS) Option {
+	return func(p *provider) {
+		p.networkListsClient = c
+	}
+}
+
+// NetworkListsClient returns the NTWRKLISTS interface
+func (p *provider) NetworkListsClient(meta akamai.OperationMeta) networklists.NTWRKLISTS {
+	if p.networkListsClient != nil {
+		return p.networkListsClient
+	}
+	return networklists.Client(meta.Session())
+}
+
+// WithSIEMClient sets the SIEM client interface function, used for mocking and testing
+func WithSIEMClient(c SIEM) Option {
+	return func(p *provider) {
+		p.siemClient = c
+	}
+}
+
+// SIEMClient returns the SIEM interface
+func (p *provider) SIEMClient(meta akamai.OperationMeta) SIEM {
+	if p.siemClient != nil {
+		return p.siemClient
+	}
+	return newSIEM(meta.Session())
+}
+
 func getAPPSECV1Service(d *schema.ResourceData) (interface{}, error) {
 	var section string
 
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/networklists"
 	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -59,6 +60,32 @@ func useClient(client appsec.APPSEC, f func()) {
 	f()
 }
 
+// useNetworkListsClient swaps out the network lists client on the global instance for the duration of the given func;
+// it must be called within useClient
+func useNetworkListsClient(client networklists.NTWRKLISTS, f func()) {
+	orig := inst.networkListsClient
+	inst.networkListsClient = client
+
+	defer func() {
+		inst.networkListsClient = orig
+	}()
+
+	f()
+}
+
+// useSIEMClient swaps out the SIEM client on the global instance for the duration of the given func;
+// it must be called within useClient
+func useSIEMClient(client SIEM, f func()) {
+	orig := inst.siemClient
+	inst.siemClient = client
+
+	defer func() {
+		inst.siemClient = orig
+	}()
+
+	f()
+}
+
 // loadFixtureBytes returns the entire contents of the given file as a byte slice
 func loadFixtureBytes(path string) []byte {
 	contents, err := ioutil.ReadFile(path)
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
@@ -14,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // appsec v1
@@ -73,7 +75,27 @@ func resourceActivations() *schema.Resource {
 				Required:    true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Description: "List of email addresses to be notified with the results of the activation",
-			}, "status": {
+			},
+			"pre_activation_checks": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          activationChecksNone,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{activationChecksNone, activationChecksWarn, activationChecksError}, false)),
+				Description:      "Whether issues found before activation (network lists not active on the network, expiring evaluations, pending rule upgrades) are reported as warnings (warn), fail the activation (error) or aren't checked (none)",
+			},
+			"activate_network_lists": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to activate the network lists referenced by the configuration version that aren't active on the network before activating it",
+			},
+			"pre_activation_issues": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Issues found by the pre-activation checks",
+			},
+			"status": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: "The results of the activation",
@@ -150,11 +172,18 @@ func resourceActivationsCreate(ctx context.Context, d *schema.ResourceData, m in
 		ConfigVersion: version,
 	})
 
+	diags := preActivationChecks(ctx, d, m, configID, version, network, note, notificationEmails)
+	if diags.HasError() {
+		return diags
+	}
+
 	postresp, err := client.CreateActivations(ctx, createActivationRequest, true)
 	if err != nil {
 		logger.Errorf("calling 'createActivations': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	// The activated version is no longer editable, so resources changed later in the run use a new version.
+	configVersions.forget(meta.OperationID(), configID)
 
 	d.SetId(strconv.Itoa(postresp.ActivationID))
 
@@ -184,7 +213,7 @@ func resourceActivationsCreate(ctx context.Context, d *schema.ResourceData, m in
 		}
 	}
 
-	return resourceActivationsRead(ctx, d, m)
+	return append(diags, resourceActivationsRead(ctx, d, m)...)
 }
 
 func resourceActivationsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -221,6 +250,10 @@ func resourceActivationsUpdate(ctx context.Context, d *schema.ResourceData, m in
 	logger := meta.Log("APPSEC", "resourceActivationsUpdate")
 	logger.Debug("in resourceActivationsUpdate")
 
+	if !d.HasChangesExcept("pre_activation_checks", "activate_network_lists") {
+		return resourceActivationsRead(ctx, d, m)
+	}
+
 	configID, err := tools.GetIntValue("config_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -267,11 +300,18 @@ func resourceActivationsUpdate(ctx context.Context, d *schema.ResourceData, m in
 		ConfigVersion: version,
 	})
 
+	diags := preActivationChecks(ctx, d, m, configID, version, network, note, notificationEmails)
+	if diags.HasError() {
+		return diags
+	}
+
 	postresp, err := client.CreateActivations(ctx, createActivationRequest, true)
 	if err != nil {
 		logger.Errorf("calling 'createActivations': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	// The activated version is no longer editable, so resources changed later in the run use a new version.
+	configVersions.forget(meta.OperationID(), configID)
 
 	d.SetId(strconv.Itoa(postresp.ActivationID))
 
@@ -302,7 +342,7 @@ func resourceActivationsUpdate(ctx context.Context, d *schema.ResourceData, m in
 		}
 	}
 
-	return resourceActivationsRead(ctx, d, m)
+	return append(diags, resourceActivationsRead(ctx, d, m)...)
 }
 
 func resourceActivationsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -442,6 +482,12 @@ func resourceImporter(ctx context.Context, d *schema.ResourceData, m interface{}
 			if err = d.Set("activate", true); err != nil {
 				return nil, err
 			}
+			if err = d.Set("pre_activation_checks", activationChecksNone); err != nil {
+				return nil, err
+			}
+			if err = d.Set("activate_network_lists", false); err != nil {
+				return nil, err
+			}
 			if err = d.Set("config_id", configID); err != nil {
 				return nil, err
 			}
@@ -466,6 +512,67 @@ func resourceImporter(ctx context.Context, d *schema.ResourceData, m interface{}
 
 }
 
+// preActivationChecks checks a configuration version before its activation as set by pre_activation_checks and
+// activate_network_lists, returning the issues found as warnings or as an error.
+func preActivationChecks(ctx context.Context, d *schema.ResourceData, m interface{}, configID, version int, network, note string, notificationEmails []string) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("APPSEC", "preActivationChecks")
+
+	mode, err := tools.GetStringValue("pre_activation_checks", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	if mode == "" {
+		mode = activationChecksNone
+	}
+	activateNetworkLists, err := tools.GetBoolValue("activate_network_lists", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+
+	issues := []string{}
+	if mode != activationChecksNone || activateNetworkLists {
+		checker := activationChecker{
+			client:               inst.Client(meta),
+			networkListsClient:   inst.NetworkListsClient(meta),
+			configID:             configID,
+			version:              version,
+			network:              network,
+			activateNetworkLists: activateNetworkLists,
+			comments:             note,
+			notificationEmails:   notificationEmails,
+			networkListsOnly:     mode == activationChecksNone,
+		}
+		found, err := checker.check(ctx)
+		if err != nil {
+			logger.Errorf("pre-activation checks: %s", err.Error())
+			return diag.FromErr(err)
+		}
+		issues = append(issues, found...)
+	}
+
+	if err := d.Set("pre_activation_issues", issues); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if len(issues) == 0 {
+		return nil
+	}
+	if mode == activationChecksError {
+		return diag.Errorf("pre-activation checks of security configuration %d version %d on %s failed:\n%s",
+			configID, version, network, strings.Join(issues, "\n"))
+	}
+
+	var diags diag.Diagnostics
+	for _, issue := range issues {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("pre-activation check of security configuration %d version %d", configID, version),
+			Detail:   issue,
+		})
+	}
+	return diags
+}
+
 func lookupActivation(ctx context.Context, client appsec.APPSEC, query appsec.GetActivationsRequest) (*appsec.GetActivationsResponse, error) {
 	activations, err := client.GetActivations(ctx, query)
 	if err != nil {
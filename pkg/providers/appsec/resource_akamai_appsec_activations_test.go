@@ -2,9 +2,11 @@ package appsec
 
 import (
 	"encoding/json"
+	"regexp"
 	"testing"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/networklists"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -76,4 +78,100 @@ func TestAkamaiActivations_res_basic(t *testing.T) {
 		client.AssertExpectations(t)
 	})
 
+	t.Run("pre-activation checks fail activation", func(t *testing.T) {
+		client := &appsec.Mock{}
+		networkListsClient := &networklists.Mock{}
+		mockActivationChecksExport(t, client)
+		mockNetworkListStatus(networkListsClient, "1024_BLOCK", "ACTIVE")
+		mockNetworkListStatus(networkListsClient, "1304_GEO", "INACTIVE")
+		mockNetworkListStatus(networkListsClient, "1410_BYPASS", "ACTIVE")
+
+		client.On("GetWAFMode",
+			mock.Anything,
+			mock.AnythingOfType("appsec.GetWAFModeRequest"),
+		).Return(&appsec.GetWAFModeResponse{Mode: AAG}, nil)
+
+		useClient(client, func() {
+			useNetworkListsClient(networkListsClient, func() {
+				resource.Test(t, resource.TestCase{
+					IsUnitTest:        true,
+					ProviderFactories: testAccProviders,
+					Steps: []resource.TestStep{
+						{
+							Config:      loadFixtureString("testdata/TestResActivations/pre_activation_checks.tf"),
+							ExpectError: regexp.MustCompile(`network list 1304_GEO is INACTIVE on STAGING`),
+						},
+					},
+				})
+			})
+		})
+
+		client.AssertNotCalled(t, "CreateActivations", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("changing only pre-activation options doesn't activate again", func(t *testing.T) {
+		client := &appsec.Mock{}
+
+		getActivationsResponse := appsec.GetActivationsResponse{}
+		err := json.Unmarshal(loadFixtureBytes("testdata/TestResActivations/Activations.json"), &getActivationsResponse)
+		require.NoError(t, err)
+
+		createActivationsResponse := appsec.CreateActivationsResponse{}
+		err = json.Unmarshal(loadFixtureBytes("testdata/TestResActivations/Activations.json"), &createActivationsResponse)
+		require.NoError(t, err)
+
+		removeActivationsResponse := appsec.RemoveActivationsResponse{}
+		err = json.Unmarshal(loadFixtureBytes("testdata/TestResActivations/ActivationsDelete.json"), &removeActivationsResponse)
+		require.NoError(t, err)
+
+		client.On("GetActivations",
+			mock.Anything,
+			appsec.GetActivationsRequest{ActivationID: 547694},
+		).Return(&getActivationsResponse, nil)
+
+		client.On("CreateActivations",
+			mock.Anything,
+			appsec.CreateActivationsRequest{
+				Action:             "ACTIVATE",
+				Network:            "STAGING",
+				Note:               "TEST Notes",
+				NotificationEmails: []string{"martin@email.io"},
+				ActivationConfigs: []struct {
+					ConfigID      int `json:"configId"`
+					ConfigVersion int `json:"configVersion"`
+				}{{ConfigID: 43253, ConfigVersion: 7}}},
+		).Return(&createActivationsResponse, nil).Once()
+
+		client.On("RemoveActivations",
+			mock.Anything,
+			mock.AnythingOfType("appsec.RemoveActivationsRequest"),
+		).Run(func(mock.Arguments) {
+			getActivationsResponse.Status = removeActivationsResponse.Status
+		}).Return(&removeActivationsResponse, nil)
+
+		useClient(client, func() {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:        true,
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResActivations/match_by_id.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_appsec_activations.test", "id", "547694"),
+						),
+					},
+					{
+						Config: loadFixtureString("testdata/TestResActivations/options_only.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_appsec_activations.test", "pre_activation_checks", "warn"),
+							resource.TestCheckResourceAttr("akamai_appsec_activations.test", "activate_network_lists", "true"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertNumberOfCalls(t, "CreateActivations", 1)
+		client.AssertExpectations(t)
+	})
 }
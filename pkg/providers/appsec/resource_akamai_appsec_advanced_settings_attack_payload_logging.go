@@ -59,10 +59,6 @@ func resourceAdvancedSettingsAttackPayloadLoggingCreate(ctx context.Context, d *
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "attackPayloadLoggingSetting", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return diag.FromErr(err)
@@ -73,12 +69,15 @@ func resourceAdvancedSettingsAttackPayloadLoggingCreate(ctx context.Context, d *
 
 	createAdvancedSettingsAttackPayloadLogging := appsec.UpdateAdvancedSettingsAttackPayloadLoggingRequest{
 		ConfigID:       configID,
-		Version:        version,
 		PolicyID:       policyID,
 		JSONPayloadRaw: rawJSON,
 	}
 
-	_, err = client.UpdateAdvancedSettingsAttackPayloadLogging(ctx, createAdvancedSettingsAttackPayloadLogging)
+	err = updateModifiableConfigVersion(ctx, configID, "attackPayloadLoggingSetting", m, func(version int) error {
+		createAdvancedSettingsAttackPayloadLogging.Version = version
+		_, err := client.UpdateAdvancedSettingsAttackPayloadLogging(ctx, createAdvancedSettingsAttackPayloadLogging)
+		return err
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -148,10 +147,6 @@ func resourceAdvancedSettingsAttackPayloadLoggingUpdate(ctx context.Context, d *
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "attackPayloadLoggingSetting", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return diag.FromErr(err)
@@ -161,11 +156,14 @@ func resourceAdvancedSettingsAttackPayloadLoggingUpdate(ctx context.Context, d *
 	jsonPayloadRaw := []byte(jsonPostPayload.(string))
 	rawJSON := (json.RawMessage)(jsonPayloadRaw)
 
-	_, err = client.UpdateAdvancedSettingsAttackPayloadLogging(ctx, appsec.UpdateAdvancedSettingsAttackPayloadLoggingRequest{
-		ConfigID:       configID,
-		Version:        version,
-		PolicyID:       policyID,
-		JSONPayloadRaw: rawJSON,
+	err = updateModifiableConfigVersion(ctx, configID, "attackPayloadLoggingSetting", m, func(version int) error {
+		_, err := client.UpdateAdvancedSettingsAttackPayloadLogging(ctx, appsec.UpdateAdvancedSettingsAttackPayloadLoggingRequest{
+			ConfigID:       configID,
+			Version:        version,
+			PolicyID:       policyID,
+			JSONPayloadRaw: rawJSON,
+		})
+		return err
 	})
 	if err != nil {
 		return diag.FromErr(err)
@@ -184,10 +182,6 @@ func resourceAdvancedSettingsAttackPayloadLoggingDelete(ctx context.Context, d *
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "attackPayloadLoggingSetting", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return diag.FromErr(err)
@@ -195,7 +189,6 @@ func resourceAdvancedSettingsAttackPayloadLoggingDelete(ctx context.Context, d *
 
 	removeAdvancedSettingsAttackPayloadLogging := appsec.RemoveAdvancedSettingsAttackPayloadLoggingRequest{
 		ConfigID: configID,
-		Version:  version,
 		PolicyID: policyID,
 	}
 
@@ -207,7 +200,11 @@ func resourceAdvancedSettingsAttackPayloadLoggingDelete(ctx context.Context, d *
 		removeAdvancedSettingsAttackPayloadLogging.Override = false
 	}
 
-	_, err = client.RemoveAdvancedSettingsAttackPayloadLogging(ctx, removeAdvancedSettingsAttackPayloadLogging)
+	err = updateModifiableConfigVersion(ctx, configID, "attackPayloadLoggingSetting", m, func(version int) error {
+		removeAdvancedSettingsAttackPayloadLogging.Version = version
+		_, err := client.RemoveAdvancedSettingsAttackPayloadLogging(ctx, removeAdvancedSettingsAttackPayloadLogging)
+		return err
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -60,10 +60,6 @@ func resourceAdvancedSettingsEvasivePathMatchCreate(ctx context.Context, d *sche
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "evasivePathMatchSetting", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return diag.FromErr(err)
@@ -75,12 +71,15 @@ func resourceAdvancedSettingsEvasivePathMatchCreate(ctx context.Context, d *sche
 
 	createAdvancedSettingsEvasivePathMatch := appsec.UpdateAdvancedSettingsEvasivePathMatchRequest{
 		ConfigID:        configID,
-		Version:         version,
 		PolicyID:        policyID,
 		EnablePathMatch: enablePathMatch,
 	}
 
-	_, err = client.UpdateAdvancedSettingsEvasivePathMatch(ctx, createAdvancedSettingsEvasivePathMatch)
+	err = updateModifiableConfigVersion(ctx, configID, "evasivePathMatchSetting", m, func(version int) error {
+		createAdvancedSettingsEvasivePathMatch.Version = version
+		_, err := client.UpdateAdvancedSettingsEvasivePathMatch(ctx, createAdvancedSettingsEvasivePathMatch)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'createAdvancedSettingsEvasivePathMatch': %s", err.Error())
 		return diag.FromErr(err)
@@ -169,27 +168,17 @@ func resourceAdvancedSettingsEvasivePathMatchUpdate(ctx context.Context, d *sche
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		version, err := getModifiableConfigVersion(ctx, configID, "evasivePathMatchSetting", m)
-		if err != nil {
-			return diag.FromErr(err)
-		}
 		policyID := iDParts[1]
 
 		updateAdvancedSettingsEvasivePathMatch.ConfigID = configID
-		updateAdvancedSettingsEvasivePathMatch.Version = version
 		updateAdvancedSettingsEvasivePathMatch.PolicyID = policyID
 	} else {
 		configID, err := strconv.Atoi(d.Id())
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		version, err := getModifiableConfigVersion(ctx, configID, "evasivePathMatchSetting", m)
-		if err != nil {
-			return diag.FromErr(err)
-		}
 
 		updateAdvancedSettingsEvasivePathMatch.ConfigID = configID
-		updateAdvancedSettingsEvasivePathMatch.Version = version
 	}
 	enablePathMatch, err := tools.GetBoolValue("enable_path_match", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
@@ -197,7 +186,11 @@ func resourceAdvancedSettingsEvasivePathMatchUpdate(ctx context.Context, d *sche
 	}
 	updateAdvancedSettingsEvasivePathMatch.EnablePathMatch = enablePathMatch
 
-	_, err = client.UpdateAdvancedSettingsEvasivePathMatch(ctx, updateAdvancedSettingsEvasivePathMatch)
+	err = updateModifiableConfigVersion(ctx, updateAdvancedSettingsEvasivePathMatch.ConfigID, "evasivePathMatchSetting", m, func(version int) error {
+		updateAdvancedSettingsEvasivePathMatch.Version = version
+		_, err := client.UpdateAdvancedSettingsEvasivePathMatch(ctx, updateAdvancedSettingsEvasivePathMatch)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateAdvancedSettingsEvasivePathMatch': %s", err.Error())
 		return diag.FromErr(err)
@@ -222,32 +215,26 @@ func resourceAdvancedSettingsEvasivePathMatchDelete(ctx context.Context, d *sche
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		version, err := getModifiableConfigVersion(ctx, configID, "evasivePathMatchSetting", m)
-		if err != nil {
-			return diag.FromErr(err)
-		}
 		policyID := iDParts[1]
 
 		removeAdvancedSettingsEvasivePathMatch.ConfigID = configID
-		removeAdvancedSettingsEvasivePathMatch.Version = version
 		removeAdvancedSettingsEvasivePathMatch.PolicyID = policyID
 	} else {
 		configID, err := strconv.Atoi(d.Id())
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		version, err := getModifiableConfigVersion(ctx, configID, "evasivePathMatchSetting", m)
-		if err != nil {
-			return diag.FromErr(err)
-		}
 
 		removeAdvancedSettingsEvasivePathMatch.ConfigID = configID
-		removeAdvancedSettingsEvasivePathMatch.Version = version
 	}
 
 	removeAdvancedSettingsEvasivePathMatch.EnablePathMatch = false
 
-	_, err := client.RemoveAdvancedSettingsEvasivePathMatch(ctx, removeAdvancedSettingsEvasivePathMatch)
+	err := updateModifiableConfigVersion(ctx, removeAdvancedSettingsEvasivePathMatch.ConfigID, "evasivePathMatchSetting", m, func(version int) error {
+		removeAdvancedSettingsEvasivePathMatch.Version = version
+		_, err := client.RemoveAdvancedSettingsEvasivePathMatch(ctx, removeAdvancedSettingsEvasivePathMatch)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'removeAdvancedSettingsEvasivePathMatch': %s", err.Error())
 		return diag.FromErr(err)
@@ -64,10 +64,6 @@ func resourceAdvancedSettingsLoggingCreate(ctx context.Context, d *schema.Resour
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "loggingSetting", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return diag.FromErr(err)
@@ -78,12 +74,15 @@ func resourceAdvancedSettingsLoggingCreate(ctx context.Context, d *schema.Resour
 
 	createAdvancedSettingsLogging := appsec.UpdateAdvancedSettingsLoggingRequest{
 		ConfigID:       configID,
-		Version:        version,
 		PolicyID:       policyID,
 		JsonPayloadRaw: rawJSON,
 	}
 
-	_, err = client.UpdateAdvancedSettingsLogging(ctx, createAdvancedSettingsLogging)
+	err = updateModifiableConfigVersion(ctx, configID, "loggingSetting", m, func(version int) error {
+		createAdvancedSettingsLogging.Version = version
+		_, err := client.UpdateAdvancedSettingsLogging(ctx, createAdvancedSettingsLogging)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'UpdateAdvancedSettingsLogging': %s", err.Error())
 		return diag.FromErr(err)
@@ -176,27 +175,17 @@ func resourceAdvancedSettingsLoggingUpdate(ctx context.Context, d *schema.Resour
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		version, err := getModifiableConfigVersion(ctx, configID, "loggingSetting", m)
-		if err != nil {
-			return diag.FromErr(err)
-		}
 		policyID := iDParts[1]
 
 		updateAdvancedSettingsLogging.ConfigID = configID
-		updateAdvancedSettingsLogging.Version = version
 		updateAdvancedSettingsLogging.PolicyID = policyID
 	} else {
 		configID, err := strconv.Atoi(d.Id())
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		version, err := getModifiableConfigVersion(ctx, configID, "loggingSetting", m)
-		if err != nil {
-			return diag.FromErr(err)
-		}
 
 		updateAdvancedSettingsLogging.ConfigID = configID
-		updateAdvancedSettingsLogging.Version = version
 	}
 
 	jsonpostpayload := d.Get("logging")
@@ -204,7 +193,11 @@ func resourceAdvancedSettingsLoggingUpdate(ctx context.Context, d *schema.Resour
 	rawJSON := (json.RawMessage)(jsonPayloadRaw)
 
 	updateAdvancedSettingsLogging.JsonPayloadRaw = rawJSON
-	_, err := client.UpdateAdvancedSettingsLogging(ctx, updateAdvancedSettingsLogging)
+	err := updateModifiableConfigVersion(ctx, updateAdvancedSettingsLogging.ConfigID, "loggingSetting", m, func(version int) error {
+		updateAdvancedSettingsLogging.Version = version
+		_, err := client.UpdateAdvancedSettingsLogging(ctx, updateAdvancedSettingsLogging)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateAdvancedSettingsLogging': %s", err.Error())
 		return diag.FromErr(err)
@@ -229,14 +222,9 @@ func resourceAdvancedSettingsLoggingDelete(ctx context.Context, d *schema.Resour
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		version, err := getModifiableConfigVersion(ctx, configID, "loggingSetting", m)
-		if err != nil {
-			return diag.FromErr(err)
-		}
 		policyID := iDParts[1]
 
 		removeAdvancedSettingsLogging.ConfigID = configID
-		removeAdvancedSettingsLogging.Version = version
 		removeAdvancedSettingsLogging.PolicyID = policyID
 		removeAdvancedSettingsLogging.Override = false
 	} else {
@@ -244,17 +232,16 @@ func resourceAdvancedSettingsLoggingDelete(ctx context.Context, d *schema.Resour
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		version, err := getModifiableConfigVersion(ctx, configID, "loggingSetting", m)
-		if err != nil {
-			return diag.FromErr(err)
-		}
 
 		removeAdvancedSettingsLogging.ConfigID = configID
-		removeAdvancedSettingsLogging.Version = version
 		removeAdvancedSettingsLogging.AllowSampling = false
 	}
 
-	_, err := client.RemoveAdvancedSettingsLogging(ctx, removeAdvancedSettingsLogging)
+	err := updateModifiableConfigVersion(ctx, removeAdvancedSettingsLogging.ConfigID, "loggingSetting", m, func(version int) error {
+		removeAdvancedSettingsLogging.Version = version
+		_, err := client.RemoveAdvancedSettingsLogging(ctx, removeAdvancedSettingsLogging)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'removeAdvancedSettingsLogging': %s", err.Error())
 		return diag.FromErr(err)
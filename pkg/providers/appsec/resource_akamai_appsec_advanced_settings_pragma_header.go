@@ -61,10 +61,6 @@ func resourceAdvancedSettingsPragmaHeaderCreate(ctx context.Context, d *schema.R
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "pragmaSetting", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return diag.FromErr(err)
@@ -75,12 +71,15 @@ func resourceAdvancedSettingsPragmaHeaderCreate(ctx context.Context, d *schema.R
 
 	createAdvancedSettingsPragma := appsec.UpdateAdvancedSettingsPragmaRequest{
 		ConfigID:       configID,
-		Version:        version,
 		PolicyID:       policyID,
 		JsonPayloadRaw: rawJSON,
 	}
 
-	_, err = client.UpdateAdvancedSettingsPragma(ctx, createAdvancedSettingsPragma)
+	err = updateModifiableConfigVersion(ctx, configID, "pragmaSetting", m, func(version int) error {
+		createAdvancedSettingsPragma.Version = version
+		_, err := client.UpdateAdvancedSettingsPragma(ctx, createAdvancedSettingsPragma)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'createAdvancedSettingsPragma': %s", err.Error())
 		return diag.FromErr(err)
@@ -180,14 +179,9 @@ func resourceAdvancedSettingsPragmaHeaderDelete(ctx context.Context, d *schema.R
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		version, err := getModifiableConfigVersion(ctx, configID, "pragmaSetting", m)
-		if err != nil {
-			return diag.FromErr(err)
-		}
 		policyID := iDParts[1]
 
 		removeAdvancedSettingsPragma.ConfigID = configID
-		removeAdvancedSettingsPragma.Version = version
 		removeAdvancedSettingsPragma.PolicyID = policyID
 
 	} else {
@@ -195,16 +189,15 @@ func resourceAdvancedSettingsPragmaHeaderDelete(ctx context.Context, d *schema.R
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		version, err := getModifiableConfigVersion(ctx, configID, "pragmaSetting", m)
-		if err != nil {
-			return diag.FromErr(err)
-		}
 
 		removeAdvancedSettingsPragma.ConfigID = configID
-		removeAdvancedSettingsPragma.Version = version
 	}
 
-	_, err := client.UpdateAdvancedSettingsPragma(ctx, removeAdvancedSettingsPragma)
+	err := updateModifiableConfigVersion(ctx, removeAdvancedSettingsPragma.ConfigID, "pragmaSetting", m, func(version int) error {
+		removeAdvancedSettingsPragma.Version = version
+		_, err := client.UpdateAdvancedSettingsPragma(ctx, removeAdvancedSettingsPragma)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'removeAdvancedSettingsLogging': %s", err.Error())
 		return diag.FromErr(err)
@@ -228,27 +221,17 @@ func resourceAdvancedSettingsPragmaHeaderUpdate(ctx context.Context, d *schema.R
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		version, err := getModifiableConfigVersion(ctx, configID, "pragmaSetting", m)
-		if err != nil {
-			return diag.FromErr(err)
-		}
 
 		policyID := iDParts[1]
 
 		updateAdvancedSettingsPragma.ConfigID = configID
-		updateAdvancedSettingsPragma.Version = version
 		updateAdvancedSettingsPragma.PolicyID = policyID
 	} else {
 		configID, err := strconv.Atoi(d.Id())
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		version, err := getModifiableConfigVersion(ctx, configID, "pragmaSetting", m)
-		if err != nil {
-			return diag.FromErr(err)
-		}
 		updateAdvancedSettingsPragma.ConfigID = configID
-		updateAdvancedSettingsPragma.Version = version
 	}
 
 	jsonpostpayload := d.Get("pragma_header")
@@ -257,7 +240,11 @@ func resourceAdvancedSettingsPragmaHeaderUpdate(ctx context.Context, d *schema.R
 	rawJSON := (json.RawMessage)(jsonPayloadRaw)
 
 	updateAdvancedSettingsPragma.JsonPayloadRaw = rawJSON
-	_, err := client.UpdateAdvancedSettingsPragma(ctx, updateAdvancedSettingsPragma)
+	err := updateModifiableConfigVersion(ctx, updateAdvancedSettingsPragma.ConfigID, "pragmaSetting", m, func(version int) error {
+		updateAdvancedSettingsPragma.Version = version
+		_, err := client.UpdateAdvancedSettingsPragma(ctx, updateAdvancedSettingsPragma)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateAdvancedSettingsPragma': %s", err.Error())
 		return diag.FromErr(err)
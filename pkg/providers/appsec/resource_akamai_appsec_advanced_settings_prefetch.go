@@ -70,10 +70,6 @@ func resourceAdvancedSettingsPrefetchCreate(ctx context.Context, d *schema.Resou
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "prefetchSetting", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	enableAppLayer, err := tools.GetBoolValue("enable_app_layer", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -98,14 +94,17 @@ func resourceAdvancedSettingsPrefetchCreate(ctx context.Context, d *schema.Resou
 
 	createAdvancedSettingsPrefetch := appsec.UpdateAdvancedSettingsPrefetchRequest{
 		ConfigID:           configID,
-		Version:            version,
 		EnableAppLayer:     enableAppLayer,
 		AllExtensions:      allExtensions,
 		Extensions:         exts,
 		EnableRateControls: enableRateControls,
 	}
 
-	_, err = client.UpdateAdvancedSettingsPrefetch(ctx, createAdvancedSettingsPrefetch)
+	err = updateModifiableConfigVersion(ctx, configID, "prefetchSetting", m, func(version int) error {
+		createAdvancedSettingsPrefetch.Version = version
+		_, err := client.UpdateAdvancedSettingsPrefetch(ctx, createAdvancedSettingsPrefetch)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'createAdvancedSettingsPrefetch': %s", err.Error())
 		return diag.FromErr(err)
@@ -171,10 +170,6 @@ func resourceAdvancedSettingsPrefetchUpdate(ctx context.Context, d *schema.Resou
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "prefetchSetting", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	enableAppLayer, err := tools.GetBoolValue("enable_app_layer", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return diag.FromErr(err)
@@ -199,14 +194,17 @@ func resourceAdvancedSettingsPrefetchUpdate(ctx context.Context, d *schema.Resou
 
 	updateAdvancedSettingsPrefetch := appsec.UpdateAdvancedSettingsPrefetchRequest{
 		ConfigID:           configID,
-		Version:            version,
 		EnableAppLayer:     enableAppLayer,
 		AllExtensions:      allExtensions,
 		Extensions:         exts,
 		EnableRateControls: enableRateControls,
 	}
 
-	_, err = client.UpdateAdvancedSettingsPrefetch(ctx, updateAdvancedSettingsPrefetch)
+	err = updateModifiableConfigVersion(ctx, configID, "prefetchSetting", m, func(version int) error {
+		updateAdvancedSettingsPrefetch.Version = version
+		_, err := client.UpdateAdvancedSettingsPrefetch(ctx, updateAdvancedSettingsPrefetch)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateAdvancedSettingsPrefetch': %s", err.Error())
 		return diag.FromErr(err)
@@ -225,18 +223,17 @@ func resourceAdvancedSettingsPrefetchDelete(ctx context.Context, d *schema.Resou
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "prefetchSetting", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	removeAdvancedSettingsPrefetch := appsec.UpdateAdvancedSettingsPrefetchRequest{
 		ConfigID:           configID,
-		Version:            version,
 		EnableAppLayer:     false,
 		EnableRateControls: false,
 	}
 
-	_, err = client.UpdateAdvancedSettingsPrefetch(ctx, removeAdvancedSettingsPrefetch)
+	err = updateModifiableConfigVersion(ctx, configID, "prefetchSetting", m, func(version int) error {
+		removeAdvancedSettingsPrefetch.Version = version
+		_, err := client.UpdateAdvancedSettingsPrefetch(ctx, removeAdvancedSettingsPrefetch)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'removeAdvancedSettingsPrefetch': %s", err.Error())
 		return diag.FromErr(err)
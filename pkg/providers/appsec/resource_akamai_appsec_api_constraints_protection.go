@@ -64,10 +64,6 @@ func resourceAPIConstraintsProtectionCreate(ctx context.Context, d *schema.Resou
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "apiConstraintsProtection", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -79,11 +75,14 @@ func resourceAPIConstraintsProtectionCreate(ctx context.Context, d *schema.Resou
 
 	request := appsec.UpdateAPIConstraintsProtectionRequest{
 		ConfigID:            configID,
-		Version:             version,
 		PolicyID:            policyID,
 		ApplyAPIConstraints: enabled,
 	}
-	_, err = client.UpdateAPIConstraintsProtection(ctx, request)
+	err = updateModifiableConfigVersion(ctx, configID, "apiConstraintsProtection", m, func(version int) error {
+		request.Version = version
+		_, err := client.UpdateAPIConstraintsProtection(ctx, request)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling UpdateAPIConstraints: %s", err.Error())
 		return diag.FromErr(err)
@@ -162,10 +161,6 @@ func resourceAPIConstraintsProtectionUpdate(ctx context.Context, d *schema.Resou
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "apiConstraintsProtection", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	enabled, err := tools.GetBoolValue("enabled", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
@@ -174,11 +169,14 @@ func resourceAPIConstraintsProtectionUpdate(ctx context.Context, d *schema.Resou
 
 	request := appsec.UpdateAPIConstraintsProtectionRequest{
 		ConfigID:            configID,
-		Version:             version,
 		PolicyID:            policyID,
 		ApplyAPIConstraints: enabled,
 	}
-	_, err = client.UpdateAPIConstraintsProtection(ctx, request)
+	err = updateModifiableConfigVersion(ctx, configID, "apiConstraintsProtection", m, func(version int) error {
+		request.Version = version
+		_, err := client.UpdateAPIConstraintsProtection(ctx, request)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling UpdateAPIConstraintsProtection: %s", err.Error())
 		return diag.FromErr(err)
@@ -201,19 +199,18 @@ func resourceAPIConstraintsProtectionDelete(ctx context.Context, d *schema.Resou
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "apiConstraintsProtection", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 
 	request := appsec.UpdateAPIConstraintsProtectionRequest{
 		ConfigID:            configID,
-		Version:             version,
 		PolicyID:            policyID,
 		ApplyAPIConstraints: false,
 	}
-	_, err = client.UpdateAPIConstraintsProtection(ctx, request)
+	err = updateModifiableConfigVersion(ctx, configID, "apiConstraintsProtection", m, func(version int) error {
+		request.Version = version
+		_, err := client.UpdateAPIConstraintsProtection(ctx, request)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling UpdateAPIConstraintsProtection: %s", err.Error())
 		return diag.FromErr(err)
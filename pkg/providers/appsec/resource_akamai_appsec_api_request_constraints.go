@@ -67,10 +67,6 @@ func resourceAPIRequestConstraintsCreate(ctx context.Context, d *schema.Resource
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "apirequestconstraints", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -86,13 +82,16 @@ func resourceAPIRequestConstraintsCreate(ctx context.Context, d *schema.Resource
 
 	createAPIRequestConstraints := appsec.UpdateApiRequestConstraintsRequest{
 		ConfigID: configID,
-		Version:  version,
 		PolicyID: policyID,
 		ApiID:    apiEndpointID,
 		Action:   action,
 	}
 
-	_, err = client.UpdateApiRequestConstraints(ctx, createAPIRequestConstraints)
+	err = updateModifiableConfigVersion(ctx, configID, "apirequestconstraints", m, func(version int) error {
+		createAPIRequestConstraints.Version = version
+		_, err := client.UpdateApiRequestConstraints(ctx, createAPIRequestConstraints)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'createAPIRequestConstraints': %s", err.Error())
 		return diag.FromErr(err)
@@ -182,10 +181,6 @@ func resourceAPIRequestConstraintsUpdate(ctx context.Context, d *schema.Resource
 	if errconv != nil {
 		return diag.FromErr(errconv)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "apirequestconstraints", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := s[1]
 
 	apiID := 0
@@ -200,12 +195,15 @@ func resourceAPIRequestConstraintsUpdate(ctx context.Context, d *schema.Resource
 		return diag.FromErr(err)
 	}
 
-	_, err = client.UpdateApiRequestConstraints(ctx, appsec.UpdateApiRequestConstraintsRequest{
-		ConfigID: configID,
-		Version:  version,
-		PolicyID: policyID,
-		ApiID:    apiID,
-		Action:   action,
+	err = updateModifiableConfigVersion(ctx, configID, "apirequestconstraints", m, func(version int) error {
+		_, err := client.UpdateApiRequestConstraints(ctx, appsec.UpdateApiRequestConstraintsRequest{
+			ConfigID: configID,
+			Version:  version,
+			PolicyID: policyID,
+			ApiID:    apiID,
+			Action:   action,
+		})
+		return err
 	})
 	if err != nil {
 		logger.Errorf("calling 'updateAPIRequestConstraints': %s", err.Error())
@@ -227,10 +225,6 @@ func resourceAPIRequestConstraintsDelete(ctx context.Context, d *schema.Resource
 	if errconv != nil {
 		return diag.FromErr(errconv)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "apirequestconstraints", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := s[1]
 
 	apiID := 0
@@ -243,17 +237,19 @@ func resourceAPIRequestConstraintsDelete(ctx context.Context, d *schema.Resource
 
 	removeAPIRequestConstraints := appsec.RemoveApiRequestConstraintsRequest{
 		ConfigID: configID,
-		Version:  version,
 		PolicyID: policyID,
 		ApiID:    apiID,
 	}
 
 	if removeAPIRequestConstraints.ApiID == 0 {
-		_, err := client.UpdateAPIConstraintsProtection(ctx, appsec.UpdateAPIConstraintsProtectionRequest{
-			ConfigID:            configID,
-			Version:             version,
-			PolicyID:            policyID,
-			ApplyAPIConstraints: false,
+		err := updateModifiableConfigVersion(ctx, configID, "apirequestconstraints", m, func(version int) error {
+			_, err := client.UpdateAPIConstraintsProtection(ctx, appsec.UpdateAPIConstraintsProtectionRequest{
+				ConfigID:            configID,
+				Version:             version,
+				PolicyID:            policyID,
+				ApplyAPIConstraints: false,
+			})
+			return err
 		})
 		if err != nil {
 			logger.Errorf("calling 'UpdateAPIConstraintsProtection': %s", err.Error())
@@ -261,7 +257,11 @@ func resourceAPIRequestConstraintsDelete(ctx context.Context, d *schema.Resource
 		}
 	} else {
 		removeAPIRequestConstraints.Action = "none"
-		_, err := client.RemoveApiRequestConstraints(ctx, removeAPIRequestConstraints)
+		err := updateModifiableConfigVersion(ctx, configID, "apirequestconstraints", m, func(version int) error {
+			removeAPIRequestConstraints.Version = version
+			_, err := client.RemoveApiRequestConstraints(ctx, removeAPIRequestConstraints)
+			return err
+		})
 		if err != nil {
 			logger.Errorf("calling 'removeApiRequestConstraints': %s", err.Error())
 			return diag.FromErr(err)
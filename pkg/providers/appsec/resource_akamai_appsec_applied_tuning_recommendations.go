@@ -0,0 +1,575 @@
+package appsec
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Kinds of tuning recommendations, the first part of a recommendation ID.
+const (
+	tuningAttackGroup = "attack_group"
+	tuningRule        = "rule"
+)
+
+// tuningExceptionNames is an entry of the specificHeaderCookieParamXmlOrJsonNames exception of a rule or an attack group.
+type tuningExceptionNames struct {
+	Names    []string `json:"names,omitempty"`
+	Selector string   `json:"selector,omitempty"`
+	Wildcard bool     `json:"wildcard,omitempty"`
+}
+
+// tuningRecommendation is a tuning recommendation for an attack group or a rule of a security policy.
+type tuningRecommendation struct {
+	Group     string
+	RuleID    int
+	Exception []tuningExceptionNames
+}
+
+// appsec v1
+//
+// https://techdocs.akamai.com/application-security/reference/api
+func resourceAppliedTuningRecommendations() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAppliedTuningRecommendationsCreate,
+		ReadContext:   resourceAppliedTuningRecommendationsRead,
+		UpdateContext: resourceAppliedTuningRecommendationsUpdate,
+		DeleteContext: resourceAppliedTuningRecommendationsDelete,
+		Schema: map[string]*schema.Schema{
+			"config_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Unique identifier of the security configuration",
+			},
+			"security_policy_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Unique identifier of the security policy",
+			},
+			"recommendation_ids": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the tuning recommendations to apply, as returned by the akamai_appsec_tuning_recommendations data source",
+			},
+			"applied_recommendations": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "JSON-formatted exception entries added by each applied tuning recommendation, by recommendation ID",
+			},
+		},
+	}
+}
+
+func resourceAppliedTuningRecommendationsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("APPSEC", "resourceAppliedTuningRecommendationsCreate")
+	logger.Debugf("in resourceAppliedTuningRecommendationsCreate")
+
+	configID, err := tools.GetIntValue("config_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	policyID, err := tools.GetStringValue("security_policy_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The ID is set first so that the recommendations applied before a failure are kept in the state
+	d.SetId(fmt.Sprintf("%d:%s", configID, policyID))
+
+	if err := applyTuningRecommendations(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceAppliedTuningRecommendationsRead(ctx, d, m)
+}
+
+func resourceAppliedTuningRecommendationsRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("APPSEC", "resourceAppliedTuningRecommendationsRead")
+	logger.Debugf("in resourceAppliedTuningRecommendationsRead")
+
+	iDParts, err := splitID(d.Id(), 2, "configID:securityPolicyID")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	configID, err := strconv.Atoi(iDParts[0])
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("config_id", configID); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("security_policy_id", iDParts[1]); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+
+	return nil
+}
+
+func resourceAppliedTuningRecommendationsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("APPSEC", "resourceAppliedTuningRecommendationsUpdate")
+	logger.Debugf("in resourceAppliedTuningRecommendationsUpdate")
+
+	if err := applyTuningRecommendations(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceAppliedTuningRecommendationsRead(ctx, d, m)
+}
+
+func resourceAppliedTuningRecommendationsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("APPSEC", "resourceAppliedTuningRecommendationsDelete")
+	logger.Debugf("in resourceAppliedTuningRecommendationsDelete")
+
+	if err := d.Set("recommendation_ids", []string{}); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := applyTuningRecommendations(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// applyTuningRecommendations removes the exception entries added by the recommendations no longer selected and merges
+// the entries of the newly selected ones, recording them in applied_recommendations. When applying fails, the
+// recommendations applied so far are recorded, and recommendation_ids is set to them, so that the next apply
+// continues where this one stopped.
+func applyTuningRecommendations(ctx context.Context, d *schema.ResourceData, m interface{}) (err error) {
+	meta := akamai.Meta(m)
+	client := inst.Client(meta)
+	logger := meta.Log("APPSEC", "applyTuningRecommendations")
+
+	configID, err := tools.GetIntValue("config_id", d)
+	if err != nil {
+		return err
+	}
+	policyID, err := tools.GetStringValue("security_policy_id", d)
+	if err != nil {
+		return err
+	}
+	selectedSet, err := tools.GetSetValue("recommendation_ids", d)
+	if err != nil {
+		return err
+	}
+	selected := make(map[string]bool)
+	for _, id := range tools.SetToStringSlice(selectedSet) {
+		selected[id] = true
+	}
+
+	applied := make(map[string]string)
+	if v, ok := d.Get("applied_recommendations").(map[string]interface{}); ok {
+		for id, fragment := range v {
+			applied[id] = fragment.(string)
+		}
+	}
+	defer func() {
+		if setErr := d.Set("applied_recommendations", applied); setErr != nil {
+			if err == nil {
+				err = fmt.Errorf("%s: %s", tools.ErrValueSet, setErr.Error())
+			}
+			return
+		}
+		if err == nil {
+			return
+		}
+		ids := make([]string, 0, len(applied))
+		for id := range applied {
+			ids = append(ids, id)
+		}
+		if setErr := d.Set("recommendation_ids", ids); setErr != nil {
+			logger.Errorf("%s: %s", tools.ErrValueSet, setErr.Error())
+		}
+	}()
+
+	var toRemove, toAdd []string
+	for id := range applied {
+		if !selected[id] {
+			toRemove = append(toRemove, id)
+		}
+	}
+	for id := range selected {
+		if _, ok := applied[id]; !ok {
+			toAdd = append(toAdd, id)
+		}
+	}
+	sort.Strings(toRemove)
+	sort.Strings(toAdd)
+	if len(toRemove) == 0 && len(toAdd) == 0 {
+		return nil
+	}
+
+	// Recommendations applied or removed before a version conflict are recorded in applied, so that a retry
+	// with a new version only changes the remaining ones.
+	return updateModifiableConfigVersion(ctx, configID, "tuningRecommendations", m, func(version int) error {
+		wafMode, err := getWAFMode(ctx, m, configID, version, policyID)
+		if err != nil {
+			logger.Errorf("calling 'getWAFMode': %s", err.Error())
+			return err
+		}
+		applier := tuningApplier{client: client, configID: configID, version: version, policyID: policyID, wafMode: wafMode}
+
+		for _, id := range toRemove {
+			if _, ok := applied[id]; !ok {
+				continue
+			}
+			var fragment []tuningExceptionNames
+			if err := json.Unmarshal([]byte(applied[id]), &fragment); err != nil {
+				return err
+			}
+			group, ruleID, err := parseTuningRecommendationID(id)
+			if err != nil {
+				return err
+			}
+			err = applier.update(ctx, group, ruleID, func(conditionException []byte) ([]byte, error) {
+				return removeTuningException(conditionException, fragment)
+			})
+			if err != nil {
+				logger.Errorf("removing tuning recommendation %s: %s", id, err.Error())
+				return err
+			}
+			delete(applied, id)
+		}
+
+		if len(toAdd) > 0 {
+			response, err := client.GetTuningRecommendations(ctx, appsec.GetTuningRecommendationsRequest{
+				ConfigID:    configID,
+				Version:     version,
+				PolicyID:    policyID,
+				RulesetType: appsec.RulesetTypeActive,
+			})
+			if err != nil {
+				logger.Errorf("calling 'GetTuningRecommendations': %s", err.Error())
+				return err
+			}
+			recommendations, err := newTuningRecommendations(response)
+			if err != nil {
+				return err
+			}
+
+			for _, id := range toAdd {
+				if _, ok := applied[id]; ok {
+					continue
+				}
+				recommendation, ok := recommendations[id]
+				if !ok {
+					return fmt.Errorf("tuning recommendation %s is not available for security policy %s", id, policyID)
+				}
+				var added []tuningExceptionNames
+				err = applier.update(ctx, recommendation.Group, recommendation.RuleID, func(conditionException []byte) ([]byte, error) {
+					merged, entries, err := mergeTuningException(conditionException, recommendation.Exception)
+					added = entries
+					return merged, err
+				})
+				if err != nil {
+					logger.Errorf("applying tuning recommendation %s: %s", id, err.Error())
+					return err
+				}
+				fragment, err := json.Marshal(added)
+				if err != nil {
+					return err
+				}
+				applied[id] = string(fragment)
+			}
+		}
+
+		return nil
+	})
+}
+
+// tuningApplier updates the condition exception of the attack groups and rules of a security policy.
+type tuningApplier struct {
+	client   appsec.APPSEC
+	configID int
+	version  int
+	policyID string
+	wafMode  string
+}
+
+// update replaces the condition exception of an attack group, or a rule if group is empty, with the result of change.
+func (a tuningApplier) update(ctx context.Context, group string, ruleID int, change func([]byte) ([]byte, error)) error {
+	if group != "" {
+		attackGroup, err := a.client.GetAttackGroup(ctx, appsec.GetAttackGroupRequest{
+			ConfigID: a.configID,
+			Version:  a.version,
+			PolicyID: a.policyID,
+			Group:    group,
+		})
+		if err != nil {
+			return err
+		}
+		conditionException, err := changeConditionException(attackGroup.ConditionException, change)
+		if err != nil {
+			return err
+		}
+		_, err = a.client.UpdateAttackGroup(ctx, appsec.UpdateAttackGroupRequest{
+			ConfigID:       a.configID,
+			Version:        a.version,
+			PolicyID:       a.policyID,
+			Group:          group,
+			Action:         attackGroup.Action,
+			JsonPayloadRaw: conditionException,
+		})
+		return err
+	}
+
+	rule, err := a.client.GetRule(ctx, appsec.GetRuleRequest{
+		ConfigID: a.configID,
+		Version:  a.version,
+		PolicyID: a.policyID,
+		RuleID:   ruleID,
+	})
+	if err != nil {
+		return err
+	}
+	conditionException, err := changeConditionException(rule.ConditionException, change)
+	if err != nil {
+		return err
+	}
+
+	if a.wafMode == AseAuto { // action is read only, only condition exception is writable
+		ruleConditionException := appsec.RuleConditionException{}
+		if err := json.Unmarshal(conditionException, &ruleConditionException); err != nil {
+			return err
+		}
+		_, err = a.client.UpdateRuleConditionException(ctx, appsec.UpdateConditionExceptionRequest{
+			ConfigID:               a.configID,
+			Version:                a.version,
+			PolicyID:               a.policyID,
+			RuleID:                 ruleID,
+			Conditions:             ruleConditionException.Conditions,
+			Exception:              ruleConditionException.Exception,
+			AdvancedExceptionsList: ruleConditionException.AdvancedExceptionsList,
+		})
+		return err
+	}
+
+	_, err = a.client.UpdateRule(ctx, appsec.UpdateRuleRequest{
+		ConfigID:       a.configID,
+		Version:        a.version,
+		PolicyID:       a.policyID,
+		RuleID:         ruleID,
+		Action:         rule.Action,
+		JsonPayloadRaw: conditionException,
+	})
+	return err
+}
+
+func changeConditionException(conditionException interface{}, change func([]byte) ([]byte, error)) (json.RawMessage, error) {
+	body, err := json.Marshal(conditionException)
+	if err != nil {
+		return nil, err
+	}
+	return change(body)
+}
+
+// newTuningRecommendations returns the tuning recommendations of a security policy by recommendation ID.
+func newTuningRecommendations(response *appsec.GetTuningRecommendationsResponse) (map[string]tuningRecommendation, error) {
+	recommendations := make(map[string]tuningRecommendation)
+	add := func(group string, ruleID int, exception *appsec.AttackGroupException) error {
+		recommendation := tuningRecommendation{Group: group, RuleID: ruleID}
+		if exception != nil {
+			body, err := json.Marshal(exception.SpecificHeaderCookieParamXMLOrJSONNames)
+			if err != nil {
+				return err
+			}
+			if err := json.Unmarshal(body, &recommendation.Exception); err != nil {
+				return err
+			}
+		}
+		id, err := tuningRecommendationID(group, ruleID, exception)
+		if err != nil {
+			return err
+		}
+		recommendations[id] = recommendation
+		return nil
+	}
+
+	for _, r := range response.AttackGroupRecommendations {
+		if err := add(r.Group, 0, r.Exception); err != nil {
+			return nil, err
+		}
+	}
+	for _, r := range response.RuleRecommendations {
+		if err := add("", r.RuleId, r.Exception); err != nil {
+			return nil, err
+		}
+	}
+	return recommendations, nil
+}
+
+// tuningRecommendationIDs returns the IDs of the tuning recommendations of a response, in the order of the response.
+func tuningRecommendationIDs(response *appsec.GetTuningRecommendationsResponse) ([]string, error) {
+	ids := make([]string, 0, len(response.AttackGroupRecommendations)+len(response.RuleRecommendations))
+	for _, r := range response.AttackGroupRecommendations {
+		id, err := tuningRecommendationID(r.Group, 0, r.Exception)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	for _, r := range response.RuleRecommendations {
+		id, err := tuningRecommendationID("", r.RuleId, r.Exception)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// tuningRecommendationID identifies a tuning recommendation by its attack group or rule and the exception it recommends.
+func tuningRecommendationID(group string, ruleID int, exception *appsec.AttackGroupException) (string, error) {
+	body, err := json.Marshal(exception)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(body)
+	if group != "" {
+		return fmt.Sprintf("%s:%s:%x", tuningAttackGroup, group, sum[:4]), nil
+	}
+	return fmt.Sprintf("%s:%d:%x", tuningRule, ruleID, sum[:4]), nil
+}
+
+// parseTuningRecommendationID returns the attack group or the rule ID of a tuning recommendation ID.
+func parseTuningRecommendationID(id string) (string, int, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 3 {
+		return "", 0, fmt.Errorf("invalid tuning recommendation ID %q", id)
+	}
+	switch parts[0] {
+	case tuningAttackGroup:
+		return parts[1], 0, nil
+	case tuningRule:
+		ruleID, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return "", 0, fmt.Errorf("invalid tuning recommendation ID %q: %s", id, err)
+		}
+		return "", ruleID, nil
+	}
+	return "", 0, fmt.Errorf("invalid tuning recommendation ID %q", id)
+}
+
+// mergeTuningException adds the names of a recommended exception that aren't already excepted to a condition
+// exception, returning the updated condition exception and the entries actually added.
+func mergeTuningException(conditionException []byte, recommended []tuningExceptionNames) ([]byte, []tuningExceptionNames, error) {
+	var added []tuningExceptionNames
+	result, err := changeTuningException(conditionException, func(entries []tuningExceptionNames) []tuningExceptionNames {
+		for _, r := range recommended {
+			i := findTuningExceptionEntry(entries, r)
+			if i < 0 {
+				entries = append(entries, tuningExceptionNames{Selector: r.Selector, Wildcard: r.Wildcard})
+				i = len(entries) - 1
+			}
+			entry := tuningExceptionNames{Selector: r.Selector, Wildcard: r.Wildcard}
+			for _, name := range r.Names {
+				if !tools.ContainsString(entries[i].Names, name) {
+					entries[i].Names = append(entries[i].Names, name)
+					entry.Names = append(entry.Names, name)
+				}
+			}
+			if len(entry.Names) > 0 {
+				added = append(added, entry)
+			}
+		}
+		return entries
+	})
+	return result, added, err
+}
+
+// removeTuningException removes the names added by mergeTuningException from a condition exception, leaving
+// the names excepted otherwise in place.
+func removeTuningException(conditionException []byte, fragment []tuningExceptionNames) ([]byte, error) {
+	return changeTuningException(conditionException, func(entries []tuningExceptionNames) []tuningExceptionNames {
+		for _, f := range fragment {
+			i := findTuningExceptionEntry(entries, f)
+			if i < 0 {
+				continue
+			}
+			names := make([]string, 0, len(entries[i].Names))
+			for _, name := range entries[i].Names {
+				if !tools.ContainsString(f.Names, name) {
+					names = append(names, name)
+				}
+			}
+			if len(names) > 0 {
+				entries[i].Names = names
+				continue
+			}
+			entries = append(entries[:i], entries[i+1:]...)
+		}
+		return entries
+	})
+}
+
+func findTuningExceptionEntry(entries []tuningExceptionNames, entry tuningExceptionNames) int {
+	for i, e := range entries {
+		if e.Selector == entry.Selector && e.Wildcard == entry.Wildcard {
+			return i
+		}
+	}
+	return -1
+}
+
+// changeTuningException replaces the specificHeaderCookieParamXmlOrJsonNames exception of a condition exception
+// with the result of change, keeping all its other members.
+func changeTuningException(conditionException []byte, change func([]tuningExceptionNames) []tuningExceptionNames) ([]byte, error) {
+	const namesKey = "specificHeaderCookieParamXmlOrJsonNames"
+
+	members := map[string]json.RawMessage{}
+	if len(conditionException) > 0 && string(conditionException) != "null" {
+		if err := json.Unmarshal(conditionException, &members); err != nil {
+			return nil, err
+		}
+	}
+	exception := map[string]json.RawMessage{}
+	if body, ok := members["exception"]; ok && string(body) != "null" {
+		if err := json.Unmarshal(body, &exception); err != nil {
+			return nil, err
+		}
+	}
+	var entries []tuningExceptionNames
+	if body, ok := exception[namesKey]; ok && string(body) != "null" {
+		if err := json.Unmarshal(body, &entries); err != nil {
+			return nil, err
+		}
+	}
+
+	entries = change(entries)
+
+	delete(exception, namesKey)
+	if len(entries) > 0 {
+		body, err := json.Marshal(entries)
+		if err != nil {
+			return nil, err
+		}
+		exception[namesKey] = body
+	}
+	delete(members, "exception")
+	if len(exception) > 0 {
+		body, err := json.Marshal(exception)
+		if err != nil {
+			return nil, err
+		}
+		members["exception"] = body
+	}
+	return json.Marshal(members)
+}
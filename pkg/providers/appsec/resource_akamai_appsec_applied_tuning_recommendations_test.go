@@ -0,0 +1,221 @@
+package appsec
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTuningRecommendationIDs(t *testing.T) {
+	response := appsec.GetTuningRecommendationsResponse{}
+	err := json.Unmarshal(loadFixtureBytes("testdata/TestResAppliedTuningRecommendations/Recommendations.json"), &response)
+	require.NoError(t, err)
+
+	ids, err := tuningRecommendationIDs(&response)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"attack_group:XSS:fe8d80e4", "rule:950002:b479a52b"}, ids)
+
+	recommendations, err := newTuningRecommendations(&response)
+	require.NoError(t, err)
+	assert.Equal(t, 950002, recommendations["rule:950002:b479a52b"].RuleID)
+	assert.Equal(t, "XSS", recommendations["attack_group:XSS:fe8d80e4"].Group)
+
+	group, ruleID, err := parseTuningRecommendationID("rule:950002:b479a52b")
+	require.NoError(t, err)
+	assert.Equal(t, "", group)
+	assert.Equal(t, 950002, ruleID)
+
+	_, _, err = parseTuningRecommendationID("policy:AAAA_81230:b479a52b")
+	assert.Error(t, err)
+}
+
+func TestMergeTuningException(t *testing.T) {
+	existing := `{"conditions":[{"type":"pathMatch","paths":["/admin"]}],"exception":{"headerCookieOrParamValues":["manual-value"],"specificHeaderCookieParamXmlOrJsonNames":[{"names":["X-MANUAL"],"selector":"REQUEST_HEADERS","wildcard":true}]}}`
+	recommended := []tuningExceptionNames{
+		{Names: []string{"UTAF-TEST-HEADER", "X-MANUAL"}, Selector: "REQUEST_HEADERS", Wildcard: true},
+		{Names: []string{"session"}, Selector: "REQUEST_COOKIES"},
+	}
+
+	merged, added, err := mergeTuningException([]byte(existing), recommended)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"conditions":[{"type":"pathMatch","paths":["/admin"]}],"exception":{"headerCookieOrParamValues":["manual-value"],"specificHeaderCookieParamXmlOrJsonNames":[{"names":["X-MANUAL","UTAF-TEST-HEADER"],"selector":"REQUEST_HEADERS","wildcard":true},{"names":["session"],"selector":"REQUEST_COOKIES"}]}}`, string(merged))
+	assert.Equal(t, []tuningExceptionNames{
+		{Names: []string{"UTAF-TEST-HEADER"}, Selector: "REQUEST_HEADERS", Wildcard: true},
+		{Names: []string{"session"}, Selector: "REQUEST_COOKIES"},
+	}, added)
+
+	// Removing the recommendation restores the manual exceptions only.
+	removed, err := removeTuningException(merged, added)
+	require.NoError(t, err)
+	assert.JSONEq(t, existing, string(removed))
+
+	merged, _, err = mergeTuningException([]byte("null"), recommended[1:])
+	require.NoError(t, err)
+	removed, err = removeTuningException(merged, recommended[1:])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(removed))
+}
+
+func TestAkamaiAppliedTuningRecommendations_res_basic(t *testing.T) {
+	t.Run("match by AppliedTuningRecommendations ID", func(t *testing.T) {
+		client := &appsec.Mock{}
+
+		config := appsec.GetConfigurationResponse{}
+		err := json.Unmarshal(loadFixtureBytes("testdata/TestResConfiguration/LatestConfiguration.json"), &config)
+		require.NoError(t, err)
+
+		recommendations := appsec.GetTuningRecommendationsResponse{}
+		err = json.Unmarshal(loadFixtureBytes("testdata/TestResAppliedTuningRecommendations/Recommendations.json"), &recommendations)
+		require.NoError(t, err)
+
+		attackGroup := appsec.GetAttackGroupResponse{}
+		err = json.Unmarshal(loadFixtureBytes("testdata/TestResAppliedTuningRecommendations/AttackGroup.json"), &attackGroup)
+		require.NoError(t, err)
+
+		client.On("GetConfiguration",
+			mock.Anything,
+			appsec.GetConfigurationRequest{ConfigID: 43253},
+		).Return(&config, nil)
+
+		client.On("GetWAFMode",
+			mock.Anything,
+			appsec.GetWAFModeRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230"},
+		).Return(&appsec.GetWAFModeResponse{Mode: KRS}, nil)
+
+		client.On("GetTuningRecommendations",
+			mock.Anything,
+			appsec.GetTuningRecommendationsRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", RulesetType: appsec.RulesetTypeActive},
+		).Return(&recommendations, nil)
+
+		client.On("GetAttackGroup",
+			mock.Anything,
+			appsec.GetAttackGroupRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", Group: "XSS"},
+		).Return(&attackGroup, nil)
+
+		client.On("UpdateAttackGroup",
+			mock.Anything,
+			appsec.UpdateAttackGroupRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", Group: "XSS", Action: "deny", JsonPayloadRaw: json.RawMessage(
+				`{"exception":{"headerCookieOrParamValues":["manual-value"],"specificHeaderCookieParamXmlOrJsonNames":[{"names":["X-MANUAL","UTAF-TEST-HEADER"],"selector":"REQUEST_HEADERS","wildcard":true}]}}`)},
+		).Return(&appsec.UpdateAttackGroupResponse{}, nil).Once()
+
+		client.On("UpdateAttackGroup",
+			mock.Anything,
+			appsec.UpdateAttackGroupRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", Group: "XSS", Action: "deny", JsonPayloadRaw: json.RawMessage(
+				`{"exception":{"headerCookieOrParamValues":["manual-value"],"specificHeaderCookieParamXmlOrJsonNames":[{"names":["X-MANUAL"],"selector":"REQUEST_HEADERS","wildcard":true}]}}`)},
+		).Return(&appsec.UpdateAttackGroupResponse{}, nil).Once()
+
+		useClient(client, func() {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:        true,
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResAppliedTuningRecommendations/match_by_id.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_appsec_applied_tuning_recommendations.test", "id", "43253:AAAA_81230"),
+							resource.TestCheckResourceAttr("akamai_appsec_applied_tuning_recommendations.test", "applied_recommendations.attack_group:XSS:fe8d80e4",
+								`[{"names":["UTAF-TEST-HEADER"],"selector":"REQUEST_HEADERS","wildcard":true}]`),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("recommendations applied before a failure are kept", func(t *testing.T) {
+		client := &appsec.Mock{}
+
+		config := appsec.GetConfigurationResponse{}
+		err := json.Unmarshal(loadFixtureBytes("testdata/TestResConfiguration/LatestConfiguration.json"), &config)
+		require.NoError(t, err)
+
+		recommendations := appsec.GetTuningRecommendationsResponse{}
+		err = json.Unmarshal(loadFixtureBytes("testdata/TestResAppliedTuningRecommendations/Recommendations.json"), &recommendations)
+		require.NoError(t, err)
+
+		attackGroup := appsec.GetAttackGroupResponse{}
+		err = json.Unmarshal(loadFixtureBytes("testdata/TestResAppliedTuningRecommendations/AttackGroup.json"), &attackGroup)
+		require.NoError(t, err)
+
+		client.On("GetConfiguration",
+			mock.Anything,
+			appsec.GetConfigurationRequest{ConfigID: 43253},
+		).Return(&config, nil)
+
+		client.On("GetWAFMode",
+			mock.Anything,
+			appsec.GetWAFModeRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230"},
+		).Return(&appsec.GetWAFModeResponse{Mode: KRS}, nil)
+
+		client.On("GetTuningRecommendations",
+			mock.Anything,
+			appsec.GetTuningRecommendationsRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", RulesetType: appsec.RulesetTypeActive},
+		).Return(&recommendations, nil)
+
+		client.On("GetAttackGroup",
+			mock.Anything,
+			appsec.GetAttackGroupRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", Group: "XSS"},
+		).Return(&attackGroup, nil)
+
+		client.On("GetRule",
+			mock.Anything,
+			appsec.GetRuleRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", RuleID: 950002},
+		).Return(&appsec.GetRuleResponse{Action: "alert"}, nil)
+
+		// The attack group recommendation is applied once, before the rule update fails.
+		client.On("UpdateAttackGroup",
+			mock.Anything,
+			appsec.UpdateAttackGroupRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", Group: "XSS", Action: "deny", JsonPayloadRaw: json.RawMessage(
+				`{"exception":{"headerCookieOrParamValues":["manual-value"],"specificHeaderCookieParamXmlOrJsonNames":[{"names":["X-MANUAL","UTAF-TEST-HEADER"],"selector":"REQUEST_HEADERS","wildcard":true}]}}`)},
+		).Return(&appsec.UpdateAttackGroupResponse{}, nil).Once()
+
+		updateRule := appsec.UpdateRuleRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", RuleID: 950002, Action: "alert", JsonPayloadRaw: json.RawMessage(
+			`{"exception":{"specificHeaderCookieParamXmlOrJsonNames":[{"names":["session"],"selector":"REQUEST_COOKIES"}]}}`)}
+		client.On("UpdateRule", mock.Anything, updateRule).Return(nil, fmt.Errorf("update failed")).Once()
+		client.On("UpdateRule", mock.Anything, updateRule).Return(&appsec.UpdateRuleResponse{}, nil).Once()
+
+		client.On("UpdateAttackGroup",
+			mock.Anything,
+			appsec.UpdateAttackGroupRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", Group: "XSS", Action: "deny", JsonPayloadRaw: json.RawMessage(
+				`{"exception":{"headerCookieOrParamValues":["manual-value"],"specificHeaderCookieParamXmlOrJsonNames":[{"names":["X-MANUAL"],"selector":"REQUEST_HEADERS","wildcard":true}]}}`)},
+		).Return(&appsec.UpdateAttackGroupResponse{}, nil).Once()
+
+		client.On("UpdateRule",
+			mock.Anything,
+			appsec.UpdateRuleRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", RuleID: 950002, Action: "alert", JsonPayloadRaw: json.RawMessage(`{}`)},
+		).Return(&appsec.UpdateRuleResponse{}, nil).Once()
+
+		useClient(client, func() {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:        true,
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config:      loadFixtureString("testdata/TestResAppliedTuningRecommendations/partial_failure.tf"),
+						ExpectError: regexp.MustCompile("update failed"),
+					},
+					{
+						Config: loadFixtureString("testdata/TestResAppliedTuningRecommendations/partial_failure.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_appsec_applied_tuning_recommendations.test", "id", "43253:AAAA_81230"),
+							resource.TestCheckResourceAttr("akamai_appsec_applied_tuning_recommendations.test", "applied_recommendations.%", "2"),
+							resource.TestCheckResourceAttr("akamai_appsec_applied_tuning_recommendations.test", "applied_recommendations.rule:950002:b479a52b",
+								`[{"names":["session"],"selector":"REQUEST_COOKIES"}]`),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
@@ -75,10 +75,6 @@ func resourceAttackGroupCreate(ctx context.Context, d *schema.ResourceData, m in
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "atackGroup", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -104,14 +100,17 @@ func resourceAttackGroupCreate(ctx context.Context, d *schema.ResourceData, m in
 
 	createAttackGroup := appsec.UpdateAttackGroupRequest{
 		ConfigID:       configID,
-		Version:        version,
 		PolicyID:       policyID,
 		Group:          attackgroup,
 		Action:         action,
 		JsonPayloadRaw: rawJSON,
 	}
 
-	_, err = client.UpdateAttackGroup(ctx, createAttackGroup)
+	err = updateModifiableConfigVersion(ctx, configID, "atackGroup", m, func(version int) error {
+		createAttackGroup.Version = version
+		_, err := client.UpdateAttackGroup(ctx, createAttackGroup)
+		return err
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -194,10 +193,6 @@ func resourceAttackGroupUpdate(ctx context.Context, d *schema.ResourceData, m in
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "attackGroup", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	group := iDParts[2]
 
@@ -219,14 +214,17 @@ func resourceAttackGroupUpdate(ctx context.Context, d *schema.ResourceData, m in
 
 	updateAttackGroup := appsec.UpdateAttackGroupRequest{
 		ConfigID:       configID,
-		Version:        version,
 		PolicyID:       policyID,
 		Group:          group,
 		Action:         action,
 		JsonPayloadRaw: rawJSON,
 	}
 
-	_, err = client.UpdateAttackGroup(ctx, updateAttackGroup)
+	err = updateModifiableConfigVersion(ctx, configID, "attackGroup", m, func(version int) error {
+		updateAttackGroup.Version = version
+		_, err := client.UpdateAttackGroup(ctx, updateAttackGroup)
+		return err
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -248,22 +246,21 @@ func resourceAttackGroupDelete(ctx context.Context, d *schema.ResourceData, m in
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "attackGroup", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	group := iDParts[2]
 
 	removeAttackGroup := appsec.UpdateAttackGroupRequest{
 		ConfigID: configID,
-		Version:  version,
 		PolicyID: policyID,
 		Group:    group,
 		Action:   "none",
 	}
 
-	_, err = client.UpdateAttackGroup(ctx, removeAttackGroup)
+	err = updateModifiableConfigVersion(ctx, configID, "attackGroup", m, func(version int) error {
+		removeAttackGroup.Version = version
+		_, err := client.UpdateAttackGroup(ctx, removeAttackGroup)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'RemoveAttackGroup': %s", err.Error())
 		return diag.FromErr(err)
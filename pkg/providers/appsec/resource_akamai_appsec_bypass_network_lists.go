@@ -71,18 +71,17 @@ func resourceBypassNetworkListsCreate(ctx context.Context, d *schema.ResourceDat
 		networkListIDList = append(networkListIDList, networkListID.(string))
 	}
 
-	version, err := getModifiableConfigVersion(ctx, configID, "bypassnetworklists", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	updateBypassNetworkLists := appsec.UpdateWAPBypassNetworkListsRequest{
 		ConfigID:     configID,
-		Version:      version,
 		PolicyID:     policyID,
 		NetworkLists: networkListIDList,
 	}
 
-	_, err = client.UpdateWAPBypassNetworkLists(ctx, updateBypassNetworkLists)
+	err = updateModifiableConfigVersion(ctx, configID, "bypassnetworklists", m, func(version int) error {
+		updateBypassNetworkLists.Version = version
+		_, err := client.UpdateWAPBypassNetworkLists(ctx, updateBypassNetworkLists)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'UpdateWAPBypassNetworkLists': %s", err.Error())
 		return diag.FromErr(err)
@@ -160,18 +159,17 @@ func resourceBypassNetworkListsUpdate(ctx context.Context, d *schema.ResourceDat
 		networkListIDList = append(networkListIDList, networkListID.(string))
 	}
 
-	version, err := getModifiableConfigVersion(ctx, configID, "bypassnetworklists", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	updateBypassNetworkLists := appsec.UpdateWAPBypassNetworkListsRequest{
 		ConfigID:     configID,
-		Version:      version,
 		PolicyID:     policyID,
 		NetworkLists: networkListIDList,
 	}
 
-	_, err = client.UpdateWAPBypassNetworkLists(ctx, updateBypassNetworkLists)
+	err = updateModifiableConfigVersion(ctx, configID, "bypassnetworklists", m, func(version int) error {
+		updateBypassNetworkLists.Version = version
+		_, err := client.UpdateWAPBypassNetworkLists(ctx, updateBypassNetworkLists)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'UpdateWAPBypassNetworkLists': %s", err.Error())
 		return diag.FromErr(err)
@@ -200,18 +198,17 @@ func resourceBypassNetworkListsDelete(ctx context.Context, d *schema.ResourceDat
 	// Send an empty list to remove the entire current list.
 	networkListIDList := make([]string, 0)
 
-	version, err := getModifiableConfigVersion(ctx, configID, "bypassnetworklists", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	removeBypassNetworkLists := appsec.RemoveWAPBypassNetworkListsRequest{
 		ConfigID:     configID,
-		Version:      version,
 		PolicyID:     policyID,
 		NetworkLists: networkListIDList,
 	}
 
-	_, err = client.RemoveWAPBypassNetworkLists(ctx, removeBypassNetworkLists)
+	err = updateModifiableConfigVersion(ctx, configID, "bypassnetworklists", m, func(version int) error {
+		removeBypassNetworkLists.Version = version
+		_, err := client.RemoveWAPBypassNetworkLists(ctx, removeBypassNetworkLists)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'RemoveWAPBypassNetworkLists': %s", err.Error())
 		return diag.FromErr(err)
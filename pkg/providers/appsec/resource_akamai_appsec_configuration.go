@@ -256,17 +256,16 @@ func resourceConfigurationUpdate(ctx context.Context, d *schema.ResourceData, m
 			hostnames = append(hostnames, hostname)
 		}
 
-		version, err := getModifiableConfigVersion(ctx, configID, "configuration", m)
-		if err != nil {
-			return diag.FromErr(err)
-		}
 		updateSelectedHostnames := appsec.UpdateSelectedHostnamesRequest{
 			ConfigID:     configID,
-			Version:      version,
 			HostnameList: hostnames,
 		}
 
-		_, err = client.UpdateSelectedHostnames(ctx, updateSelectedHostnames)
+		err = updateModifiableConfigVersion(ctx, configID, "configuration", m, func(version int) error {
+			updateSelectedHostnames.Version = version
+			_, err := client.UpdateSelectedHostnames(ctx, updateSelectedHostnames)
+			return err
+		})
 		if err != nil {
 			logger.Errorf("calling 'UpdateSelectedHostnames': %s", err.Error())
 			return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
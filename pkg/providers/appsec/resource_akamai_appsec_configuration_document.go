@@ -0,0 +1,220 @@
+package appsec
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// appsec v1
+//
+// https://techdocs.akamai.com/application-security/reference/api
+func resourceConfigurationDocument() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConfigurationDocumentCreate,
+		ReadContext:   resourceConfigurationDocumentRead,
+		UpdateContext: resourceConfigurationDocumentUpdate,
+		DeleteContext: resourceConfigurationDocumentDelete,
+		CustomizeDiff: planConfigurationDocumentChanges,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"config_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Unique identifier of the security configuration",
+			},
+			"document": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+				DiffSuppressFunc: suppressEquivalentConfigurationDocuments,
+				Description:      "JSON-formatted export of a security configuration version describing the desired configuration",
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Version of the security configuration the document is applied to",
+			},
+			"changes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Changes to individual configuration objects made by the last create or update",
+			},
+		},
+	}
+}
+
+// planConfigurationDocumentChanges lists the objects changed by an update of the document, so
+// that they show up in the plan. Changes made by a create are only known once it's applied,
+// since they depend on the current editable version.
+func planConfigurationDocumentChanges(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.HasChange("document") {
+		return nil
+	}
+	if d.Id() == "" {
+		return d.SetNewComputed("changes")
+	}
+
+	oldDocument, newDocument := d.GetChange("document")
+	current, err := parseConfigurationDocument(oldDocument.(string))
+	if err != nil {
+		return err
+	}
+	desired, err := parseConfigurationDocument(newDocument.(string))
+	if err != nil {
+		return err
+	}
+	if err := d.SetNew("changes", documentChangeStrings(planConfigurationDocument(current, desired))); err != nil {
+		return err
+	}
+	return d.SetNewComputed("version")
+}
+
+func resourceConfigurationDocumentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("APPSEC", "resourceConfigurationDocumentCreate")
+	logger.Debugf("in resourceConfigurationDocumentCreate")
+
+	configID, err := tools.GetIntValue("config_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	changes, err := applyConfigurationDocument(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("changes", changes); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+
+	d.SetId(strconv.Itoa(configID))
+
+	return resourceConfigurationDocumentRead(ctx, d, m)
+}
+
+func resourceConfigurationDocumentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	client := inst.Client(meta)
+	logger := meta.Log("APPSEC", "resourceConfigurationDocumentRead")
+	logger.Debugf("in resourceConfigurationDocumentRead")
+
+	configID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	version, err := getLatestConfigVersion(ctx, configID, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	exportconfiguration, err := client.GetExportConfiguration(ctx, appsec.GetExportConfigurationRequest{ConfigID: configID, Version: version})
+	if err != nil {
+		logger.Errorf("calling 'getExportConfiguration': %s", err.Error())
+		return diag.FromErr(err)
+	}
+
+	jsonBody, err := json.Marshal(exportconfiguration)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("config_id", configID); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("version", version); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("document", string(jsonBody)); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+
+	return nil
+}
+
+func resourceConfigurationDocumentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("APPSEC", "resourceConfigurationDocumentUpdate")
+	logger.Debugf("in resourceConfigurationDocumentUpdate")
+
+	// The changes listed in the plan are kept; the ones applied are computed against the
+	// current editable version and only differ if the configuration changed after the refresh.
+	if _, err := applyConfigurationDocument(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceConfigurationDocumentRead(ctx, d, m)
+}
+
+func resourceConfigurationDocumentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("APPSEC", "resourceConfigurationDocumentDelete")
+	logger.Debugf("in resourceConfigurationDocumentDelete")
+
+	// The security configuration itself is managed by akamai_appsec_configuration, so removing
+	// the document only removes it from the state.
+	d.SetId("")
+
+	return nil
+}
+
+// applyConfigurationDocument applies the changed objects of the document to the editable version
+// of the configuration and returns the list of changes made.
+func applyConfigurationDocument(ctx context.Context, d *schema.ResourceData, m interface{}) ([]string, error) {
+	meta := akamai.Meta(m)
+	client := inst.Client(meta)
+	logger := meta.Log("APPSEC", "applyConfigurationDocument")
+
+	configID, err := tools.GetIntValue("config_id", d)
+	if err != nil {
+		return nil, err
+	}
+	document, err := tools.GetStringValue("document", d)
+	if err != nil {
+		return nil, err
+	}
+	desired, err := parseConfigurationDocument(document)
+	if err != nil {
+		return nil, err
+	}
+
+	// The changes are computed again against the editable version when the update is retried after a
+	// version conflict.
+	var changes []documentChange
+	err = updateModifiableConfigVersion(ctx, configID, "configurationDocument", m, func(version int) error {
+		exportconfiguration, err := client.GetExportConfiguration(ctx, appsec.GetExportConfigurationRequest{ConfigID: configID, Version: version})
+		if err != nil {
+			logger.Errorf("calling 'getExportConfiguration': %s", err.Error())
+			return err
+		}
+		current, err := newConfigurationDocument(exportconfiguration)
+		if err != nil {
+			return err
+		}
+
+		changes = planConfigurationDocument(current, desired)
+		logger.Debugf("applying %d changes to configuration %d version %d", len(changes), configID, version)
+		if err := newDocumentApplier(client, configID, version, current, desired).apply(ctx, changes); err != nil {
+			logger.Errorf("applying configuration document: %s", err.Error())
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return documentChangeStrings(changes), nil
+}
@@ -0,0 +1,198 @@
+package appsec
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func loadConfigurationDocument(t *testing.T, path string) *configurationDocument {
+	doc, err := parseConfigurationDocument(loadFixtureString(path))
+	require.NoError(t, err)
+	return doc
+}
+
+func mockConfigurationDocumentChanges(client *appsec.Mock) {
+	client.On("UpdateReputationProfileAction",
+		mock.Anything,
+		appsec.UpdateReputationProfileActionRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", ReputationProfileID: 12, Action: "none"},
+	).Return(&appsec.UpdateReputationProfileActionResponse{}, nil).Once()
+
+	client.On("UpdateSelectedHostnames",
+		mock.Anything,
+		appsec.UpdateSelectedHostnamesRequest{ConfigID: 43253, Version: 7, HostnameList: []appsec.Hostname{
+			{Hostname: "rinaldi.sandbox.akamaideveloper.com"},
+			{Hostname: "sujala.sandbox.akamaideveloper.com"},
+		}},
+	).Return(&appsec.UpdateSelectedHostnamesResponse{}, nil).Once()
+
+	client.On("CreateSecurityPolicy",
+		mock.Anything,
+		appsec.CreateSecurityPolicyRequest{ConfigID: 43253, Version: 7, PolicyName: "API", PolicyPrefix: "BBBB", DefaultSettings: true},
+	).Return(&appsec.CreateSecurityPolicyResponse{PolicyID: "BBBB_99"}, nil).Once()
+
+	client.On("CreateCustomRule",
+		mock.Anything,
+		appsec.CreateCustomRuleRequest{ConfigID: 43253, JsonPayloadRaw: json.RawMessage(
+			`{"conditions":[{"positiveMatch":true,"type":"pathMatch","value":["/admin"]}],"name":"Admin","operation":"AND"}`)},
+	).Return(&appsec.CreateCustomRuleResponse{ID: 70}, nil).Once()
+
+	client.On("UpdateCustomRule",
+		mock.Anything,
+		appsec.UpdateCustomRuleRequest{ConfigID: 43253, ID: 661, JsonPayloadRaw: json.RawMessage(
+			`{"conditions":[{"positiveMatch":true,"type":"pathMatch","value":["/bots","/crawlers"]}],"name":"Bad bots","operation":"AND"}`)},
+	).Return(&appsec.UpdateCustomRuleResponse{}, nil).Once()
+
+	client.On("UpdateCustomRuleAction",
+		mock.Anything,
+		appsec.UpdateCustomRuleActionRequest{ConfigID: 43253, Version: 7, PolicyID: "BBBB_99", RuleID: 70, Action: "deny"},
+	).Return(&appsec.UpdateCustomRuleActionResponse{}, nil).Once()
+
+	client.On("UpdateCustomRuleAction",
+		mock.Anything,
+		appsec.UpdateCustomRuleActionRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", RuleID: 661, Action: "deny"},
+	).Return(&appsec.UpdateCustomRuleActionResponse{}, nil).Once()
+}
+
+func TestDiffConfigurationDocuments(t *testing.T) {
+	current := loadConfigurationDocument(t, "testdata/TestResConfigurationDocument/Current.json")
+	desired := loadConfigurationDocument(t, "testdata/TestResConfigurationDocument/Desired.json")
+
+	assert.Equal(t, []string{
+		`remove reputation_profile_action "Scrapers" in security policy "Main"`,
+		`update selected_hosts`,
+		`create security_policy "API"`,
+		`create custom_rule "Admin"`,
+		`update custom_rule "Bad bots"`,
+		`create custom_rule_action "Admin" in security policy "API"`,
+		`update custom_rule_action "Bad bots" in security policy "Main"`,
+	}, documentChangeStrings(diffConfigurationDocuments(current, desired)))
+
+	// IDs assigned by the API don't matter, so the applied document matches the desired one.
+	applied := loadConfigurationDocument(t, "testdata/TestResConfigurationDocument/Applied.json")
+	assert.Empty(t, diffConfigurationDocuments(applied, desired))
+	assert.Empty(t, diffConfigurationDocuments(current, current))
+
+	// Objects of a removed security policy are removed along with it.
+	changes := diffConfigurationDocuments(desired, current)
+	assert.Contains(t, documentChangeStrings(changes), `remove security_policy "API"`)
+	assert.NotContains(t, documentChangeStrings(changes), `remove custom_rule_action "Admin" in security policy "API"`)
+}
+
+func TestPlanConfigurationDocumentMatchTargets(t *testing.T) {
+	export := appsec.GetExportConfigurationResponse{}
+	require.NoError(t, json.Unmarshal(loadFixtureBytes("testdata/TestResConfigurationDocument/Desired.json"), &export))
+	target := export.MatchTargets.WebsiteTargets[0]
+	target.ID = 2002
+	target.FilePaths = []string{"/api/*"}
+	export.MatchTargets.WebsiteTargets = append(export.MatchTargets.WebsiteTargets, target)
+	desired, err := newConfigurationDocument(&export)
+	require.NoError(t, err)
+
+	current := loadConfigurationDocument(t, "testdata/TestResConfigurationDocument/Applied.json")
+	assert.Equal(t, []string{`create match_target "2002" in security policy "Main"`}, documentChangeStrings(planConfigurationDocument(current, desired)))
+
+	// The API assigns another ID to the created match target, which still matches the document.
+	export.MatchTargets.WebsiteTargets[1].ID = 3050
+	applied, err := newConfigurationDocument(&export)
+	require.NoError(t, err)
+	export.MatchTargets.WebsiteTargets[1].ID = 2002
+	desired, err = newConfigurationDocument(&export)
+	require.NoError(t, err)
+	assert.Empty(t, planConfigurationDocument(applied, desired))
+
+	// A changed match target whose ID isn't in the configuration replaces the one it was created as.
+	export.MatchTargets.WebsiteTargets[1].FilePaths = []string{"/api/v2/*"}
+	desired, err = newConfigurationDocument(&export)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`remove match_target "3050" in security policy "Main"`,
+		`create match_target "2002" in security policy "Main"`,
+	}, documentChangeStrings(planConfigurationDocument(applied, desired)))
+}
+
+func TestConfigurationDocumentNames(t *testing.T) {
+	doc := loadConfigurationDocument(t, "testdata/TestDSExportConfiguration/ExportConfiguration.json")
+
+	assert.Equal(t, 60036362, doc.customRules["Existing Test Rule 1"])
+	assert.Equal(t, 60036332, doc.customRules["Existing Test Rule 1#60036332"])
+	assert.Contains(t, doc.objects, "match_target/akamaitools/3008967")
+	assert.Contains(t, doc.objects, "ip_geo/akamaitools/")
+	assert.Contains(t, doc.objects, "prefetch//")
+
+	_, err := parseConfigurationDocument(`{"customRules": {}}`)
+	assert.Error(t, err)
+}
+
+func TestDocumentApplier(t *testing.T) {
+	current := loadConfigurationDocument(t, "testdata/TestResConfigurationDocument/Current.json")
+	desired := loadConfigurationDocument(t, "testdata/TestResConfigurationDocument/Desired.json")
+
+	client := &appsec.Mock{}
+	mockConfigurationDocumentChanges(client)
+
+	err := newDocumentApplier(client, 43253, 7, current, desired).apply(context.Background(), diffConfigurationDocuments(current, desired))
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+}
+
+func TestAkamaiConfigurationDocument_res_basic(t *testing.T) {
+	t.Run("match by ConfigurationDocument ID", func(t *testing.T) {
+		client := &appsec.Mock{}
+
+		config := appsec.GetConfigurationResponse{}
+		err := json.Unmarshal(loadFixtureBytes("testdata/TestResConfiguration/LatestConfiguration.json"), &config)
+		require.NoError(t, err)
+
+		current := appsec.GetExportConfigurationResponse{}
+		err = json.Unmarshal(loadFixtureBytes("testdata/TestResConfigurationDocument/Current.json"), &current)
+		require.NoError(t, err)
+
+		applied := appsec.GetExportConfigurationResponse{}
+		err = json.Unmarshal(loadFixtureBytes("testdata/TestResConfigurationDocument/Applied.json"), &applied)
+		require.NoError(t, err)
+
+		client.On("GetConfiguration",
+			mock.Anything,
+			appsec.GetConfigurationRequest{ConfigID: 43253},
+		).Return(&config, nil)
+
+		client.On("GetExportConfiguration",
+			mock.Anything,
+			appsec.GetExportConfigurationRequest{ConfigID: 43253, Version: 7},
+		).Return(&current, nil).Once()
+
+		client.On("GetExportConfiguration",
+			mock.Anything,
+			appsec.GetExportConfigurationRequest{ConfigID: 43253, Version: 7},
+		).Return(&applied, nil)
+
+		mockConfigurationDocumentChanges(client)
+
+		useClient(client, func() {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:        true,
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResConfigurationDocument/match_by_id.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_appsec_configuration_document.test", "id", "43253"),
+							resource.TestCheckResourceAttr("akamai_appsec_configuration_document.test", "version", "7"),
+							resource.TestCheckResourceAttr("akamai_appsec_configuration_document.test", "changes.#", "7"),
+							resource.TestCheckResourceAttr("akamai_appsec_configuration_document.test", "changes.2", `create security_policy "API"`),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,261 @@
+package appsec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// appsec v1
+//
+// https://techdocs.akamai.com/application-security/reference/api
+func resourceConfigurationPromotion() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConfigurationPromotionCreate,
+		ReadContext:   resourceConfigurationPromotionRead,
+		UpdateContext: resourceConfigurationPromotionUpdate,
+		DeleteContext: resourceConfigurationPromotionDelete,
+		CustomizeDiff: planConfigurationPromotion,
+		Schema: map[string]*schema.Schema{
+			"config_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Unique identifier of the security configuration the source configuration is promoted to",
+			},
+			"source_export": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+				DiffSuppressFunc: suppressEquivalentJSONDiffsGeneric,
+				Description:      "JSON-formatted export of the security configuration version to promote",
+			},
+			"hostname_mappings": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Hostnames of the target configuration by hostname of the source configuration",
+			},
+			"network_list_mappings": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Network list IDs of the target account by network list ID of the source account",
+			},
+			"custom_rule_mappings": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "IDs of existing custom rules of the target configuration by custom rule ID of the source configuration",
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Version of the target configuration the source configuration was promoted to",
+			},
+			"changes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Changes to individual configuration objects made by the last promotion",
+			},
+			"skipped": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Objects of the source configuration that couldn't be carried over by the last promotion",
+			},
+		},
+	}
+}
+
+// planConfigurationPromotion marks the results of the promotion as unknown when it's applied again.
+func planConfigurationPromotion(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.HasChanges("source_export", "hostname_mappings", "network_list_mappings", "custom_rule_mappings") {
+		return nil
+	}
+	for _, key := range []string{"version", "changes", "skipped"} {
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceConfigurationPromotionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("APPSEC", "resourceConfigurationPromotionCreate")
+	logger.Debugf("in resourceConfigurationPromotionCreate")
+
+	configID, err := tools.GetIntValue("config_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	diags := applyConfigurationPromotion(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(strconv.Itoa(configID))
+
+	return append(diags, resourceConfigurationPromotionRead(ctx, d, m)...)
+}
+
+func resourceConfigurationPromotionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	client := inst.Client(meta)
+	logger := meta.Log("APPSEC", "resourceConfigurationPromotionRead")
+	logger.Debugf("in resourceConfigurationPromotionRead")
+
+	configID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	configuration, err := client.GetConfiguration(ctx, appsec.GetConfigurationRequest{ConfigID: configID})
+	if err != nil {
+		logger.Errorf("calling 'getConfiguration': %s", err.Error())
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("config_id", configuration.ID); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+
+	return nil
+}
+
+func resourceConfigurationPromotionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("APPSEC", "resourceConfigurationPromotionUpdate")
+	logger.Debugf("in resourceConfigurationPromotionUpdate")
+
+	diags := applyConfigurationPromotion(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceConfigurationPromotionRead(ctx, d, m)...)
+}
+
+func resourceConfigurationPromotionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("APPSEC", "resourceConfigurationPromotionDelete")
+	logger.Debugf("in resourceConfigurationPromotionDelete")
+
+	// The promoted objects belong to the target configuration, so removing the promotion only
+	// removes it from the state.
+	d.SetId("")
+
+	return nil
+}
+
+// applyConfigurationPromotion applies the remapped source configuration to the editable version of the
+// target configuration and records the changes made and the objects skipped.
+func applyConfigurationPromotion(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	client := inst.Client(meta)
+	logger := meta.Log("APPSEC", "applyConfigurationPromotion")
+
+	configID, err := tools.GetIntValue("config_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sourceExport, err := tools.GetStringValue("source_export", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var source appsec.GetExportConfigurationResponse
+	if err := json.Unmarshal([]byte(sourceExport), &source); err != nil {
+		return diag.Errorf("invalid source_export: %s", err.Error())
+	}
+
+	promotion := configurationPromotion{
+		hostnames:    map[string]string{},
+		networkLists: map[string]string{},
+		customRules:  map[int]int{},
+	}
+	for k, v := range d.Get("hostname_mappings").(map[string]interface{}) {
+		promotion.hostnames[k] = v.(string)
+	}
+	for k, v := range d.Get("network_list_mappings").(map[string]interface{}) {
+		promotion.networkLists[k] = v.(string)
+	}
+	for k, v := range d.Get("custom_rule_mappings").(map[string]interface{}) {
+		sourceID, err := strconv.Atoi(k)
+		if err != nil {
+			return diag.Errorf("invalid custom rule ID %q in custom_rule_mappings", k)
+		}
+		promotion.customRules[sourceID] = v.(int)
+	}
+
+	// remap rewrites the source, so it's decoded again when the promotion is retried after a version conflict.
+	var (
+		version int
+		changes []documentChange
+		skipped []string
+	)
+	err = updateModifiableConfigVersion(ctx, configID, "configurationPromotion", m, func(v int) error {
+		version = v
+		source = appsec.GetExportConfigurationResponse{}
+		if err := json.Unmarshal([]byte(sourceExport), &source); err != nil {
+			return fmt.Errorf("invalid source_export: %s", err.Error())
+		}
+
+		target, err := client.GetExportConfiguration(ctx, appsec.GetExportConfigurationRequest{ConfigID: configID, Version: version})
+		if err != nil {
+			logger.Errorf("calling 'getExportConfiguration': %s", err.Error())
+			return err
+		}
+
+		var desired *configurationDocument
+		desired, skipped, err = promotion.remap(&source, target)
+		if err != nil {
+			return err
+		}
+		current, err := newConfigurationDocument(target)
+		if err != nil {
+			return err
+		}
+
+		changes = planConfigurationDocument(current, desired)
+		logger.Debugf("promoting configuration %d version %d to configuration %d version %d with %d changes", source.ConfigID, source.Version, configID, version, len(changes))
+		if err := newDocumentApplier(client, configID, version, current, desired).apply(ctx, changes); err != nil {
+			logger.Errorf("applying promotion: %s", err.Error())
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("version", version); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("changes", documentChangeStrings(changes)); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("skipped", skipped); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+
+	var diags diag.Diagnostics
+	if len(skipped) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%d objects of configuration %d couldn't be promoted", len(skipped), source.ConfigID),
+			Detail:   fmt.Sprintf("See the skipped attribute of the promotion to configuration %d", configID),
+		})
+	}
+	return diags
+}
@@ -0,0 +1,78 @@
+package appsec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAkamaiConfigurationPromotion_res_basic(t *testing.T) {
+	t.Run("match by ConfigurationPromotion ID", func(t *testing.T) {
+		client := &appsec.Mock{}
+
+		config := appsec.GetConfigurationResponse{}
+		err := json.Unmarshal(loadFixtureBytes("testdata/TestResConfiguration/LatestConfiguration.json"), &config)
+		require.NoError(t, err)
+
+		client.On("GetConfiguration",
+			mock.Anything,
+			appsec.GetConfigurationRequest{ConfigID: 43253},
+		).Return(&config, nil)
+
+		client.On("GetExportConfiguration",
+			mock.Anything,
+			appsec.GetExportConfigurationRequest{ConfigID: 43253, Version: 7},
+		).Return(loadPromotionExport(t, "testdata/TestResConfigurationPromotion/Target.json"), nil)
+
+		client.On("UpdateSelectedHostnames",
+			mock.Anything,
+			appsec.UpdateSelectedHostnamesRequest{ConfigID: 43253, Version: 7, HostnameList: []appsec.Hostname{{Hostname: "www.example.com"}}},
+		).Return(&appsec.UpdateSelectedHostnamesResponse{}, nil)
+
+		client.On("CreateCustomRule",
+			mock.Anything,
+			mock.MatchedBy(func(req appsec.CreateCustomRuleRequest) bool { return req.ConfigID == 43253 }),
+		).Return(&appsec.CreateCustomRuleResponse{ID: 701, Name: "Block admin"}, nil)
+
+		client.On("UpdateCustomRuleAction",
+			mock.Anything,
+			appsec.UpdateCustomRuleActionRequest{ConfigID: 43253, Version: 7, PolicyID: "PRD1_1", RuleID: 701, Action: "deny"},
+		).Return(&appsec.UpdateCustomRuleActionResponse{}, nil)
+
+		client.On("UpdateIPGeo",
+			mock.Anything,
+			appsec.UpdateIPGeoRequest{
+				ConfigID:   43253,
+				Version:    7,
+				PolicyID:   "PRD1_1",
+				Block:      "blockSpecificIPGeo",
+				IPControls: &appsec.IPGeoIPControls{BlockedIPNetworkLists: &appsec.IPGeoNetworkLists{NetworkList: []string{"2_PROD"}}},
+			},
+		).Return(&appsec.UpdateIPGeoResponse{}, nil)
+
+		useClient(client, func() {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:        true,
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResConfigurationPromotion/match_by_id.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_appsec_configuration_promotion.test", "id", "43253"),
+							resource.TestCheckResourceAttr("akamai_appsec_configuration_promotion.test", "version", "7"),
+							resource.TestCheckResourceAttr("akamai_appsec_configuration_promotion.test", "changes.#", "4"),
+							resource.TestCheckResourceAttr("akamai_appsec_configuration_promotion.test", "skipped.#", "4"),
+							resource.TestCheckResourceAttr("akamai_appsec_configuration_promotion.test", "skipped.2", "network list 3_DEV has no mapping"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
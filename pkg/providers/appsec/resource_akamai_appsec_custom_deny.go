@@ -64,21 +64,22 @@ func resourceCustomDenyCreate(ctx context.Context, d *schema.ResourceData, m int
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "customDeny", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	jsonpostpayload := d.Get("custom_deny")
 	jsonPayloadRaw := []byte(jsonpostpayload.(string))
 	rawJSON := (json.RawMessage)(jsonPayloadRaw)
 
 	createCustomDeny := appsec.CreateCustomDenyRequest{
 		ConfigID:       configID,
-		Version:        version,
 		JsonPayloadRaw: rawJSON,
 	}
 
-	createCustomDenyResponse, err := client.CreateCustomDeny(ctx, createCustomDeny)
+	var createCustomDenyResponse *appsec.CreateCustomDenyResponse
+	err = updateModifiableConfigVersion(ctx, configID, "customDeny", m, func(version int) error {
+		createCustomDeny.Version = version
+		created, err := client.CreateCustomDeny(ctx, createCustomDeny)
+		createCustomDenyResponse = created
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'createCustomDeny': %s", err.Error())
 		return diag.FromErr(err)
@@ -165,18 +166,17 @@ func resourceCustomDenyUpdate(ctx context.Context, d *schema.ResourceData, m int
 	jsonPayloadRaw := []byte(jsonpostpayload.(string))
 	rawJSON := (json.RawMessage)(jsonPayloadRaw)
 
-	version, err := getModifiableConfigVersion(ctx, configID, "customDeny", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	updateCustomDeny := appsec.UpdateCustomDenyRequest{
 		ConfigID:       configID,
-		Version:        version,
 		ID:             customDenyID,
 		JsonPayloadRaw: rawJSON,
 	}
 
-	_, err = client.UpdateCustomDeny(ctx, updateCustomDeny)
+	err = updateModifiableConfigVersion(ctx, configID, "customDeny", m, func(version int) error {
+		updateCustomDeny.Version = version
+		_, err := client.UpdateCustomDeny(ctx, updateCustomDeny)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateCustomDeny': %s", err.Error())
 		return diag.FromErr(err)
@@ -202,17 +202,16 @@ func resourceCustomDenyDelete(ctx context.Context, d *schema.ResourceData, m int
 	}
 	customDenyID := iDParts[1]
 
-	version, err := getModifiableConfigVersion(ctx, configID, "customDeny", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	removeCustomDeny := appsec.RemoveCustomDenyRequest{
 		ConfigID: configID,
-		Version:  version,
 		ID:       customDenyID,
 	}
 
-	_, err = client.RemoveCustomDeny(ctx, removeCustomDeny)
+	err = updateModifiableConfigVersion(ctx, configID, "customDeny", m, func(version int) error {
+		removeCustomDeny.Version = version
+		_, err := client.RemoveCustomDeny(ctx, removeCustomDeny)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'removeCustomDeny': %s", err.Error())
 		return diag.FromErr(err)
@@ -27,29 +27,40 @@ func resourceCustomRule() *schema.Resource {
 		DeleteContext: resourceCustomRuleDelete,
 		CustomizeDiff: customdiff.All(
 			VerifyIDUnchanged,
+			customRuleStructuredDiff,
 		),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Schema: map[string]*schema.Schema{
-			"config_id": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				Description: "Unique identifier of the security configuration",
-			},
-			"custom_rule": {
-				Type:             schema.TypeString,
-				Required:         true,
-				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
-				DiffSuppressFunc: suppressEquivalentJSONDiffsGeneric,
-				Description:      "JSON-formatted definition of the custom rule",
-			},
-			"custom_rule_id": {
-				Type:     schema.TypeInt,
-				Computed: true,
-			},
+		Schema: resourceCustomRuleSchema(),
+	}
+}
+
+func resourceCustomRuleSchema() map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"config_id": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			Description: "Unique identifier of the security configuration",
 		},
+		"custom_rule": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			ExactlyOneOf:     []string{"custom_rule", "name"},
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+			DiffSuppressFunc: suppressEquivalentJSONDiffsGeneric,
+			Description:      "JSON-formatted definition of the custom rule",
+		},
+		"custom_rule_id": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+	}
+	for key, structured := range customRuleStructuredSchema() {
+		s[key] = structured
 	}
+	return s
 }
 
 func resourceCustomRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -137,6 +148,22 @@ func resourceCustomRuleRead(ctx context.Context, d *schema.ResourceData, m inter
 	if err := d.Set("custom_rule", string(jsonBody)); err != nil {
 		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
 	}
+
+	structured, err := customRuleStructuredValues(string(jsonBody))
+	if err != nil {
+		logger.Debugf("custom rule %d can't be described by condition blocks: %s", customRuleID, err.Error())
+		for _, key := range customRuleStructuredKeys {
+			if err := d.Set(key, nil); err != nil {
+				return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+			}
+		}
+		return nil
+	}
+	for _, key := range customRuleStructuredKeys {
+		if err := d.Set(key, structured[key]); err != nil {
+			return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+		}
+	}
 	return nil
 }
 
@@ -65,10 +65,6 @@ func resourceCustomRuleActionCreate(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "customRuleAction", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -84,13 +80,16 @@ func resourceCustomRuleActionCreate(ctx context.Context, d *schema.ResourceData,
 
 	createCustomRuleAction := appsec.UpdateCustomRuleActionRequest{
 		ConfigID: configID,
-		Version:  version,
 		PolicyID: policyID,
 		RuleID:   ruleID,
 		Action:   customruleaction,
 	}
 
-	_, err = client.UpdateCustomRuleAction(ctx, createCustomRuleAction)
+	err = updateModifiableConfigVersion(ctx, configID, "customRuleAction", m, func(version int) error {
+		createCustomRuleAction.Version = version
+		_, err := client.UpdateCustomRuleAction(ctx, createCustomRuleAction)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'createCustomRuleAction': %s", err.Error())
 		return diag.FromErr(err)
@@ -167,10 +166,6 @@ func resourceCustomRuleActionUpdate(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "customRuleAction", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	ruleID, err := strconv.Atoi(iDParts[2])
 	if err != nil {
@@ -183,13 +178,16 @@ func resourceCustomRuleActionUpdate(ctx context.Context, d *schema.ResourceData,
 
 	updateCustomRuleAction := appsec.UpdateCustomRuleActionRequest{
 		ConfigID: configID,
-		Version:  version,
 		PolicyID: policyID,
 		RuleID:   ruleID,
 		Action:   customruleaction,
 	}
 
-	_, err = client.UpdateCustomRuleAction(ctx, updateCustomRuleAction)
+	err = updateModifiableConfigVersion(ctx, configID, "customRuleAction", m, func(version int) error {
+		updateCustomRuleAction.Version = version
+		_, err := client.UpdateCustomRuleAction(ctx, updateCustomRuleAction)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateCustomRuleAction': %s", err.Error())
 		return diag.FromErr(err)
@@ -212,10 +210,6 @@ func resourceCustomRuleActionDelete(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "customRuleAction", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	ruleID, err := strconv.Atoi(iDParts[2])
 	if err != nil {
@@ -224,13 +218,16 @@ func resourceCustomRuleActionDelete(ctx context.Context, d *schema.ResourceData,
 
 	updateCustomRuleAction := appsec.UpdateCustomRuleActionRequest{
 		ConfigID: configID,
-		Version:  version,
 		PolicyID: policyID,
 		RuleID:   ruleID,
 		Action:   "none",
 	}
 
-	_, err = client.UpdateCustomRuleAction(ctx, updateCustomRuleAction)
+	err = updateModifiableConfigVersion(ctx, configID, "customRuleAction", m, func(version int) error {
+		updateCustomRuleAction.Version = version
+		_, err := client.UpdateCustomRuleAction(ctx, updateCustomRuleAction)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'removeCustomRuleAction': %s", err.Error())
 		return diag.FromErr(err)
@@ -3,6 +3,7 @@ package appsec
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"regexp"
 	"testing"
 
@@ -100,6 +101,79 @@ func TestAkamaiCustomRule_res_basic(t *testing.T) {
 
 }
 
+func TestAkamaiCustomRule_res_structured(t *testing.T) {
+	t.Run("CustomRule_structured", func(t *testing.T) {
+		client := &appsec.Mock{}
+
+		createCustomRuleResponse := appsec.CreateCustomRuleResponse{}
+		err := json.Unmarshal(loadFixtureBytes("testdata/TestResCustomRule/CustomRule.json"), &createCustomRuleResponse)
+		require.NoError(t, err)
+
+		getCustomRuleResponse := appsec.GetCustomRuleResponse{}
+		err = json.Unmarshal(loadFixtureBytes("testdata/TestResCustomRule/CustomRule.json"), &getCustomRuleResponse)
+		require.NoError(t, err)
+
+		removeCustomRuleResponse := appsec.RemoveCustomRuleResponse{}
+		err = json.Unmarshal(loadFixtureBytes("testdata/TestResCustomRule/CustomRulesDeleted.json"), &removeCustomRuleResponse)
+		require.NoError(t, err)
+
+		getCustomRulesAfterDelete := appsec.GetCustomRulesResponse{}
+		err = json.Unmarshal(loadFixtureBytes("testdata/TestResCustomRule/CustomRulesForDelete.json"), &getCustomRulesAfterDelete)
+		require.NoError(t, err)
+
+		var createCustomRuleJSON interface{}
+		err = json.Unmarshal(loadFixtureBytes("testdata/TestResCustomRule/CreateCustomRule.json"), &createCustomRuleJSON)
+		require.NoError(t, err)
+
+		client.On("CreateCustomRule",
+			mock.Anything,
+			mock.MatchedBy(func(req appsec.CreateCustomRuleRequest) bool {
+				var payload interface{}
+				if err := json.Unmarshal(req.JsonPayloadRaw, &payload); err != nil {
+					return false
+				}
+				return req.ConfigID == 43253 && reflect.DeepEqual(payload, createCustomRuleJSON)
+			}),
+		).Return(&createCustomRuleResponse, nil)
+
+		client.On("GetCustomRule",
+			mock.Anything,
+			appsec.GetCustomRuleRequest{ConfigID: 43253, ID: 661699},
+		).Return(&getCustomRuleResponse, nil)
+
+		client.On("GetCustomRules",
+			mock.Anything,
+			appsec.GetCustomRulesRequest{ConfigID: 43253, ID: 661699},
+		).Return(&getCustomRulesAfterDelete, nil)
+
+		client.On("RemoveCustomRule",
+			mock.Anything,
+			appsec.RemoveCustomRuleRequest{ConfigID: 43253, ID: 661699},
+		).Return(&removeCustomRuleResponse, nil)
+
+		useClient(client, func() {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:        true,
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResCustomRule/structured.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_appsec_custom_rule.test", "id", "43253:661699"),
+							resource.TestCheckResourceAttr("akamai_appsec_custom_rule.test", "condition.#", "3"),
+							resource.TestCheckResourceAttr("akamai_appsec_custom_rule.test", "condition.2.value_wildcard", "true"),
+							resource.TestCheckResourceAttrSet("akamai_appsec_custom_rule.test", "custom_rule"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+}
+
 func TestAkamaiCustomRule_res_error_removing_active_rule(t *testing.T) {
 	t.Run("CustomRule_removing_active_rule", func(t *testing.T) {
 		client := &appsec.Mock{}
@@ -96,10 +96,6 @@ func resourceEvalCreate(ctx context.Context, d *schema.ResourceData, m interface
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "ruleevaluation", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -116,13 +112,16 @@ func resourceEvalCreate(ctx context.Context, d *schema.ResourceData, m interface
 
 	createEval := appsec.UpdateEvalRequest{
 		ConfigID: configID,
-		Version:  version,
 		PolicyID: policyID,
 		Eval:     evaloperation,
 		Mode:     evalmode,
 	}
 
-	_, err = client.UpdateEval(ctx, createEval)
+	err = updateModifiableConfigVersion(ctx, configID, "ruleevaluation", m, func(version int) error {
+		createEval.Version = version
+		_, err := client.UpdateEval(ctx, createEval)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'createEval': %s", err.Error())
 		return diag.FromErr(err)
@@ -201,10 +200,6 @@ func resourceEvalUpdate(ctx context.Context, d *schema.ResourceData, m interface
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "ruleevaluation", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	evaloperation, err := tools.GetStringValue("eval_operation", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
@@ -217,13 +212,16 @@ func resourceEvalUpdate(ctx context.Context, d *schema.ResourceData, m interface
 
 	updateEval := appsec.UpdateEvalRequest{
 		ConfigID: configID,
-		Version:  version,
 		PolicyID: policyID,
 		Eval:     evaloperation,
 		Mode:     evalmode,
 	}
 
-	_, err = client.UpdateEval(ctx, updateEval)
+	err = updateModifiableConfigVersion(ctx, configID, "ruleevaluation", m, func(version int) error {
+		updateEval.Version = version
+		_, err := client.UpdateEval(ctx, updateEval)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateEval': %s", err.Error())
 		return diag.FromErr(err)
@@ -246,20 +244,19 @@ func resourceEvalDelete(ctx context.Context, d *schema.ResourceData, m interface
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "ruleevaluation", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 
 	removeEval := appsec.RemoveEvalRequest{
 		ConfigID: configID,
-		Version:  version,
 		PolicyID: policyID,
 		Eval:     "STOP",
 	}
 
-	_, err = client.RemoveEval(ctx, removeEval)
+	err = updateModifiableConfigVersion(ctx, configID, "ruleevaluation", m, func(version int) error {
+		removeEval.Version = version
+		_, err := client.RemoveEval(ctx, removeEval)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'removeEval': %s", err.Error())
 		return diag.FromErr(err)
@@ -75,10 +75,6 @@ func resourceEvalGroupCreate(ctx context.Context, d *schema.ResourceData, m inte
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "atackGroup", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -104,14 +100,17 @@ func resourceEvalGroupCreate(ctx context.Context, d *schema.ResourceData, m inte
 
 	createAttackGroup := appsec.UpdateAttackGroupRequest{
 		ConfigID:       configID,
-		Version:        version,
 		PolicyID:       policyID,
 		Group:          attackgroup,
 		Action:         action,
 		JsonPayloadRaw: rawJSON,
 	}
 
-	_, err = client.UpdateEvalGroup(ctx, createAttackGroup)
+	err = updateModifiableConfigVersion(ctx, configID, "atackGroup", m, func(version int) error {
+		createAttackGroup.Version = version
+		_, err := client.UpdateEvalGroup(ctx, createAttackGroup)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'createEvalGroup': %s", err.Error())
 		return diag.FromErr(err)
@@ -195,10 +194,6 @@ func resourceEvalGroupUpdate(ctx context.Context, d *schema.ResourceData, m inte
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "evalGroup", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	group := iDParts[2]
 
@@ -219,14 +214,17 @@ func resourceEvalGroupUpdate(ctx context.Context, d *schema.ResourceData, m inte
 
 	updateAttackGroup := appsec.UpdateAttackGroupRequest{
 		ConfigID:       configID,
-		Version:        version,
 		PolicyID:       policyID,
 		Group:          group,
 		Action:         action,
 		JsonPayloadRaw: rawJSON,
 	}
 
-	_, err = client.UpdateEvalGroup(ctx, updateAttackGroup)
+	err = updateModifiableConfigVersion(ctx, configID, "evalGroup", m, func(version int) error {
+		updateAttackGroup.Version = version
+		_, err := client.UpdateEvalGroup(ctx, updateAttackGroup)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateEvalGroup': %s", err.Error())
 		return diag.FromErr(err)
@@ -249,22 +247,21 @@ func resourceEvalGroupDelete(ctx context.Context, d *schema.ResourceData, m inte
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "evalGroup", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	group := iDParts[2]
 
 	removeAttackGroup := appsec.UpdateAttackGroupRequest{
 		ConfigID: configID,
-		Version:  version,
 		PolicyID: policyID,
 		Group:    group,
 		Action:   "none",
 	}
 
-	_, err = client.UpdateEvalGroup(ctx, removeAttackGroup)
+	err = updateModifiableConfigVersion(ctx, configID, "evalGroup", m, func(version int) error {
+		removeAttackGroup.Version = version
+		_, err := client.UpdateEvalGroup(ctx, removeAttackGroup)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'RemoveEvalGroup': %s", err.Error())
 		return diag.FromErr(err)
@@ -69,10 +69,6 @@ func resourceEvalPenaltyBoxCreate(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "evalPenaltyBox", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -88,13 +84,16 @@ func resourceEvalPenaltyBoxCreate(ctx context.Context, d *schema.ResourceData, m
 
 	createPenaltyBox := appsec.UpdatePenaltyBoxRequest{
 		ConfigID:             configID,
-		Version:              version,
 		PolicyID:             policyID,
 		PenaltyBoxProtection: penaltyboxprotection,
 		Action:               penaltyboxaction,
 	}
 
-	_, err = client.UpdateEvalPenaltyBox(ctx, createPenaltyBox)
+	err = updateModifiableConfigVersion(ctx, configID, "evalPenaltyBox", m, func(version int) error {
+		createPenaltyBox.Version = version
+		_, err := client.UpdateEvalPenaltyBox(ctx, createPenaltyBox)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'createEvalPenaltyBox': %s", err.Error())
 		return diag.FromErr(err)
@@ -167,10 +166,6 @@ func resourceEvalPenaltyBoxUpdate(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "evalPenaltyBox", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	penaltyboxprotection, err := tools.GetBoolValue("penalty_box_protection", d)
 	if err != nil {
@@ -183,13 +178,16 @@ func resourceEvalPenaltyBoxUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	updatePenaltyBox := appsec.UpdatePenaltyBoxRequest{
 		ConfigID:             configID,
-		Version:              version,
 		PolicyID:             policyID,
 		PenaltyBoxProtection: penaltyboxprotection,
 		Action:               penaltyboxaction,
 	}
 
-	_, err = client.UpdateEvalPenaltyBox(ctx, updatePenaltyBox)
+	err = updateModifiableConfigVersion(ctx, configID, "evalPenaltyBox", m, func(version int) error {
+		updatePenaltyBox.Version = version
+		_, err := client.UpdateEvalPenaltyBox(ctx, updatePenaltyBox)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateEvalPenaltyBox': %s", err.Error())
 		return diag.FromErr(err)
@@ -212,21 +210,20 @@ func resourceEvalPenaltyBoxDelete(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "evalPenaltyBox", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 
 	removePenaltyBox := appsec.UpdatePenaltyBoxRequest{
 		ConfigID:             configID,
-		Version:              version,
 		PolicyID:             policyID,
 		PenaltyBoxProtection: false,
 		Action:               string(appsec.ActionTypeNone),
 	}
 
-	_, err = client.UpdateEvalPenaltyBox(ctx, removePenaltyBox)
+	err = updateModifiableConfigVersion(ctx, configID, "evalPenaltyBox", m, func(version int) error {
+		removePenaltyBox.Version = version
+		_, err := client.UpdateEvalPenaltyBox(ctx, removePenaltyBox)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'removeEvalPenaltyBox': %s", err.Error())
 		return diag.FromErr(err)
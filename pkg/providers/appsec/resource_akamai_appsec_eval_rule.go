@@ -75,10 +75,6 @@ func resourceEvalRuleCreate(ctx context.Context, d *schema.ResourceData, m inter
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "evalRule", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -104,14 +100,17 @@ func resourceEvalRuleCreate(ctx context.Context, d *schema.ResourceData, m inter
 
 	createEvalRule := appsec.UpdateEvalRuleRequest{
 		ConfigID:       configID,
-		Version:        version,
 		PolicyID:       policyID,
 		RuleID:         ruleID,
 		Action:         action,
 		JsonPayloadRaw: rawJSON,
 	}
 
-	_, err = client.UpdateEvalRule(ctx, createEvalRule)
+	err = updateModifiableConfigVersion(ctx, configID, "evalRule", m, func(version int) error {
+		createEvalRule.Version = version
+		_, err := client.UpdateEvalRule(ctx, createEvalRule)
+		return err
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -197,10 +196,6 @@ func resourceEvalRuleUpdate(ctx context.Context, d *schema.ResourceData, m inter
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "evalRule", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	ruleID, err := strconv.Atoi(iDParts[2])
 	if err != nil {
@@ -224,14 +219,17 @@ func resourceEvalRuleUpdate(ctx context.Context, d *schema.ResourceData, m inter
 
 	updateEvalRule := appsec.UpdateEvalRuleRequest{
 		ConfigID:       configID,
-		Version:        version,
 		PolicyID:       policyID,
 		RuleID:         ruleID,
 		Action:         action,
 		JsonPayloadRaw: rawJSON,
 	}
 
-	_, err = client.UpdateEvalRule(ctx, updateEvalRule)
+	err = updateModifiableConfigVersion(ctx, configID, "evalRule", m, func(version int) error {
+		updateEvalRule.Version = version
+		_, err := client.UpdateEvalRule(ctx, updateEvalRule)
+		return err
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -253,10 +251,6 @@ func resourceEvalRuleDelete(ctx context.Context, d *schema.ResourceData, m inter
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "evalRule", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	ruleID, err := strconv.Atoi(iDParts[2])
 	if err != nil {
@@ -265,13 +259,16 @@ func resourceEvalRuleDelete(ctx context.Context, d *schema.ResourceData, m inter
 
 	removeEvalRule := appsec.UpdateEvalRuleRequest{
 		ConfigID: configID,
-		Version:  version,
 		PolicyID: policyID,
 		RuleID:   ruleID,
 		Action:   "none",
 	}
 
-	_, err = client.UpdateEvalRule(ctx, removeEvalRule)
+	err = updateModifiableConfigVersion(ctx, configID, "evalRule", m, func(version int) error {
+		removeEvalRule.Version = version
+		_, err := client.UpdateEvalRule(ctx, removeEvalRule)
+		return err
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -124,10 +124,6 @@ func resourceIPGeoCreate(ctx context.Context, d *schema.ResourceData, m interfac
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "ipgeo", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -151,7 +147,6 @@ func resourceIPGeoCreate(ctx context.Context, d *schema.ResourceData, m interfac
 
 	request := appsec.UpdateIPGeoRequest{
 		ConfigID: configID,
-		Version:  version,
 		PolicyID: policyID,
 	}
 
@@ -165,7 +160,11 @@ func resourceIPGeoCreate(ctx context.Context, d *schema.ResourceData, m interfac
 		request.IPControls = ipControlsFromBlockAndAllowLists(blockedIPLists, exceptionIPLists)
 	}
 
-	_, err = client.UpdateIPGeo(ctx, request)
+	err = updateModifiableConfigVersion(ctx, configID, "ipgeo", m, func(version int) error {
+		request.Version = version
+		_, err := client.UpdateIPGeo(ctx, request)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'createIPGeo': %s", err.Error())
 		return diag.FromErr(err)
@@ -286,10 +285,6 @@ func resourceIPGeoUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "ipgeo", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	mode, err := tools.GetStringValue("mode", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
@@ -310,7 +305,6 @@ func resourceIPGeoUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 
 	request := appsec.UpdateIPGeoRequest{
 		ConfigID: configID,
-		Version:  version,
 		PolicyID: policyID,
 	}
 	if mode == Allow {
@@ -323,7 +317,11 @@ func resourceIPGeoUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 		request.IPControls = ipControlsFromBlockAndAllowLists(blockedIPLists, exceptionIPLists)
 	}
 
-	_, err = client.UpdateIPGeo(ctx, request)
+	err = updateModifiableConfigVersion(ctx, configID, "ipgeo", m, func(version int) error {
+		request.Version = version
+		_, err := client.UpdateIPGeo(ctx, request)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateIPGeo': %s", err.Error())
 		return diag.FromErr(err)
@@ -346,17 +344,16 @@ func resourceIPGeoDelete(ctx context.Context, d *schema.ResourceData, m interfac
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "ipgeo", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 
-	_, err = client.UpdateIPGeoProtection(ctx, appsec.UpdateIPGeoProtectionRequest{
-		ConfigID:                  configID,
-		Version:                   version,
-		PolicyID:                  policyID,
-		ApplyNetworkLayerControls: false,
+	err = updateModifiableConfigVersion(ctx, configID, "ipgeo", m, func(version int) error {
+		_, err := client.UpdateIPGeoProtection(ctx, appsec.UpdateIPGeoProtectionRequest{
+			ConfigID:                  configID,
+			Version:                   version,
+			PolicyID:                  policyID,
+			ApplyNetworkLayerControls: false,
+		})
+		return err
 	})
 	if err != nil {
 		logger.Errorf("calling UpdateIPGeoProtection: %s", err.Error())
@@ -64,10 +64,6 @@ func resourceIPGeoProtectionCreate(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "ipgeoProtection", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -77,11 +73,14 @@ func resourceIPGeoProtectionCreate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	_, err = client.UpdateIPGeoProtection(ctx, appsec.UpdateIPGeoProtectionRequest{
-		ConfigID:                  configID,
-		Version:                   version,
-		PolicyID:                  policyID,
-		ApplyNetworkLayerControls: enabled,
+	err = updateModifiableConfigVersion(ctx, configID, "ipgeoProtection", m, func(version int) error {
+		_, err := client.UpdateIPGeoProtection(ctx, appsec.UpdateIPGeoProtectionRequest{
+			ConfigID:                  configID,
+			Version:                   version,
+			PolicyID:                  policyID,
+			ApplyNetworkLayerControls: enabled,
+		})
+		return err
 	})
 	if err != nil {
 		logger.Errorf("calling UpdateIPGeoProtection: %s", err.Error())
@@ -160,21 +159,20 @@ func resourceIPGeoProtectionUpdate(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "networkProtection", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	enabled, err := tools.GetBoolValue("enabled", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return diag.FromErr(err)
 	}
 
-	_, err = client.UpdateIPGeoProtection(ctx, appsec.UpdateIPGeoProtectionRequest{
-		ConfigID:                  configID,
-		Version:                   version,
-		PolicyID:                  policyID,
-		ApplyNetworkLayerControls: enabled,
+	err = updateModifiableConfigVersion(ctx, configID, "networkProtection", m, func(version int) error {
+		_, err := client.UpdateIPGeoProtection(ctx, appsec.UpdateIPGeoProtectionRequest{
+			ConfigID:                  configID,
+			Version:                   version,
+			PolicyID:                  policyID,
+			ApplyNetworkLayerControls: enabled,
+		})
+		return err
 	})
 	if err != nil {
 		logger.Errorf("calling UpdateIPGeoProtection: %s", err.Error())
@@ -198,17 +196,16 @@ func resourceIPGeoProtectionDelete(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "ipgeoProtection", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 
-	_, err = client.UpdateIPGeoProtection(ctx, appsec.UpdateIPGeoProtectionRequest{
-		ConfigID:                  configID,
-		Version:                   version,
-		PolicyID:                  policyID,
-		ApplyNetworkLayerControls: false,
+	err = updateModifiableConfigVersion(ctx, configID, "ipgeoProtection", m, func(version int) error {
+		_, err := client.UpdateIPGeoProtection(ctx, appsec.UpdateIPGeoProtectionRequest{
+			ConfigID:                  configID,
+			Version:                   version,
+			PolicyID:                  policyID,
+			ApplyNetworkLayerControls: false,
+		})
+		return err
 	})
 	if err != nil {
 		logger.Errorf("calling UpdateIPGeoProtection: %s", err.Error())
@@ -60,10 +60,6 @@ func resourceMalwarePolicyCreate(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "malwarePolicy", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	payload, err := tools.GetStringValue("malware_policy", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -74,10 +70,15 @@ func resourceMalwarePolicyCreate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
-	response, err := client.CreateMalwarePolicy(ctx, appsec.CreateMalwarePolicyRequest{
-		ConfigID:      configID,
-		ConfigVersion: version,
-		Policy:        &policy,
+	var response *appsec.MalwarePolicyResponse
+	err = updateModifiableConfigVersion(ctx, configID, "malwarePolicy", m, func(version int) error {
+		created, err := client.CreateMalwarePolicy(ctx, appsec.CreateMalwarePolicyRequest{
+			ConfigID:      configID,
+			ConfigVersion: version,
+			Policy:        &policy,
+		})
+		response = created
+		return err
 	})
 	if err != nil {
 		logger.Warnf("calling 'createMalwarePolicy': %s", err.Error())
@@ -170,10 +171,6 @@ func resourceMalwarePolicyUpdate(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "malwarePolicy", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 
 	payload, err := tools.GetStringValue("malware_policy", d)
 	if err != nil {
@@ -185,11 +182,14 @@ func resourceMalwarePolicyUpdate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
-	_, err = client.UpdateMalwarePolicy(ctx, appsec.UpdateMalwarePolicyRequest{
-		ConfigID:        configID,
-		ConfigVersion:   version,
-		MalwarePolicyID: malwarePolicyID,
-		Policy:          &policy,
+	err = updateModifiableConfigVersion(ctx, configID, "malwarePolicy", m, func(version int) error {
+		_, err := client.UpdateMalwarePolicy(ctx, appsec.UpdateMalwarePolicyRequest{
+			ConfigID:        configID,
+			ConfigVersion:   version,
+			MalwarePolicyID: malwarePolicyID,
+			Policy:          &policy,
+		})
+		return err
 	})
 	if err != nil {
 		logger.Warnf("calling 'updateMalwarePolicy': %s", err.Error())
@@ -214,19 +214,17 @@ func resourceMalwarePolicyDelete(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "malwarePolicy", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	malwarePolicyID, err := strconv.Atoi(iDParts[1])
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	err = client.RemoveMalwarePolicy(ctx, appsec.RemoveMalwarePolicyRequest{
-		ConfigID:        configID,
-		ConfigVersion:   version,
-		MalwarePolicyID: malwarePolicyID,
+	err = updateModifiableConfigVersion(ctx, configID, "malwarePolicy", m, func(version int) error {
+		return client.RemoveMalwarePolicy(ctx, appsec.RemoveMalwarePolicyRequest{
+			ConfigID:        configID,
+			ConfigVersion:   version,
+			MalwarePolicyID: malwarePolicyID,
+		})
 	})
 	if err != nil {
 		logger.Warnf("calling 'removeMalwarePolicy': %s", err.Error())
@@ -68,10 +68,6 @@ func resourceMalwarePolicyActionCreate(ctx context.Context, d *schema.ResourceDa
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "malwarePolicyAction", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	securityPolicyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -89,13 +85,16 @@ func resourceMalwarePolicyActionCreate(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(err)
 	}
 
-	_, err = client.UpdateMalwarePolicyAction(ctx, appsec.UpdateMalwarePolicyActionRequest{
-		ConfigID:        configID,
-		Version:         version,
-		PolicyID:        securityPolicyID,
-		MalwarePolicyID: malwarePolicyID,
-		Action:          action,
-		UnscannedAction: unscannedaction,
+	err = updateModifiableConfigVersion(ctx, configID, "malwarePolicyAction", m, func(version int) error {
+		_, err := client.UpdateMalwarePolicyAction(ctx, appsec.UpdateMalwarePolicyActionRequest{
+			ConfigID:        configID,
+			Version:         version,
+			PolicyID:        securityPolicyID,
+			MalwarePolicyID: malwarePolicyID,
+			Action:          action,
+			UnscannedAction: unscannedaction,
+		})
+		return err
 	})
 	if err != nil {
 		logger.Errorf("calling 'updateMalwarePolicyAction': %s", err.Error())
@@ -180,10 +179,6 @@ func resourceMalwarePolicyActionUpdate(ctx context.Context, d *schema.ResourceDa
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "malwarePolicyAction", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	securityPolicyID := iDParts[1]
 	malwarePolicyID, err := strconv.Atoi(iDParts[2])
 	if err != nil {
@@ -198,13 +193,16 @@ func resourceMalwarePolicyActionUpdate(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(err)
 	}
 
-	_, err = client.UpdateMalwarePolicyAction(ctx, appsec.UpdateMalwarePolicyActionRequest{
-		ConfigID:        configID,
-		Version:         version,
-		PolicyID:        securityPolicyID,
-		MalwarePolicyID: malwarePolicyID,
-		Action:          action,
-		UnscannedAction: unscannedaction,
+	err = updateModifiableConfigVersion(ctx, configID, "malwarePolicyAction", m, func(version int) error {
+		_, err := client.UpdateMalwarePolicyAction(ctx, appsec.UpdateMalwarePolicyActionRequest{
+			ConfigID:        configID,
+			Version:         version,
+			PolicyID:        securityPolicyID,
+			MalwarePolicyID: malwarePolicyID,
+			Action:          action,
+			UnscannedAction: unscannedaction,
+		})
+		return err
 	})
 	if err != nil {
 		logger.Errorf("calling 'updateMalwarePolicyAction': %s", err.Error())
@@ -228,23 +226,22 @@ func resourceMalwarePolicyActionDelete(ctx context.Context, d *schema.ResourceDa
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "malwarePolicyAction", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	securityPolicyID := iDParts[1]
 	malwarePolicyID, err := strconv.Atoi(iDParts[2])
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	_, err = client.UpdateMalwarePolicyAction(ctx, appsec.UpdateMalwarePolicyActionRequest{
-		ConfigID:        configID,
-		Version:         version,
-		PolicyID:        securityPolicyID,
-		MalwarePolicyID: malwarePolicyID,
-		Action:          "none",
-		UnscannedAction: "none",
+	err = updateModifiableConfigVersion(ctx, configID, "malwarePolicyAction", m, func(version int) error {
+		_, err := client.UpdateMalwarePolicyAction(ctx, appsec.UpdateMalwarePolicyActionRequest{
+			ConfigID:        configID,
+			Version:         version,
+			PolicyID:        securityPolicyID,
+			MalwarePolicyID: malwarePolicyID,
+			Action:          "none",
+			UnscannedAction: "none",
+		})
+		return err
 	})
 	if err != nil {
 		logger.Errorf("calling 'removeMalwarePolicyAction': %s", err.Error())
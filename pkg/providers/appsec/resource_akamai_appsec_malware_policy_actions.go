@@ -60,10 +60,6 @@ func resourceMalwarePolicyActionsCreate(ctx context.Context, d *schema.ResourceD
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "malwarePolicyActions", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	securityPolicyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -74,11 +70,14 @@ func resourceMalwarePolicyActionsCreate(ctx context.Context, d *schema.ResourceD
 	}
 	jsonPayloadRaw := []byte(jsonPostPayload)
 
-	_, err = client.UpdateMalwarePolicyActions(ctx, appsec.UpdateMalwarePolicyActionsRequest{
-		ConfigID:             configID,
-		Version:              version,
-		PolicyID:             securityPolicyID,
-		MalwarePolicyActions: jsonPayloadRaw,
+	err = updateModifiableConfigVersion(ctx, configID, "malwarePolicyActions", m, func(version int) error {
+		_, err := client.UpdateMalwarePolicyActions(ctx, appsec.UpdateMalwarePolicyActionsRequest{
+			ConfigID:             configID,
+			Version:              version,
+			PolicyID:             securityPolicyID,
+			MalwarePolicyActions: jsonPayloadRaw,
+		})
+		return err
 	})
 	if err != nil {
 		logger.Errorf("calling 'updateMalwarePolicyActions': %s", err.Error())
@@ -152,10 +151,6 @@ func resourceMalwarePolicyActionsUpdate(ctx context.Context, d *schema.ResourceD
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "malwarePolicyActions", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	securityPolicyID := iDParts[1]
 	jsonPostPayload, err := tools.GetStringValue("malware_policy_actions", d)
 	if err != nil {
@@ -163,11 +158,14 @@ func resourceMalwarePolicyActionsUpdate(ctx context.Context, d *schema.ResourceD
 	}
 	jsonPayloadRaw := []byte(jsonPostPayload)
 
-	_, err = client.UpdateMalwarePolicyActions(ctx, appsec.UpdateMalwarePolicyActionsRequest{
-		ConfigID:             configID,
-		Version:              version,
-		PolicyID:             securityPolicyID,
-		MalwarePolicyActions: jsonPayloadRaw,
+	err = updateModifiableConfigVersion(ctx, configID, "malwarePolicyActions", m, func(version int) error {
+		_, err := client.UpdateMalwarePolicyActions(ctx, appsec.UpdateMalwarePolicyActionsRequest{
+			ConfigID:             configID,
+			Version:              version,
+			PolicyID:             securityPolicyID,
+			MalwarePolicyActions: jsonPayloadRaw,
+		})
+		return err
 	})
 	if err != nil {
 		logger.Errorf("calling 'updateMalwarePolicyActions': %s", err.Error())
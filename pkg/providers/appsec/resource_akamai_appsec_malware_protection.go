@@ -61,10 +61,6 @@ func resourceMalwareProtectionCreate(ctx context.Context, d *schema.ResourceData
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "malwareProtection", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -74,11 +70,14 @@ func resourceMalwareProtectionCreate(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	_, err = client.UpdateMalwareProtection(ctx, appsec.UpdateMalwareProtectionRequest{
-		ConfigID:             configID,
-		Version:              version,
-		PolicyID:             policyID,
-		ApplyMalwareControls: enabled,
+	err = updateModifiableConfigVersion(ctx, configID, "malwareProtection", m, func(version int) error {
+		_, err := client.UpdateMalwareProtection(ctx, appsec.UpdateMalwareProtectionRequest{
+			ConfigID:             configID,
+			Version:              version,
+			PolicyID:             policyID,
+			ApplyMalwareControls: enabled,
+		})
+		return err
 	})
 	if err != nil {
 		logger.Errorf("calling UpdateMalwareProtection: %s", err.Error())
@@ -157,21 +156,20 @@ func resourceMalwareProtectionUpdate(ctx context.Context, d *schema.ResourceData
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "malwareProtection", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	enabled, err := tools.GetBoolValue("enabled", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return diag.FromErr(err)
 	}
 
-	_, err = client.UpdateMalwareProtection(ctx, appsec.UpdateMalwareProtectionRequest{
-		ConfigID:             configID,
-		Version:              version,
-		PolicyID:             policyID,
-		ApplyMalwareControls: enabled,
+	err = updateModifiableConfigVersion(ctx, configID, "malwareProtection", m, func(version int) error {
+		_, err := client.UpdateMalwareProtection(ctx, appsec.UpdateMalwareProtectionRequest{
+			ConfigID:             configID,
+			Version:              version,
+			PolicyID:             policyID,
+			ApplyMalwareControls: enabled,
+		})
+		return err
 	})
 	if err != nil {
 		logger.Errorf("calling UpdateMalwareProtection: %s", err.Error())
@@ -195,17 +193,16 @@ func resourceMalwareProtectionDelete(ctx context.Context, d *schema.ResourceData
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "malwareProtection", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 
-	_, err = client.UpdateMalwareProtection(ctx, appsec.UpdateMalwareProtectionRequest{
-		ConfigID:             configID,
-		Version:              version,
-		PolicyID:             policyID,
-		ApplyMalwareControls: false,
+	err = updateModifiableConfigVersion(ctx, configID, "malwareProtection", m, func(version int) error {
+		_, err := client.UpdateMalwareProtection(ctx, appsec.UpdateMalwareProtectionRequest{
+			ConfigID:             configID,
+			Version:              version,
+			PolicyID:             policyID,
+			ApplyMalwareControls: false,
+		})
+		return err
 	})
 	if err != nil {
 		logger.Errorf("calling UpdateMalwareProtection: %s", err.Error())
@@ -62,20 +62,21 @@ func resourceMatchTargetCreate(ctx context.Context, d *schema.ResourceData, m in
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "matchTarget", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	createMatchTarget := appsec.CreateMatchTargetRequest{}
 	jsonpostpayload := d.Get("match_target")
 	jsonPayloadRaw := []byte(jsonpostpayload.(string))
 	rawJSON := (json.RawMessage)(jsonPayloadRaw)
 
 	createMatchTarget.ConfigID = configID
-	createMatchTarget.ConfigVersion = version
 	createMatchTarget.JsonPayloadRaw = rawJSON
 
-	postresp, err := client.CreateMatchTarget(ctx, createMatchTarget)
+	var postresp *appsec.CreateMatchTargetResponse
+	err = updateModifiableConfigVersion(ctx, configID, "matchTarget", m, func(version int) error {
+		createMatchTarget.ConfigVersion = version
+		created, err := client.CreateMatchTarget(ctx, createMatchTarget)
+		postresp = created
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'createMatchTarget': %s", err.Error())
 		return diag.FromErr(err)
@@ -154,10 +155,6 @@ func resourceMatchTargetUpdate(ctx context.Context, d *schema.ResourceData, m in
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "matchTarget", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	targetID, err := strconv.Atoi(iDParts[1])
 	if err != nil {
 		return diag.FromErr(err)
@@ -168,12 +165,15 @@ func resourceMatchTargetUpdate(ctx context.Context, d *schema.ResourceData, m in
 
 	updateMatchTarget := appsec.UpdateMatchTargetRequest{
 		ConfigID:       configID,
-		ConfigVersion:  version,
 		TargetID:       targetID,
 		JsonPayloadRaw: rawJSON,
 	}
 
-	_, err = client.UpdateMatchTarget(ctx, updateMatchTarget)
+	err = updateModifiableConfigVersion(ctx, configID, "matchTarget", m, func(version int) error {
+		updateMatchTarget.ConfigVersion = version
+		_, err := client.UpdateMatchTarget(ctx, updateMatchTarget)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateMatchTarget': %s", err.Error())
 		return diag.FromErr(err)
@@ -197,22 +197,21 @@ func resourceMatchTargetDelete(ctx context.Context, d *schema.ResourceData, m in
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "matchTarget", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	targetID, err := strconv.Atoi(iDParts[1])
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	removeMatchTarget := appsec.RemoveMatchTargetRequest{
-		ConfigID:      configID,
-		ConfigVersion: version,
-		TargetID:      targetID,
+		ConfigID: configID,
+		TargetID: targetID,
 	}
 
-	_, err = client.RemoveMatchTarget(ctx, removeMatchTarget)
+	err = updateModifiableConfigVersion(ctx, configID, "matchTarget", m, func(version int) error {
+		removeMatchTarget.ConfigVersion = version
+		_, err := client.RemoveMatchTarget(ctx, removeMatchTarget)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'removeMatchTarget': %s", err.Error())
 		return diag.FromErr(err)
@@ -54,10 +54,6 @@ func resourceMatchTargetSequenceCreate(ctx context.Context, d *schema.ResourceDa
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "matchTargetSequence", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	jsonPayload := d.Get("match_target_sequence")
 
 	createMatchTargetSequence := appsec.UpdateMatchTargetSequenceRequest{}
@@ -65,9 +61,12 @@ func resourceMatchTargetSequenceCreate(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(err)
 	}
 	createMatchTargetSequence.ConfigID = configID
-	createMatchTargetSequence.ConfigVersion = version
 
-	_, err = client.UpdateMatchTargetSequence(ctx, createMatchTargetSequence)
+	err = updateModifiableConfigVersion(ctx, configID, "matchTargetSequence", m, func(version int) error {
+		createMatchTargetSequence.ConfigVersion = version
+		_, err := client.UpdateMatchTargetSequence(ctx, createMatchTargetSequence)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateMatchTargetSequence': %s", err.Error())
 		return diag.FromErr(err)
@@ -140,10 +139,6 @@ func resourceMatchTargetSequenceUpdate(ctx context.Context, d *schema.ResourceDa
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "matchTargetSequence", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	matchTargetType := iDParts[1]
 
 	jsonPayload := d.Get("match_target_sequence")
@@ -153,14 +148,17 @@ func resourceMatchTargetSequenceUpdate(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(err)
 	}
 	updateMatchTargetSequence.ConfigID = configID
-	updateMatchTargetSequence.ConfigVersion = version
 
 	if matchTargetType != updateMatchTargetSequence.Type {
 		err = fmt.Errorf("match target type %s cannot be changed to %s", matchTargetType, updateMatchTargetSequence.Type)
 		return diag.FromErr(err)
 	}
 
-	_, err = client.UpdateMatchTargetSequence(ctx, updateMatchTargetSequence)
+	err = updateModifiableConfigVersion(ctx, configID, "matchTargetSequence", m, func(version int) error {
+		updateMatchTargetSequence.ConfigVersion = version
+		_, err := client.UpdateMatchTargetSequence(ctx, updateMatchTargetSequence)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateMatchTargetSequence': %s", err.Error())
 		return diag.FromErr(err)
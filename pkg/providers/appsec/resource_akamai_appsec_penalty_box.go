@@ -70,10 +70,6 @@ func resourcePenaltyBoxCreate(ctx context.Context, d *schema.ResourceData, m int
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "penaltyBoxAction", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -89,13 +85,16 @@ func resourcePenaltyBoxCreate(ctx context.Context, d *schema.ResourceData, m int
 
 	createPenaltyBox := appsec.UpdatePenaltyBoxRequest{
 		ConfigID:             configID,
-		Version:              version,
 		PolicyID:             policyID,
 		PenaltyBoxProtection: penaltyboxprotection,
 		Action:               penaltyboxaction,
 	}
 
-	_, err = client.UpdatePenaltyBox(ctx, createPenaltyBox)
+	err = updateModifiableConfigVersion(ctx, configID, "penaltyBoxAction", m, func(version int) error {
+		createPenaltyBox.Version = version
+		_, err := client.UpdatePenaltyBox(ctx, createPenaltyBox)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'createPenaltyBox': %s", err.Error())
 		return diag.FromErr(err)
@@ -168,10 +167,6 @@ func resourcePenaltyBoxUpdate(ctx context.Context, d *schema.ResourceData, m int
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "penaltyBoxAction", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	penaltyboxprotection, err := tools.GetBoolValue("penalty_box_protection", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
@@ -184,13 +179,16 @@ func resourcePenaltyBoxUpdate(ctx context.Context, d *schema.ResourceData, m int
 
 	updatePenaltyBox := appsec.UpdatePenaltyBoxRequest{
 		ConfigID:             configID,
-		Version:              version,
 		PolicyID:             policyID,
 		PenaltyBoxProtection: penaltyboxprotection,
 		Action:               penaltyboxaction,
 	}
 
-	_, err = client.UpdatePenaltyBox(ctx, updatePenaltyBox)
+	err = updateModifiableConfigVersion(ctx, configID, "penaltyBoxAction", m, func(version int) error {
+		updatePenaltyBox.Version = version
+		_, err := client.UpdatePenaltyBox(ctx, updatePenaltyBox)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updatePenaltyBox': %s", err.Error())
 		return diag.FromErr(err)
@@ -213,21 +211,20 @@ func resourcePenaltyBoxDelete(ctx context.Context, d *schema.ResourceData, m int
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "penaltyBoxAction", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 
 	removePenaltyBox := appsec.UpdatePenaltyBoxRequest{
 		ConfigID:             configID,
-		Version:              version,
 		PolicyID:             policyID,
 		PenaltyBoxProtection: false,
 		Action:               string(appsec.ActionTypeNone),
 	}
 
-	_, err = client.UpdatePenaltyBox(ctx, removePenaltyBox)
+	err = updateModifiableConfigVersion(ctx, configID, "penaltyBoxAction", m, func(version int) error {
+		removePenaltyBox.Version = version
+		_, err := client.UpdatePenaltyBox(ctx, removePenaltyBox)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'removePenaltyBox': %s", err.Error())
 		return diag.FromErr(err)
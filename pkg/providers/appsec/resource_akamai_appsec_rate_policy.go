@@ -63,21 +63,22 @@ func resourceRatePolicyCreate(ctx context.Context, d *schema.ResourceData, m int
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "ratePolicy", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	jsonpostpayload := d.Get("rate_policy")
 	jsonPayloadRaw := []byte(jsonpostpayload.(string))
 	rawJSON := (json.RawMessage)(jsonPayloadRaw)
 
 	createRatePolicy := appsec.CreateRatePolicyRequest{
 		ConfigID:       configID,
-		ConfigVersion:  version,
 		JsonPayloadRaw: rawJSON,
 	}
 
-	ratepolicy, err := client.CreateRatePolicy(ctx, createRatePolicy)
+	var ratepolicy *appsec.CreateRatePolicyResponse
+	err = updateModifiableConfigVersion(ctx, configID, "ratePolicy", m, func(version int) error {
+		createRatePolicy.ConfigVersion = version
+		created, err := client.CreateRatePolicy(ctx, createRatePolicy)
+		ratepolicy = created
+		return err
+	})
 	if err != nil {
 		logger.Warnf("calling 'createRatePolicy': %s", err.Error())
 		return diag.FromErr(err)
@@ -165,18 +166,17 @@ func resourceRatePolicyUpdate(ctx context.Context, d *schema.ResourceData, m int
 	jsonPayloadRaw := []byte(jsonpostpayload.(string))
 	rawJSON := (json.RawMessage)(jsonPayloadRaw)
 
-	version, err := getModifiableConfigVersion(ctx, configID, "ratePolicy", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	updateRatePolicy := appsec.UpdateRatePolicyRequest{
 		ConfigID:       configID,
-		ConfigVersion:  version,
 		RatePolicyID:   ratePolicyID,
 		JsonPayloadRaw: rawJSON,
 	}
 
-	_, err = client.UpdateRatePolicy(ctx, updateRatePolicy)
+	err = updateModifiableConfigVersion(ctx, configID, "ratePolicy", m, func(version int) error {
+		updateRatePolicy.ConfigVersion = version
+		_, err := client.UpdateRatePolicy(ctx, updateRatePolicy)
+		return err
+	})
 	if err != nil {
 		logger.Warnf("calling 'updateRatePolicy': %s", err.Error())
 		return diag.FromErr(err)
@@ -200,22 +200,21 @@ func resourceRatePolicyDelete(ctx context.Context, d *schema.ResourceData, m int
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "ratePolicy", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	ratePolicyID, err := strconv.Atoi(iDParts[1])
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	deleteRatePolicy := appsec.RemoveRatePolicyRequest{
-		ConfigID:      configID,
-		ConfigVersion: version,
-		RatePolicyID:  ratePolicyID,
+		ConfigID:     configID,
+		RatePolicyID: ratePolicyID,
 	}
 
-	_, err = client.RemoveRatePolicy(ctx, deleteRatePolicy)
+	err = updateModifiableConfigVersion(ctx, configID, "ratePolicy", m, func(version int) error {
+		deleteRatePolicy.ConfigVersion = version
+		_, err := client.RemoveRatePolicy(ctx, deleteRatePolicy)
+		return err
+	})
 	if err != nil {
 		logger.Warnf("calling 'removeRatePolicy': %s", err.Error())
 		return diag.FromErr(err)
@@ -72,10 +72,6 @@ func resourceRatePolicyActionCreate(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "ratePolicyAction", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	securityPolicyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -95,14 +91,17 @@ func resourceRatePolicyActionCreate(ctx context.Context, d *schema.ResourceData,
 
 	updateRatePolicyAction := appsec.UpdateRatePolicyActionRequest{
 		ConfigID:     configID,
-		Version:      version,
 		PolicyID:     securityPolicyID,
 		RatePolicyID: ratePolicyID,
 		Ipv4Action:   ipv4action,
 		Ipv6Action:   ipv6action,
 	}
 
-	_, err = client.UpdateRatePolicyAction(ctx, updateRatePolicyAction)
+	err = updateModifiableConfigVersion(ctx, configID, "ratePolicyAction", m, func(version int) error {
+		updateRatePolicyAction.Version = version
+		_, err := client.UpdateRatePolicyAction(ctx, updateRatePolicyAction)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateRatePolicyAction': %s", err.Error())
 		return diag.FromErr(err)
@@ -188,10 +187,6 @@ func resourceRatePolicyActionUpdate(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "ratePolicyAction", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	securityPolicyID := iDParts[1]
 	ratePolicyID, err := strconv.Atoi(iDParts[2])
 	if err != nil {
@@ -208,14 +203,17 @@ func resourceRatePolicyActionUpdate(ctx context.Context, d *schema.ResourceData,
 
 	updateRatePolicyAction := appsec.UpdateRatePolicyActionRequest{
 		ConfigID:     configID,
-		Version:      version,
 		PolicyID:     securityPolicyID,
 		RatePolicyID: ratePolicyID,
 		Ipv4Action:   ipv4action,
 		Ipv6Action:   ipv6action,
 	}
 
-	_, err = client.UpdateRatePolicyAction(ctx, updateRatePolicyAction)
+	err = updateModifiableConfigVersion(ctx, configID, "ratePolicyAction", m, func(version int) error {
+		updateRatePolicyAction.Version = version
+		_, err := client.UpdateRatePolicyAction(ctx, updateRatePolicyAction)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateRatePolicyAction': %s", err.Error())
 		return diag.FromErr(err)
@@ -238,10 +236,6 @@ func resourceRatePolicyActionDelete(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "ratePolicyAction", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	securityPolicyID := iDParts[1]
 	ratePolicyID, err := strconv.Atoi(iDParts[2])
 	if err != nil {
@@ -250,14 +244,17 @@ func resourceRatePolicyActionDelete(ctx context.Context, d *schema.ResourceData,
 
 	deleteRatePolicyAction := appsec.UpdateRatePolicyActionRequest{
 		ConfigID:     configID,
-		Version:      version,
 		PolicyID:     securityPolicyID,
 		RatePolicyID: ratePolicyID,
 		Ipv4Action:   "none",
 		Ipv6Action:   "none",
 	}
 
-	_, err = client.UpdateRatePolicyAction(ctx, deleteRatePolicyAction)
+	err = updateModifiableConfigVersion(ctx, configID, "ratePolicyAction", m, func(version int) error {
+		deleteRatePolicyAction.Version = version
+		_, err := client.UpdateRatePolicyAction(ctx, deleteRatePolicyAction)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'removeRatePolicyAction': %s", err.Error())
 		return diag.FromErr(err)
@@ -63,10 +63,6 @@ func resourceRateProtectionCreate(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "rateProtection", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -78,11 +74,14 @@ func resourceRateProtectionCreate(ctx context.Context, d *schema.ResourceData, m
 
 	request := appsec.UpdateRateProtectionRequest{
 		ConfigID:          configID,
-		Version:           version,
 		PolicyID:          policyID,
 		ApplyRateControls: enabled,
 	}
-	_, err = client.UpdateRateProtection(ctx, request)
+	err = updateModifiableConfigVersion(ctx, configID, "rateProtection", m, func(version int) error {
+		request.Version = version
+		_, err := client.UpdateRateProtection(ctx, request)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling UpdateRateProtection: %s", err.Error())
 		return diag.FromErr(err)
@@ -161,10 +160,6 @@ func resourceRateProtectionUpdate(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "rateProtection", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	enabled, err := tools.GetBoolValue("enabled", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
@@ -173,11 +168,14 @@ func resourceRateProtectionUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	request := appsec.UpdateRateProtectionRequest{
 		ConfigID:          configID,
-		Version:           version,
 		PolicyID:          policyID,
 		ApplyRateControls: enabled,
 	}
-	_, err = client.UpdateRateProtection(ctx, request)
+	err = updateModifiableConfigVersion(ctx, configID, "rateProtection", m, func(version int) error {
+		request.Version = version
+		_, err := client.UpdateRateProtection(ctx, request)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling UpdateRateProtection: %s", err.Error())
 		return diag.FromErr(err)
@@ -200,19 +198,18 @@ func resourceRateProtectionDelete(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "rateProtection", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 
 	request := appsec.UpdateRateProtectionRequest{
 		ConfigID:          configID,
-		Version:           version,
 		PolicyID:          policyID,
 		ApplyRateControls: false,
 	}
-	_, err = client.UpdateRateProtection(ctx, request)
+	err = updateModifiableConfigVersion(ctx, configID, "rateProtection", m, func(version int) error {
+		request.Version = version
+		_, err := client.UpdateRateProtection(ctx, request)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling UpdateRateProtection: %s", err.Error())
 		return diag.FromErr(err)
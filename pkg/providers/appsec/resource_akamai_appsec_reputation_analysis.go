@@ -64,10 +64,6 @@ func resourceReputationAnalysisCreate(ctx context.Context, d *schema.ResourceDat
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "reputationProfileAnalysis", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -83,13 +79,16 @@ func resourceReputationAnalysisCreate(ctx context.Context, d *schema.ResourceDat
 
 	createReputationAnalysis := appsec.UpdateReputationAnalysisRequest{
 		ConfigID:                           configID,
-		Version:                            version,
 		PolicyID:                           policyID,
 		ForwardToHTTPHeader:                forwardToHTTPHeader,
 		ForwardSharedIPToHTTPHeaderAndSIEM: forwardSharedIPToHTTPHeaderSiem,
 	}
 
-	_, err = client.UpdateReputationAnalysis(ctx, createReputationAnalysis)
+	err = updateModifiableConfigVersion(ctx, configID, "reputationProfileAnalysis", m, func(version int) error {
+		createReputationAnalysis.Version = version
+		_, err := client.UpdateReputationAnalysis(ctx, createReputationAnalysis)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'createReputationAnalysis': %s", err.Error())
 		return diag.FromErr(err)
@@ -162,10 +161,6 @@ func resourceReputationAnalysisUpdate(ctx context.Context, d *schema.ResourceDat
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "reputationProfileAnalysis", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	forwardToHTTPHeader, err := tools.GetBoolValue("forward_to_http_header", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
@@ -178,13 +173,16 @@ func resourceReputationAnalysisUpdate(ctx context.Context, d *schema.ResourceDat
 
 	updateReputationAnalysis := appsec.UpdateReputationAnalysisRequest{
 		ConfigID:                           configID,
-		Version:                            version,
 		PolicyID:                           policyID,
 		ForwardToHTTPHeader:                forwardToHTTPHeader,
 		ForwardSharedIPToHTTPHeaderAndSIEM: forwardSharedIPToHTTPHeaderSiem,
 	}
 
-	_, err = client.UpdateReputationAnalysis(ctx, updateReputationAnalysis)
+	err = updateModifiableConfigVersion(ctx, configID, "reputationProfileAnalysis", m, func(version int) error {
+		updateReputationAnalysis.Version = version
+		_, err := client.UpdateReputationAnalysis(ctx, updateReputationAnalysis)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateReputationAnalysis': %s", err.Error())
 		return diag.FromErr(err)
@@ -207,21 +205,20 @@ func resourceReputationAnalysisDelete(ctx context.Context, d *schema.ResourceDat
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "reputationProfileAnalysis", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 
 	RemoveReputationAnalysis := appsec.RemoveReputationAnalysisRequest{
 		ConfigID:                           configID,
-		Version:                            version,
 		PolicyID:                           policyID,
 		ForwardToHTTPHeader:                false,
 		ForwardSharedIPToHTTPHeaderAndSIEM: false,
 	}
 
-	_, err = client.RemoveReputationAnalysis(ctx, RemoveReputationAnalysis)
+	err = updateModifiableConfigVersion(ctx, configID, "reputationProfileAnalysis", m, func(version int) error {
+		RemoveReputationAnalysis.Version = version
+		_, err := client.RemoveReputationAnalysis(ctx, RemoveReputationAnalysis)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'RemoveReputationAnalysis': %s", err.Error())
 		return diag.FromErr(err)
@@ -63,10 +63,6 @@ func resourceReputationProfileCreate(ctx context.Context, d *schema.ResourceData
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "reputationProfile", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	jsonpostpayload, err := tools.GetStringValue("reputation_profile", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -76,11 +72,16 @@ func resourceReputationProfileCreate(ctx context.Context, d *schema.ResourceData
 
 	createReputationProfile := appsec.CreateReputationProfileRequest{
 		ConfigID:       configID,
-		ConfigVersion:  version,
 		JsonPayloadRaw: rawJSON,
 	}
 
-	response, err := client.CreateReputationProfile(ctx, createReputationProfile)
+	var response *appsec.CreateReputationProfileResponse
+	err = updateModifiableConfigVersion(ctx, configID, "reputationProfile", m, func(version int) error {
+		createReputationProfile.ConfigVersion = version
+		created, err := client.CreateReputationProfile(ctx, createReputationProfile)
+		response = created
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'CreateReputationProfile': %s", err.Error())
 		return diag.FromErr(err)
@@ -167,21 +168,20 @@ func resourceReputationProfileUpdate(ctx context.Context, d *schema.ResourceData
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "reputationProfile", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	jsonPayloadRaw := []byte(jsonpostpayload)
 	rawJSON := (json.RawMessage)(jsonPayloadRaw)
 
 	updateReputationProfile := appsec.UpdateReputationProfileRequest{
 		ConfigID:            configID,
-		ConfigVersion:       version,
 		ReputationProfileId: reputationProfileID,
 		JsonPayloadRaw:      rawJSON,
 	}
 
-	_, err = client.UpdateReputationProfile(ctx, updateReputationProfile)
+	err = updateModifiableConfigVersion(ctx, configID, "reputationProfile", m, func(version int) error {
+		updateReputationProfile.ConfigVersion = version
+		_, err := client.UpdateReputationProfile(ctx, updateReputationProfile)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateReputationProfile': %s", err.Error())
 		return diag.FromErr(err)
@@ -205,10 +205,6 @@ func resourceReputationProfileDelete(ctx context.Context, d *schema.ResourceData
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "reputationProfile", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	reputationProfileID, err := strconv.Atoi(iDParts[1])
 	if err != nil {
 		return diag.FromErr(err)
@@ -216,11 +212,14 @@ func resourceReputationProfileDelete(ctx context.Context, d *schema.ResourceData
 
 	deleteReputationProfile := appsec.RemoveReputationProfileRequest{
 		ConfigID:            configID,
-		ConfigVersion:       version,
 		ReputationProfileId: reputationProfileID,
 	}
 
-	_, err = client.RemoveReputationProfile(ctx, deleteReputationProfile)
+	err = updateModifiableConfigVersion(ctx, configID, "reputationProfile", m, func(version int) error {
+		deleteReputationProfile.ConfigVersion = version
+		_, err := client.RemoveReputationProfile(ctx, deleteReputationProfile)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'removeReputationProfile': %s", err.Error())
 		return diag.FromErr(err)
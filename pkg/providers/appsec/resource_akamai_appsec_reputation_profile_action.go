@@ -65,10 +65,6 @@ func resourceReputationProfileActionCreate(ctx context.Context, d *schema.Resour
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "reputationProfileAction", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -84,13 +80,16 @@ func resourceReputationProfileActionCreate(ctx context.Context, d *schema.Resour
 
 	createReputationProfileAction := appsec.UpdateReputationProfileActionRequest{
 		ConfigID:            configID,
-		Version:             version,
 		PolicyID:            policyID,
 		ReputationProfileID: reputationProfileID,
 		Action:              action,
 	}
 
-	_, err = client.UpdateReputationProfileAction(ctx, createReputationProfileAction)
+	err = updateModifiableConfigVersion(ctx, configID, "reputationProfileAction", m, func(version int) error {
+		createReputationProfileAction.Version = version
+		_, err := client.UpdateReputationProfileAction(ctx, createReputationProfileAction)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'createReputationProfileAction': %s", err.Error())
 		return diag.FromErr(err)
@@ -169,10 +168,6 @@ func resourceReputationProfileActionUpdate(ctx context.Context, d *schema.Resour
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "reputationProfileAction", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	reputationProfileID, err := strconv.Atoi(iDParts[2])
 	if err != nil {
@@ -185,13 +180,16 @@ func resourceReputationProfileActionUpdate(ctx context.Context, d *schema.Resour
 
 	updateReputationProfileAction := appsec.UpdateReputationProfileActionRequest{
 		ConfigID:            configID,
-		Version:             version,
 		PolicyID:            policyID,
 		ReputationProfileID: reputationProfileID,
 		Action:              action,
 	}
 
-	_, err = client.UpdateReputationProfileAction(ctx, updateReputationProfileAction)
+	err = updateModifiableConfigVersion(ctx, configID, "reputationProfileAction", m, func(version int) error {
+		updateReputationProfileAction.Version = version
+		_, err := client.UpdateReputationProfileAction(ctx, updateReputationProfileAction)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateReputationProfileAction': %s", err.Error())
 		return diag.FromErr(err)
@@ -214,10 +212,6 @@ func resourceReputationProfileActionDelete(ctx context.Context, d *schema.Resour
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "reputationProfileAction", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	reputationProfileID, err := strconv.Atoi(iDParts[2])
 	if err != nil {
@@ -226,13 +220,16 @@ func resourceReputationProfileActionDelete(ctx context.Context, d *schema.Resour
 
 	removeReputationProfileAction := appsec.UpdateReputationProfileActionRequest{
 		ConfigID:            configID,
-		Version:             version,
 		PolicyID:            policyID,
 		ReputationProfileID: reputationProfileID,
 		Action:              "none",
 	}
 
-	_, err = client.UpdateReputationProfileAction(ctx, removeReputationProfileAction)
+	err = updateModifiableConfigVersion(ctx, configID, "reputationProfileAction", m, func(version int) error {
+		removeReputationProfileAction.Version = version
+		_, err := client.UpdateReputationProfileAction(ctx, removeReputationProfileAction)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'removeReputationProfileAction': %s", err.Error())
 		return diag.FromErr(err)
@@ -64,10 +64,6 @@ func resourceReputationProtectionCreate(ctx context.Context, d *schema.ResourceD
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "reputationProtection", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return diag.FromErr(err)
@@ -79,11 +75,14 @@ func resourceReputationProtectionCreate(ctx context.Context, d *schema.ResourceD
 
 	request := appsec.UpdateReputationProtectionRequest{
 		ConfigID:                configID,
-		Version:                 version,
 		PolicyID:                policyID,
 		ApplyReputationControls: enabled,
 	}
-	_, err = client.UpdateReputationProtection(ctx, request)
+	err = updateModifiableConfigVersion(ctx, configID, "reputationProtection", m, func(version int) error {
+		request.Version = version
+		_, err := client.UpdateReputationProtection(ctx, request)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling UpdateReputationProtection: %s", err.Error())
 		return diag.FromErr(err)
@@ -162,10 +161,6 @@ func resourceReputationProtectionUpdate(ctx context.Context, d *schema.ResourceD
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "reputationProtection", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	enabled, err := tools.GetBoolValue("enabled", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
@@ -174,11 +169,14 @@ func resourceReputationProtectionUpdate(ctx context.Context, d *schema.ResourceD
 
 	request := appsec.UpdateReputationProtectionRequest{
 		ConfigID:                configID,
-		Version:                 version,
 		PolicyID:                policyID,
 		ApplyReputationControls: enabled,
 	}
-	_, err = client.UpdateReputationProtection(ctx, request)
+	err = updateModifiableConfigVersion(ctx, configID, "reputationProtection", m, func(version int) error {
+		request.Version = version
+		_, err := client.UpdateReputationProtection(ctx, request)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling UpdateReputationProtection: %s", err.Error())
 		return diag.FromErr(err)
@@ -201,19 +199,18 @@ func resourceReputationProtectionDelete(ctx context.Context, d *schema.ResourceD
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "reputationProtection", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 
 	request := appsec.UpdateReputationProtectionRequest{
 		ConfigID:                configID,
-		Version:                 version,
 		PolicyID:                policyID,
 		ApplyReputationControls: false,
 	}
-	_, err = client.UpdateReputationProtection(ctx, request)
+	err = updateModifiableConfigVersion(ctx, configID, "reputationProtection", m, func(version int) error {
+		request.Version = version
+		_, err := client.UpdateReputationProtection(ctx, request)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling UpdateReputationProtection: %s", err.Error())
 		return diag.FromErr(err)
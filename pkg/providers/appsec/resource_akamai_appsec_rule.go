@@ -82,10 +82,6 @@ func resourceRuleCreate(ctx context.Context, d *schema.ResourceData, m interface
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "rule", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -103,66 +99,72 @@ func resourceRuleCreate(ctx context.Context, d *schema.ResourceData, m interface
 	jsonPayloadRaw := []byte(conditionexception)
 	rawJSON := (json.RawMessage)(jsonPayloadRaw)
 
-	wafMode, err := getWAFMode(ctx, m, configID, version, policyID)
-	if err != nil {
-		logger.Errorf("calling 'getWAFMode': %s", err.Error())
-		return diag.FromErr(err)
-	}
+	err = updateModifiableConfigVersion(ctx, configID, "rule", m, func(version int) error {
+		wafMode, err := getWAFMode(ctx, m, configID, version, policyID)
+		if err != nil {
+			logger.Errorf("calling 'getWAFMode': %s", err.Error())
+			return err
+		}
 
-	if wafMode == AseAuto { // action is read only, only condition exception is writable
-		ruleConditionException := appsec.RuleConditionException{}
-		if conditionexception != "" {
-			err = json.Unmarshal([]byte(rawJSON), &ruleConditionException)
-			if err != nil {
-				return diag.FromErr(err)
+		if wafMode == AseAuto { // action is read only, only condition exception is writable
+			ruleConditionException := appsec.RuleConditionException{}
+			if conditionexception != "" {
+				err = json.Unmarshal([]byte(rawJSON), &ruleConditionException)
+				if err != nil {
+					return err
+				}
 			}
-		}
 
-		createRule := appsec.UpdateConditionExceptionRequest{
-			ConfigID:               configID,
-			Version:                version,
-			PolicyID:               policyID,
-			RuleID:                 ruleID,
-			Conditions:             ruleConditionException.Conditions,
-			Exception:              ruleConditionException.Exception,
-			AdvancedExceptionsList: ruleConditionException.AdvancedExceptionsList,
-		}
+			createRule := appsec.UpdateConditionExceptionRequest{
+				ConfigID:               configID,
+				Version:                version,
+				PolicyID:               policyID,
+				RuleID:                 ruleID,
+				Conditions:             ruleConditionException.Conditions,
+				Exception:              ruleConditionException.Exception,
+				AdvancedExceptionsList: ruleConditionException.AdvancedExceptionsList,
+			}
 
-		resp, err := client.UpdateRuleConditionException(ctx, createRule)
-		if err != nil {
-			logger.Errorf("calling 'UpdateRule': %s", err.Error())
-			return diag.FromErr(err)
-		}
-		logger.Debugf("calling 'UpdateRule Response': %s", resp)
-		d.SetId(fmt.Sprintf("%d:%s:%d", createRule.ConfigID, createRule.PolicyID, createRule.RuleID))
-	} else {
-		action, err := tools.GetStringValue("rule_action", d)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		if err := validateActionAndConditionException(action, conditionexception); err != nil {
-			return diag.FromErr(err)
-		}
+			resp, err := client.UpdateRuleConditionException(ctx, createRule)
+			if err != nil {
+				logger.Errorf("calling 'UpdateRule': %s", err.Error())
+				return err
+			}
+			logger.Debugf("calling 'UpdateRule Response': %s", resp)
+		} else {
+			action, err := tools.GetStringValue("rule_action", d)
+			if err != nil {
+				return err
+			}
+			if err := validateActionAndConditionException(action, conditionexception); err != nil {
+				return err
+			}
 
-		createRule := appsec.UpdateRuleRequest{
-			ConfigID:       configID,
-			Version:        version,
-			PolicyID:       policyID,
-			RuleID:         ruleID,
-			Action:         action,
-			JsonPayloadRaw: rawJSON,
-		}
+			createRule := appsec.UpdateRuleRequest{
+				ConfigID:       configID,
+				Version:        version,
+				PolicyID:       policyID,
+				RuleID:         ruleID,
+				Action:         action,
+				JsonPayloadRaw: rawJSON,
+			}
 
-		resp, err := client.UpdateRule(ctx, createRule)
-		if err != nil {
-			logger.Errorf("calling 'UpdateRule': %s", err.Error())
-			return diag.FromErr(err)
-		}
-		logger.Debugf("calling 'UpdateRule Response': %s", resp)
-		d.SetId(fmt.Sprintf("%d:%s:%d", createRule.ConfigID, createRule.PolicyID, createRule.RuleID))
+			resp, err := client.UpdateRule(ctx, createRule)
+			if err != nil {
+				logger.Errorf("calling 'UpdateRule': %s", err.Error())
+				return err
+			}
+			logger.Debugf("calling 'UpdateRule Response': %s", resp)
 
+		}
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
+	d.SetId(fmt.Sprintf("%d:%s:%d", configID, policyID, ruleID))
+
 	return resourceRuleRead(ctx, d, m)
 }
 
@@ -295,10 +297,6 @@ func resourceRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.FromErr(err)
 	}
 	policyID := iDParts[1]
-	version, err := getModifiableConfigVersion(ctx, configID, "rule", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	ruleID, err := strconv.Atoi(iDParts[2])
 	if err != nil {
 		return diag.FromErr(err)
@@ -310,61 +308,67 @@ func resourceRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface
 	jsonPayloadRaw := []byte(conditionexception)
 	rawJSON := (json.RawMessage)(jsonPayloadRaw)
 
-	wafMode, err := getWAFMode(ctx, m, configID, version, policyID)
-	if err != nil {
-		logger.Errorf("calling 'getWAFMode': %s", err.Error())
-		return diag.FromErr(err)
-	}
+	err = updateModifiableConfigVersion(ctx, configID, "rule", m, func(version int) error {
+		wafMode, err := getWAFMode(ctx, m, configID, version, policyID)
+		if err != nil {
+			logger.Errorf("calling 'getWAFMode': %s", err.Error())
+			return err
+		}
 
-	if wafMode == AseAuto { // action is read only, only exception is writable
-		ruleConditionException := appsec.RuleConditionException{}
-		if conditionexception != "" {
-			err = json.Unmarshal([]byte(rawJSON), &ruleConditionException)
-			if err != nil {
-				return diag.FromErr(err)
+		if wafMode == AseAuto { // action is read only, only exception is writable
+			ruleConditionException := appsec.RuleConditionException{}
+			if conditionexception != "" {
+				err = json.Unmarshal([]byte(rawJSON), &ruleConditionException)
+				if err != nil {
+					return err
+				}
 			}
-		}
 
-		updateRule := appsec.UpdateConditionExceptionRequest{
-			ConfigID:               configID,
-			Version:                version,
-			PolicyID:               policyID,
-			RuleID:                 ruleID,
-			Conditions:             ruleConditionException.Conditions,
-			Exception:              ruleConditionException.Exception,
-			AdvancedExceptionsList: ruleConditionException.AdvancedExceptionsList,
-		}
+			updateRule := appsec.UpdateConditionExceptionRequest{
+				ConfigID:               configID,
+				Version:                version,
+				PolicyID:               policyID,
+				RuleID:                 ruleID,
+				Conditions:             ruleConditionException.Conditions,
+				Exception:              ruleConditionException.Exception,
+				AdvancedExceptionsList: ruleConditionException.AdvancedExceptionsList,
+			}
 
-		resp, err := client.UpdateRuleConditionException(ctx, updateRule)
-		if err != nil {
-			logger.Errorf("calling 'UpdateRule': %s", err.Error())
-			return diag.FromErr(err)
-		}
-		logger.Debugf("calling 'UpdateRule Response': %s", resp)
-	} else {
+			resp, err := client.UpdateRuleConditionException(ctx, updateRule)
+			if err != nil {
+				logger.Errorf("calling 'UpdateRule': %s", err.Error())
+				return err
+			}
+			logger.Debugf("calling 'UpdateRule Response': %s", resp)
+		} else {
 
-		action, err := tools.GetStringValue("rule_action", d)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		if err := validateActionAndConditionException(action, conditionexception); err != nil {
-			return diag.FromErr(err)
-		}
+			action, err := tools.GetStringValue("rule_action", d)
+			if err != nil {
+				return err
+			}
+			if err := validateActionAndConditionException(action, conditionexception); err != nil {
+				return err
+			}
 
-		updateRule := appsec.UpdateRuleRequest{
-			ConfigID:       configID,
-			Version:        version,
-			PolicyID:       policyID,
-			RuleID:         ruleID,
-			Action:         action,
-			JsonPayloadRaw: rawJSON,
-		}
+			updateRule := appsec.UpdateRuleRequest{
+				ConfigID:       configID,
+				Version:        version,
+				PolicyID:       policyID,
+				RuleID:         ruleID,
+				Action:         action,
+				JsonPayloadRaw: rawJSON,
+			}
 
-		_, err = client.UpdateRule(ctx, updateRule)
-		if err != nil {
-			logger.Errorf("calling 'UpdateRule': %s", err.Error())
-			return diag.FromErr(err)
+			_, err = client.UpdateRule(ctx, updateRule)
+			if err != nil {
+				logger.Errorf("calling 'UpdateRule': %s", err.Error())
+				return err
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	return resourceRuleRead(ctx, d, m)
@@ -385,48 +389,50 @@ func resourceRuleDelete(ctx context.Context, d *schema.ResourceData, m interface
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "rule", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	ruleID, err := strconv.Atoi(iDParts[2])
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	wafMode, err := getWAFMode(ctx, m, configID, version, policyID)
-	if err != nil {
-		logger.Errorf("calling 'getWAFMode': %s", err.Error())
-		return diag.FromErr(err)
-	}
-
-	if wafMode == AseAuto {
-		updateRule := appsec.UpdateConditionExceptionRequest{
-			ConfigID: configID,
-			Version:  version,
-			PolicyID: policyID,
-			RuleID:   ruleID,
-		}
-
-		_, err = client.UpdateRuleConditionException(ctx, updateRule)
+	err = updateModifiableConfigVersion(ctx, configID, "rule", m, func(version int) error {
+		wafMode, err := getWAFMode(ctx, m, configID, version, policyID)
 		if err != nil {
-			logger.Errorf("calling 'UpdateRule': %s", err.Error())
-			return diag.FromErr(err)
-		}
-	} else {
-		updateRule := appsec.UpdateRuleRequest{
-			ConfigID: configID,
-			Version:  version,
-			PolicyID: policyID,
-			RuleID:   ruleID,
-			Action:   "none",
+			logger.Errorf("calling 'getWAFMode': %s", err.Error())
+			return err
 		}
-		_, err = client.UpdateRule(ctx, updateRule)
-		if err != nil {
-			logger.Errorf("calling 'UpdateRule': %s", err.Error())
-			return diag.FromErr(err)
+
+		if wafMode == AseAuto {
+			updateRule := appsec.UpdateConditionExceptionRequest{
+				ConfigID: configID,
+				Version:  version,
+				PolicyID: policyID,
+				RuleID:   ruleID,
+			}
+
+			_, err = client.UpdateRuleConditionException(ctx, updateRule)
+			if err != nil {
+				logger.Errorf("calling 'UpdateRule': %s", err.Error())
+				return err
+			}
+		} else {
+			updateRule := appsec.UpdateRuleRequest{
+				ConfigID: configID,
+				Version:  version,
+				PolicyID: policyID,
+				RuleID:   ruleID,
+				Action:   "none",
+			}
+			_, err = client.UpdateRule(ctx, updateRule)
+			if err != nil {
+				logger.Errorf("calling 'UpdateRule': %s", err.Error())
+				return err
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 	return nil
 }
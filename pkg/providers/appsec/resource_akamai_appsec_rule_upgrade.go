@@ -80,10 +80,6 @@ func resourceRuleUpgradeCreate(ctx context.Context, d *schema.ResourceData, m in
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "krsRuleUgrade", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -96,13 +92,16 @@ func resourceRuleUpgradeCreate(ctx context.Context, d *schema.ResourceData, m in
 
 	createRuleUpgrade := appsec.UpdateRuleUpgradeRequest{
 		ConfigID: configID,
-		Version:  version,
 		PolicyID: policyID,
 		Upgrade:  true,
 		Mode:     upgrademode,
 	}
 
-	_, err = client.UpdateRuleUpgrade(ctx, createRuleUpgrade)
+	err = updateModifiableConfigVersion(ctx, configID, "krsRuleUgrade", m, func(version int) error {
+		createRuleUpgrade.Version = version
+		_, err := client.UpdateRuleUpgrade(ctx, createRuleUpgrade)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'createRuleUpgrade': %s", err.Error())
 		return diag.FromErr(err)
@@ -178,10 +177,6 @@ func resourceRuleUpgradeUpdate(ctx context.Context, d *schema.ResourceData, m in
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "securityPolicyRename", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 
 	upgrademode, err := tools.GetStringValue("upgrade_mode", d)
@@ -191,13 +186,16 @@ func resourceRuleUpgradeUpdate(ctx context.Context, d *schema.ResourceData, m in
 
 	updateRuleUpgrade := appsec.UpdateRuleUpgradeRequest{
 		ConfigID: configID,
-		Version:  version,
 		PolicyID: policyID,
 		Upgrade:  true,
 		Mode:     upgrademode,
 	}
 
-	_, err = client.UpdateRuleUpgrade(ctx, updateRuleUpgrade)
+	err = updateModifiableConfigVersion(ctx, configID, "securityPolicyRename", m, func(version int) error {
+		updateRuleUpgrade.Version = version
+		_, err := client.UpdateRuleUpgrade(ctx, updateRuleUpgrade)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateRuleUpgrade': %s", err.Error())
 		return diag.FromErr(err)
@@ -0,0 +1,533 @@
+package appsec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var (
+	// RulesUpdateParallelism is the maximum number of rules akamai_appsec_rules updates at the same time
+	RulesUpdateParallelism = 10
+)
+
+type (
+	// ruleSetting is the action and JSON-formatted condition and exception of a rule
+	ruleSetting struct {
+		Action             string
+		ConditionException string
+	}
+
+	// ruleUpdate is a change made to a rule by akamai_appsec_rules
+	ruleUpdate struct {
+		RuleID int
+		ruleSetting
+	}
+)
+
+// appsec v1
+//
+// https://techdocs.akamai.com/application-security/reference/api
+func resourceRules() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRulesCreate,
+		ReadContext:   resourceRulesRead,
+		UpdateContext: resourceRulesUpdate,
+		DeleteContext: resourceRulesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRulesImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"config_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Unique identifier of the security configuration",
+			},
+			"security_policy_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Unique identifier of the security policy",
+			},
+			"rule_actions": {
+				Type:             schema.TypeMap,
+				Optional:         true,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				ValidateDiagFunc: validateRuleActions,
+				Description:      "Actions to be taken when the rules are triggered, by rule ID",
+			},
+			"condition_exceptions": {
+				Type:             schema.TypeMap,
+				Optional:         true,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				ValidateDiagFunc: validateRuleConditionExceptions,
+				Description:      "JSON-formatted condition and exception information of the rules, by rule ID",
+			},
+		},
+	}
+}
+
+func resourceRulesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("APPSEC", "resourceRulesCreate")
+	logger.Debugf("in resourceRulesCreate")
+
+	configID, err := tools.GetIntValue("config_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	policyID, err := tools.GetStringValue("security_policy_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if diags := applyRules(ctx, d, m, configID, policyID); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(fmt.Sprintf("%d:%s", configID, policyID))
+
+	return resourceRulesRead(ctx, d, m)
+}
+
+func resourceRulesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("APPSEC", "resourceRulesRead")
+	logger.Debugf("in resourceRulesRead")
+
+	configID, policyID, err := splitRulesID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	version, err := getLatestConfigVersion(ctx, configID, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	current, err := getRuleSettings(ctx, m, configID, version, policyID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Only the rules in the state are read, so that rules managed elsewhere don't show as changes.
+	stateActions := d.Get("rule_actions").(map[string]interface{})
+	stateConditionExceptions := d.Get("condition_exceptions").(map[string]interface{})
+	ruleActions := make(map[string]interface{}, len(stateActions))
+	conditionExceptions := make(map[string]interface{}, len(stateConditionExceptions))
+	for key := range stateActions {
+		ruleID, _ := strconv.Atoi(key)
+		if setting, ok := current[ruleID]; ok {
+			ruleActions[key] = setting.Action
+		}
+	}
+	for _, key := range ruleIDKeys(stateActions, stateConditionExceptions) {
+		ruleID, _ := strconv.Atoi(key)
+		setting, ok := current[ruleID]
+		if !ok || setting.ConditionException == "" {
+			continue
+		}
+		// Equivalent JSON in the state is kept, so that formatting differences don't show as changes.
+		if old, ok := stateConditionExceptions[key].(string); ok && ruleConditionExceptionsEqual(old, setting.ConditionException) {
+			conditionExceptions[key] = old
+			continue
+		}
+		conditionExceptions[key] = setting.ConditionException
+	}
+
+	if err := d.Set("config_id", configID); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("security_policy_id", policyID); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("rule_actions", ruleActions); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("condition_exceptions", conditionExceptions); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+
+	return nil
+}
+
+func resourceRulesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("APPSEC", "resourceRulesUpdate")
+	logger.Debugf("in resourceRulesUpdate")
+
+	configID, policyID, err := splitRulesID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if diags := applyRules(ctx, d, m, configID, policyID); diags.HasError() {
+		return diags
+	}
+
+	return resourceRulesRead(ctx, d, m)
+}
+
+func resourceRulesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("APPSEC", "resourceRulesDelete")
+	logger.Debugf("in resourceRulesDelete")
+
+	configID, policyID, err := splitRulesID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Applying empty maps resets all the rules of the state.
+	previous := ruleIDKeys(d.Get("rule_actions").(map[string]interface{}), d.Get("condition_exceptions").(map[string]interface{}))
+	return updateRules(ctx, m, configID, policyID, nil, nil, previous)
+}
+
+// resourceRulesImport imports the actions and condition and exception information of all the rules of the
+// security policy.
+func resourceRulesImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	meta := akamai.Meta(m)
+	logger := meta.Log("APPSEC", "resourceRulesImport")
+	logger.Debugf("in resourceRulesImport")
+
+	configID, policyID, err := splitRulesID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	version, err := getLatestConfigVersion(ctx, configID, m)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := getRuleSettings(ctx, m, configID, version, policyID)
+	if err != nil {
+		return nil, err
+	}
+	wafMode, err := getWAFMode(ctx, m, configID, version, policyID)
+	if err != nil {
+		logger.Errorf("calling 'getWAFMode': %s", err.Error())
+		return nil, err
+	}
+
+	ruleActions := map[string]interface{}{}
+	conditionExceptions := map[string]interface{}{}
+	for ruleID, setting := range current {
+		key := strconv.Itoa(ruleID)
+		// Actions are read only in automatic mode, so they aren't imported.
+		if wafMode != AseAuto {
+			ruleActions[key] = setting.Action
+		}
+		if setting.ConditionException != "" {
+			conditionExceptions[key] = setting.ConditionException
+		}
+	}
+	if err := d.Set("rule_actions", ruleActions); err != nil {
+		return nil, fmt.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("condition_exceptions", conditionExceptions); err != nil {
+		return nil, fmt.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// applyRules updates the rules of the configuration and of the state that changed.
+func applyRules(ctx context.Context, d *schema.ResourceData, m interface{}, configID int, policyID string) diag.Diagnostics {
+	ruleActions, err := ruleIDMap(d.Get("rule_actions").(map[string]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	conditionExceptions, err := ruleIDMap(d.Get("condition_exceptions").(map[string]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	oldActions, _ := d.GetChange("rule_actions")
+	oldConditionExceptions, _ := d.GetChange("condition_exceptions")
+	previous := ruleIDKeys(oldActions.(map[string]interface{}), oldConditionExceptions.(map[string]interface{}))
+
+	return updateRules(ctx, m, configID, policyID, ruleActions, conditionExceptions, previous)
+}
+
+// updateRules reads the rules of the security policy in one call and updates the ones whose setting differs
+// from the desired one, RulesUpdateParallelism at a time. Rules of previous that are no longer managed are reset.
+func updateRules(ctx context.Context, m interface{}, configID int, policyID string, ruleActions, conditionExceptions map[int]string, previous []string) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	client := inst.Client(meta)
+	logger := meta.Log("APPSEC", "updateRules")
+
+	var diags diag.Diagnostics
+	err := updateModifiableConfigVersion(ctx, configID, "rules", m, func(version int) error {
+		wafMode, err := getWAFMode(ctx, m, configID, version, policyID)
+		if err != nil {
+			logger.Errorf("calling 'getWAFMode': %s", err.Error())
+			return err
+		}
+		if wafMode == AseAuto && len(ruleActions) > 0 {
+			return fmt.Errorf("rule_actions can't be set when the security policy's WAF mode is %s", AseAuto)
+		}
+
+		current, err := getRuleSettings(ctx, m, configID, version, policyID)
+		if err != nil {
+			return err
+		}
+		previousIDs := make([]int, 0, len(previous))
+		for _, key := range previous {
+			ruleID, err := strconv.Atoi(key)
+			if err != nil {
+				return fmt.Errorf("invalid rule ID %q", key)
+			}
+			previousIDs = append(previousIDs, ruleID)
+		}
+		updates, err := planRuleUpdates(current, ruleActions, conditionExceptions, previousIDs, wafMode == AseAuto)
+		if err != nil {
+			return err
+		}
+		logger.Debugf("updating %d of %d rules of configuration %d version %d policy %s", len(updates), len(current), configID, version, policyID)
+
+		diags = nil
+		var mu sync.Mutex
+		var conflict error
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, RulesUpdateParallelism)
+		for _, update := range updates {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(update ruleUpdate) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				err := updateRule(ctx, client, configID, version, policyID, update, wafMode == AseAuto)
+				if err == nil {
+					return
+				}
+				logger.Errorf("updating rule %d: %s", update.RuleID, err.Error())
+				mu.Lock()
+				defer mu.Unlock()
+				if isConfigVersionConflict(err) {
+					conflict = err
+				}
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  fmt.Sprintf("updating rule %d", update.RuleID),
+					Detail:   err.Error(),
+				})
+			}(update)
+		}
+		wg.Wait()
+
+		sort.Slice(diags, func(i, j int) bool { return diags[i].Summary < diags[j].Summary })
+		// A version conflict is retried with all the updates, which set the same values again.
+		return conflict
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// updateRule sets the action and condition and exception of a rule. In automatic mode, only the condition and
+// exception is set.
+func updateRule(ctx context.Context, client appsec.APPSEC, configID, version int, policyID string, update ruleUpdate, aseAuto bool) error {
+	if aseAuto {
+		ruleConditionException := appsec.RuleConditionException{}
+		if update.ConditionException != "" {
+			if err := json.Unmarshal([]byte(update.ConditionException), &ruleConditionException); err != nil {
+				return err
+			}
+		}
+		_, err := client.UpdateRuleConditionException(ctx, appsec.UpdateConditionExceptionRequest{
+			ConfigID:               configID,
+			Version:                version,
+			PolicyID:               policyID,
+			RuleID:                 update.RuleID,
+			Conditions:             ruleConditionException.Conditions,
+			Exception:              ruleConditionException.Exception,
+			AdvancedExceptionsList: ruleConditionException.AdvancedExceptionsList,
+		})
+		return err
+	}
+
+	updateRule := appsec.UpdateRuleRequest{
+		ConfigID: configID,
+		Version:  version,
+		PolicyID: policyID,
+		RuleID:   update.RuleID,
+		Action:   update.Action,
+	}
+	if update.ConditionException != "" {
+		updateRule.JsonPayloadRaw = json.RawMessage(update.ConditionException)
+	}
+	_, err := client.UpdateRule(ctx, updateRule)
+	return err
+}
+
+// planRuleUpdates returns the updates giving the rules their desired action and condition and exception, sorted by
+// rule ID. Rules without a desired action keep their current one. Rules of previous without a desired setting are
+// reset: their action is set to none, or their condition and exception is removed in automatic mode.
+func planRuleUpdates(current map[int]ruleSetting, ruleActions, conditionExceptions map[int]string, previous []int, aseAuto bool) ([]ruleUpdate, error) {
+	ids := map[int]bool{}
+	for ruleID := range ruleActions {
+		ids[ruleID] = true
+	}
+	for ruleID := range conditionExceptions {
+		ids[ruleID] = true
+	}
+	for _, ruleID := range previous {
+		if _, ok := ids[ruleID]; !ok {
+			ids[ruleID] = false
+		}
+	}
+
+	updates := make([]ruleUpdate, 0)
+	for ruleID, managed := range ids {
+		setting, ok := current[ruleID]
+		if !ok {
+			if managed {
+				return nil, fmt.Errorf("rule %d doesn't exist in the security policy", ruleID)
+			}
+			continue
+		}
+
+		desired := ruleSetting{Action: setting.Action}
+		switch {
+		case managed:
+			if action, ok := ruleActions[ruleID]; ok {
+				desired.Action = action
+			}
+			desired.ConditionException = conditionExceptions[ruleID]
+		case !aseAuto:
+			desired.Action = "none"
+		}
+		if err := validateActionAndConditionException(desired.Action, desired.ConditionException); err != nil {
+			return nil, fmt.Errorf("rule %d: %s", ruleID, err)
+		}
+
+		if (aseAuto || desired.Action == setting.Action) && ruleConditionExceptionsEqual(desired.ConditionException, setting.ConditionException) {
+			continue
+		}
+		updates = append(updates, ruleUpdate{RuleID: ruleID, ruleSetting: desired})
+	}
+
+	sort.Slice(updates, func(i, j int) bool { return updates[i].RuleID < updates[j].RuleID })
+	return updates, nil
+}
+
+// getRuleSettings returns the action and condition and exception of all the rules of the security policy.
+func getRuleSettings(ctx context.Context, m interface{}, configID, version int, policyID string) (map[int]ruleSetting, error) {
+	meta := akamai.Meta(m)
+	client := inst.Client(meta)
+	logger := meta.Log("APPSEC", "getRuleSettings")
+
+	rules, err := client.GetRules(ctx, appsec.GetRulesRequest{ConfigID: configID, Version: version, PolicyID: policyID})
+	if err != nil {
+		logger.Errorf("calling 'getRules': %s", err.Error())
+		return nil, err
+	}
+
+	settings := make(map[int]ruleSetting, len(rules.Rules))
+	for _, r := range rules.Rules {
+		setting := ruleSetting{Action: r.Action}
+		if r.ConditionException != nil && (r.ConditionException.Conditions != nil || r.ConditionException.Exception != nil || r.ConditionException.AdvancedExceptionsList != nil) {
+			jsonBody, err := json.Marshal(r.ConditionException)
+			if err != nil {
+				return nil, err
+			}
+			setting.ConditionException = string(jsonBody)
+		}
+		settings[r.ID] = setting
+	}
+	return settings, nil
+}
+
+// ruleConditionExceptionsEqual returns whether two JSON-formatted conditions and exceptions are equivalent,
+// treating an empty string as no condition and exception.
+func ruleConditionExceptionsEqual(old, new string) bool {
+	if old == new {
+		return true
+	}
+	if old == "" {
+		old = "{}"
+	}
+	if new == "" {
+		new = "{}"
+	}
+	return compareConditionExceptionJSON(old, new)
+}
+
+func splitRulesID(id string) (int, string, error) {
+	iDParts, err := splitID(id, 2, "configID:securityPolicyID")
+	if err != nil {
+		return 0, "", err
+	}
+	configID, err := strconv.Atoi(iDParts[0])
+	if err != nil {
+		return 0, "", err
+	}
+	return configID, iDParts[1], nil
+}
+
+// ruleIDMap converts a map attribute keyed by rule ID.
+func ruleIDMap(m map[string]interface{}) (map[int]string, error) {
+	result := make(map[int]string, len(m))
+	for key, value := range m {
+		ruleID, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule ID %q", key)
+		}
+		result[ruleID] = value.(string)
+	}
+	return result, nil
+}
+
+// ruleIDKeys returns the keys of the map attributes, sorted.
+func ruleIDKeys(maps ...map[string]interface{}) []string {
+	keys := make([]string, 0)
+	seen := map[string]bool{}
+	for _, m := range maps {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func validateRuleActions(v interface{}, path cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for key, value := range v.(map[string]interface{}) {
+		if _, err := strconv.Atoi(key); err != nil {
+			diags = append(diags, diag.Errorf("%q isn't a rule ID", key)...)
+			continue
+		}
+		diags = append(diags, ValidateActions(value, path)...)
+	}
+	return diags
+}
+
+func validateRuleConditionExceptions(v interface{}, _ cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for key, value := range v.(map[string]interface{}) {
+		if _, err := strconv.Atoi(key); err != nil {
+			diags = append(diags, diag.Errorf("%q isn't a rule ID", key)...)
+			continue
+		}
+		if !json.Valid([]byte(value.(string))) {
+			diags = append(diags, diag.Errorf("condition and exception of rule %s isn't valid JSON", key)...)
+		}
+	}
+	return diags
+}
@@ -0,0 +1,170 @@
+package appsec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAkamaiRules_res_basic(t *testing.T) {
+	t.Run("match by security policy ID", func(t *testing.T) {
+		client := &appsec.Mock{}
+
+		getRulesResponse := appsec.GetRulesResponse{}
+		err := json.Unmarshal(loadFixtureBytes("testdata/TestResRules/Rules.json"), &getRulesResponse)
+		require.NoError(t, err)
+
+		getRulesUpdatedResponse := appsec.GetRulesResponse{}
+		err = json.Unmarshal(loadFixtureBytes("testdata/TestResRules/RulesUpdated.json"), &getRulesUpdatedResponse)
+		require.NoError(t, err)
+
+		getWAFModeResponse := appsec.GetWAFModeResponse{}
+		err = json.Unmarshal(loadFixtureBytes("testdata/TestResWAFMode/WAFMode.json"), &getWAFModeResponse)
+		require.NoError(t, err)
+
+		config := appsec.GetConfigurationResponse{}
+		err = json.Unmarshal(loadFixtureBytes("testdata/TestResConfiguration/LatestConfiguration.json"), &config)
+		require.NoError(t, err)
+
+		client.On("GetConfiguration",
+			mock.Anything,
+			appsec.GetConfigurationRequest{ConfigID: 43253},
+		).Return(&config, nil)
+
+		client.On("GetWAFMode",
+			mock.Anything,
+			appsec.GetWAFModeRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230"},
+		).Return(&getWAFModeResponse, nil)
+
+		client.On("GetRules",
+			mock.Anything,
+			appsec.GetRulesRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230"},
+		).Return(&getRulesResponse, nil).Once()
+
+		client.On("GetRules",
+			mock.Anything,
+			appsec.GetRulesRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230"},
+		).Return(&getRulesUpdatedResponse, nil)
+
+		client.On("UpdateRule",
+			mock.Anything,
+			appsec.UpdateRuleRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", RuleID: 950001, Action: "deny"},
+		).Return(&appsec.UpdateRuleResponse{Action: "deny"}, nil).Once()
+
+		client.On("UpdateRule",
+			mock.Anything,
+			appsec.UpdateRuleRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", RuleID: 950002, Action: "alert",
+				JsonPayloadRaw: json.RawMessage(`{"exception":{"headerCookieOrParamValues":["abc"]}}`)},
+		).Return(&appsec.UpdateRuleResponse{Action: "alert"}, nil).Once()
+
+		for _, ruleID := range []int{950001, 950002} {
+			client.On("UpdateRule",
+				mock.Anything,
+				appsec.UpdateRuleRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", RuleID: ruleID, Action: "none"},
+			).Return(&appsec.UpdateRuleResponse{Action: "none"}, nil).Once()
+		}
+
+		useClient(client, func() {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:        true,
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResRules/match_by_id.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_appsec_rules.test", "id", "43253:AAAA_81230"),
+							resource.TestCheckResourceAttr("akamai_appsec_rules.test", "rule_actions.%", "2"),
+							resource.TestCheckResourceAttr("akamai_appsec_rules.test", "rule_actions.950001", "deny"),
+							resource.TestCheckResourceAttr("akamai_appsec_rules.test", "condition_exceptions.%", "1"),
+						),
+					},
+					{
+						ResourceName:      "akamai_appsec_rules.test",
+						ImportState:       true,
+						ImportStateId:     "43253:AAAA_81230",
+						ImportStateVerify: false,
+						ImportStateCheck: func(states []*terraform.InstanceState) error {
+							assert.Equal(t, "3", states[0].Attributes["rule_actions.%"])
+							assert.Equal(t, "2", states[0].Attributes["condition_exceptions.%"])
+							return nil
+						},
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
+
+func TestPlanRuleUpdates(t *testing.T) {
+	current := map[int]ruleSetting{
+		950001: {Action: "alert"},
+		950002: {Action: "none"},
+		950003: {Action: "deny", ConditionException: `{"exception":{"headerCookieOrParamValues":["xyz"]}}`},
+		950004: {Action: "alert"},
+	}
+
+	tests := map[string]struct {
+		ruleActions         map[int]string
+		conditionExceptions map[int]string
+		previous            []int
+		aseAuto             bool
+		expected            []ruleUpdate
+		withError           string
+	}{
+		"only changed rules are updated": {
+			ruleActions: map[int]string{950001: "deny", 950002: "none", 950003: "deny"},
+			conditionExceptions: map[int]string{
+				950003: `{ "exception": { "headerCookieOrParamValues": [ "xyz" ] } }`,
+			},
+			expected: []ruleUpdate{{RuleID: 950001, ruleSetting: ruleSetting{Action: "deny"}}},
+		},
+		"condition exception keeps the current action": {
+			conditionExceptions: map[int]string{950001: `{"exception":{"headerCookieOrParamValues":["abc"]}}`},
+			expected: []ruleUpdate{{RuleID: 950001, ruleSetting: ruleSetting{
+				Action:             "alert",
+				ConditionException: `{"exception":{"headerCookieOrParamValues":["abc"]}}`,
+			}}},
+		},
+		"removed rules are reset": {
+			ruleActions: map[int]string{950001: "alert"},
+			previous:    []int{950001, 950003, 950004},
+			expected: []ruleUpdate{
+				{RuleID: 950003, ruleSetting: ruleSetting{Action: "none"}},
+				{RuleID: 950004, ruleSetting: ruleSetting{Action: "none"}},
+			},
+		},
+		"removed rules only lose their condition exception in automatic mode": {
+			previous: []int{950001, 950003},
+			aseAuto:  true,
+			expected: []ruleUpdate{{RuleID: 950003, ruleSetting: ruleSetting{Action: "deny"}}},
+		},
+		"unknown rule": {
+			ruleActions: map[int]string{123: "deny"},
+			withError:   "rule 123 doesn't exist in the security policy",
+		},
+		"condition exception without action": {
+			conditionExceptions: map[int]string{950002: `{"exception":{"headerCookieOrParamValues":["abc"]}}`},
+			withError:           "rule 950002: action cannot be 'none' if non-empty condition/exception is supplied",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			updates, err := planRuleUpdates(current, test.ruleActions, test.conditionExceptions, test.previous, test.aseAuto)
+			if test.withError != "" {
+				assert.EqualError(t, err, test.withError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, updates)
+		})
+	}
+}
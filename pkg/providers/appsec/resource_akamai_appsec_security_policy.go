@@ -80,10 +80,6 @@ func resourceSecurityPolicyCreate(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "securityPolicy", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyname, err := tools.GetStringValue("security_policy_name", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -104,13 +100,18 @@ func resourceSecurityPolicyCreate(ctx context.Context, d *schema.ResourceData, m
 	if len(createfromsecuritypolicy) > 0 {
 		createSecurityPolicyClone := appsec.CreateSecurityPolicyCloneRequest{
 			ConfigID:                 configID,
-			Version:                  version,
 			CreateFromSecurityPolicy: createfromsecuritypolicy,
 			PolicyName:               policyname,
 			PolicyPrefix:             policyprefix,
 		}
 
-		spcr, err := client.CreateSecurityPolicyClone(ctx, createSecurityPolicyClone)
+		var spcr *appsec.CreateSecurityPolicyCloneResponse
+		err = updateModifiableConfigVersion(ctx, configID, "securityPolicy", m, func(version int) error {
+			createSecurityPolicyClone.Version = version
+			created, err := client.CreateSecurityPolicyClone(ctx, createSecurityPolicyClone)
+			spcr = created
+			return err
+		})
 		if err != nil {
 			logger.Errorf("calling 'createSecurityPolicyClone': %s", err.Error())
 			return diag.FromErr(err)
@@ -121,13 +122,18 @@ func resourceSecurityPolicyCreate(ctx context.Context, d *schema.ResourceData, m
 	} else {
 		createSecurityPolicy := appsec.CreateSecurityPolicyRequest{
 			ConfigID:        configID,
-			Version:         version,
 			PolicyName:      policyname,
 			DefaultSettings: defaultSettings,
 			PolicyPrefix:    policyprefix,
 		}
 
-		spcr, err := client.CreateSecurityPolicy(ctx, createSecurityPolicy)
+		var spcr *appsec.CreateSecurityPolicyResponse
+		err = updateModifiableConfigVersion(ctx, configID, "securityPolicy", m, func(version int) error {
+			createSecurityPolicy.Version = version
+			created, err := client.CreateSecurityPolicy(ctx, createSecurityPolicy)
+			spcr = created
+			return err
+		})
 		if err != nil {
 			logger.Errorf("calling 'createSecurityPolicy': %s", err.Error())
 			return diag.FromErr(err)
@@ -213,10 +219,6 @@ func resourceSecurityPolicyUpdate(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "securityPolicy", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	securityPolicyID := iDParts[1]
 
 	// Prevent an update call with the same policy name since API will reject it.
@@ -231,12 +233,15 @@ func resourceSecurityPolicyUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	updateSecurityPolicy := appsec.UpdateSecurityPolicyRequest{
 		ConfigID:   configID,
-		Version:    version,
 		PolicyID:   securityPolicyID,
 		PolicyName: policyname,
 	}
 
-	_, err = client.UpdateSecurityPolicy(ctx, updateSecurityPolicy)
+	err = updateModifiableConfigVersion(ctx, configID, "securityPolicy", m, func(version int) error {
+		updateSecurityPolicy.Version = version
+		_, err := client.UpdateSecurityPolicy(ctx, updateSecurityPolicy)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateSecurityPolicy': %s", err.Error())
 		return diag.FromErr(err)
@@ -260,23 +265,22 @@ func resourceSecurityPolicyDelete(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "securityPolicy", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	securityPolicyID := iDParts[1]
 
-	latestVersion, err := getLatestConfigVersion(ctx, configID, m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	stagingVersion, productionVersion, err := getActiveConfigVersions(ctx, configID, m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	if latestVersion == stagingVersion || latestVersion == productionVersion {
-		logger.Debugf("latest version %d is active, DeleteContext is a no-op", latestVersion)
-	} else {
+	err = updateModifiableConfigVersion(ctx, configID, "securityPolicy", m, func(version int) error {
+		latestVersion, err := getLatestConfigVersion(ctx, configID, m)
+		if err != nil {
+			return err
+		}
+		stagingVersion, productionVersion, err := getActiveConfigVersions(ctx, configID, m)
+		if err != nil {
+			return err
+		}
+		if latestVersion == stagingVersion || latestVersion == productionVersion {
+			logger.Debugf("latest version %d is active, DeleteContext is a no-op", latestVersion)
+			return nil
+		}
+
 		removeSecurityPolicy := appsec.RemoveSecurityPolicyRequest{
 			ConfigID: configID,
 			Version:  version,
@@ -286,9 +290,11 @@ func resourceSecurityPolicyDelete(ctx context.Context, d *schema.ResourceData, m
 		_, err = client.RemoveSecurityPolicy(ctx, removeSecurityPolicy)
 		if err != nil {
 			logger.Errorf("calling 'removeSecurityPolicy': %s", err.Error())
-			return diag.FromErr(err)
 		}
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
-
 	return nil
 }
@@ -0,0 +1,477 @@
+package appsec
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// appsec v1
+//
+// https://techdocs.akamai.com/application-security/reference/api
+func resourceSecurityPolicyFromTemplate() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSecurityPolicyFromTemplateCreate,
+		ReadContext:   resourceSecurityPolicyFromTemplateRead,
+		UpdateContext: resourceSecurityPolicyFromTemplateUpdate,
+		DeleteContext: resourceSecurityPolicyFromTemplateDelete,
+		CustomizeDiff: planSecurityPolicyFromTemplate,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"config_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Unique identifier of the security configuration",
+			},
+			"security_policy_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the security policy",
+			},
+			"security_policy_prefix": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(regexp.MustCompile(`^[A-Za-z0-9]{4}$`), "must be four alphanumeric characters")),
+				Description:      "Four-character alphanumeric string prefix used in creating the security policy ID",
+			},
+			"template": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+				DiffSuppressFunc: suppressEquivalentJSONDiffsGeneric,
+				Description:      "JSON-formatted template of the security policy",
+			},
+			"variables": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Values of the variables of the template, by name",
+			},
+			"adopt_existing_objects": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether rate policies and reputation profiles of the template that already exist in the configuration are used and updated",
+			},
+			"security_policy_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier of the security policy",
+			},
+			"template_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the template the security policy was created from",
+			},
+			"template_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Version of the template the security policy was created from",
+			},
+			"drift": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Differences between the security policy and its template",
+			},
+			"created_rate_policies": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the rate policies created for the security policy",
+			},
+			"created_reputation_profiles": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the reputation profiles created for the security policy",
+			},
+		},
+	}
+}
+
+// policyTemplateCreatedAttributes maps the kinds of objects created for a security policy to the
+// attributes listing them.
+var policyTemplateCreatedAttributes = map[string]string{
+	docRatePolicy:        "created_rate_policies",
+	docReputationProfile: "created_reputation_profiles",
+}
+
+// planSecurityPolicyFromTemplate checks that the template renders with the given variables, and
+// plans applying the template again when it changed or the security policy drifted from it.
+func planSecurityPolicyFromTemplate(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("template") || !d.NewValueKnown("variables") {
+		for _, key := range []string{"template_name", "template_version", "drift"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	template, err := renderPolicyTemplate(d.Get("template").(string), policyTemplateVariables(d.Get("variables")))
+	if err != nil {
+		return err
+	}
+	if err := d.SetNew("template_name", template.Name); err != nil {
+		return err
+	}
+	if err := d.SetNew("template_version", template.Version); err != nil {
+		return err
+	}
+	if d.Id() != "" && (d.HasChanges("template", "variables", "security_policy_name") || len(d.Get("drift").([]interface{})) > 0) {
+		for _, key := range []string{"drift", "created_rate_policies", "created_reputation_profiles"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func resourceSecurityPolicyFromTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("APPSEC", "resourceSecurityPolicyFromTemplateCreate")
+	logger.Debugf("in resourceSecurityPolicyFromTemplateCreate")
+
+	configID, err := tools.GetIntValue("config_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	policyID, err := applySecurityPolicyFromTemplate(ctx, d, m, configID, "")
+	if policyID != "" {
+		d.SetId(fmt.Sprintf("%d:%s", configID, policyID))
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceSecurityPolicyFromTemplateRead(ctx, d, m)
+}
+
+func resourceSecurityPolicyFromTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	client := inst.Client(meta)
+	logger := meta.Log("APPSEC", "resourceSecurityPolicyFromTemplateRead")
+	logger.Debugf("in resourceSecurityPolicyFromTemplateRead")
+
+	configID, policyID, err := splitSecurityPolicyFromTemplateID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	version, err := getLatestConfigVersion(ctx, configID, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	export, err := client.GetExportConfiguration(ctx, appsec.GetExportConfigurationRequest{ConfigID: configID, Version: version})
+	if err != nil {
+		logger.Errorf("calling 'getExportConfiguration': %s", err.Error())
+		return diag.FromErr(err)
+	}
+	policyName, found := "", false
+	for _, p := range export.SecurityPolicies {
+		if p.ID == policyID {
+			policyName, found = p.Name, true
+		}
+	}
+	if !found {
+		logger.Warnf("security policy %s no longer exists in configuration %d version %d", policyID, configID, version)
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("config_id", configID); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("security_policy_id", policyID); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("security_policy_name", policyName); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("security_policy_prefix", strings.SplitN(policyID, "_", 2)[0]); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+
+	// Imported security policies have no template until one is configured.
+	template := d.Get("template").(string)
+	if template == "" {
+		return nil
+	}
+	t, err := renderPolicyTemplate(template, policyTemplateVariables(d.Get("variables")))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	plan, err := planPolicyTemplate(t, export, policyID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("template_name", t.Name); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("template_version", t.Version); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("drift", plan.Drift); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
+	}
+
+	return nil
+}
+
+func resourceSecurityPolicyFromTemplateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("APPSEC", "resourceSecurityPolicyFromTemplateUpdate")
+	logger.Debugf("in resourceSecurityPolicyFromTemplateUpdate")
+
+	configID, policyID, err := splitSecurityPolicyFromTemplateID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if _, err := applySecurityPolicyFromTemplate(ctx, d, m, configID, policyID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceSecurityPolicyFromTemplateRead(ctx, d, m)
+}
+
+func resourceSecurityPolicyFromTemplateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	client := inst.Client(meta)
+	logger := meta.Log("APPSEC", "resourceSecurityPolicyFromTemplateDelete")
+	logger.Debugf("in resourceSecurityPolicyFromTemplateDelete")
+
+	configID, policyID, err := splitSecurityPolicyFromTemplateID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The rate policies and reputation profiles created for the security policy are removed with it,
+	// unless other security policies use them.
+	created := policyTemplateCreatedObjects(d)
+	err = updateModifiableConfigVersion(ctx, configID, "securityPolicyFromTemplate", m, func(version int) error {
+		export, err := client.GetExportConfiguration(ctx, appsec.GetExportConfigurationRequest{ConfigID: configID, Version: version})
+		if err != nil {
+			logger.Errorf("calling 'getExportConfiguration': %s", err.Error())
+			return err
+		}
+		current, err := newConfigurationDocument(export)
+		if err != nil {
+			return err
+		}
+		var policyName string
+		for name, id := range current.policies {
+			if id == policyID {
+				policyName = name
+			}
+		}
+
+		if _, err := client.RemoveSecurityPolicy(ctx, appsec.RemoveSecurityPolicyRequest{ConfigID: configID, Version: version, PolicyID: policyID}); err != nil {
+			logger.Errorf("calling 'removeSecurityPolicy': %s", err.Error())
+			return err
+		}
+		if err := newDocumentApplier(client, configID, version, current, current).apply(ctx, policyTemplateRemovals(current, policyName, created)); err != nil {
+			logger.Errorf("removing template objects: %s", err.Error())
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// applySecurityPolicyFromTemplate creates the security policy when policyID is empty, and changes
+// the parts of the policy that differ from its template. It returns the ID of the policy.
+func applySecurityPolicyFromTemplate(ctx context.Context, d *schema.ResourceData, m interface{}, configID int, policyID string) (_ string, err error) {
+	meta := akamai.Meta(m)
+	client := inst.Client(meta)
+	logger := meta.Log("APPSEC", "applySecurityPolicyFromTemplate")
+
+	policyName, err := tools.GetStringValue("security_policy_name", d)
+	if err != nil {
+		return "", err
+	}
+	policyPrefix, err := tools.GetStringValue("security_policy_prefix", d)
+	if err != nil {
+		return "", err
+	}
+	template, err := renderPolicyTemplate(d.Get("template").(string), policyTemplateVariables(d.Get("variables")))
+	if err != nil {
+		return "", err
+	}
+	adopt := d.Get("adopt_existing_objects").(bool)
+
+	// The rate policies and reputation profiles created are recorded even when applying the template
+	// fails, so that they're removed with the security policy.
+	created := policyTemplateCreatedObjects(d)
+	defer func() {
+		for kind, key := range policyTemplateCreatedAttributes {
+			names := make([]string, 0, len(created[kind]))
+			for name := range created[kind] {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			if setErr := d.Set(key, names); setErr != nil && err == nil {
+				err = fmt.Errorf("%s: %s", tools.ErrValueSet, setErr.Error())
+			}
+		}
+	}()
+
+	// The policy is created once, even when a version conflict makes the template be applied again.
+	err = updateModifiableConfigVersion(ctx, configID, "securityPolicyFromTemplate", m, func(version int) error {
+		export, err := client.GetExportConfiguration(ctx, appsec.GetExportConfigurationRequest{ConfigID: configID, Version: version})
+		if err != nil {
+			logger.Errorf("calling 'getExportConfiguration': %s", err.Error())
+			return err
+		}
+		current, err := newConfigurationDocument(export)
+		if err != nil {
+			return err
+		}
+		if existing := existingPolicyTemplateObjects(template, current, created); len(existing) > 0 && !adopt {
+			return fmt.Errorf("%s of template %s already exist in configuration %d, set adopt_existing_objects to use them for the security policy",
+				strings.Join(existing, ", "), template.Name, configID)
+		}
+
+		if policyID == "" {
+			policy, err := client.CreateSecurityPolicy(ctx, appsec.CreateSecurityPolicyRequest{
+				ConfigID:        configID,
+				Version:         version,
+				PolicyName:      policyName,
+				PolicyPrefix:    policyPrefix,
+				DefaultSettings: true,
+			})
+			if err != nil {
+				logger.Errorf("calling 'createSecurityPolicy': %s", err.Error())
+				return err
+			}
+			policyID = policy.PolicyID
+
+			if export, err = client.GetExportConfiguration(ctx, appsec.GetExportConfigurationRequest{ConfigID: configID, Version: version}); err != nil {
+				logger.Errorf("calling 'getExportConfiguration': %s", err.Error())
+				return err
+			}
+		}
+
+		plan, err := planPolicyTemplate(template, export, policyID)
+		if err != nil {
+			return err
+		}
+		logger.Debugf("applying template %s version %s to security policy %s of configuration %d version %d with %d differences",
+			template.Name, template.Version, policyID, configID, version, len(plan.Drift))
+
+		if plan.PolicyName != policyName {
+			_, err := client.UpdateSecurityPolicy(ctx, appsec.UpdateSecurityPolicyRequest{ConfigID: configID, Version: version, PolicyID: policyID, PolicyName: policyName})
+			if err != nil {
+				logger.Errorf("calling 'updateSecurityPolicy': %s", err.Error())
+				return err
+			}
+		}
+		// Protections are updated first, since rate policy and reputation profile actions
+		// require the corresponding controls.
+		if plan.Protections != nil {
+			request := *plan.Protections
+			request.ConfigID, request.Version, request.PolicyID = configID, version, policyID
+			if _, err := client.UpdatePolicyProtections(ctx, request); err != nil {
+				logger.Errorf("calling 'updatePolicyProtections': %s", err.Error())
+				return err
+			}
+		}
+		applier := newDocumentApplier(client, configID, version, plan.current, plan.current)
+		err = applier.apply(ctx, plan.Changes)
+		for kind, ids := range map[string]map[string]int{docRatePolicy: applier.ratePolicies, docReputationProfile: applier.reputationProfiles} {
+			for name := range ids {
+				if _, ok := plan.current.objects[documentObject{Kind: kind, Name: name}.key()]; !ok {
+					created[kind][name] = true
+				}
+			}
+		}
+		if err != nil {
+			logger.Errorf("applying template: %s", err.Error())
+			return err
+		}
+		if !plan.RulesDrift {
+			return nil
+		}
+
+		wafMode, err := getWAFMode(ctx, m, configID, version, policyID)
+		if err != nil {
+			logger.Errorf("calling 'getWAFMode': %s", err.Error())
+			return err
+		}
+		if wafMode == AseAuto && len(plan.RuleActions) > 0 {
+			return fmt.Errorf("template %s sets rule actions, which can't be set when the security policy's WAF mode is %s", template.Name, AseAuto)
+		}
+		settings, err := getRuleSettings(ctx, m, configID, version, policyID)
+		if err != nil {
+			return err
+		}
+		updates, err := planRuleUpdates(settings, plan.RuleActions, plan.ConditionExceptions, nil, wafMode == AseAuto)
+		if err != nil {
+			return err
+		}
+		for _, update := range updates {
+			if err := updateRule(ctx, client, configID, version, policyID, update, wafMode == AseAuto); err != nil {
+				logger.Errorf("updating rule %d: %s", update.RuleID, err.Error())
+				return fmt.Errorf("updating rule %d: %w", update.RuleID, err)
+			}
+		}
+		return nil
+	})
+
+	return policyID, err
+}
+
+// policyTemplateCreatedObjects returns the names of the rate policies and reputation profiles
+// created for the security policy, by kind.
+func policyTemplateCreatedObjects(d *schema.ResourceData) map[string]map[string]bool {
+	created := make(map[string]map[string]bool, len(policyTemplateCreatedAttributes))
+	for kind, key := range policyTemplateCreatedAttributes {
+		created[kind] = map[string]bool{}
+		for _, name := range d.Get(key).([]interface{}) {
+			created[kind][name.(string)] = true
+		}
+	}
+	return created
+}
+
+// policyTemplateVariables converts the variables attribute.
+func policyTemplateVariables(v interface{}) map[string]string {
+	variables := map[string]string{}
+	for name, value := range v.(map[string]interface{}) {
+		variables[name] = value.(string)
+	}
+	return variables
+}
+
+func splitSecurityPolicyFromTemplateID(id string) (int, string, error) {
+	iDParts, err := splitID(id, 2, "configID:securityPolicyID")
+	if err != nil {
+		return 0, "", err
+	}
+	configID, err := strconv.Atoi(iDParts[0])
+	if err != nil {
+		return 0, "", err
+	}
+	return configID, iDParts[1], nil
+}
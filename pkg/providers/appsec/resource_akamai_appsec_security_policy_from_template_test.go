@@ -0,0 +1,177 @@
+package appsec
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAkamaiSecurityPolicyFromTemplate_res_basic(t *testing.T) {
+	t.Run("match by SecurityPolicyFromTemplate ID", func(t *testing.T) {
+		client := &appsec.Mock{}
+
+		config := appsec.GetConfigurationResponse{}
+		err := json.Unmarshal(loadFixtureBytes("testdata/TestResConfiguration/LatestConfiguration.json"), &config)
+		require.NoError(t, err)
+
+		getRulesResponse := appsec.GetRulesResponse{}
+		err = json.Unmarshal(loadFixtureBytes("testdata/TestResRules/Rules.json"), &getRulesResponse)
+		require.NoError(t, err)
+
+		getWAFModeResponse := appsec.GetWAFModeResponse{}
+		err = json.Unmarshal(loadFixtureBytes("testdata/TestResWAFMode/WAFMode.json"), &getWAFModeResponse)
+		require.NoError(t, err)
+
+		created := appsec.GetExportConfigurationResponse{}
+		err = json.Unmarshal(loadFixtureBytes("testdata/TestResSecurityPolicyFromTemplate/Created.json"), &created)
+		require.NoError(t, err)
+
+		applied := appsec.GetExportConfigurationResponse{}
+		err = json.Unmarshal(loadFixtureBytes("testdata/TestResSecurityPolicyFromTemplate/Applied.json"), &applied)
+		require.NoError(t, err)
+
+		client.On("GetConfiguration",
+			mock.Anything,
+			appsec.GetConfigurationRequest{ConfigID: 43253},
+		).Return(&config, nil)
+
+		client.On("CreateSecurityPolicy",
+			mock.Anything,
+			appsec.CreateSecurityPolicyRequest{ConfigID: 43253, Version: 7, PolicyName: "Web", PolicyPrefix: "AAAA", DefaultSettings: true},
+		).Return(&appsec.CreateSecurityPolicyResponse{PolicyID: "AAAA_81230", PolicyName: "Web"}, nil).Once()
+
+		client.On("GetExportConfiguration",
+			mock.Anything,
+			appsec.GetExportConfigurationRequest{ConfigID: 43253, Version: 7},
+		).Return(&created, nil).Twice()
+
+		client.On("GetExportConfiguration",
+			mock.Anything,
+			appsec.GetExportConfigurationRequest{ConfigID: 43253, Version: 7},
+		).Return(&applied, nil)
+
+		client.On("UpdatePolicyProtections",
+			mock.Anything,
+			appsec.UpdatePolicyProtectionsRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230",
+				ApplyApplicationLayerControls: true, ApplyRateControls: true, ApplyReputationControls: true},
+		).Return(&appsec.PolicyProtectionsResponse{}, nil).Once()
+
+		client.On("CreateRatePolicy",
+			mock.Anything,
+			appsec.CreateRatePolicyRequest{ConfigID: 43253, ConfigVersion: 7, JsonPayloadRaw: json.RawMessage(
+				`{"averageThreshold":50,"burstThreshold":100,"clientIdentifier":"ip","matchType":"path","name":"Origin Burst","pathMatchType":"Custom","pathUriPositiveMatch":true,"requestType":"ClientRequest","sameActionOnIpv6":true,"type":"WAF"}`)},
+		).Return(&appsec.CreateRatePolicyResponse{ID: 134}, nil).Once()
+
+		client.On("UpdateReputationProfile",
+			mock.Anything,
+			appsec.UpdateReputationProfileRequest{ConfigID: 43253, ConfigVersion: 7, ReputationProfileId: 12, JsonPayloadRaw: json.RawMessage(
+				`{"context":"WEBSCRP","name":"Scrapers","sharedIpHandling":"NON_SHARED","threshold":7}`)},
+		).Return(&appsec.UpdateReputationProfileResponse{}, nil).Once()
+
+		client.On("UpdateRatePolicyAction",
+			mock.Anything,
+			appsec.UpdateRatePolicyActionRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", RatePolicyID: 134, Ipv4Action: "alert", Ipv6Action: "alert"},
+		).Return(&appsec.UpdateRatePolicyActionResponse{}, nil).Once()
+
+		client.On("UpdateReputationProfileAction",
+			mock.Anything,
+			appsec.UpdateReputationProfileActionRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", ReputationProfileID: 12, Action: "alert"},
+		).Return(&appsec.UpdateReputationProfileActionResponse{}, nil).Once()
+
+		client.On("GetWAFMode",
+			mock.Anything,
+			appsec.GetWAFModeRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230"},
+		).Return(&getWAFModeResponse, nil)
+
+		client.On("GetRules",
+			mock.Anything,
+			appsec.GetRulesRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230"},
+		).Return(&getRulesResponse, nil).Once()
+
+		client.On("UpdateRule",
+			mock.Anything,
+			appsec.UpdateRuleRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", RuleID: 950001, Action: "deny"},
+		).Return(&appsec.UpdateRuleResponse{Action: "deny"}, nil).Once()
+
+		client.On("UpdateRule",
+			mock.Anything,
+			appsec.UpdateRuleRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", RuleID: 950002, Action: "alert",
+				JsonPayloadRaw: json.RawMessage(`{"exception":{"headerCookieOrParamValues":["session"]}}`)},
+		).Return(&appsec.UpdateRuleResponse{Action: "alert"}, nil).Once()
+
+		client.On("RemoveSecurityPolicy",
+			mock.Anything,
+			appsec.RemoveSecurityPolicyRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230"},
+		).Return(&appsec.RemoveSecurityPolicyResponse{}, nil).Once()
+
+		client.On("RemoveRatePolicy",
+			mock.Anything,
+			appsec.RemoveRatePolicyRequest{ConfigID: 43253, ConfigVersion: 7, RatePolicyID: 134},
+		).Return(&appsec.RemoveRatePolicyResponse{}, nil).Once()
+
+		useClient(client, func() {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:        true,
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResSecurityPolicyFromTemplate/match_by_id.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_appsec_security_policy_from_template.test", "id", "43253:AAAA_81230"),
+							resource.TestCheckResourceAttr("akamai_appsec_security_policy_from_template.test", "security_policy_id", "AAAA_81230"),
+							resource.TestCheckResourceAttr("akamai_appsec_security_policy_from_template.test", "template_name", "standard-web"),
+							resource.TestCheckResourceAttr("akamai_appsec_security_policy_from_template.test", "template_version", "1.2.0"),
+							resource.TestCheckResourceAttr("akamai_appsec_security_policy_from_template.test", "drift.#", "0"),
+							resource.TestCheckResourceAttr("akamai_appsec_security_policy_from_template.test", "created_rate_policies.#", "1"),
+							resource.TestCheckResourceAttr("akamai_appsec_security_policy_from_template.test", "created_rate_policies.0", "Origin Burst"),
+							resource.TestCheckResourceAttr("akamai_appsec_security_policy_from_template.test", "created_reputation_profiles.#", "0"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+	t.Run("existing objects aren't adopted", func(t *testing.T) {
+		client := &appsec.Mock{}
+
+		config := appsec.GetConfigurationResponse{}
+		err := json.Unmarshal(loadFixtureBytes("testdata/TestResConfiguration/LatestConfiguration.json"), &config)
+		require.NoError(t, err)
+
+		created := appsec.GetExportConfigurationResponse{}
+		err = json.Unmarshal(loadFixtureBytes("testdata/TestResSecurityPolicyFromTemplate/Created.json"), &created)
+		require.NoError(t, err)
+
+		client.On("GetConfiguration",
+			mock.Anything,
+			appsec.GetConfigurationRequest{ConfigID: 43253},
+		).Return(&config, nil)
+
+		client.On("GetExportConfiguration",
+			mock.Anything,
+			appsec.GetExportConfigurationRequest{ConfigID: 43253, Version: 7},
+		).Return(&created, nil).Once()
+
+		useClient(client, func() {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:        true,
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config:      loadFixtureString("testdata/TestResSecurityPolicyFromTemplate/without_adoption.tf"),
+						ExpectError: regexp.MustCompile(`reputation profile "Scrapers" of template standard-web already exist in configuration 43253`),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
@@ -56,10 +56,6 @@ func resourceSecurityPolicyRenameCreate(ctx context.Context, d *schema.ResourceD
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "securityPolicyRename", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -71,12 +67,15 @@ func resourceSecurityPolicyRenameCreate(ctx context.Context, d *schema.ResourceD
 
 	createSecurityPolicy := appsec.UpdateSecurityPolicyRequest{
 		ConfigID:   configID,
-		Version:    version,
 		PolicyID:   policyID,
 		PolicyName: policyname,
 	}
 
-	_, err = client.UpdateSecurityPolicy(ctx, createSecurityPolicy)
+	err = updateModifiableConfigVersion(ctx, configID, "securityPolicyRename", m, func(version int) error {
+		createSecurityPolicy.Version = version
+		_, err := client.UpdateSecurityPolicy(ctx, createSecurityPolicy)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'createSecurityPolicy': %s", err.Error())
 		return diag.FromErr(err)
@@ -145,10 +144,6 @@ func resourceSecurityPolicyRenameUpdate(ctx context.Context, d *schema.ResourceD
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "securityPolicyRename", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	policyname, err := tools.GetStringValue("security_policy_name", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
@@ -157,12 +152,15 @@ func resourceSecurityPolicyRenameUpdate(ctx context.Context, d *schema.ResourceD
 
 	updateSecurityPolicy := appsec.UpdateSecurityPolicyRequest{
 		ConfigID:   configID,
-		Version:    version,
 		PolicyID:   policyID,
 		PolicyName: policyname,
 	}
 
-	_, err = client.UpdateSecurityPolicy(ctx, updateSecurityPolicy)
+	err = updateModifiableConfigVersion(ctx, configID, "securityPolicyRename", m, func(version int) error {
+		updateSecurityPolicy.Version = version
+		_, err := client.UpdateSecurityPolicy(ctx, updateSecurityPolicy)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateSecurityPolicy': %s", err.Error())
 		return diag.FromErr(err)
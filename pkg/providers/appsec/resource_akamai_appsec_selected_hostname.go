@@ -116,17 +116,16 @@ func resourceSelectedHostnameCreate(ctx context.Context, d *schema.ResourceData,
 		newhostnames = append(newhostnames, hostname)
 	}
 
-	version, err = getModifiableConfigVersion(ctx, configID, "selectedHostname", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	updateSelectedHostnames := appsec.UpdateSelectedHostnamesRequest{
 		ConfigID:     configID,
-		Version:      version,
 		HostnameList: newhostnames,
 	}
 
-	_, err = client.UpdateSelectedHostnames(ctx, updateSelectedHostnames)
+	err = updateModifiableConfigVersion(ctx, configID, "selectedHostname", m, func(version int) error {
+		updateSelectedHostnames.Version = version
+		_, err := client.UpdateSelectedHostnames(ctx, updateSelectedHostnames)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'UpdateSelectedHostnames': %s", err.Error())
 		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
@@ -264,17 +263,16 @@ func resourceSelectedHostnameUpdate(ctx context.Context, d *schema.ResourceData,
 		newhostnames = append(newhostnames, hostname)
 	}
 
-	version, err = getModifiableConfigVersion(ctx, configID, "selectedHostname", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	updateSelectedHostnames := appsec.UpdateSelectedHostnamesRequest{
 		ConfigID:     configID,
-		Version:      version,
 		HostnameList: newhostnames,
 	}
 
-	_, err = client.UpdateSelectedHostnames(ctx, updateSelectedHostnames)
+	err = updateModifiableConfigVersion(ctx, configID, "selectedHostname", m, func(version int) error {
+		updateSelectedHostnames.Version = version
+		_, err := client.UpdateSelectedHostnames(ctx, updateSelectedHostnames)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'UpdateSelectedHostnames': %s", err.Error())
 		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
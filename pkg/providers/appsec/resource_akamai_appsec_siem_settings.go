@@ -75,10 +75,6 @@ func resourceSiemSettingsCreate(ctx context.Context, d *schema.ResourceData, m i
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "siemSetting", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	enableSiem, err := tools.GetBoolValue("enable_siem", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -107,7 +103,6 @@ func resourceSiemSettingsCreate(ctx context.Context, d *schema.ResourceData, m i
 
 	createSiemSettings := appsec.UpdateSiemSettingsRequest{
 		ConfigID:                configID,
-		Version:                 version,
 		EnableSiem:              enableSiem,
 		EnableForAllPolicies:    enableForAllPolicies,
 		FirewallPolicyIds:       spIDs,
@@ -115,7 +110,11 @@ func resourceSiemSettingsCreate(ctx context.Context, d *schema.ResourceData, m i
 		SiemDefinitionID:        siemID,
 	}
 
-	_, err = client.UpdateSiemSettings(ctx, createSiemSettings)
+	err = updateModifiableConfigVersion(ctx, configID, "siemSetting", m, func(version int) error {
+		createSiemSettings.Version = version
+		_, err := client.UpdateSiemSettings(ctx, createSiemSettings)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'createSiemSettings': %s", err.Error())
 		return diag.FromErr(err)
@@ -184,10 +183,6 @@ func resourceSiemSettingsUpdate(ctx context.Context, d *schema.ResourceData, m i
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "siemSetting", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	enableSiem, err := tools.GetBoolValue("enable_siem", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return diag.FromErr(err)
@@ -216,7 +211,6 @@ func resourceSiemSettingsUpdate(ctx context.Context, d *schema.ResourceData, m i
 
 	updateSiemSettings := appsec.UpdateSiemSettingsRequest{
 		ConfigID:                configID,
-		Version:                 version,
 		EnableSiem:              enableSiem,
 		EnableForAllPolicies:    enableForAllPolicies,
 		FirewallPolicyIds:       spIDs,
@@ -224,7 +218,11 @@ func resourceSiemSettingsUpdate(ctx context.Context, d *schema.ResourceData, m i
 		SiemDefinitionID:        siemID,
 	}
 
-	_, err = client.UpdateSiemSettings(ctx, updateSiemSettings)
+	err = updateModifiableConfigVersion(ctx, configID, "siemSetting", m, func(version int) error {
+		updateSiemSettings.Version = version
+		_, err := client.UpdateSiemSettings(ctx, updateSiemSettings)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateSiemSettings': %s", err.Error())
 		return diag.FromErr(err)
@@ -243,18 +241,17 @@ func resourceSiemSettingsDelete(ctx context.Context, d *schema.ResourceData, m i
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "siemSetting", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 
 	removeSiemSettings := appsec.RemoveSiemSettingsRequest{
 		ConfigID:   configID,
-		Version:    version,
 		EnableSiem: false,
 	}
 
-	_, err = client.RemoveSiemSettings(ctx, removeSiemSettings)
+	err = updateModifiableConfigVersion(ctx, configID, "siemSetting", m, func(version int) error {
+		removeSiemSettings.Version = version
+		_, err := client.RemoveSiemSettings(ctx, removeSiemSettings)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateSiemSettings': %s", err.Error())
 		return diag.FromErr(err)
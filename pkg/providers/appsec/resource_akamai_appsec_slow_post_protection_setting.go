@@ -82,10 +82,6 @@ func resourceSlowPostProtectionSettingCreate(ctx context.Context, d *schema.Reso
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "slowpostSettings", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -109,7 +105,6 @@ func resourceSlowPostProtectionSettingCreate(ctx context.Context, d *schema.Reso
 
 	createSlowPostProtectionSetting := appsec.UpdateSlowPostProtectionSettingRequest{
 		ConfigID: configID,
-		Version:  version,
 		PolicyID: policyID,
 		Action:   slowrateaction,
 	}
@@ -117,7 +112,11 @@ func resourceSlowPostProtectionSettingCreate(ctx context.Context, d *schema.Reso
 	createSlowPostProtectionSetting.SlowRateThreshold.Period = slowratethresholdperiod
 	createSlowPostProtectionSetting.DurationThreshold.Timeout = durationthresholdtimeout
 
-	_, err = client.UpdateSlowPostProtectionSetting(ctx, createSlowPostProtectionSetting)
+	err = updateModifiableConfigVersion(ctx, configID, "slowpostSettings", m, func(version int) error {
+		createSlowPostProtectionSetting.Version = version
+		_, err := client.UpdateSlowPostProtectionSetting(ctx, createSlowPostProtectionSetting)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateSlowPostProtectionSetting': %s", err.Error())
 		return diag.FromErr(err)
@@ -199,10 +198,6 @@ func resourceSlowPostProtectionSettingUpdate(ctx context.Context, d *schema.Reso
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "slowpostSettings", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	slowrateaction, err := tools.GetStringValue("slow_rate_action", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
@@ -223,7 +218,6 @@ func resourceSlowPostProtectionSettingUpdate(ctx context.Context, d *schema.Reso
 
 	updateSlowPostProtectionSetting := appsec.UpdateSlowPostProtectionSettingRequest{
 		ConfigID: configID,
-		Version:  version,
 		PolicyID: policyID,
 		Action:   slowrateaction,
 	}
@@ -231,7 +225,11 @@ func resourceSlowPostProtectionSettingUpdate(ctx context.Context, d *schema.Reso
 	updateSlowPostProtectionSetting.SlowRateThreshold.Period = slowratethresholdperiod
 	updateSlowPostProtectionSetting.DurationThreshold.Timeout = durationthresholdtimeout
 
-	_, err = client.UpdateSlowPostProtectionSetting(ctx, updateSlowPostProtectionSetting)
+	err = updateModifiableConfigVersion(ctx, configID, "slowpostSettings", m, func(version int) error {
+		updateSlowPostProtectionSetting.Version = version
+		_, err := client.UpdateSlowPostProtectionSetting(ctx, updateSlowPostProtectionSetting)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateSlowPostProtectionSetting': %s", err.Error())
 		return diag.FromErr(err)
@@ -254,19 +252,18 @@ func resourceSlowPostProtectionSettingDelete(ctx context.Context, d *schema.Reso
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "slowpostSettings", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 
 	request := appsec.UpdateSlowPostProtectionRequest{
 		ConfigID:              configID,
-		Version:               version,
 		PolicyID:              policyID,
 		ApplySlowPostControls: false,
 	}
-	_, err = client.UpdateSlowPostProtection(ctx, request)
+	err = updateModifiableConfigVersion(ctx, configID, "slowpostSettings", m, func(version int) error {
+		request.Version = version
+		_, err := client.UpdateSlowPostProtection(ctx, request)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling UpdateSlowPostProtection: %s", err.Error())
 		return diag.FromErr(err)
@@ -64,10 +64,6 @@ func resourceSlowPostProtectionCreate(ctx context.Context, d *schema.ResourceDat
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "slowpostProtection", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -79,11 +75,14 @@ func resourceSlowPostProtectionCreate(ctx context.Context, d *schema.ResourceDat
 
 	request := appsec.UpdateSlowPostProtectionRequest{
 		ConfigID:              configID,
-		Version:               version,
 		PolicyID:              policyID,
 		ApplySlowPostControls: enabled,
 	}
-	_, err = client.UpdateSlowPostProtection(ctx, request)
+	err = updateModifiableConfigVersion(ctx, configID, "slowpostProtection", m, func(version int) error {
+		request.Version = version
+		_, err := client.UpdateSlowPostProtection(ctx, request)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling UpdateSlowPostProtection: %s", err.Error())
 		return diag.FromErr(err)
@@ -162,10 +161,6 @@ func resourceSlowPostProtectionUpdate(ctx context.Context, d *schema.ResourceDat
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "slowpostProtection", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	enabled, err := tools.GetBoolValue("enabled", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
@@ -174,11 +169,14 @@ func resourceSlowPostProtectionUpdate(ctx context.Context, d *schema.ResourceDat
 
 	request := appsec.UpdateSlowPostProtectionRequest{
 		ConfigID:              configID,
-		Version:               version,
 		PolicyID:              policyID,
 		ApplySlowPostControls: enabled,
 	}
-	_, err = client.UpdateSlowPostProtection(ctx, request)
+	err = updateModifiableConfigVersion(ctx, configID, "slowpostProtection", m, func(version int) error {
+		request.Version = version
+		_, err := client.UpdateSlowPostProtection(ctx, request)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling UpdateSlowPostProtection: %s", err.Error())
 		return diag.FromErr(err)
@@ -201,19 +199,18 @@ func resourceSlowPostProtectionDelete(ctx context.Context, d *schema.ResourceDat
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "slowpostProtection", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 
 	request := appsec.UpdateSlowPostProtectionRequest{
 		ConfigID:              configID,
-		Version:               version,
 		PolicyID:              policyID,
 		ApplySlowPostControls: false,
 	}
-	_, err = client.UpdateSlowPostProtection(ctx, request)
+	err = updateModifiableConfigVersion(ctx, configID, "slowpostProtection", m, func(version int) error {
+		request.Version = version
+		_, err := client.UpdateSlowPostProtection(ctx, request)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling UpdateSlowPostProtection: %s", err.Error())
 		return diag.FromErr(err)
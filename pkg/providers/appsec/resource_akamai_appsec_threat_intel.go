@@ -64,10 +64,6 @@ func resourceThreatIntelCreate(ctx context.Context, d *schema.ResourceData, m in
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "threatIntel", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -79,12 +75,15 @@ func resourceThreatIntelCreate(ctx context.Context, d *schema.ResourceData, m in
 
 	createThreatIntel := appsec.UpdateThreatIntelRequest{
 		ConfigID:    configID,
-		Version:     version,
 		PolicyID:    policyID,
 		ThreatIntel: threatintel,
 	}
 
-	_, err = client.UpdateThreatIntel(ctx, createThreatIntel)
+	err = updateModifiableConfigVersion(ctx, configID, "threatIntel", m, func(version int) error {
+		createThreatIntel.Version = version
+		_, err := client.UpdateThreatIntel(ctx, createThreatIntel)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'createThreatIntel': %s", err.Error())
 		return diag.FromErr(err)
@@ -154,10 +153,6 @@ func resourceThreatIntelUpdate(ctx context.Context, d *schema.ResourceData, m in
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "threatIntel", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 
 	threatintel, err := tools.GetStringValue("threat_intel", d)
@@ -167,12 +162,15 @@ func resourceThreatIntelUpdate(ctx context.Context, d *schema.ResourceData, m in
 
 	updateThreatIntel := appsec.UpdateThreatIntelRequest{
 		ConfigID:    configID,
-		Version:     version,
 		PolicyID:    policyID,
 		ThreatIntel: threatintel,
 	}
 
-	_, err = client.UpdateThreatIntel(ctx, updateThreatIntel)
+	err = updateModifiableConfigVersion(ctx, configID, "threatIntel", m, func(version int) error {
+		updateThreatIntel.Version = version
+		_, err := client.UpdateThreatIntel(ctx, updateThreatIntel)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateThreatIntel': %s", err.Error())
 		return diag.FromErr(err)
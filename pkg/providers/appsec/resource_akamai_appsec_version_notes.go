@@ -59,10 +59,6 @@ func resourceVersionNotesCreate(ctx context.Context, d *schema.ResourceData, m i
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "editVersionNotes", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	notes, err := tools.GetStringValue("version_notes", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -70,11 +66,14 @@ func resourceVersionNotesCreate(ctx context.Context, d *schema.ResourceData, m i
 
 	createVersionNotes := appsec.UpdateVersionNotesRequest{
 		ConfigID: configID,
-		Version:  version,
 		Notes:    notes,
 	}
 
-	_, err = client.UpdateVersionNotes(ctx, createVersionNotes)
+	err = updateModifiableConfigVersion(ctx, configID, "editVersionNotes", m, func(version int) error {
+		createVersionNotes.Version = version
+		_, err := client.UpdateVersionNotes(ctx, createVersionNotes)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'createVersionNotes': %s", err.Error())
 		return diag.FromErr(err)
@@ -140,10 +139,6 @@ func resourceVersionNotesUpdate(ctx context.Context, d *schema.ResourceData, m i
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "editVersionNotes", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	notes, err := tools.GetStringValue("version_notes", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return diag.FromErr(err)
@@ -151,11 +146,14 @@ func resourceVersionNotesUpdate(ctx context.Context, d *schema.ResourceData, m i
 
 	updateVersionNotes := appsec.UpdateVersionNotesRequest{
 		ConfigID: configID,
-		Version:  version,
 		Notes:    notes,
 	}
 
-	_, err = client.UpdateVersionNotes(ctx, updateVersionNotes)
+	err = updateModifiableConfigVersion(ctx, configID, "editVersionNotes", m, func(version int) error {
+		updateVersionNotes.Version = version
+		_, err := client.UpdateVersionNotes(ctx, updateVersionNotes)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateVersionNotes': %s", err.Error())
 		return diag.FromErr(err)
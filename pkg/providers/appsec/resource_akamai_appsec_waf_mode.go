@@ -91,10 +91,6 @@ func resourceWAFModeCreate(ctx context.Context, d *schema.ResourceData, m interf
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "wafMode", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -106,12 +102,15 @@ func resourceWAFModeCreate(ctx context.Context, d *schema.ResourceData, m interf
 
 	createWAFMode := appsec.UpdateWAFModeRequest{
 		ConfigID: configID,
-		Version:  version,
 		PolicyID: policyID,
 		Mode:     mode,
 	}
 
-	_, err = client.UpdateWAFMode(ctx, createWAFMode)
+	err = updateModifiableConfigVersion(ctx, configID, "wafMode", m, func(version int) error {
+		createWAFMode.Version = version
+		_, err := client.UpdateWAFMode(ctx, createWAFMode)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'createWAFMode': %s", err.Error())
 		return diag.FromErr(err)
@@ -202,10 +201,6 @@ func resourceWAFModeUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "wafMode", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	mode, err := tools.GetStringValue("mode", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
@@ -214,12 +209,15 @@ func resourceWAFModeUpdate(ctx context.Context, d *schema.ResourceData, m interf
 
 	updateWAFMode := appsec.UpdateWAFModeRequest{
 		ConfigID: configID,
-		Version:  version,
 		PolicyID: policyID,
 		Mode:     mode,
 	}
 
-	_, err = client.UpdateWAFMode(ctx, updateWAFMode)
+	err = updateModifiableConfigVersion(ctx, configID, "wafMode", m, func(version int) error {
+		updateWAFMode.Version = version
+		_, err := client.UpdateWAFMode(ctx, updateWAFMode)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'updateWAFMode': %s", err.Error())
 		return diag.FromErr(err)
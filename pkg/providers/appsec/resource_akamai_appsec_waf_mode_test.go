@@ -2,6 +2,7 @@ package appsec
 
 import (
 	"encoding/json"
+	"net/http"
 	"testing"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
@@ -59,4 +60,60 @@ func TestAkamaiWAFMode_res_basic(t *testing.T) {
 		client.AssertExpectations(t)
 	})
 
+	t.Run("version conflict is retried", func(t *testing.T) {
+		client := &appsec.Mock{}
+
+		updateWAFModeResponse := appsec.UpdateWAFModeResponse{}
+		err := json.Unmarshal(loadFixtureBytes("testdata/TestResWAFMode/WAFMode.json"), &updateWAFModeResponse)
+		require.NoError(t, err)
+
+		getWAFModeResponse := appsec.GetWAFModeResponse{}
+		err = json.Unmarshal(loadFixtureBytes("testdata/TestResWAFMode/WAFMode.json"), &getWAFModeResponse)
+		require.NoError(t, err)
+
+		config := appsec.GetConfigurationResponse{}
+		err = json.Unmarshal(loadFixtureBytes("testdata/TestResConfiguration/LatestConfiguration.json"), &config)
+		require.NoError(t, err)
+
+		client.On("GetConfiguration",
+			mock.Anything,
+			appsec.GetConfigurationRequest{ConfigID: 43253},
+		).Return(&config, nil)
+
+		client.On("GetWAFMode",
+			mock.Anything,
+			appsec.GetWAFModeRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230"},
+		).Return(&getWAFModeResponse, nil)
+
+		client.On("UpdateWAFMode",
+			mock.Anything,
+			appsec.UpdateWAFModeRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", Mode: "AAG"},
+		).Return(nil, &appsec.Error{StatusCode: http.StatusConflict}).Once()
+		client.On("UpdateWAFMode",
+			mock.Anything,
+			appsec.UpdateWAFModeRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", Mode: "AAG"},
+		).Return(&updateWAFModeResponse, nil).Once()
+
+		origInterval := ConfigVersionConflictRetryInterval
+		ConfigVersionConflictRetryInterval = 0
+		defer func() { ConfigVersionConflictRetryInterval = origInterval }()
+
+		useClient(client, func() {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:        true,
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResWAFMode/match_by_id.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_appsec_waf_mode.test", "id", "43253:AAAA_81230"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertNumberOfCalls(t, "UpdateWAFMode", 2)
+		client.AssertExpectations(t)
+	})
 }
@@ -64,10 +64,6 @@ func resourceWAFProtectionCreate(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "wafProtection", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID, err := tools.GetStringValue("security_policy_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -79,11 +75,14 @@ func resourceWAFProtectionCreate(ctx context.Context, d *schema.ResourceData, m
 
 	request := appsec.UpdateWAFProtectionRequest{
 		ConfigID:                      configID,
-		Version:                       version,
 		PolicyID:                      policyID,
 		ApplyApplicationLayerControls: enabled,
 	}
-	_, err = client.UpdateWAFProtection(ctx, request)
+	err = updateModifiableConfigVersion(ctx, configID, "wafProtection", m, func(version int) error {
+		request.Version = version
+		_, err := client.UpdateWAFProtection(ctx, request)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling UpdateWAFProtection: %s", err.Error())
 		return diag.FromErr(err)
@@ -162,10 +161,6 @@ func resourceWAFProtectionUpdate(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "wafProtection", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 	enabled, err := tools.GetBoolValue("enabled", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
@@ -174,11 +169,14 @@ func resourceWAFProtectionUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	request := appsec.UpdateWAFProtectionRequest{
 		ConfigID:                      configID,
-		Version:                       version,
 		PolicyID:                      policyID,
 		ApplyApplicationLayerControls: enabled,
 	}
-	_, err = client.UpdateWAFProtection(ctx, request)
+	err = updateModifiableConfigVersion(ctx, configID, "wafProtection", m, func(version int) error {
+		request.Version = version
+		_, err := client.UpdateWAFProtection(ctx, request)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling UpdateWAFProtection: %s", err.Error())
 		return diag.FromErr(err)
@@ -201,19 +199,18 @@ func resourceWAFProtectionDelete(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	version, err := getModifiableConfigVersion(ctx, configID, "wafProtection", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	policyID := iDParts[1]
 
 	request := appsec.UpdateWAFProtectionRequest{
 		ConfigID:                      configID,
-		Version:                       version,
 		PolicyID:                      policyID,
 		ApplyApplicationLayerControls: false,
 	}
-	_, err = client.UpdateWAFProtection(ctx, request)
+	err = updateModifiableConfigVersion(ctx, configID, "wafProtection", m, func(version int) error {
+		request.Version = version
+		_, err := client.UpdateWAFProtection(ctx, request)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling UpdateWAFProtection: %s", err.Error())
 		return diag.FromErr(err)
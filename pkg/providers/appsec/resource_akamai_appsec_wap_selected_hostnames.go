@@ -93,19 +93,18 @@ func resourceWAPSelectedHostnamesCreate(ctx context.Context, d *schema.ResourceD
 		evalHostnames = make([]string, 0)
 	}
 
-	version, err := getModifiableConfigVersion(ctx, configID, "wapSelectedHostnames", m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	updateWAPSelectedHostnames := appsec.UpdateWAPSelectedHostnamesRequest{
 		ConfigID:         configID,
-		Version:          version,
 		SecurityPolicyID: securityPolicyID,
 		ProtectedHosts:   protectedHostnames,
 		EvaluatedHosts:   evalHostnames,
 	}
 
-	_, err = client.UpdateWAPSelectedHostnames(ctx, updateWAPSelectedHostnames)
+	err = updateModifiableConfigVersion(ctx, configID, "wapSelectedHostnames", m, func(version int) error {
+		updateWAPSelectedHostnames.Version = version
+		_, err := client.UpdateWAPSelectedHostnames(ctx, updateWAPSelectedHostnames)
+		return err
+	})
 	if err != nil {
 		logger.Errorf("calling 'UpdateWAPSelectedHostnames': %s", err.Error())
 		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
@@ -0,0 +1,416 @@
+package appsec
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/session"
+)
+
+type (
+	// SIEM is the security events interface of the SIEM integration API, which isn't provided by edgegrid-golang
+	SIEM interface {
+		// GetSecurityEvents returns the security events of a security configuration.
+		//
+		// See: https://techdocs.akamai.com/siem-integration/reference/get-configid
+		GetSecurityEvents(ctx context.Context, params GetSecurityEventsRequest) (*GetSecurityEventsResponse, error)
+	}
+
+	siemAPI struct {
+		session.Session
+	}
+
+	// GetSecurityEventsRequest is the argument of GetSecurityEvents. Events are fetched either from an offset
+	// or over a time window, in seconds since the epoch.
+	GetSecurityEventsRequest struct {
+		ConfigID int
+		Offset   string
+		From     int64
+		To       int64
+		Limit    int
+	}
+
+	// GetSecurityEventsResponse is returned by GetSecurityEvents
+	GetSecurityEventsResponse struct {
+		Events []SecurityEvent
+		Total  int
+		Offset string
+		Limit  int
+	}
+
+	// SecurityEvent is a security event as returned by the SIEM API, with encoded rule fields and headers
+	SecurityEvent struct {
+		Format      string                   `json:"format"`
+		Type        string                   `json:"type"`
+		Version     string                   `json:"version"`
+		AttackData  SecurityEventAttackData  `json:"attackData"`
+		HTTPMessage SecurityEventHTTPMessage `json:"httpMessage"`
+		Geo         SecurityEventGeo         `json:"geo"`
+	}
+
+	// SecurityEventAttackData describes the rules triggered by a security event. Rule fields are URL-encoded
+	// lists of base64-encoded values separated by semicolons.
+	SecurityEventAttackData struct {
+		ConfigID      string `json:"configId"`
+		PolicyID      string `json:"policyId"`
+		ClientIP      string `json:"clientIP"`
+		Rules         string `json:"rules"`
+		RuleVersions  string `json:"ruleVersions"`
+		RuleMessages  string `json:"ruleMessages"`
+		RuleTags      string `json:"ruleTags"`
+		RuleData      string `json:"ruleData"`
+		RuleSelectors string `json:"ruleSelectors"`
+		RuleActions   string `json:"ruleActions"`
+	}
+
+	// SecurityEventHTTPMessage describes the request of a security event. Headers are URL-encoded.
+	SecurityEventHTTPMessage struct {
+		RequestID       string `json:"requestId"`
+		Start           string `json:"start"`
+		Protocol        string `json:"protocol"`
+		Method          string `json:"method"`
+		Host            string `json:"host"`
+		Port            string `json:"port"`
+		Path            string `json:"path"`
+		Query           string `json:"query"`
+		RequestHeaders  string `json:"requestHeaders"`
+		Status          string `json:"status"`
+		Bytes           string `json:"bytes"`
+		ResponseHeaders string `json:"responseHeaders"`
+	}
+
+	// SecurityEventGeo describes the location of the client of a security event
+	SecurityEventGeo struct {
+		Continent  string `json:"continent"`
+		Country    string `json:"country"`
+		City       string `json:"city"`
+		RegionCode string `json:"regionCode"`
+		ASN        string `json:"asn"`
+	}
+)
+
+// maxSecurityEventSize is the size of the largest security event line read from the SIEM API
+const maxSecurityEventSize = 4 << 20
+
+func newSIEM(sess session.Session) SIEM {
+	return &siemAPI{Session: sess}
+}
+
+// Validate validates GetSecurityEventsRequest
+func (r GetSecurityEventsRequest) Validate() error {
+	switch {
+	case r.ConfigID == 0:
+		return errors.New("config ID is required")
+	case r.Offset != "" && (r.From != 0 || r.To != 0):
+		return errors.New("offset can't be used with a time window")
+	case r.To != 0 && r.From == 0:
+		return errors.New("to requires from")
+	case r.To != 0 && r.To <= r.From:
+		return errors.New("to must be after from")
+	}
+	return nil
+}
+
+func (p *siemAPI) GetSecurityEvents(ctx context.Context, params GetSecurityEventsRequest) (*GetSecurityEventsResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", appsec.ErrStructValidation, err.Error())
+	}
+
+	uri, err := url.Parse(fmt.Sprintf("/siem/v1/configs/%d", params.ConfigID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse url: %w", err)
+	}
+	query := uri.Query()
+	if params.Offset != "" {
+		query.Set("offset", params.Offset)
+	}
+	if params.From != 0 {
+		query.Set("from", strconv.FormatInt(params.From, 10))
+	}
+	if params.To != 0 {
+		query.Set("to", strconv.FormatInt(params.To, 10))
+	}
+	if params.Limit != 0 {
+		query.Set("limit", strconv.Itoa(params.Limit))
+	}
+	uri.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GetSecurityEvents request: %w", err)
+	}
+
+	resp, err := p.Exec(req, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get security events request failed: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading security events: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &appsec.Error{StatusCode: resp.StatusCode}
+		if err := json.Unmarshal(body, apiErr); err != nil {
+			apiErr.Title = "Failed to unmarshal error body"
+			apiErr.Detail = err.Error()
+		}
+		return nil, apiErr
+	}
+
+	return parseSecurityEvents(body)
+}
+
+// parseSecurityEvents reads the response of the SIEM API: one security event per line, followed by a line
+// with the offset to fetch the next events from.
+func parseSecurityEvents(body []byte) (*GetSecurityEventsResponse, error) {
+	result := GetSecurityEventsResponse{Events: make([]SecurityEvent, 0)}
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSecurityEventSize)
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		var offset struct {
+			Total  *int   `json:"total"`
+			Offset string `json:"offset"`
+			Limit  int    `json:"limit"`
+		}
+		if err := json.Unmarshal(data, &offset); err != nil {
+			return nil, fmt.Errorf("line %d: %s", line, err)
+		}
+		if offset.Total != nil {
+			result.Total = *offset.Total
+			result.Offset = offset.Offset
+			result.Limit = offset.Limit
+			continue
+		}
+		var event SecurityEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			return nil, fmt.Errorf("line %d: %s", line, err)
+		}
+		result.Events = append(result.Events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading security events: %w", err)
+	}
+
+	return &result, nil
+}
+
+type (
+	// securityEventRecord is a security event with its rule fields and headers decoded
+	securityEventRecord struct {
+		RequestID      string
+		Time           string
+		PolicyID       string
+		ClientIP       string
+		Country        string
+		ASN            string
+		Method         string
+		Host           string
+		Path           string
+		Query          string
+		Status         string
+		RequestHeaders string
+		Rules          []securityEventRule
+		Action         string
+	}
+
+	// securityEventRule is a rule triggered by a security event
+	securityEventRule struct {
+		ID       string
+		Version  string
+		Message  string
+		Tag      string
+		Data     string
+		Selector string
+		Action   string
+	}
+
+	// securityEventSummary counts the security events by rule and by client
+	securityEventSummary struct {
+		TopRules   []securityEventCount
+		TopClients []securityEventCount
+	}
+
+	// securityEventCount is the number of security events of a rule or client, and how many of them were denied
+	securityEventCount struct {
+		Key     string
+		Message string
+		Events  int
+		Denied  int
+	}
+)
+
+// decodeSecurityEvent decodes the rule fields and headers of a security event.
+func decodeSecurityEvent(event SecurityEvent) (securityEventRecord, error) {
+	record := securityEventRecord{
+		RequestID: event.HTTPMessage.RequestID,
+		PolicyID:  event.AttackData.PolicyID,
+		ClientIP:  event.AttackData.ClientIP,
+		Country:   event.Geo.Country,
+		ASN:       event.Geo.ASN,
+		Method:    event.HTTPMessage.Method,
+		Host:      event.HTTPMessage.Host,
+		Path:      event.HTTPMessage.Path,
+		Query:     event.HTTPMessage.Query,
+		Status:    event.HTTPMessage.Status,
+		Rules:     make([]securityEventRule, 0),
+	}
+
+	if event.HTTPMessage.Start != "" {
+		start, err := strconv.ParseFloat(event.HTTPMessage.Start, 64)
+		if err != nil {
+			return record, fmt.Errorf("request %s: invalid start %q", record.RequestID, event.HTTPMessage.Start)
+		}
+		record.Time = time.Unix(int64(start), 0).UTC().Format(time.RFC3339)
+	}
+	headers, err := url.PathUnescape(event.HTTPMessage.RequestHeaders)
+	if err != nil {
+		return record, fmt.Errorf("request %s: requestHeaders: %s", record.RequestID, err)
+	}
+	record.RequestHeaders = headers
+
+	fields := []struct {
+		name  string
+		value string
+		set   func(*securityEventRule, string)
+	}{
+		{"rules", event.AttackData.Rules, func(r *securityEventRule, v string) { r.ID = v }},
+		{"ruleVersions", event.AttackData.RuleVersions, func(r *securityEventRule, v string) { r.Version = v }},
+		{"ruleMessages", event.AttackData.RuleMessages, func(r *securityEventRule, v string) { r.Message = v }},
+		{"ruleTags", event.AttackData.RuleTags, func(r *securityEventRule, v string) { r.Tag = v }},
+		{"ruleData", event.AttackData.RuleData, func(r *securityEventRule, v string) { r.Data = v }},
+		{"ruleSelectors", event.AttackData.RuleSelectors, func(r *securityEventRule, v string) { r.Selector = v }},
+		{"ruleActions", event.AttackData.RuleActions, func(r *securityEventRule, v string) { r.Action = v }},
+	}
+	for _, field := range fields {
+		values, err := decodeSecurityEventRuleField(field.value)
+		if err != nil {
+			return record, fmt.Errorf("request %s: %s: %s", record.RequestID, field.name, err)
+		}
+		for i, v := range values {
+			if i == len(record.Rules) {
+				record.Rules = append(record.Rules, securityEventRule{})
+			}
+			field.set(&record.Rules[i], v)
+		}
+	}
+
+	for _, r := range record.Rules {
+		if record.Action == "" || simulationActionSeverity(r.Action) > simulationActionSeverity(record.Action) {
+			record.Action = r.Action
+		}
+	}
+
+	return record, nil
+}
+
+// decodeSecurityEventRuleField decodes an attackData rule field: a URL-encoded list of base64-encoded values
+// separated by semicolons.
+func decodeSecurityEventRuleField(field string) ([]string, error) {
+	if field == "" {
+		return nil, nil
+	}
+	// PathUnescape keeps the + of base64 values, which QueryUnescape would turn into spaces.
+	unescaped, err := url.PathUnescape(field)
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.Split(unescaped, ";")
+	values := make([]string, len(parts))
+	for i, part := range parts {
+		value, err := base64.StdEncoding.DecodeString(part)
+		if err != nil {
+			return nil, fmt.Errorf("value %d: %s", i, err)
+		}
+		values[i] = string(value)
+	}
+	return values, nil
+}
+
+// matches returns whether the event applies to the security policy and triggered the rule, when given.
+func (r securityEventRecord) matches(policyID, ruleID string) bool {
+	if policyID != "" && r.PolicyID != policyID {
+		return false
+	}
+	if ruleID == "" {
+		return true
+	}
+	for _, rule := range r.Rules {
+		if rule.ID == ruleID {
+			return true
+		}
+	}
+	return false
+}
+
+// summarizeSecurityEvents returns the top rules and clients of the events, by number of events.
+func summarizeSecurityEvents(records []securityEventRecord, top int) securityEventSummary {
+	rules := map[string]*securityEventCount{}
+	clients := map[string]*securityEventCount{}
+	count := func(counts map[string]*securityEventCount, key, message string, denied bool) {
+		c, ok := counts[key]
+		if !ok {
+			c = &securityEventCount{Key: key, Message: message}
+			counts[key] = c
+		}
+		c.Events++
+		if denied {
+			c.Denied++
+		}
+	}
+
+	for _, record := range records {
+		seen := map[string]bool{}
+		for _, rule := range record.Rules {
+			if seen[rule.ID] {
+				continue
+			}
+			seen[rule.ID] = true
+			count(rules, rule.ID, rule.Message, simulationActionSeverity(rule.Action) == 2)
+		}
+		count(clients, record.ClientIP, record.Country, simulationActionSeverity(record.Action) == 2)
+	}
+
+	return securityEventSummary{
+		TopRules:   topSecurityEventCounts(rules, top),
+		TopClients: topSecurityEventCounts(clients, top),
+	}
+}
+
+func topSecurityEventCounts(counts map[string]*securityEventCount, top int) []securityEventCount {
+	result := make([]securityEventCount, 0, len(counts))
+	for _, c := range counts {
+		result = append(result, *c)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Events != result[j].Events {
+			return result[i].Events > result[j].Events
+		}
+		return result[i].Key < result[j].Key
+	})
+	if top > 0 && len(result) > top {
+		result = result[:top]
+	}
+	return result
+}
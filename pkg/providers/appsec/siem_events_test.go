@@ -0,0 +1,173 @@
+package appsec
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/appsec"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSIEM struct {
+	mock.Mock
+}
+
+func (m *mockSIEM) GetSecurityEvents(ctx context.Context, params GetSecurityEventsRequest) (*GetSecurityEventsResponse, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*GetSecurityEventsResponse), args.Error(1)
+}
+
+func mockSIEMAPI(t *testing.T, server *httptest.Server) SIEM {
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	certPool := x509.NewCertPool()
+	certPool.AddCert(server.Certificate())
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: certPool},
+		},
+	}
+	sess, err := session.New(session.WithClient(httpClient), session.WithSigner(&edgegrid.Config{Host: serverURL.Host}))
+	require.NoError(t, err)
+	return newSIEM(sess)
+}
+
+func TestSIEMGetSecurityEvents(t *testing.T) {
+	tests := map[string]struct {
+		params           GetSecurityEventsRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *GetSecurityEventsResponse
+		withError        func(*testing.T, error)
+	}{
+		"time window": {
+			params:         GetSecurityEventsRequest{ConfigID: 43253, From: 1678888800, To: 1678892400, Limit: 10},
+			responseStatus: http.StatusOK,
+			responseBody:   loadFixtureString("testdata/TestDSSecurityEvents/SecurityEvents.ndjson"),
+			expectedPath:   "/siem/v1/configs/43253?from=1678888800&limit=10&to=1678892400",
+		},
+		"offset": {
+			params:           GetSecurityEventsRequest{ConfigID: 43253, Offset: "faf3-2b6f"},
+			responseStatus:   http.StatusOK,
+			responseBody:     `{"total":0,"offset":"faf3-2b6f","limit":10000}` + "\n",
+			expectedPath:     "/siem/v1/configs/43253?offset=faf3-2b6f",
+			expectedResponse: &GetSecurityEventsResponse{Events: []SecurityEvent{}, Offset: "faf3-2b6f", Limit: 10000},
+		},
+		"API error": {
+			params:         GetSecurityEventsRequest{ConfigID: 43253, Offset: "faf3-2b6f"},
+			responseStatus: http.StatusBadRequest,
+			responseBody:   `{"type":"invalid-offset","title":"Invalid offset","detail":"The offset has expired"}`,
+			expectedPath:   "/siem/v1/configs/43253?offset=faf3-2b6f",
+			withError: func(t *testing.T, err error) {
+				var apiErr *appsec.Error
+				require.True(t, errors.As(err, &apiErr))
+				assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+				assert.Equal(t, "Invalid offset", apiErr.Title)
+			},
+		},
+		"offset with time window": {
+			params: GetSecurityEventsRequest{ConfigID: 43253, Offset: "faf3-2b6f", From: 1678888800},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, appsec.ErrStructValidation))
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer server.Close()
+
+			result, err := mockSIEMAPI(t, server).GetSecurityEvents(context.Background(), test.params)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if test.expectedResponse != nil {
+				assert.Equal(t, test.expectedResponse, result)
+				return
+			}
+			assert.Len(t, result.Events, 3)
+			assert.Equal(t, 3, result.Total)
+			assert.Equal(t, "faf3-2b6f-aa00-1d4b", result.Offset)
+		})
+	}
+}
+
+func TestDecodeSecurityEvent(t *testing.T) {
+	response, err := parseSecurityEvents(loadFixtureBytes("testdata/TestDSSecurityEvents/SecurityEvents.ndjson"))
+	require.NoError(t, err)
+
+	record, err := decodeSecurityEvent(response.Events[0])
+	require.NoError(t, err)
+	assert.Equal(t, securityEventRecord{
+		RequestID:      "1a2b3c",
+		Time:           "2023-03-15T14:00:00Z",
+		PolicyID:       "AAAA_81230",
+		ClientIP:       "198.51.100.7",
+		Country:        "US",
+		ASN:            "64496",
+		Method:         "GET",
+		Host:           "www.example.com",
+		Path:           "/cgi-bin/test",
+		Query:          "cmd=ls+-la",
+		Status:         "403",
+		RequestHeaders: "Host: www.example.com\r\nUser-Agent: curl/7.88.1\r\n",
+		Rules: []securityEventRule{
+			{ID: "950002", Version: "1", Message: "System Command Access", Tag: "OWASP_CRS/WEB_ATTACK/FILE_INJECTION", Data: "Vector Score: 5, ls -la", Selector: "ARGS:cmd", Action: "deny"},
+			{ID: "3000001", Version: "2", Message: "Anomaly Score Exceeded", Tag: "AKAMAI/POLICY/INBOUND_ANOMALY", Data: "Total Score: 5", Action: "alert"},
+		},
+		Action: "deny",
+	}, record)
+
+	event := response.Events[0]
+	event.AttackData.Rules = "not%20base64"
+	_, err = decodeSecurityEvent(event)
+	assert.EqualError(t, err, "request 1a2b3c: rules: value 0: illegal base64 data at input byte 3")
+}
+
+func TestSummarizeSecurityEvents(t *testing.T) {
+	response, err := parseSecurityEvents(loadFixtureBytes("testdata/TestDSSecurityEvents/SecurityEvents.ndjson"))
+	require.NoError(t, err)
+	records := make([]securityEventRecord, 0)
+	for _, event := range response.Events {
+		record, err := decodeSecurityEvent(event)
+		require.NoError(t, err)
+		records = append(records, record)
+	}
+
+	summary := summarizeSecurityEvents(records, 2)
+	assert.Equal(t, []securityEventCount{
+		{Key: "950002", Message: "System Command Access", Events: 2, Denied: 1},
+		{Key: "3000001", Message: "Anomaly Score Exceeded", Events: 1},
+	}, summary.TopRules)
+	assert.Equal(t, []securityEventCount{
+		{Key: "198.51.100.7", Message: "US", Events: 2, Denied: 1},
+		{Key: "203.0.113.5", Message: "DE", Events: 1, Denied: 1},
+	}, summary.TopClients)
+
+	assert.True(t, records[0].matches("", "950002"))
+	assert.False(t, records[1].matches("", "950002"))
+	assert.False(t, records[2].matches("AAAA_81230", ""))
+}
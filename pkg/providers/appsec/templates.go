@@ -307,6 +307,9 @@ func InitTemplates(otm map[string]*OutputTemplate) {
 	otm["slowPostDS"] = &OutputTemplate{TemplateName: "slowPost", TableTitle: "Action|SLOW_RATE_THRESHOLD RATE|SLOW_RATE_THRESHOLD PERIOD|DURATION_THRESHOLD TIMEOUT", TemplateType: "TABULAR", TemplateString: "{{.Action}}|{{if .SlowRateThreshold}}{{.SlowRateThreshold.Rate}}|{{.SlowRateThreshold.Period}}{{else}}null|null{{end}}|{{if .DurationThreshold}}{{.DurationThreshold.Timeout}}{{else}}null{{end}}"}
 	otm["slowPost"] = &OutputTemplate{TemplateName: "slowPost", TableTitle: "Action|SLOW_RATE_THRESHOLD RATE|SLOW_RATE_THRESHOLD PERIOD|DURATION_THRESHOLD TIMEOUT", TemplateType: "TABULAR", TemplateString: "{{range $index, $element := .SecurityPolicies}}{{if $index}},{{end}}{{.SlowPost.Action}}|{{.SlowPost.DurationThreshold.Timeout}}|{{.SlowPost.SlowRateThreshold.Rate}}|{{.SlowPost.SlowRateThreshold.Period}}{{end}}"}
 	otm["wafModesDS"] = &OutputTemplate{TemplateName: "wafMode", TableTitle: "Current|Mode|Eval", TemplateType: "TABULAR", TemplateString: "{{.Current}}|{{.Mode}}|{{.Eval}}"}
+	otm["versionDiffDS"] = &OutputTemplate{TemplateName: "versionDiffDS", TableTitle: "Action|Type|Security Policy|Name", TemplateType: "TABULAR", TemplateString: "{{range $index, $element := .}}{{if $index}},{{end}}{{.Action}}|{{.Kind}}|{{replace \",\" \"\" (replace \"|\" \" \" .Policy)}}|{{replace \",\" \"\" (replace \"|\" \" \" .Name)}}{{end}}"}
+	otm["policySimulationDS"] = &OutputTemplate{TemplateName: "policySimulationDS", TableTitle: "Request|Match Target|Security Policy|IP/Geo|Custom Rules|Action", TemplateType: "TABULAR", TemplateString: "{{range $index, $element := .}}{{if $index}},{{end}}{{replace \",\" \"\" (replace \"|\" \" \" .Request)}}|{{.MatchTargetID}}|{{.PolicyID}}|{{.IPGeoAction}}|{{range $i, $c := .CustomRules}}{{if $i}} {{end}}{{$c.ID}}:{{$c.Action}}{{end}}|{{.Action}}{{end}}"}
+	otm["securityEventsDS"] = &OutputTemplate{TemplateName: "securityEventsDS", TableTitle: "Rule ID|Message|Events|Denied", TemplateType: "TABULAR", TemplateString: "{{range $index, $element := .TopRules}}{{if $index}},{{end}}{{.Key}}|{{replace \",\" \"\" (replace \"|\" \" \" .Message)}}|{{.Events}}|{{.Denied}}{{end}}"}
 	otm["versionNotesDS"] = &OutputTemplate{TemplateName: "versionNotesDS", TableTitle: "Version Notes", TemplateType: "TABULAR", TemplateString: "{{.Notes}}"}
 	otm["AttackGroupDS"] = &OutputTemplate{TemplateName: "AttackGroup", TableTitle: "GroupID|Action|Exceptions|Advanced Exceptions", TemplateType: "TABULAR", TemplateString: "{{range $index, $element := .AttackGroups}}{{if $index}},{{end}}{{.Group}}|{{.Action}}|{{with .ConditionException}}{{if .Exception}}True{{else}}False{{end}}{{else}}False{{end}}|{{with .ConditionException}}{{if .AdvancedExceptionsList}}True{{else}}False{{end}}{{else}}False{{end}}{{end}}"}
 	otm["EvalGroupDS"] = &OutputTemplate{TemplateName: "EvalGroup", TableTitle: "GroupID|Action|Exceptions|Advanced Exceptions", TemplateType: "TABULAR", TemplateString: "{{range $index, $element := .AttackGroups}}{{if $index}},{{end}}{{.Group}}|{{.Action}}|{{with .ConditionException}}{{if .Exception}}True{{else}}False{{end}}{{else}}False{{end}}|{{with .ConditionException}}{{if .AdvancedExceptionsList}}True{{else}}False{{end}}{{else}}False{{end}}{{end}}"}
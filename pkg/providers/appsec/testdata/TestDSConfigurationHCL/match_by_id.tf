@@ -0,0 +1,10 @@
+provider "akamai" {
+  edgerc        = "../../test/edgerc"
+  cache_enabled = false
+}
+
+data "akamai_appsec_configuration_hcl" "test" {
+  config_id      = 43253
+  version        = 7
+  json_directory = "settings"
+}
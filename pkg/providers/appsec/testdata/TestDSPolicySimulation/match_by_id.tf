@@ -0,0 +1,32 @@
+provider "akamai" {
+  edgerc        = "../../test/edgerc"
+  cache_enabled = false
+}
+
+data "akamai_appsec_policy_simulation" "test" {
+  config_id = 43253
+  version   = 7
+
+  request {
+    name   = "admin-post"
+    method = "POST"
+    host   = "www.example.com"
+    path   = "/admin/users"
+    headers = {
+      "X-Debug" = "1"
+    }
+    client_ip = "192.0.2.10"
+  }
+
+  request {
+    name           = "blocked-country"
+    host           = "shop.example.com"
+    client_ip      = "192.0.2.20"
+    client_country = "XX"
+  }
+
+  network_list {
+    id       = "1304_GEO"
+    elements = ["XX"]
+  }
+}
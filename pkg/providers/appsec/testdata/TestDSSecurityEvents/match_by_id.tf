@@ -0,0 +1,11 @@
+provider "akamai" {
+  edgerc        = "../../test/edgerc"
+  cache_enabled = false
+}
+
+data "akamai_appsec_security_events" "test" {
+  config_id = 43253
+  from      = 1678888800
+  to        = 1678892400
+  rule_id   = "950002"
+}
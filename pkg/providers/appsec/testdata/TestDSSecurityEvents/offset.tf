@@ -0,0 +1,10 @@
+provider "akamai" {
+  edgerc        = "../../test/edgerc"
+  cache_enabled = false
+}
+
+data "akamai_appsec_security_events" "test" {
+  config_id = 43253
+  offset    = "faf3-2b6f-aa00-1d4b"
+  limit     = 100
+}
@@ -0,0 +1,9 @@
+provider "akamai" {
+  edgerc        = "../../test/edgerc"
+  cache_enabled = false
+}
+
+data "akamai_appsec_version_diff" "test" {
+  config_id    = 43253
+  from_version = 5
+}
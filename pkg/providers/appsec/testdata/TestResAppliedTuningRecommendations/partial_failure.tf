@@ -0,0 +1,10 @@
+provider "akamai" {
+  edgerc        = "../../test/edgerc"
+  cache_enabled = false
+}
+
+resource "akamai_appsec_applied_tuning_recommendations" "test" {
+  config_id          = 43253
+  security_policy_id = "AAAA_81230"
+  recommendation_ids = ["attack_group:XSS:fe8d80e4", "rule:950002:b479a52b"]
+}
@@ -0,0 +1,9 @@
+provider "akamai" {
+  edgerc        = "../../test/edgerc"
+  cache_enabled = false
+}
+
+resource "akamai_appsec_configuration_document" "test" {
+  config_id = 43253
+  document  = file("testdata/TestResConfigurationDocument/Desired.json")
+}
@@ -0,0 +1,86 @@
+provider "akamai" {
+  edgerc        = "../../test/edgerc"
+  cache_enabled = false
+}
+
+resource "akamai_appsec_configuration_promotion" "test" {
+  config_id = 43253
+  hostname_mappings = {
+    "dev.example.com" = "www.example.com"
+  }
+  network_list_mappings = {
+    "1_DEV" = "1_PROD"
+    "2_DEV" = "2_PROD"
+  }
+  custom_rule_mappings = {
+    "101" = 700
+  }
+  source_export = <<-EOT
+{
+    "configId": 11111,
+    "configName": "Development",
+    "version": 3,
+    "selectedHosts": ["dev.example.com", "legacy.example.com"],
+    "selectableHosts": ["dev.example.com", "legacy.example.com"],
+    "customRules": [
+        {
+            "id": 100,
+            "name": "Block admin",
+            "conditions": [
+                {"type": "pathMatch", "positiveMatch": true, "value": ["/admin"]}
+            ]
+        },
+        {
+            "id": 101,
+            "name": "Debug header",
+            "conditions": [
+                {"type": "requestHeaderMatch", "positiveMatch": true, "name": ["X-Debug"]}
+            ]
+        }
+    ],
+    "matchTargets": {
+        "websiteTargets": [
+            {
+                "id": 500,
+                "type": "website",
+                "defaultFile": "NO_MATCH",
+                "filePaths": ["/*"],
+                "hostnames": ["dev.example.com"],
+                "isNegativeFileExtensionMatch": false,
+                "isNegativePathMatch": false,
+                "bypassNetworkLists": [{"id": "1_DEV", "name": "Dev bypass"}],
+                "securityPolicy": {"policyId": "DEV1_1"}
+            }
+        ],
+        "apiTargets": [
+            {
+                "targetId": 501,
+                "type": "api",
+                "apis": [{"id": 8001, "name": "Dev API"}],
+                "securityPolicy": {"policyId": "DEV1_1"}
+            }
+        ]
+    },
+    "securityPolicies": [
+        {
+            "id": "DEV1_1",
+            "name": "Main",
+            "securityControls": {"applyApplicationLayerControls": true, "applyNetworkLayerControls": true},
+            "webApplicationFirewall": {
+                "ruleActions": [{"action": "deny", "id": 950002, "rulesetVersionId": 1}]
+            },
+            "customRuleActions": [
+                {"id": 100, "action": "deny"},
+                {"id": 101, "action": "alert"}
+            ],
+            "ipGeoFirewall": {
+                "block": "blockSpecificIPGeo",
+                "ipControls": {
+                    "blockedIPNetworkLists": {"networkList": ["2_DEV", "3_DEV"]}
+                }
+            }
+        }
+    ]
+}
+EOT
+}
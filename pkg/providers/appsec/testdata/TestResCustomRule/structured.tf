@@ -0,0 +1,34 @@
+provider "akamai" {
+  edgerc        = "../../test/edgerc"
+  cache_enabled = false
+}
+
+resource "akamai_appsec_custom_rule" "test" {
+  config_id     = 43253
+  name          = "Rule Test New"
+  description   = "Can I create all conditions?"
+  tag           = ["test"]
+  sampling_rate = 5
+
+  condition {
+    type  = "requestMethodMatch"
+    value = ["GET", "CONNECT", "TRACE", "PUT", "POST", "OPTIONS", "DELETE", "HEAD"]
+  }
+
+  condition {
+    type  = "pathMatch"
+    value = ["/H", "/Li", "/He"]
+  }
+
+  condition {
+    type           = "extensionMatch"
+    value          = ["Li", "He", "H"]
+    value_wildcard = true
+    value_case     = true
+  }
+
+  effective_time_period {
+    start_date = "2022-05-03T18:19:55Z"
+    end_date   = "2022-06-02T18:19:55Z"
+  }
+}
@@ -0,0 +1,20 @@
+provider "akamai" {
+  edgerc        = "../../test/edgerc"
+  cache_enabled = false
+}
+
+resource "akamai_appsec_rules" "test" {
+  config_id          = 43253
+  security_policy_id = "AAAA_81230"
+  rule_actions = {
+    "950001" = "deny"
+    "950002" = "alert"
+  }
+  condition_exceptions = {
+    "950002" = jsonencode({
+      exception = {
+        headerCookieOrParamValues = ["abc"]
+      }
+    })
+  }
+}
@@ -0,0 +1,15 @@
+provider "akamai" {
+  edgerc        = "../../test/edgerc"
+  cache_enabled = false
+}
+
+resource "akamai_appsec_security_policy_from_template" "test" {
+  config_id              = 43253
+  security_policy_name   = "Web"
+  security_policy_prefix = "AAAA"
+  adopt_existing_objects = true
+  template               = file("testdata/TestResSecurityPolicyFromTemplate/Template.json")
+  variables = {
+    excluded_cookie = "session"
+  }
+}
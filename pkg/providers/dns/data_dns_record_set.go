@@ -9,6 +9,7 @@ import (
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/session"
 	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	canonicalrdata "github.com/akamai/terraform-provider-akamai/v3/pkg/providers/dns/rdata"
 	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -39,6 +40,12 @@ func dataSourceDNSRecordSet() *schema.Resource {
 				Computed:    true,
 				Description: "An array of data strings that represent multiple records within a set",
 			},
+			"canonical_rdata": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "The records in canonical form, in the same order as rdata",
+			},
 		},
 	}
 }
@@ -86,6 +93,18 @@ func dataSourceDNSRecordSetRead(ctx context.Context, d *schema.ResourceData, m i
 	if err := d.Set("rdata", rdata); err != nil {
 		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
 	}
+	canonical := make([]string, 0, len(rdata))
+	for _, record := range rdata {
+		value, err := canonicalrdata.Canonical(recordType, record)
+		if err != nil {
+			logger.Warnf("Keeping record as returned: %s", err)
+			value = record
+		}
+		canonical = append(canonical, value)
+	}
+	if err := d.Set("canonical_rdata", canonical); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
 	d.SetId(host)
 	return nil
 }
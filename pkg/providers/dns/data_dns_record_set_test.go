@@ -40,6 +40,7 @@ func TestDataSourceDNSRecordSet_basic(t *testing.T) {
 							// rdata is an array that becomes rdata.0 and rdata.1 in tf state
 							resource.TestCheckResourceAttrSet(dataSourceName, "rdata.0"),
 							resource.TestCheckResourceAttrSet(dataSourceName, "rdata.1"),
+							resource.TestCheckResourceAttr(dataSourceName, "canonical_rdata.0", "10.1.0.1"),
 							resource.TestCheckResourceAttrSet(dataSourceName, "id"),
 							resource.TestCheckOutput(outputName, strings.Join(rdata, ",")),
 						),
@@ -0,0 +1,236 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDNSZoneDNSSECStatus() *schema.Resource {
+	attributes := map[string]*schema.Schema{
+		"zone": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The Sign and Serve zone",
+		},
+	}
+	for name, attr := range dnssecStatusSchema() {
+		attributes[name] = attr
+	}
+	return &schema.Resource{
+		ReadContext: dataSourceDNSZoneDNSSECStatusRead,
+		Schema:      attributes,
+	}
+}
+
+// dnssecStatusSchema returns the computed DNSSEC status attributes shared by the status data source and
+// the key rotation resource
+func dnssecStatusSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"alerts": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Computed:    true,
+			Description: "Problems reported for the DNSSEC configuration of the zone",
+		},
+		"ksk": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The current key signing keys",
+			Elem:        dnsKeySchema(),
+		},
+		"zsk": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The current zone signing keys",
+			Elem:        dnsKeySchema(),
+		},
+		"ds_records": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The DS records to publish in the parent zone",
+			Elem:        dsRecordSchema(),
+		},
+		"dnskey_records": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The current DNSKEY records as returned by the API",
+		},
+		"expected_ttl": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The TTL the DS records are expected to have in the parent zone",
+		},
+		"last_modified_date": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "When the current keys were last changed",
+		},
+		"rollover_in_progress": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether a key rollover is in progress",
+		},
+		"new_ds_records": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The DS records of the keys being rolled over to",
+			Elem:        dsRecordSchema(),
+		},
+	}
+}
+
+func dnsKeySchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"key_tag": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"flags": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"algorithm": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"public_key": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dsRecordSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"key_tag": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"algorithm": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"digest_type": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"digest": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"record": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The DS record data as a single string",
+			},
+		},
+	}
+}
+
+func dataSourceDNSZoneDNSSECStatusRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "dataSourceDNSZoneDNSSECStatusRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	zone, err := tools.GetStringValue("zone", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	logger.WithField("zone", zone).Debug("Fetching DNSSEC status")
+	status, err := getZoneDNSSECStatus(ctx, meta, zone)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := setZoneDNSSECStatus(d, status); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(zone)
+	return nil
+}
+
+// getZoneDNSSECStatus returns the DNSSEC status of a single zone
+func getZoneDNSSECStatus(ctx context.Context, meta akamai.OperationMeta, zone string) (*DNSSECStatus, error) {
+	resp, err := inst.DNSSECClient(meta).GetDNSSECStatus(ctx, DNSSECStatusRequest{Zones: []string{zone}})
+	if err != nil {
+		return nil, fmt.Errorf("looking up DNSSEC status for zone %s: %w", zone, err)
+	}
+	for _, status := range resp.DNSSecStatuses {
+		if strings.EqualFold(strings.TrimSuffix(status.Zone, "."), strings.TrimSuffix(zone, ".")) {
+			return &status, nil
+		}
+	}
+	return nil, fmt.Errorf("no DNSSEC status returned for zone %s; is sign_and_serve enabled?", zone)
+}
+
+// setZoneDNSSECStatus sets the computed DNSSEC attributes shared by the status data source and the key rotation resource
+func setZoneDNSSECStatus(d *schema.ResourceData, status *DNSSECStatus) error {
+	keys, err := parseDNSKeyRecords(status.CurrentRecords.DNSKeyRecord)
+	if err != nil {
+		return err
+	}
+	ds, err := parseDSRecords(status.CurrentRecords.DSRecord)
+	if err != nil {
+		return err
+	}
+	var newDS []dsRecord
+	if status.NewRecords != nil {
+		if newDS, err = parseDSRecords(status.NewRecords.DSRecord); err != nil {
+			return err
+		}
+	}
+	ksk, zsk := make([]interface{}, 0), make([]interface{}, 0)
+	for _, key := range keys {
+		item := map[string]interface{}{
+			"key_tag":    key.KeyTag,
+			"flags":      key.Flags,
+			"algorithm":  key.Algorithm,
+			"public_key": key.PublicKey,
+		}
+		if key.isKSK() {
+			ksk = append(ksk, item)
+		} else {
+			zsk = append(zsk, item)
+		}
+	}
+
+	return tools.SetAttrs(d, map[string]interface{}{
+		"alerts":               status.Alerts,
+		"ksk":                  ksk,
+		"zsk":                  zsk,
+		"ds_records":           flattenDSRecords(ds),
+		"dnskey_records":       status.CurrentRecords.DNSKeyRecord,
+		"expected_ttl":         status.CurrentRecords.ExpectedTTL,
+		"last_modified_date":   status.CurrentRecords.LastModifiedDate,
+		"rollover_in_progress": status.NewRecords != nil,
+		"new_ds_records":       flattenDSRecords(newDS),
+	})
+}
+
+func flattenDSRecords(records []dsRecord) []interface{} {
+	result := make([]interface{}, 0, len(records))
+	for _, ds := range records {
+		result = append(result, map[string]interface{}{
+			"key_tag":     ds.KeyTag,
+			"algorithm":   ds.Algorithm,
+			"digest_type": ds.DigestType,
+			"digest":      ds.Digest,
+			"record":      ds.Record,
+		})
+	}
+	return result
+}
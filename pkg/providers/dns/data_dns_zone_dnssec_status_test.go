@@ -0,0 +1,107 @@
+package dns
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDataSourceDNSZoneDNSSECStatus(t *testing.T) {
+	dataSourceName := "data.akamai_dns_zone_dnssec_status.test"
+	request := DNSSECStatusRequest{Zones: []string{"exampleterraform.io"}}
+
+	t.Run("rollover in progress", func(t *testing.T) {
+		client := &mockDNSSEC{}
+		client.On("GetDNSSECStatus",
+			mock.Anything, // ctx is irrelevant for this test
+			request,
+		).Return(&DNSSECStatusResponse{DNSSecStatuses: []DNSSECStatus{{
+			Zone:   "exampleterraform.io",
+			Alerts: []string{"DS record at parent does not match"},
+			CurrentRecords: DNSSECRecords{
+				DNSKeyRecord:     rfcDNSKeyRecord,
+				DSRecord:         rfcDSRecord,
+				ExpectedTTL:      86400,
+				LastModifiedDate: "2023-01-01T00:00:00Z",
+			},
+			NewRecords: &DNSSECRecords{
+				DSRecord: "exampleterraform.io. 86400 IN DS 12345 13 2 abcdef",
+			},
+		}}}, nil)
+
+		useDNSSECClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:          func() { testAccPreCheck(t) },
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestDataDnsZoneDNSSECStatus/basic.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(dataSourceName, "id", "exampleterraform.io"),
+							resource.TestCheckResourceAttr(dataSourceName, "alerts.#", "1"),
+							resource.TestCheckResourceAttr(dataSourceName, "ksk.#", "0"),
+							resource.TestCheckResourceAttr(dataSourceName, "zsk.#", "1"),
+							resource.TestCheckResourceAttr(dataSourceName, "zsk.0.key_tag", "60485"),
+							resource.TestCheckResourceAttr(dataSourceName, "ds_records.0.key_tag", "60485"),
+							resource.TestCheckResourceAttr(dataSourceName, "ds_records.0.digest", "2BB183AF5F22588179A53B0A98631FAD1A292118"),
+							resource.TestCheckResourceAttr(dataSourceName, "expected_ttl", "86400"),
+							resource.TestCheckResourceAttr(dataSourceName, "rollover_in_progress", "true"),
+							resource.TestCheckResourceAttr(dataSourceName, "new_ds_records.0.record", "12345 13 2 ABCDEF"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("zone not signed", func(t *testing.T) {
+		client := &mockDNSSEC{}
+		client.On("GetDNSSECStatus",
+			mock.Anything, // ctx is irrelevant for this test
+			request,
+		).Return(&DNSSECStatusResponse{}, nil)
+
+		useDNSSECClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:          func() { testAccPreCheck(t) },
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config:      loadFixtureString("testdata/TestDataDnsZoneDNSSECStatus/basic.tf"),
+						ExpectError: regexp.MustCompile(`no DNSSEC status returned`),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		client := &mockDNSSEC{}
+		client.On("GetDNSSECStatus",
+			mock.Anything, // ctx is irrelevant for this test
+			request,
+		).Return(nil, errors.New("oops"))
+
+		useDNSSECClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:          func() { testAccPreCheck(t) },
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config:      loadFixtureString("testdata/TestDataDnsZoneDNSSECStatus/basic.tf"),
+						ExpectError: regexp.MustCompile(`oops`),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
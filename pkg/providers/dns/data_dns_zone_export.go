@@ -0,0 +1,148 @@
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// defaultExportPageSize is the number of recordsets requested per page when exporting a zone
+const defaultExportPageSize = 100
+
+func dataSourceDNSZoneExport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDNSZoneExportRead,
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The zone to export",
+			},
+			"types": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Limits the export to the given record types",
+			},
+			"page_size": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          defaultExportPageSize,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				Description:      "The number of recordsets fetched per API request",
+			},
+			"recordsets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "All recordsets of the zone, sorted by name and type",
+				Elem:        zoneFileRecordsetSchema(),
+			},
+			"bind": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The recordsets rendered as a canonical master file",
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The recordsets as JSON, in the format accepted by the recordsets API",
+			},
+		},
+	}
+}
+
+func dataSourceDNSZoneExportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "dataSourceDNSZoneExportRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	zone, err := tools.GetStringValue("zone", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	pageSize, err := tools.GetIntValue("page_size", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	typeSet, err := tools.GetSetValue("types", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	types := make([]string, 0, typeSet.Len())
+	for _, t := range typeSet.List() {
+		types = append(types, strings.ToUpper(t.(string)))
+	}
+	sort.Strings(types)
+
+	logger.WithFields(log.Fields{
+		"zone":  zone,
+		"types": types,
+	}).Debug("Exporting zone")
+	recordsets, err := listAllRecordsets(ctx, meta, zone, strings.Join(types, ","), pageSize)
+	if err != nil {
+		return diag.Errorf("exporting zone %s: %s", zone, err)
+	}
+	sortRecordsets(recordsets)
+
+	body, err := json.MarshalIndent(dns.Recordsets{Recordsets: recordsets}, "", "  ")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("recordsets", flattenRecordsets(recordsets)); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	if err := d.Set("bind", renderZoneFile(zone, recordsets)); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	if err := d.Set("json", string(body)); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	d.SetId(zone)
+	return nil
+}
+
+// listAllRecordsets pages through the recordsets of a zone
+func listAllRecordsets(ctx context.Context, meta akamai.OperationMeta, zone, types string, pageSize int) ([]dns.Recordset, error) {
+	var recordsets []dns.Recordset
+	for page := 1; ; page++ {
+		resp, err := inst.Client(meta).GetRecordsets(ctx, zone, dns.RecordsetQueryArgs{
+			Page:     page,
+			PageSize: pageSize,
+			SortBy:   "name,type",
+			Types:    types,
+		})
+		if err != nil {
+			return nil, err
+		}
+		recordsets = append(recordsets, resp.Recordsets...)
+		if page >= resp.Metadata.LastPage || len(resp.Recordsets) == 0 {
+			return recordsets, nil
+		}
+	}
+}
+
+// sortRecordsets orders recordsets by name and type, with rdata sorted within each set
+func sortRecordsets(recordsets []dns.Recordset) {
+	for i := range recordsets {
+		sort.Strings(recordsets[i].Rdata)
+	}
+	sort.SliceStable(recordsets, func(i, j int) bool {
+		return recordsetKey(recordsets[i].Name, recordsets[i].Type) < recordsetKey(recordsets[j].Name, recordsets[j].Type)
+	})
+}
@@ -0,0 +1,90 @@
+package dns
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/dns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDataSourceDNSZoneExport(t *testing.T) {
+	dataSourceName := "data.akamai_dns_zone_export.test"
+	queryArgs := func(page int) []dns.RecordsetQueryArgs {
+		return []dns.RecordsetQueryArgs{{Page: page, PageSize: 2, SortBy: "name,type"}}
+	}
+
+	t.Run("pages through all recordsets", func(t *testing.T) {
+		client := &dns.Mock{}
+		client.On("GetRecordsets",
+			mock.Anything, // ctx is irrelevant for this test
+			"exampleterraform.io",
+			queryArgs(1),
+		).Return(&dns.RecordSetResponse{
+			Metadata: dns.MetadataH{Page: 1, PageSize: 2, LastPage: 2, TotalElements: 3},
+			Recordsets: []dns.Recordset{
+				{Name: "www.exampleterraform.io", Type: "A", TTL: 300, Rdata: []string{"10.0.0.2", "10.0.0.1"}},
+				{Name: "exampleterraform.io", Type: "SOA", TTL: 86400, Rdata: []string{"a1-1.akam.net. hostmaster.exampleterraform.io. 1 3600 600 604800 300"}},
+			},
+		}, nil)
+		client.On("GetRecordsets",
+			mock.Anything, // ctx is irrelevant for this test
+			"exampleterraform.io",
+			queryArgs(2),
+		).Return(&dns.RecordSetResponse{
+			Metadata: dns.MetadataH{Page: 2, PageSize: 2, LastPage: 2, TotalElements: 3},
+			Recordsets: []dns.Recordset{
+				{Name: "exampleterraform.io", Type: "NS", TTL: 86400, Rdata: []string{"a1-1.akam.net."}},
+			},
+		}, nil)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:          func() { testAccPreCheck(t) },
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestDataDnsZoneExport/basic.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(dataSourceName, "id", "exampleterraform.io"),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.#", "3"),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.0.type", "NS"),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.1.type", "SOA"),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.2.rdata.0", "10.0.0.1"),
+							resource.TestMatchResourceAttr(dataSourceName, "bind", regexp.MustCompile(`(?s)^\$ORIGIN exampleterraform.io.\nexampleterraform.io. 86400 IN SOA .*www.exampleterraform.io. 300 IN A 10.0.0.2\n$`)),
+							resource.TestMatchResourceAttr(dataSourceName, "json", regexp.MustCompile(`"recordsets"`)),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		client := &dns.Mock{}
+		client.On("GetRecordsets",
+			mock.Anything, // ctx is irrelevant for this test
+			"exampleterraform.io",
+			queryArgs(1),
+		).Return(nil, errors.New("zone not found"))
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:          func() { testAccPreCheck(t) },
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config:      loadFixtureString("testdata/TestDataDnsZoneExport/basic.tf"),
+						ExpectError: regexp.MustCompile(`zone not found`),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,213 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDNSZoneFile() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDNSZoneFileRead,
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The zone the master file belongs to. Used as the initial $ORIGIN",
+			},
+			"content": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The content of an RFC 1035 master file",
+			},
+			"compare_live": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to compare the parsed master file against the live zone",
+			},
+			"recordsets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The recordsets parsed from the master file",
+				Elem:        zoneFileRecordsetSchema(),
+			},
+			"canonical": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The master file rendered in canonical form",
+			},
+			"in_sync": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the live zone matches the master file. Only set when compare_live is true",
+			},
+			"drift": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Recordsets which differ between the master file and the live zone",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "One of missing (only in the master file), extra (only in the live zone) or changed",
+						},
+						"expected_ttl": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"expected_rdata": {
+							Type:     schema.TypeList,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Computed: true,
+						},
+						"live_ttl": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"live_rdata": {
+							Type:     schema.TypeList,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func zoneFileRecordsetSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ttl": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"rdata": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceDNSZoneFileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "dataSourceDNSZoneFileRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	zone, err := tools.GetStringValue("zone", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	content, err := tools.GetStringValue("content", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compareLive, err := tools.GetBoolValue("compare_live", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	logger.WithFields(log.Fields{
+		"zone":        zone,
+		"comparelive": compareLive,
+	}).Debug("Parsing master file")
+	recordsets, err := parseZoneFile(content, zone)
+	if err != nil {
+		return diag.Errorf("parsing master file for zone %s: %s", zone, err)
+	}
+
+	if err := d.Set("recordsets", flattenRecordsets(recordsets)); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	if err := d.Set("canonical", renderZoneFile(zone, recordsets)); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+
+	if compareLive {
+		liveContent, err := inst.Client(meta).GetMasterZoneFile(ctx, zone)
+		if err != nil {
+			return diag.Errorf("retrieving master file for zone %s: %s", zone, err)
+		}
+		live, err := parseZoneFile(liveContent, zone)
+		if err != nil {
+			return diag.Errorf("parsing live master file for zone %s: %s", zone, err)
+		}
+		drift := diffRecordsets(recordsets, withoutManagedRecords(zone, recordsets, live))
+		logger.WithField("drift", len(drift)).Debug("Compared master file with live zone")
+		if err := d.Set("in_sync", len(drift) == 0); err != nil {
+			return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+		}
+		if err := d.Set("drift", flattenZoneFileDrift(drift)); err != nil {
+			return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+		}
+	}
+
+	d.SetId(zone)
+	return nil
+}
+
+func flattenRecordsets(recordsets []dns.Recordset) []interface{} {
+	result := make([]interface{}, 0, len(recordsets))
+	for _, rs := range recordsets {
+		result = append(result, map[string]interface{}{
+			"name":  rs.Name,
+			"type":  rs.Type,
+			"ttl":   rs.TTL,
+			"rdata": rs.Rdata,
+		})
+	}
+	return result
+}
+
+func flattenZoneFileDrift(drift []zoneFileDrift) []interface{} {
+	result := make([]interface{}, 0, len(drift))
+	for _, entry := range drift {
+		item := map[string]interface{}{
+			"name":   entry.Name,
+			"type":   entry.Type,
+			"status": entry.Status,
+		}
+		if entry.Expected != nil {
+			item["expected_ttl"] = entry.Expected.TTL
+			item["expected_rdata"] = entry.Expected.Rdata
+		}
+		if entry.Live != nil {
+			item["live_ttl"] = entry.Live.TTL
+			item["live_rdata"] = entry.Live.Rdata
+		}
+		result = append(result, item)
+	}
+	return result
+}
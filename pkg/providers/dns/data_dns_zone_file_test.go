@@ -0,0 +1,122 @@
+package dns
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/dns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDataSourceDNSZoneFile(t *testing.T) {
+	liveZone := `$ORIGIN exampleterraform.io.
+exampleterraform.io. 86400 IN SOA a1-1.akam.net. hostmaster.exampleterraform.io. 12 3600 600 604800 300
+exampleterraform.io. 86400 IN NS a1-1.akam.net.
+www.exampleterraform.io. 300 IN A 10.0.0.1
+www.exampleterraform.io. 300 IN A 10.0.0.9
+mail.exampleterraform.io. 300 IN MX 10 mx1.exampleterraform.io.
+txt.exampleterraform.io. 300 IN TXT "v=spf1 mx -all"
+`
+	dataSourceName := "data.akamai_dns_zone_file.test"
+
+	t.Run("parse only", func(t *testing.T) {
+		client := &dns.Mock{}
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:          func() { testAccPreCheck(t) },
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestDataDnsZoneFile/basic.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(dataSourceName, "id", "exampleterraform.io"),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.#", "3"),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.0.name", "www.exampleterraform.io"),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.0.rdata.#", "2"),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.1.rdata.0", "10 mx1.exampleterraform.io."),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.2.rdata.0", `"v=spf1 mx -all"`),
+							resource.TestCheckResourceAttrSet(dataSourceName, "canonical"),
+							resource.TestCheckResourceAttr(dataSourceName, "drift.#", "0"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("compare with live zone", func(t *testing.T) {
+		client := &dns.Mock{}
+		client.On("GetMasterZoneFile",
+			mock.Anything, // ctx is irrelevant for this test
+			"exampleterraform.io",
+		).Return(liveZone, nil)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:          func() { testAccPreCheck(t) },
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestDataDnsZoneFile/compare_live.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(dataSourceName, "in_sync", "false"),
+							resource.TestCheckResourceAttr(dataSourceName, "drift.#", "1"),
+							resource.TestCheckResourceAttr(dataSourceName, "drift.0.name", "www.exampleterraform.io"),
+							resource.TestCheckResourceAttr(dataSourceName, "drift.0.status", "changed"),
+							resource.TestCheckResourceAttr(dataSourceName, "drift.0.live_rdata.1", "10.0.0.9"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("live zone lookup error", func(t *testing.T) {
+		client := &dns.Mock{}
+		client.On("GetMasterZoneFile",
+			mock.Anything, // ctx is irrelevant for this test
+			"exampleterraform.io",
+		).Return("", errors.New("zone not found"))
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:          func() { testAccPreCheck(t) },
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config:      loadFixtureString("testdata/TestDataDnsZoneFile/compare_live.tf"),
+						ExpectError: regexp.MustCompile(`zone not found`),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("invalid master file", func(t *testing.T) {
+		client := &dns.Mock{}
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:          func() { testAccPreCheck(t) },
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config:      loadFixtureString("testdata/TestDataDnsZoneFile/invalid.tf"),
+						ExpectError: regexp.MustCompile(`no TTL given`),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,268 @@
+package dns
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/session"
+)
+
+type (
+	// DNSSEC covers the Edge DNS endpoints for Sign and Serve zones, which are not part of the edgegrid dns client
+	DNSSEC interface {
+		// GetDNSSECStatus returns the current and pending DNSSEC records of the given zones.
+		//
+		// See: https://techdocs.akamai.com/edge-dns/reference/post-zones-dns-sec-status
+		GetDNSSECStatus(context.Context, DNSSECStatusRequest) (*DNSSECStatusResponse, error)
+		// RotateKeys starts a key rollover for the given zones.
+		//
+		// See: https://techdocs.akamai.com/edge-dns/reference/post-keys-rotate
+		RotateKeys(context.Context, RotateKeysRequest) (*RotateKeysResponse, error)
+	}
+
+	// DNSSECStatusRequest contains the zones to look up the DNSSEC status for
+	DNSSECStatusRequest struct {
+		Zones []string `json:"zones"`
+	}
+
+	// DNSSECStatusResponse contains the DNSSEC status of each requested zone
+	DNSSECStatusResponse struct {
+		DNSSecStatuses []DNSSECStatus `json:"dnsSecStatuses"`
+	}
+
+	// DNSSECStatus is the DNSSEC status of a single zone
+	DNSSECStatus struct {
+		Zone           string         `json:"zone"`
+		Alerts         []string       `json:"alerts"`
+		CurrentRecords DNSSECRecords  `json:"currentRecords"`
+		NewRecords     *DNSSECRecords `json:"newRecords,omitempty"`
+	}
+
+	// DNSSECRecords are the DNSKEY and DS records of a zone at a point of a key rollover
+	DNSSECRecords struct {
+		DNSKeyRecord     string `json:"dnskeyRecord"`
+		DSRecord         string `json:"dsRecord"`
+		ExpectedTTL      int    `json:"expectedTtl"`
+		LastModifiedDate string `json:"lastModifiedDate"`
+	}
+
+	// RotateKeysRequest contains the zones to rotate the keys of
+	RotateKeysRequest struct {
+		Zones   []string `json:"zones"`
+		Comment string   `json:"comment,omitempty"`
+	}
+
+	// RotateKeysResponse contains the result of a key rotation request
+	RotateKeysResponse struct {
+		RequestID      string `json:"requestId"`
+		ExpirationDate string `json:"expirationDate"`
+	}
+
+	dnssec struct {
+		session.Session
+	}
+)
+
+// newDNSSEC returns a DNSSEC client for the given session
+func newDNSSEC(sess session.Session) DNSSEC {
+	return &dnssec{Session: sess}
+}
+
+func (p *dnssec) GetDNSSECStatus(ctx context.Context, params DNSSECStatusRequest) (*DNSSECStatusResponse, error) {
+	logger := p.Log(ctx)
+	logger.Debug("GetDNSSECStatus")
+
+	if len(params.Zones) == 0 {
+		return nil, fmt.Errorf("%w: GetDNSSECStatus requires at least one zone", dns.ErrBadRequest)
+	}
+
+	var result DNSSECStatusResponse
+	if err := p.post(ctx, "/config-dns/v2/zones/dns-sec-status", params, &result); err != nil {
+		return nil, fmt.Errorf("GetDNSSECStatus request failed: %w", err)
+	}
+	return &result, nil
+}
+
+func (p *dnssec) RotateKeys(ctx context.Context, params RotateKeysRequest) (*RotateKeysResponse, error) {
+	logger := p.Log(ctx)
+	logger.Debug("RotateKeys")
+
+	if len(params.Zones) == 0 {
+		return nil, fmt.Errorf("%w: RotateKeys requires at least one zone", dns.ErrBadRequest)
+	}
+
+	var result RotateKeysResponse
+	if err := p.post(ctx, "/config-dns/v2/keys/rotate", params, &result); err != nil {
+		return nil, fmt.Errorf("RotateKeys request failed: %w", err)
+	}
+	return &result, nil
+}
+
+func (p *dnssec) post(ctx context.Context, path string, in, out interface{}) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.Exec(req, out)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
+		return p.error(resp)
+	}
+	return nil
+}
+
+// error decodes an API problem response into the same error type the edgegrid dns client returns
+func (p *dnssec) error(r *http.Response) error {
+	e := &dns.Error{StatusCode: r.StatusCode}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		e.Title = "Failed to read error body"
+		e.Detail = err.Error()
+		return e
+	}
+	if err := json.Unmarshal(body, e); err != nil {
+		e.Title = "Failed to unmarshal error body"
+		e.Detail = err.Error()
+	}
+	return e
+}
+
+// dnsKey is a parsed DNSKEY record
+type dnsKey struct {
+	Flags     int
+	Protocol  int
+	Algorithm int
+	PublicKey string
+	KeyTag    int
+}
+
+// dsRecord is a parsed DS record
+type dsRecord struct {
+	KeyTag     int
+	Algorithm  int
+	DigestType int
+	Digest     string
+	Record     string
+}
+
+// dnskeyFlagSEP is the secure entry point flag of a DNSKEY
+const dnskeyFlagSEP = 1
+
+// isKSK tells whether the key has the secure entry point flag set, which is how key signing keys are marked
+func (k dnsKey) isKSK() bool {
+	return k.Flags&dnskeyFlagSEP != 0
+}
+
+// parseDNSKeyRecords parses DNSKEY records, one per line, given either as rdata or as full resource records
+func parseDNSKeyRecords(records string) ([]dnsKey, error) {
+	entries, err := rdataFieldsByLine(records, RRTypeDnskey)
+	if err != nil {
+		return nil, err
+	}
+	var keys []dnsKey
+	for _, fields := range entries {
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("invalid DNSKEY record %q", strings.Join(fields, " "))
+		}
+		var key dnsKey
+		if key.Flags, err = strconv.Atoi(fields[0]); err != nil {
+			return nil, fmt.Errorf("invalid DNSKEY flags %q: %w", fields[0], err)
+		}
+		if key.Protocol, err = strconv.Atoi(fields[1]); err != nil {
+			return nil, fmt.Errorf("invalid DNSKEY protocol %q: %w", fields[1], err)
+		}
+		if key.Algorithm, err = strconv.Atoi(fields[2]); err != nil {
+			return nil, fmt.Errorf("invalid DNSKEY algorithm %q: %w", fields[2], err)
+		}
+		key.PublicKey = strings.Join(fields[3:], "")
+		if key.KeyTag, err = dnsKeyTag(key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// parseDSRecords parses DS records, one per line, given either as rdata or as full resource records
+func parseDSRecords(records string) ([]dsRecord, error) {
+	entries, err := rdataFieldsByLine(records, RRTypeDs)
+	if err != nil {
+		return nil, err
+	}
+	var result []dsRecord
+	for _, fields := range entries {
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("invalid DS record %q", strings.Join(fields, " "))
+		}
+		var ds dsRecord
+		if ds.KeyTag, err = strconv.Atoi(fields[0]); err != nil {
+			return nil, fmt.Errorf("invalid DS key tag %q: %w", fields[0], err)
+		}
+		if ds.Algorithm, err = strconv.Atoi(fields[1]); err != nil {
+			return nil, fmt.Errorf("invalid DS algorithm %q: %w", fields[1], err)
+		}
+		if ds.DigestType, err = strconv.Atoi(fields[2]); err != nil {
+			return nil, fmt.Errorf("invalid DS digest type %q: %w", fields[2], err)
+		}
+		ds.Digest = strings.ToUpper(strings.Join(fields[3:], ""))
+		ds.Record = fmt.Sprintf("%d %d %d %s", ds.KeyTag, ds.Algorithm, ds.DigestType, ds.Digest)
+		result = append(result, ds)
+	}
+	return result, nil
+}
+
+// rdataFieldsByLine splits records into entries, joining parenthesized continuation lines, and returns the
+// rdata fields of each. Owner, TTL, class and type are skipped when an entry holds a full resource record.
+func rdataFieldsByLine(records, recordType string) ([][]string, error) {
+	lines, err := splitZoneFileLines(records)
+	if err != nil {
+		return nil, err
+	}
+	result := make([][]string, 0, len(lines))
+	for _, line := range lines {
+		fields := line.tokens
+		for i, f := range fields {
+			if strings.EqualFold(f, recordType) {
+				fields = fields[i+1:]
+				break
+			}
+		}
+		result = append(result, fields)
+	}
+	return result, nil
+}
+
+// dnsKeyTag calculates the key tag of a DNSKEY as defined in RFC 4034, Appendix B
+func dnsKeyTag(key dnsKey) (int, error) {
+	publicKey, err := base64.StdEncoding.DecodeString(key.PublicKey)
+	if err != nil {
+		return 0, fmt.Errorf("invalid DNSKEY public key: %w", err)
+	}
+	wire := append([]byte{byte(key.Flags >> 8), byte(key.Flags), byte(key.Protocol), byte(key.Algorithm)}, publicKey...)
+	var ac uint32
+	for i, b := range wire {
+		if i&1 == 1 {
+			ac += uint32(b)
+		} else {
+			ac += uint32(b) << 8
+		}
+	}
+	ac += ac >> 16 & 0xFFFF
+	return int(ac & 0xFFFF), nil
+}
@@ -0,0 +1,117 @@
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Example key and DS record from RFC 4034, section 5.4
+const (
+	rfcDNSKeyRecord = `dskey.example.com. 86400 IN DNSKEY 256 3 5 ( AQOeiiR0GOMYkDshWoSKz9Xz
+                                             fwJr1AYtsmx3TGkJaNXVbfi/
+                                             2pHm822aJ5iI9BMzNXxeYCmZ
+                                             DRD99WYwYqUSdjMmmAphXdvx
+                                             egXd/M5+X7OrzKBaMbCVdFLU
+                                             Uh6DhweJBjEVv5f2wwjM9Xzc
+                                             nOf+EPbtG9DMBmADjFDc2w/r
+                                             ljwvFw==
+                                             ) ;  key id = 60485`
+	rfcDSRecord = `dskey.example.com. 86400 IN DS 60485 5 1 ( 2BB183AF5F22588179A53B0A
+                                              98631FAD1A292118 )`
+)
+
+func TestParseDNSKeyRecords(t *testing.T) {
+	keys, err := parseDNSKeyRecords(rfcDNSKeyRecord + "\nexample.com. 3600 IN DNSKEY 257 3 5 " + "AQOeiiR0GOMYkDshWoSKz9XzfwJr1AYtsmx3TGkJaNXVbfi/2pHm822aJ5iI9BMzNXxeYCmZDRD99WYwYqUSdjMmmAphXdvxegXd/M5+X7OrzKBaMbCVdFLUUh6DhweJBjEVv5f2wwjM9XzcnOf+EPbtG9DMBmADjFDc2w/rljwvFw==")
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, 60485, keys[0].KeyTag)
+	assert.False(t, keys[0].isKSK())
+	assert.Equal(t, 5, keys[0].Algorithm)
+	assert.Equal(t, 257, keys[1].Flags)
+	assert.True(t, keys[1].isKSK())
+	assert.Equal(t, 60486, keys[1].KeyTag)
+
+	_, err = parseDNSKeyRecords("257 3 13 not-base64!")
+	assert.Error(t, err)
+	_, err = parseDNSKeyRecords("257 3")
+	assert.Error(t, err)
+}
+
+func TestParseDSRecords(t *testing.T) {
+	records, err := parseDSRecords(rfcDSRecord + "\n12345 13 2 abcdef")
+	require.NoError(t, err)
+	assert.Equal(t, []dsRecord{
+		{KeyTag: 60485, Algorithm: 5, DigestType: 1, Digest: "2BB183AF5F22588179A53B0A98631FAD1A292118", Record: "60485 5 1 2BB183AF5F22588179A53B0A98631FAD1A292118"},
+		{KeyTag: 12345, Algorithm: 13, DigestType: 2, Digest: "ABCDEF", Record: "12345 13 2 ABCDEF"},
+	}, records)
+
+	_, err = parseDSRecords("x 13 2 abcdef")
+	assert.Error(t, err)
+}
+
+func TestDNSSECClient(t *testing.T) {
+	newClient := func(t *testing.T, handler http.HandlerFunc) DNSSEC {
+		server := httptest.NewTLSServer(handler)
+		t.Cleanup(server.Close)
+		serverURL, err := url.Parse(server.URL)
+		require.NoError(t, err)
+		sess, err := session.New(
+			session.WithClient(server.Client()),
+			session.WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+		)
+		require.NoError(t, err)
+		return newDNSSEC(sess)
+	}
+
+	t.Run("status", func(t *testing.T) {
+		client := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/config-dns/v2/zones/dns-sec-status", r.URL.Path)
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"zones":["example.com"]}`, string(body))
+			w.WriteHeader(http.StatusOK)
+			_, err = w.Write([]byte(`{"dnsSecStatuses":[{"zone":"example.com","alerts":[],"currentRecords":{"dnskeyRecord":"k","dsRecord":"d","expectedTtl":3600,"lastModifiedDate":"2023-01-01T00:00:00Z"}}]}`))
+			require.NoError(t, err)
+		})
+		resp, err := client.GetDNSSECStatus(context.Background(), DNSSECStatusRequest{Zones: []string{"example.com"}})
+		require.NoError(t, err)
+		require.Len(t, resp.DNSSecStatuses, 1)
+		assert.Equal(t, 3600, resp.DNSSecStatuses[0].CurrentRecords.ExpectedTTL)
+		assert.Nil(t, resp.DNSSecStatuses[0].NewRecords)
+	})
+
+	t.Run("rotate error", func(t *testing.T) {
+		client := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/config-dns/v2/keys/rotate", r.URL.Path)
+			var req RotateKeysRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, RotateKeysRequest{Zones: []string{"example.com"}, Comment: "yearly"}, req)
+			w.WriteHeader(http.StatusForbidden)
+			_, err := w.Write([]byte(`{"title":"Forbidden","detail":"zone is not signed"}`))
+			require.NoError(t, err)
+		})
+		_, err := client.RotateKeys(context.Background(), RotateKeysRequest{Zones: []string{"example.com"}, Comment: "yearly"})
+		var apiError *dns.Error
+		require.True(t, errors.As(err, &apiError))
+		assert.Equal(t, http.StatusForbidden, apiError.StatusCode)
+		assert.Equal(t, "zone is not signed", apiError.Detail)
+	})
+
+	t.Run("missing zones", func(t *testing.T) {
+		_, err := newDNSSEC(session.Must(session.New())).RotateKeys(context.Background(), RotateKeysRequest{})
+		assert.True(t, errors.Is(err, dns.ErrBadRequest))
+	})
+}
@@ -0,0 +1,27 @@
+package dns
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type mockDNSSEC struct {
+	mock.Mock
+}
+
+func (m *mockDNSSEC) GetDNSSECStatus(ctx context.Context, params DNSSECStatusRequest) (*DNSSECStatusResponse, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*DNSSECStatusResponse), args.Error(1)
+}
+
+func (m *mockDNSSEC) RotateKeys(ctx context.Context, params RotateKeysRequest) (*RotateKeysResponse, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*RotateKeysResponse), args.Error(1)
+}
@@ -20,6 +20,7 @@ type (
 		*schema.Provider
 
 		client dns.DNS
+		dnssec DNSSEC
 	}
 
 	// Option is a dns provider option
@@ -61,12 +62,19 @@ func Provider() *schema.Provider {
 			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"akamai_authorities_set": dataSourceAuthoritiesSet(),
-			"akamai_dns_record_set":  dataSourceDNSRecordSet(),
+			"akamai_authorities_set":        dataSourceAuthoritiesSet(),
+			"akamai_dns_record_set":         dataSourceDNSRecordSet(),
+			"akamai_dns_zone_dnssec_status": dataSourceDNSZoneDNSSECStatus(),
+			"akamai_dns_zone_export":        dataSourceDNSZoneExport(),
+			"akamai_dns_zone_file":          dataSourceDNSZoneFile(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"akamai_dns_zone":   resourceDNSv2Zone(),
-			"akamai_dns_record": resourceDNSv2Record(),
+			"akamai_dns_zone":              resourceDNSv2Zone(),
+			"akamai_dns_record":            resourceDNSv2Record(),
+			"akamai_dns_bulk_zones":        resourceDNSBulkZones(),
+			"akamai_dns_tsig_key":          resourceDNSTSIGKey(),
+			"akamai_dns_zone_file":         resourceDNSZoneFile(),
+			"akamai_dns_zone_key_rotation": resourceDNSZoneKeyRotation(),
 		},
 	}
 	return provider
@@ -79,6 +87,13 @@ func WithClient(c dns.DNS) Option {
 	}
 }
 
+// WithDNSSECClient sets the DNSSEC client interface, used for mocking and testing
+func WithDNSSECClient(c DNSSEC) Option {
+	return func(p *provider) {
+		p.dnssec = c
+	}
+}
+
 // Client returns the DNS interface
 func (p *provider) Client(meta akamai.OperationMeta) dns.DNS {
 	if p.client != nil {
@@ -87,6 +102,14 @@ func (p *provider) Client(meta akamai.OperationMeta) dns.DNS {
 	return dns.Client(meta.Session())
 }
 
+// DNSSECClient returns the DNSSEC interface
+func (p *provider) DNSSECClient(meta akamai.OperationMeta) DNSSEC {
+	if p.dnssec != nil {
+		return p.dnssec
+	}
+	return newDNSSEC(meta.Session())
+}
+
 func getConfigDNSV2Service(d *schema.ResourceData) error {
 	var inlineConfig *schema.Set
 	for _, key := range []string{"dns", "config"} {
@@ -49,6 +49,20 @@ func useClient(client dns.DNS, f func()) {
 	f()
 }
 
+// useDNSSECClient swaps out the DNSSEC client on the global instance for the duration of the given func
+func useDNSSECClient(client DNSSEC, f func()) {
+	clientLock.Lock()
+	orig := inst.dnssec
+	inst.dnssec = client
+
+	defer func() {
+		inst.dnssec = orig
+		clientLock.Unlock()
+	}()
+
+	f()
+}
+
 func TestProvider(t *testing.T) {
 	if err := inst.Provider.InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
@@ -0,0 +1,120 @@
+package rdata
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PadValue formats a LOC distance, with or without the meter suffix, with two decimals. Values which can not
+// be parsed become 0.00.
+func PadValue(str string) string {
+	v, _ := strconv.ParseFloat(strings.ReplaceAll(str, "m", ""), 32)
+	return fmt.Sprintf("%.2f", v)
+}
+
+// PadCoordinates pads the distances of a LOC record with all 12 fields present to x.xxm format.
+// It returns an empty string for shorter records.
+func PadCoordinates(str string) string {
+	s := strings.Split(str, " ")
+	if len(s) < 12 {
+		return ""
+	}
+	latD, latM, latS, latDir, longD, longM, longS, longDir, altitude, size, horizPrecision, vertPrecision := s[0], s[1], s[2], s[3], s[4], s[5], s[6], s[7], s[8], s[9], s[10], s[11]
+	return fmt.Sprintf("%s %s %s %s %s %s %s %s %sm %sm %sm %sm", latD, latM, latS, latDir, longD, longM, longS, longDir, PadValue(altitude), PadValue(size), PadValue(horizPrecision), PadValue(vertPrecision))
+}
+
+// defaults of the optional LOC distances, RFC 1876 section 3
+var locDefaults = []string{"1m", "10000m", "10m"}
+
+// parseLOC parses a location, RFC 1876 section 3:
+//
+//	d1 [m1 [s1]] {"N"|"S"} d2 [m2 [s2]] {"E"|"W"} alt["m"] [siz["m"] [hp["m"] [vp["m"]]]]
+//
+// Minutes, seconds and the optional distances are filled in, so the canonical form always has 12 fields.
+func parseLOC(tokens []token) ([]string, error) {
+	values := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		values = append(values, t.value)
+	}
+	latitude, rest, err := locCoordinate(values, "N", "S", 90)
+	if err != nil {
+		return nil, err
+	}
+	longitude, rest, err := locCoordinate(rest, "E", "W", 180)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) == 0 {
+		return nil, fmt.Errorf("altitude is missing")
+	}
+	if len(rest) > 4 {
+		return nil, fmt.Errorf("unexpected fields %v", rest[4:])
+	}
+	rest = append(rest, locDefaults[len(rest)-1:]...)
+
+	fields := append(latitude, longitude...)
+	altitude, err := locDistance(rest[0], "altitude", -100000, 42849672.95)
+	if err != nil {
+		return nil, err
+	}
+	fields = append(fields, altitude)
+	for i, name := range []string{"size", "horizontal precision", "vertical precision"} {
+		distance, err := locDistance(rest[i+1], name, 0, 90000000)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, distance)
+	}
+	return fields, nil
+}
+
+// locCoordinate parses degrees, optional minutes and seconds and the hemisphere
+func locCoordinate(values []string, positive, negative string, maxDegrees int) ([]string, []string, error) {
+	hemisphere := -1
+	for i, v := range values {
+		if i > 3 {
+			break
+		}
+		if u := strings.ToUpper(v); u == positive || u == negative {
+			hemisphere = i
+			break
+		}
+	}
+	if hemisphere < 1 {
+		return nil, nil, fmt.Errorf("expected degrees, optional minutes and seconds and %s or %s", positive, negative)
+	}
+	parts := values[:hemisphere]
+	degrees, err := strconv.Atoi(parts[0])
+	if err != nil || degrees < 0 || degrees > maxDegrees {
+		return nil, nil, fmt.Errorf("degrees %q must be between 0 and %d", parts[0], maxDegrees)
+	}
+	minutes, seconds := 0, 0.0
+	if len(parts) > 1 {
+		if minutes, err = strconv.Atoi(parts[1]); err != nil || minutes < 0 || minutes > 59 {
+			return nil, nil, fmt.Errorf("minutes %q must be between 0 and 59", parts[1])
+		}
+	}
+	if len(parts) > 2 {
+		if seconds, err = strconv.ParseFloat(parts[2], 64); err != nil || seconds < 0 || seconds >= 60 {
+			return nil, nil, fmt.Errorf("seconds %q must be between 0 and 59.999", parts[2])
+		}
+	}
+	if degrees == maxDegrees && (minutes > 0 || seconds > 0) {
+		return nil, nil, fmt.Errorf("coordinate exceeds %d degrees", maxDegrees)
+	}
+	return []string{
+		strconv.Itoa(degrees),
+		strconv.Itoa(minutes),
+		strconv.FormatFloat(seconds, 'f', 3, 64),
+		strings.ToUpper(values[hemisphere]),
+	}, values[hemisphere+1:], nil
+}
+
+func locDistance(value, name string, min, max float64) (string, error) {
+	v, err := strconv.ParseFloat(strings.TrimSuffix(strings.ToLower(value), "m"), 64)
+	if err != nil || v < min || v > max {
+		return "", fmt.Errorf("%s %q must be between %.2fm and %.2fm", name, value, min, max)
+	}
+	return strconv.FormatFloat(v, 'f', 2, 64) + "m", nil
+}
@@ -0,0 +1,79 @@
+package rdata
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalLOC(t *testing.T) {
+	// examples from RFC 1876 section 3 and common variations
+	tests := map[string]struct {
+		value     string
+		expected  string
+		withError bool
+	}{
+		"all fields": {
+			value:    "42 21 54 N 71 06 18 W -24m 30m",
+			expected: "42 21 54.000 N 71 6 18.000 W -24.00m 30.00m 10000.00m 10.00m",
+		},
+		"precisions": {
+			value:    "42 21 43.952 N 71 5 6.344 W -24m 1m 200m 10m",
+			expected: "42 21 43.952 N 71 5 6.344 W -24.00m 1.00m 200.00m 10.00m",
+		},
+		"degrees only": {
+			value:    "52 N 4 E 100",
+			expected: "52 0 0.000 N 4 0 0.000 E 100.00m 1.00m 10000.00m 10.00m",
+		},
+		"lower case hemisphere": {
+			value:    "52 22 n 4 53 e 0m",
+			expected: "52 22 0.000 N 4 53 0.000 E 0.00m 1.00m 10000.00m 10.00m",
+		},
+		"pole": {
+			value:    "90 S 0 E 0",
+			expected: "90 0 0.000 S 0 0 0.000 E 0.00m 1.00m 10000.00m 10.00m",
+		},
+		"beyond pole":      {value: "90 1 N 0 E 0", withError: true},
+		"latitude too big": {value: "91 N 0 E 0", withError: true},
+		"minutes too big":  {value: "42 60 N 71 W 0", withError: true},
+		"seconds too big":  {value: "42 21 60 N 71 W 0", withError: true},
+		"no hemisphere":    {value: "42 21 54 71 06 18 W -24m", withError: true},
+		"no altitude":      {value: "42 21 54 N 71 06 18 W", withError: true},
+		"altitude too low": {value: "42 N 71 W -100001m", withError: true},
+		"too many fields":  {value: "42 N 71 W 0 1 2 3 4", withError: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			canonical, err := Canonical("LOC", test.value)
+			if test.withError {
+				assert.True(t, errors.Is(err, ErrInvalid), "want: %s; got: %s", ErrInvalid, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, canonical)
+		})
+	}
+}
+
+func TestPadCoordinates(t *testing.T) {
+	tests := map[string]struct {
+		value    string
+		expected string
+	}{
+		"pads distances": {
+			value:    "52 22 23.000 N 4 53 32.000 E -2.00m 0.00m 10000m 10",
+			expected: "52 22 23.000 N 4 53 32.000 E -2.00m 0.00m 10000.00m 10.00m",
+		},
+		"too short": {
+			value:    "52 22 23.000 N 4 53 32.000 E -2.00m",
+			expected: "",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, PadCoordinates(test.value))
+		})
+	}
+}
@@ -0,0 +1,341 @@
+// Package rdata parses, validates and canonicalizes the RDATA of the record types supported by Edge DNS
+package rdata
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalid is returned when RDATA does not match the presentation format of its record type
+	ErrInvalid = errors.New("invalid rdata")
+	// ErrUnsupportedType is returned for record types this package has no parser for
+	ErrUnsupportedType = errors.New("unsupported record type")
+)
+
+// parser converts the tokens of a single record into its canonical fields
+type parser func(tokens []token) ([]string, error)
+
+var parsers = map[string]parser{
+	"A":          parseA,
+	"AAAA":       parseAAAA,
+	"AFSDB":      parseAFSDB,
+	"AKAMAICDN":  parseSingleName,
+	"AKAMAITLC":  parseVerbatim,
+	"CAA":        parseCAA,
+	"CERT":       parseCERT,
+	"CNAME":      parseSingleName,
+	"DNSKEY":     parseDNSKEY,
+	"DS":         parseDS,
+	"HINFO":      parseHINFO,
+	"HTTPS":      parseSVCB,
+	"LOC":        parseLOC,
+	"MX":         parseMX,
+	"NAPTR":      parseNAPTR,
+	"NS":         parseSingleName,
+	"NSEC3":      parseNSEC3,
+	"NSEC3PARAM": parseNSEC3PARAM,
+	"PTR":        parseSingleName,
+	"RP":         parseRP,
+	"RRSIG":      parseRRSIG,
+	"SOA":        parseSOA,
+	"SPF":        parseTXT,
+	"SRV":        parseSRV,
+	"SSHFP":      parseSSHFP,
+	"SVCB":       parseSVCB,
+	"TLSA":       parseTLSA,
+	"TXT":        parseTXT,
+}
+
+// Canonical returns the canonical presentation form of a single record: domain names are lower case and
+// fully qualified, numbers are decimal without leading zeros, hex is upper case, character strings are
+// quoted and at most 255 octets long, and optional fields are filled in with their defaults.
+func Canonical(recordType, value string) (string, error) {
+	recordType = strings.ToUpper(recordType)
+	parse, ok := parsers[recordType]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedType, recordType)
+	}
+	if trimmed := strings.TrimSpace(value); (recordType == "TXT" || recordType == "SPF") && !strings.HasPrefix(trimmed, `"`) {
+		// an unquoted text value is a single string, as the record resource treats it
+		value = `"` + escapeQuotes(trimmed) + `"`
+	}
+	tokens, err := tokenize(value)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s %q: %s", ErrInvalid, recordType, value, err)
+	}
+	fields, err := parse(tokens)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s %q: %s", ErrInvalid, recordType, value, err)
+	}
+	return strings.Join(fields, " "), nil
+}
+
+// Validate checks that value is valid RDATA for the record type
+func Validate(recordType, value string) error {
+	_, err := Canonical(recordType, value)
+	return err
+}
+
+// Equal tells whether two records of the given type are the same once canonicalized. Values which can not
+// be parsed are compared verbatim.
+func Equal(recordType, a, b string) bool {
+	ca, errA := Canonical(recordType, a)
+	cb, errB := Canonical(recordType, b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return ca == cb
+}
+
+// Supported tells whether the record type has a parser
+func Supported(recordType string) bool {
+	_, ok := parsers[strings.ToUpper(recordType)]
+	return ok
+}
+
+// token is a whitespace separated part of a record
+type token struct {
+	value  string
+	quoted bool
+}
+
+// tokenize splits a record into tokens. Quoted strings keep their escape sequences, and parentheses used to
+// spread a record over several lines are dropped.
+func tokenize(value string) ([]token, error) {
+	var (
+		tokens  []token
+		current strings.Builder
+		inToken bool
+		quoted  bool
+	)
+	flush := func() {
+		if inToken {
+			tokens = append(tokens, token{value: current.String(), quoted: quoted})
+		}
+		current.Reset()
+		inToken, quoted = false, false
+	}
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == '\\':
+			if i+1 == len(value) {
+				return nil, errors.New("dangling escape")
+			}
+			current.WriteByte(c)
+			current.WriteByte(value[i+1])
+			inToken = true
+			i++
+		case quoted:
+			if c == '"' {
+				tokens = append(tokens, token{value: current.String(), quoted: true})
+				current.Reset()
+				inToken, quoted = false, false
+				continue
+			}
+			current.WriteByte(c)
+		case c == '"':
+			flush()
+			inToken, quoted = true, true
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '(' || c == ')':
+			flush()
+		default:
+			current.WriteByte(c)
+			inToken = true
+		}
+	}
+	if quoted {
+		return nil, errors.New("unterminated quoted string")
+	}
+	flush()
+	return tokens, nil
+}
+
+// Name returns the canonical form of a domain name: lower case and fully qualified
+func Name(value string) (string, error) {
+	if value == "." || value == "@" {
+		return value, nil
+	}
+	name := strings.TrimSuffix(strings.ToLower(value), ".")
+	if name == "" {
+		return "", errors.New("empty domain name")
+	}
+	if len(name) > 253 {
+		return "", fmt.Errorf("domain name %q is longer than 253 characters", value)
+	}
+	for _, label := range strings.Split(name, ".") {
+		if label == "" {
+			return "", fmt.Errorf("domain name %q has an empty label", value)
+		}
+		if len(label) > 63 {
+			return "", fmt.Errorf("label %q is longer than 63 characters", label)
+		}
+	}
+	return name + ".", nil
+}
+
+func expectTokens(tokens []token, names ...string) error {
+	if len(tokens) != len(names) {
+		return fmt.Errorf("expected %d fields (%s), got %d", len(names), strings.Join(names, ", "), len(tokens))
+	}
+	return nil
+}
+
+func minTokens(tokens []token, names ...string) error {
+	if len(tokens) < len(names) {
+		return fmt.Errorf("expected at least %d fields (%s), got %d", len(names), strings.Join(names, ", "), len(tokens))
+	}
+	return nil
+}
+
+func uintField(t token, name string, bits int) (string, error) {
+	v, err := strconv.ParseUint(t.value, 10, bits)
+	if err != nil {
+		return "", fmt.Errorf("%s %q is not an unsigned %d bit number", name, t.value, bits)
+	}
+	return strconv.FormatUint(v, 10), nil
+}
+
+// uintIn parses a number which has to be one of the allowed values
+func uintIn(t token, name string, allowed ...uint64) (uint64, error) {
+	v, err := strconv.ParseUint(t.value, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("%s %q is not an unsigned 8 bit number", name, t.value)
+	}
+	for _, a := range allowed {
+		if v == a {
+			return v, nil
+		}
+	}
+	return 0, fmt.Errorf("%s %d is not one of %v", name, v, allowed)
+}
+
+func nameField(t token) (string, error) {
+	return Name(t.value)
+}
+
+// hexField joins the tokens, which may be split by whitespace, into upper case hex of the given length in octets.
+// A length of 0 accepts any length.
+func hexField(tokens []token, name string, length int) (string, error) {
+	var joined strings.Builder
+	for _, t := range tokens {
+		joined.WriteString(t.value)
+	}
+	value := joined.String()
+	decoded, err := hex.DecodeString(value)
+	if err != nil || len(decoded) == 0 {
+		return "", fmt.Errorf("%s %q is not hex encoded", name, value)
+	}
+	if length > 0 && len(decoded) != length {
+		return "", fmt.Errorf("%s has %d octets, expected %d", name, len(decoded), length)
+	}
+	return strings.ToUpper(value), nil
+}
+
+// base64Field joins the tokens, which may be split by whitespace, into a single base64 string
+func base64Field(tokens []token, name string) (string, error) {
+	var joined strings.Builder
+	for _, t := range tokens {
+		joined.WriteString(t.value)
+	}
+	value := joined.String()
+	if _, err := base64.StdEncoding.DecodeString(value); err != nil || value == "" {
+		return "", fmt.Errorf("%s is not base64 encoded", name)
+	}
+	return value, nil
+}
+
+func base32HexField(t token, name string) (string, error) {
+	value := strings.ToUpper(t.value)
+	if _, err := base32.HexEncoding.WithPadding(base32.NoPadding).DecodeString(value); err != nil || value == "" {
+		return "", fmt.Errorf("%s %q is not base32hex encoded", name, t.value)
+	}
+	return value, nil
+}
+
+// maxCharacterString is the maximum length of a character string, RFC 1035 section 3.3
+const maxCharacterString = 255
+
+// characterStrings returns the tokens as quoted character strings, splitting strings longer than 255 octets
+func characterStrings(tokens []token) []string {
+	result := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		result = append(result, splitCharacterString(t.value)...)
+	}
+	return result
+}
+
+func splitCharacterString(value string) []string {
+	var chunks []string
+	for {
+		if octets(value) <= maxCharacterString {
+			return append(chunks, `"`+value+`"`)
+		}
+		end := cut(value, maxCharacterString)
+		chunks = append(chunks, `"`+value[:end]+`"`)
+		value = value[end:]
+	}
+}
+
+// octets returns the length of a character string once escape sequences are resolved
+func octets(value string) int {
+	n := 0
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' {
+			if i+3 < len(value) && isDigit(value[i+1]) && isDigit(value[i+2]) && isDigit(value[i+3]) {
+				i += 3
+			} else {
+				i++
+			}
+		}
+		n++
+	}
+	return n
+}
+
+// cut returns the byte offset after the given number of octets, without splitting escape sequences
+func cut(value string, limit int) int {
+	n := 0
+	for i := 0; i < len(value); i++ {
+		if n == limit {
+			return i
+		}
+		if value[i] == '\\' {
+			if i+3 < len(value) && isDigit(value[i+1]) && isDigit(value[i+2]) && isDigit(value[i+3]) {
+				i += 3
+			} else {
+				i++
+			}
+		}
+		n++
+	}
+	return len(value)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// escapeQuotes escapes the double quotes of an unquoted value which is turned into a character string
+func escapeQuotes(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && i+1 < len(value):
+			b.WriteByte(value[i])
+			b.WriteByte(value[i+1])
+			i++
+		case value[i] == '"':
+			b.WriteString(`\"`)
+		default:
+			b.WriteByte(value[i])
+		}
+	}
+	return b.String()
+}
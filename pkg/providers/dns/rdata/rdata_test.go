@@ -0,0 +1,165 @@
+package rdata
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonical(t *testing.T) {
+	tests := map[string]struct {
+		recordType string
+		value      string
+		expected   string
+		withError  error
+	}{
+		// RFC 1035
+		"A":                     {recordType: "A", value: "192.0.2.1", expected: "192.0.2.1"},
+		"A lower case type":     {recordType: "a", value: " 192.0.2.1 ", expected: "192.0.2.1"},
+		"A with IPv6":           {recordType: "A", value: "2001:db8::1", withError: ErrInvalid},
+		"A with two addresses":  {recordType: "A", value: "192.0.2.1 192.0.2.2", withError: ErrInvalid},
+		"CNAME":                 {recordType: "CNAME", value: "WWW.Example.com", expected: "www.example.com."},
+		"NS":                    {recordType: "NS", value: "a1-2.akam.net.", expected: "a1-2.akam.net."},
+		"PTR empty label":       {recordType: "PTR", value: "www..example.com", withError: ErrInvalid},
+		"CNAME long label":      {recordType: "CNAME", value: strings.Repeat("a", 64) + ".com", withError: ErrInvalid},
+		"MX":                    {recordType: "MX", value: "010 Mail.example.com", expected: "10 mail.example.com."},
+		"MX preference too big": {recordType: "MX", value: "65536 mail.example.com", withError: ErrInvalid},
+		"SOA":                   {recordType: "SOA", value: "ns1.example.com. hostmaster.example.com. 2023010101 7200 3600 1209600 300", expected: "ns1.example.com. hostmaster.example.com. 2023010101 7200 3600 1209600 300"},
+		"SOA missing minimum":   {recordType: "SOA", value: "ns1.example.com. hostmaster.example.com. 1 2 3 4", withError: ErrInvalid},
+		"HINFO":                 {recordType: "HINFO", value: `"INTEL-386" Unix`, expected: `"INTEL-386" "Unix"`},
+		"TXT quoted":            {recordType: "TXT", value: `"v=spf1 -all" "second"`, expected: `"v=spf1 -all" "second"`},
+		"TXT unquoted":          {recordType: "TXT", value: `v=spf1 include:example.net -all`, expected: `"v=spf1 include:example.net -all"`},
+		"TXT embedded quote":    {recordType: "TXT", value: `say "hi"`, expected: `"say \"hi\""`},
+		"TXT escaped quote":     {recordType: "TXT", value: `"say \"hi\""`, expected: `"say \"hi\""`},
+		"TXT unterminated":      {recordType: "TXT", value: `"v=spf1`, withError: ErrInvalid},
+		"TXT split at 255":      {recordType: "TXT", value: strings.Repeat("a", 300), expected: `"` + strings.Repeat("a", 255) + `" "` + strings.Repeat("a", 45) + `"`},
+		"TXT escape is 1 octet": {recordType: "TXT", value: `"` + strings.Repeat(`\065`, 255) + `"`, expected: `"` + strings.Repeat(`\065`, 255) + `"`},
+		"SPF":                   {recordType: "SPF", value: `v=spf1 -all`, expected: `"v=spf1 -all"`},
+		// RFC 1183
+		"AFSDB": {recordType: "AFSDB", value: "1 afs.example.com", expected: "1 afs.example.com."},
+		"RP":    {recordType: "RP", value: "admin.example.com. info.example.com", expected: "admin.example.com. info.example.com."},
+		// RFC 2782
+		"SRV":              {recordType: "SRV", value: "10 60 5060 sip.example.com", expected: "10 60 5060 sip.example.com."},
+		"SRV missing port": {recordType: "SRV", value: "10 60 sip.example.com", withError: ErrInvalid},
+		// RFC 3403
+		"NAPTR":             {recordType: "NAPTR", value: `100 10 "u" "E2U+sip" "!^.*$!sip:info@example.com!" .`, expected: `100 10 "U" "E2U+sip" "!^.*$!sip:info@example.com!" .`},
+		"NAPTR replacement": {recordType: "NAPTR", value: `100 50 "s" "http+I2L+I2C+I2R" "" _http._tcp.example.com`, expected: `100 50 "S" "http+I2L+I2C+I2R" "" _http._tcp.example.com.`},
+		"NAPTR both":        {recordType: "NAPTR", value: `100 10 "u" "E2U+sip" "!^.*$!sip:info@example.com!" example.com`, withError: ErrInvalid},
+		// RFC 3596, RFC 5952
+		"AAAA":           {recordType: "AAAA", value: "2001:0DB8:0000:0000:0000:0000:0000:0001", expected: "2001:db8::1"},
+		"AAAA with IPv4": {recordType: "AAAA", value: "192.0.2.1", withError: ErrInvalid},
+		// RFC 4034
+		"DNSKEY":              {recordType: "DNSKEY", value: "256 3 5 AQPSKmynfzW4kyBv015MUG2DeIQ3 Cbl+BBZH4b/0PY1kxkmvHjcZc8no", expected: "256 3 5 AQPSKmynfzW4kyBv015MUG2DeIQ3Cbl+BBZH4b/0PY1kxkmvHjcZc8no"},
+		"DNSKEY bad protocol": {recordType: "DNSKEY", value: "256 2 5 AQPSKmynfzW4kyBv015MUG2DeIQ3", withError: ErrInvalid},
+		"DS":                  {recordType: "DS", value: "60485 5 1 2bb183af5f22588179a53b0a 98631fad1a292118", expected: "60485 5 1 2BB183AF5F22588179A53B0A98631FAD1A292118"},
+		"DS short SHA-1":      {recordType: "DS", value: "60485 5 1 2BB183AF5F22588179A53B0A", withError: ErrInvalid},
+		"DS not hex":          {recordType: "DS", value: "60485 5 2 XYZ", withError: ErrInvalid},
+		"RRSIG":               {recordType: "RRSIG", value: "a 5 3 86400 20030322173103 20030220173103 2642 example.com. oJB1W6WNGv+ldvQ3WDG0MQkg5IEhjRip 8WTr", expected: "A 5 3 86400 20030322173103 20030220173103 2642 example.com. oJB1W6WNGv+ldvQ3WDG0MQkg5IEhjRip8WTr"},
+		"RRSIG bad signature": {recordType: "RRSIG", value: "A 5 3 86400 20030322173103 20030220173103 2642 example.com. !!!", withError: ErrInvalid},
+		// RFC 4255, RFC 6594
+		"SSHFP SHA-256":      {recordType: "SSHFP", value: "4 2 " + strings.Repeat("ab", 32), expected: "4 2 " + strings.Repeat("AB", 32)},
+		"SSHFP wrong length": {recordType: "SSHFP", value: "4 1 " + strings.Repeat("ab", 32), withError: ErrInvalid},
+		// RFC 4398
+		"CERT mnemonic":         {recordType: "CERT", value: "PGP 0 0 mQGiBDnY2vERBAD3cOxqoAYHYzS+", expected: "3 0 0 mQGiBDnY2vERBAD3cOxqoAYHYzS+"},
+		"CERT number":           {recordType: "CERT", value: "1 12 8 mQGiBDnY2vERBAD3cOxqoAYHYzS+", expected: "1 12 8 mQGiBDnY2vERBAD3cOxqoAYHYzS+"},
+		"CERT unknown mnemonic": {recordType: "CERT", value: "FOO 0 0 mQGiBDnY2vERBAD3cOxqoAYHYzS+", withError: ErrInvalid},
+		// RFC 5155
+		"NSEC3PARAM":         {recordType: "NSEC3PARAM", value: "1 0 12 aabbccdd", expected: "1 0 12 AABBCCDD"},
+		"NSEC3PARAM no salt": {recordType: "NSEC3PARAM", value: "1 0 0 -", expected: "1 0 0 -"},
+		"NSEC3":              {recordType: "NSEC3", value: "1 1 12 aabbccdd 2t7b4g4vsa5smi47k61mv5bv1a22bojr ns soa mx rrsig", expected: "1 1 12 AABBCCDD 2T7B4G4VSA5SMI47K61MV5BV1A22BOJR NS SOA MX RRSIG"},
+		"NSEC3 bad hash":     {recordType: "NSEC3", value: "1 1 12 aabbccdd zzzz A", withError: ErrInvalid},
+		// RFC 6698
+		"TLSA SHA-256":          {recordType: "TLSA", value: "3 1 1 " + strings.Repeat("0d", 32), expected: "3 1 1 " + strings.Repeat("0D", 32)},
+		"TLSA full certificate": {recordType: "TLSA", value: "0 0 0 30820307", expected: "0 0 0 30820307"},
+		"TLSA bad usage":        {recordType: "TLSA", value: "4 1 1 " + strings.Repeat("0d", 32), withError: ErrInvalid},
+		"TLSA bad SHA-512":      {recordType: "TLSA", value: "3 1 2 " + strings.Repeat("0d", 32), withError: ErrInvalid},
+		// RFC 8659
+		"CAA":                 {recordType: "CAA", value: `0 issue "ca.example.net"`, expected: `0 issue "ca.example.net"`},
+		"CAA unquoted":        {recordType: "CAA", value: `128 ISSUEWILD ca.example.net`, expected: `128 issuewild "ca.example.net"`},
+		"CAA with parameters": {recordType: "CAA", value: `0 issue "ca.example.net; account=230123"`, expected: `0 issue "ca.example.net; account=230123"`},
+		"CAA bad tag":         {recordType: "CAA", value: `0 is-sue "ca.example.net"`, withError: ErrInvalid},
+		"CAA long tag":        {recordType: "CAA", value: `0 abcdefghijklmnop "ca.example.net"`, withError: ErrInvalid},
+		"CAA flags too big":   {recordType: "CAA", value: `256 issue "ca.example.net"`, withError: ErrInvalid},
+		// RFC 9460
+		"HTTPS alias mode":       {recordType: "HTTPS", value: "0 Foo.Example.com", expected: "0 foo.example.com."},
+		"HTTPS service mode":     {recordType: "HTTPS", value: `1 . port=0443 alpn="h2,h3"`, expected: "1 . alpn=h2,h3 port=443"},
+		"SVCB alias with params": {recordType: "SVCB", value: "0 foo.example.com alpn=h2", withError: ErrInvalid},
+		// Akamai
+		"AKAMAICDN":   {recordType: "AKAMAICDN", value: "www.example.com.edgekey.net", expected: "www.example.com.edgekey.net."},
+		"AKAMAITLC":   {recordType: "AKAMAITLC", value: "DV  a1.w10.akamai.net", expected: "DV a1.w10.akamai.net"},
+		"unsupported": {recordType: "WKS", value: "10.0.0.1 TCP smtp", withError: ErrUnsupportedType},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			canonical, err := Canonical(test.recordType, test.value)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, canonical)
+
+			// canonical values are canonical
+			again, err := Canonical(test.recordType, canonical)
+			require.NoError(t, err)
+			assert.Equal(t, canonical, again)
+		})
+	}
+}
+
+func TestEqual(t *testing.T) {
+	tests := map[string]struct {
+		recordType string
+		a, b       string
+		expected   bool
+	}{
+		"AAAA short and full":     {recordType: "AAAA", a: "2001:db8::1", b: "2001:0db8:0000:0000:0000:0000:0000:0001", expected: true},
+		"AAAA different":          {recordType: "AAAA", a: "2001:db8::1", b: "2001:db8::2", expected: false},
+		"CNAME trailing dot":      {recordType: "CNAME", a: "www.example.com", b: "WWW.example.com.", expected: true},
+		"CAA quoting":             {recordType: "CAA", a: `0 issue "ca.example.net"`, b: `0 issue ca.example.net`, expected: true},
+		"LOC padded":              {recordType: "LOC", a: "52 22 23.000 N 4 53 32.000 E -2.00m 0.00m 10000.00m 10.00m", b: "52 22 23 N 4 53 32 E -2m 0m", expected: true},
+		"SVCB param order":        {recordType: "SVCB", a: "1 svc.example.com. port=8443 alpn=h2", b: "1 svc.example.com alpn=h2 port=8443", expected: true},
+		"invalid values verbatim": {recordType: "A", a: "not an address", b: "not an address", expected: true},
+		"invalid and valid":       {recordType: "A", a: "not an address", b: "192.0.2.1", expected: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Equal(test.recordType, test.a, test.b))
+		})
+	}
+}
+
+func TestName(t *testing.T) {
+	tests := map[string]struct {
+		value     string
+		expected  string
+		withError bool
+	}{
+		"relative":   {value: "Example.COM", expected: "example.com."},
+		"absolute":   {value: "example.com.", expected: "example.com."},
+		"root":       {value: ".", expected: "."},
+		"wildcard":   {value: "*.example.com", expected: "*.example.com."},
+		"underscore": {value: "_sip._tcp.example.com", expected: "_sip._tcp.example.com."},
+		"empty":      {value: "", withError: true},
+		"too long":   {value: strings.Repeat("abcdefghi.", 26), withError: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := Name(test.value)
+			if test.withError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestFullIPv6(t *testing.T) {
+	assert.Equal(t, "2001:0db8:0000:0000:0000:0000:0000:0001", FullIPv6(net.ParseIP("2001:db8::1")))
+}
@@ -0,0 +1,253 @@
+package rdata
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// svcParamKeys are the registered SvcParamKeys, RFC 9460 section 14.3.2 and RFC 9461
+var svcParamKeys = map[string]int{
+	"mandatory":       0,
+	"alpn":            1,
+	"no-default-alpn": 2,
+	"port":            3,
+	"ipv4hint":        4,
+	"ech":             5,
+	"ipv6hint":        6,
+	"dohpath":         7,
+}
+
+// svcParam is a single SvcParam of a SVCB or HTTPS record
+type svcParam struct {
+	key      int
+	value    string
+	hasValue bool
+}
+
+// parseSVCB parses service binding records, RFC 9460 section 2.1
+func parseSVCB(tokens []token) ([]string, error) {
+	if err := minTokens(tokens, "priority", "target"); err != nil {
+		return nil, err
+	}
+	priority, err := uintField(tokens[0], "priority", 16)
+	if err != nil {
+		return nil, err
+	}
+	target, err := nameField(tokens[1])
+	if err != nil {
+		return nil, err
+	}
+	params, err := svcParams(tokens[2:])
+	if err != nil {
+		return nil, err
+	}
+	// RFC 9460 section 2.4.2
+	if priority == "0" && len(params) > 0 {
+		return nil, errors.New("an AliasMode record (priority 0) can not have SvcParams")
+	}
+	return append([]string{priority, target}, params...), nil
+}
+
+// SvcParams returns the canonical form of the SvcParams of a SVCB or HTTPS record: keys in increasing numeric
+// order, registered keys by name, and list values normalized
+func SvcParams(params string) (string, error) {
+	tokens, err := tokenize(params)
+	if err != nil {
+		return "", fmt.Errorf("%w: SvcParams %q: %s", ErrInvalid, params, err)
+	}
+	fields, err := svcParams(tokens)
+	if err != nil {
+		return "", fmt.Errorf("%w: SvcParams %q: %s", ErrInvalid, params, err)
+	}
+	return strings.Join(fields, " "), nil
+}
+
+func svcParams(tokens []token) ([]string, error) {
+	params, err := splitSvcParams(tokens)
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[int]*svcParam, len(params))
+	for i := range params {
+		p := &params[i]
+		if _, ok := byKey[p.key]; ok {
+			return nil, fmt.Errorf("key %s is repeated", svcParamKeyName(p.key))
+		}
+		byKey[p.key] = p
+	}
+	sort.Slice(params, func(i, j int) bool { return params[i].key < params[j].key })
+
+	fields := make([]string, 0, len(params))
+	for _, p := range params {
+		value, err := canonicalSvcParamValue(p, byKey)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", svcParamKeyName(p.key), err)
+		}
+		if !p.hasValue {
+			fields = append(fields, svcParamKeyName(p.key))
+			continue
+		}
+		if strings.ContainsAny(value, " \t\"") {
+			value = `"` + value + `"`
+		}
+		fields = append(fields, svcParamKeyName(p.key)+"="+value)
+	}
+	return fields, nil
+}
+
+// splitSvcParams parses key=value pairs. A quoted value may follow "key=" as a separate token.
+func splitSvcParams(tokens []token) ([]svcParam, error) {
+	params := make([]svcParam, 0, len(tokens))
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		if t.quoted {
+			return nil, fmt.Errorf("unexpected quoted string %q", t.value)
+		}
+		name, value, hasValue := strings.Cut(t.value, "=")
+		if hasValue && value == "" && i+1 < len(tokens) && tokens[i+1].quoted {
+			value = tokens[i+1].value
+			i++
+		}
+		key, err := svcParamKey(name)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, svcParam{key: key, value: value, hasValue: hasValue})
+	}
+	return params, nil
+}
+
+func svcParamKey(name string) (int, error) {
+	name = strings.ToLower(name)
+	if key, ok := svcParamKeys[name]; ok {
+		return key, nil
+	}
+	if strings.HasPrefix(name, "key") {
+		digits := strings.TrimPrefix(name, "key")
+		key, err := strconv.ParseUint(digits, 10, 16)
+		if err == nil && (digits == "0" || !strings.HasPrefix(digits, "0")) {
+			if key == 65535 {
+				return 0, errors.New("key65535 is reserved")
+			}
+			return int(key), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown SvcParamKey %q", name)
+}
+
+func svcParamKeyName(key int) string {
+	for name, k := range svcParamKeys {
+		if k == key {
+			return name
+		}
+	}
+	return "key" + strconv.Itoa(key)
+}
+
+func canonicalSvcParamValue(p svcParam, all map[int]*svcParam) (string, error) {
+	requireValue := func() error {
+		if !p.hasValue || p.value == "" {
+			return errors.New("a value is required")
+		}
+		return nil
+	}
+	switch p.key {
+	case svcParamKeys["mandatory"]:
+		if err := requireValue(); err != nil {
+			return "", err
+		}
+		// RFC 9460 section 8
+		seen := make(map[int]bool)
+		var keys []int
+		for _, name := range strings.Split(p.value, ",") {
+			key, err := svcParamKey(name)
+			if err != nil {
+				return "", err
+			}
+			if key == 0 {
+				return "", errors.New("mandatory can not list itself")
+			}
+			if seen[key] {
+				return "", fmt.Errorf("%s is listed twice", name)
+			}
+			if _, ok := all[key]; !ok {
+				return "", fmt.Errorf("%s is mandatory but not present", name)
+			}
+			seen[key] = true
+			keys = append(keys, key)
+		}
+		sort.Ints(keys)
+		names := make([]string, 0, len(keys))
+		for _, key := range keys {
+			names = append(names, svcParamKeyName(key))
+		}
+		return strings.Join(names, ","), nil
+	case svcParamKeys["alpn"]:
+		if err := requireValue(); err != nil {
+			return "", err
+		}
+		// the order of protocol IDs is the client's preference and is kept
+		for _, id := range strings.Split(p.value, ",") {
+			if id == "" || len(id) > 255 {
+				return "", fmt.Errorf("invalid protocol ID %q", id)
+			}
+		}
+		return p.value, nil
+	case svcParamKeys["no-default-alpn"]:
+		if p.hasValue {
+			return "", errors.New("no value is allowed")
+		}
+		// RFC 9460 section 7.1.1
+		if _, ok := all[svcParamKeys["alpn"]]; !ok {
+			return "", errors.New("alpn must be present as well")
+		}
+		return "", nil
+	case svcParamKeys["port"]:
+		if err := requireValue(); err != nil {
+			return "", err
+		}
+		port, err := strconv.ParseUint(p.value, 10, 16)
+		if err != nil {
+			return "", fmt.Errorf("%q is not a port number", p.value)
+		}
+		return strconv.FormatUint(port, 10), nil
+	case svcParamKeys["ipv4hint"], svcParamKeys["ipv6hint"]:
+		if err := requireValue(); err != nil {
+			return "", err
+		}
+		v4 := p.key == svcParamKeys["ipv4hint"]
+		addrs := strings.Split(p.value, ",")
+		for i, addr := range addrs {
+			ip := net.ParseIP(addr)
+			if ip == nil || v4 == strings.Contains(addr, ":") {
+				return "", fmt.Errorf("%q is not an IPv%s address", addr, map[bool]string{true: "4", false: "6"}[v4])
+			}
+			addrs[i] = ip.String()
+		}
+		return strings.Join(addrs, ","), nil
+	case svcParamKeys["ech"]:
+		if err := requireValue(); err != nil {
+			return "", err
+		}
+		if _, err := base64.StdEncoding.DecodeString(p.value); err != nil {
+			return "", errors.New("value is not base64 encoded")
+		}
+		return p.value, nil
+	case svcParamKeys["dohpath"]:
+		if err := requireValue(); err != nil {
+			return "", err
+		}
+		// RFC 9461 section 5
+		if !strings.HasPrefix(p.value, "/") || !strings.Contains(p.value, "{?dns}") {
+			return "", errors.New("value must be a relative URI template with a dns variable")
+		}
+		return p.value, nil
+	default:
+		return p.value, nil
+	}
+}
@@ -0,0 +1,58 @@
+package rdata
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSvcParams(t *testing.T) {
+	// examples from RFC 9460 appendix D and section 8
+	tests := map[string]struct {
+		params    string
+		expected  string
+		withError bool
+	}{
+		"port":                {params: "port=53", expected: "port=53"},
+		"generic key":         {params: `key667=hello`, expected: "key667=hello"},
+		"generic quoted key":  {params: `key667="hello\210qoo"`, expected: `key667=hello\210qoo`},
+		"registered generic":  {params: "key3=8443", expected: "port=8443"},
+		"ipv6hint":            {params: `ipv6hint="2001:db8::1,2001:db8::53:1"`, expected: "ipv6hint=2001:db8::1,2001:db8::53:1"},
+		"ipv6hint expanded":   {params: "ipv6hint=2001:0db8:0000:0000:0000:0000:0000:0001", expected: "ipv6hint=2001:db8::1"},
+		"ipv4 mapped":         {params: "ipv6hint=::ffff:198.51.100.100", expected: "ipv6hint=198.51.100.100"},
+		"sorted keys":         {params: `alpn=h2,h3-19 mandatory=ipv4hint,alpn ipv4hint=192.0.2.1`, expected: "mandatory=alpn,ipv4hint alpn=h2,h3-19 ipv4hint=192.0.2.1"},
+		"alpn order is kept":  {params: "alpn=h3,h2", expected: "alpn=h3,h2"},
+		"no-default-alpn":     {params: "no-default-alpn alpn=h2", expected: "alpn=h2 no-default-alpn"},
+		"ech":                 {params: "ech=AEP+DQA/ABYAIA==", expected: "ech=AEP+DQA/ABYAIA=="},
+		"dohpath":             {params: "alpn=h2 dohpath=/dns-query{?dns}", expected: "alpn=h2 dohpath=/dns-query{?dns}"},
+		"empty":               {params: "", expected: ""},
+		"repeated key":        {params: "port=53 key3=54", withError: true},
+		"mandatory itself":    {params: "mandatory=mandatory", withError: true},
+		"mandatory missing":   {params: "mandatory=port", withError: true},
+		"mandatory repeated":  {params: "mandatory=port,port port=53", withError: true},
+		"no-default no alpn":  {params: "no-default-alpn", withError: true},
+		"no-default value":    {params: "no-default-alpn=h2 alpn=h2", withError: true},
+		"port too big":        {params: "port=65536", withError: true},
+		"ipv4hint with IPv6":  {params: "ipv4hint=2001:db8::1", withError: true},
+		"ipv6hint with IPv4":  {params: "ipv6hint=192.0.2.1", withError: true},
+		"empty alpn":          {params: "alpn=", withError: true},
+		"unknown key":         {params: "foo=bar", withError: true},
+		"reserved key":        {params: "key65535=x", withError: true},
+		"leading zero key":    {params: "key03=x", withError: true},
+		"ech not base64":      {params: "ech=!!", withError: true},
+		"dohpath no template": {params: "dohpath=/dns-query", withError: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			canonical, err := SvcParams(test.params)
+			if test.withError {
+				assert.True(t, errors.Is(err, ErrInvalid), "want: %s; got: %s", ErrInvalid, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, canonical)
+		})
+	}
+}
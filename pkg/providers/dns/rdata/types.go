@@ -0,0 +1,479 @@
+package rdata
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// parseA parses an IPv4 address, RFC 1035 section 3.4.1
+func parseA(tokens []token) ([]string, error) {
+	if err := expectTokens(tokens, "address"); err != nil {
+		return nil, err
+	}
+	ip := net.ParseIP(tokens[0].value)
+	if ip == nil || ip.To4() == nil || strings.Contains(tokens[0].value, ":") {
+		return nil, fmt.Errorf("%q is not an IPv4 address", tokens[0].value)
+	}
+	return []string{ip.To4().String()}, nil
+}
+
+// parseAAAA parses an IPv6 address, RFC 3596 section 2.4. The canonical form is the one of RFC 5952.
+func parseAAAA(tokens []token) ([]string, error) {
+	if err := expectTokens(tokens, "address"); err != nil {
+		return nil, err
+	}
+	ip := net.ParseIP(tokens[0].value)
+	if ip == nil || !strings.Contains(tokens[0].value, ":") {
+		return nil, fmt.Errorf("%q is not an IPv6 address", tokens[0].value)
+	}
+	return []string{ip.String()}, nil
+}
+
+// FullIPv6 encodes IPV6 as a full string
+func FullIPv6(ip net.IP) string {
+	dst := make([]byte, hex.EncodedLen(len(ip)))
+	_ = hex.Encode(dst, ip)
+	return string(dst[0:4]) + ":" +
+		string(dst[4:8]) + ":" +
+		string(dst[8:12]) + ":" +
+		string(dst[12:16]) + ":" +
+		string(dst[16:20]) + ":" +
+		string(dst[20:24]) + ":" +
+		string(dst[24:28]) + ":" +
+		string(dst[28:])
+}
+
+// parseSingleName parses the domain name of CNAME, NS, PTR and AKAMAICDN records
+func parseSingleName(tokens []token) ([]string, error) {
+	if err := expectTokens(tokens, "name"); err != nil {
+		return nil, err
+	}
+	name, err := nameField(tokens[0])
+	if err != nil {
+		return nil, err
+	}
+	return []string{name}, nil
+}
+
+// parseVerbatim only normalizes the whitespace of records which are not parsed, such as AKAMAITLC
+func parseVerbatim(tokens []token) ([]string, error) {
+	if len(tokens) == 0 {
+		return nil, errors.New("empty record")
+	}
+	fields := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		if t.quoted {
+			fields = append(fields, `"`+t.value+`"`)
+		} else {
+			fields = append(fields, t.value)
+		}
+	}
+	return fields, nil
+}
+
+// parseMX parses a mail exchange, RFC 1035 section 3.3.9
+func parseMX(tokens []token) ([]string, error) {
+	if err := expectTokens(tokens, "preference", "exchange"); err != nil {
+		return nil, err
+	}
+	return uintAndName(tokens, "preference")
+}
+
+// parseAFSDB parses an AFS database location, RFC 1183 section 1
+func parseAFSDB(tokens []token) ([]string, error) {
+	if err := expectTokens(tokens, "subtype", "hostname"); err != nil {
+		return nil, err
+	}
+	return uintAndName(tokens, "subtype")
+}
+
+func uintAndName(tokens []token, name string) ([]string, error) {
+	value, err := uintField(tokens[0], name, 16)
+	if err != nil {
+		return nil, err
+	}
+	host, err := nameField(tokens[1])
+	if err != nil {
+		return nil, err
+	}
+	return []string{value, host}, nil
+}
+
+// parseSRV parses a service location, RFC 2782
+func parseSRV(tokens []token) ([]string, error) {
+	if err := expectTokens(tokens, "priority", "weight", "port", "target"); err != nil {
+		return nil, err
+	}
+	fields := make([]string, 0, 4)
+	for i, name := range []string{"priority", "weight", "port"} {
+		value, err := uintField(tokens[i], name, 16)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, value)
+	}
+	target, err := nameField(tokens[3])
+	if err != nil {
+		return nil, err
+	}
+	return append(fields, target), nil
+}
+
+// parseTXT parses one or more character strings, RFC 1035 section 3.3.14
+func parseTXT(tokens []token) ([]string, error) {
+	if len(tokens) == 0 {
+		return nil, errors.New("expected at least one character string")
+	}
+	return characterStrings(tokens), nil
+}
+
+// parseHINFO parses host information, RFC 1035 section 3.3.2
+func parseHINFO(tokens []token) ([]string, error) {
+	if err := expectTokens(tokens, "cpu", "os"); err != nil {
+		return nil, err
+	}
+	for _, t := range tokens {
+		if octets(t.value) > maxCharacterString {
+			return nil, fmt.Errorf("%q is longer than %d octets", t.value, maxCharacterString)
+		}
+	}
+	return characterStrings(tokens), nil
+}
+
+// parseRP parses a responsible person, RFC 1183 section 2.2
+func parseRP(tokens []token) ([]string, error) {
+	if err := expectTokens(tokens, "mailbox", "txt"); err != nil {
+		return nil, err
+	}
+	return names(tokens)
+}
+
+func names(tokens []token) ([]string, error) {
+	result := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		name, err := nameField(t)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, name)
+	}
+	return result, nil
+}
+
+// parseNAPTR parses a naming authority pointer, RFC 3403 section 4.1
+func parseNAPTR(tokens []token) ([]string, error) {
+	if err := expectTokens(tokens, "order", "preference", "flags", "service", "regexp", "replacement"); err != nil {
+		return nil, err
+	}
+	order, err := uintField(tokens[0], "order", 16)
+	if err != nil {
+		return nil, err
+	}
+	preference, err := uintField(tokens[1], "preference", 16)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range tokens[2].value {
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9') {
+			return nil, fmt.Errorf("flags %q may only hold alphanumeric characters", tokens[2].value)
+		}
+	}
+	replacement, err := nameField(tokens[5])
+	if err != nil {
+		return nil, err
+	}
+	if tokens[4].value != "" && replacement != "." {
+		return nil, errors.New("only one of regexp and replacement may be set")
+	}
+	fields := []string{order, preference, `"` + strings.ToUpper(tokens[2].value) + `"`}
+	fields = append(fields, characterStrings(tokens[3:5])...)
+	return append(fields, replacement), nil
+}
+
+// parseSOA parses a start of authority, RFC 1035 section 3.3.13
+func parseSOA(tokens []token) ([]string, error) {
+	if err := expectTokens(tokens, "mname", "rname", "serial", "refresh", "retry", "expire", "minimum"); err != nil {
+		return nil, err
+	}
+	fields, err := names(tokens[:2])
+	if err != nil {
+		return nil, err
+	}
+	for i, name := range []string{"serial", "refresh", "retry", "expire", "minimum"} {
+		value, err := uintField(tokens[i+2], name, 32)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, value)
+	}
+	return fields, nil
+}
+
+var caaTag = regexp.MustCompile(`^[a-zA-Z0-9]{1,15}$`)
+
+// parseCAA parses a certification authority authorization, RFC 8659 section 4.1
+func parseCAA(tokens []token) ([]string, error) {
+	if err := expectTokens(tokens, "flags", "tag", "value"); err != nil {
+		return nil, err
+	}
+	flags, err := uintField(tokens[0], "flags", 8)
+	if err != nil {
+		return nil, err
+	}
+	if !caaTag.MatchString(tokens[1].value) {
+		return nil, fmt.Errorf("tag %q must be 1 to 15 alphanumeric characters", tokens[1].value)
+	}
+	// tags are matched case insensitively
+	return []string{flags, strings.ToLower(tokens[1].value), `"` + tokens[2].value + `"`}, nil
+}
+
+// parseTLSA parses a TLS certificate association, RFC 6698 section 2.2
+func parseTLSA(tokens []token) ([]string, error) {
+	if err := minTokens(tokens, "usage", "selector", "matching type", "certificate association data"); err != nil {
+		return nil, err
+	}
+	// 255 is reserved for private use in each field
+	usage, err := uintIn(tokens[0], "usage", 0, 1, 2, 3, 255)
+	if err != nil {
+		return nil, err
+	}
+	selector, err := uintIn(tokens[1], "selector", 0, 1, 255)
+	if err != nil {
+		return nil, err
+	}
+	matching, err := uintIn(tokens[2], "matching type", 0, 1, 2, 255)
+	if err != nil {
+		return nil, err
+	}
+	length := map[uint64]int{1: 32, 2: 64}[matching]
+	data, err := hexField(tokens[3:], "certificate association data", length)
+	if err != nil {
+		return nil, err
+	}
+	return []string{strconv.FormatUint(usage, 10), strconv.FormatUint(selector, 10), strconv.FormatUint(matching, 10), data}, nil
+}
+
+// parseSSHFP parses an SSH key fingerprint, RFC 4255 section 3.2 and RFC 6594
+func parseSSHFP(tokens []token) ([]string, error) {
+	if err := minTokens(tokens, "algorithm", "fingerprint type", "fingerprint"); err != nil {
+		return nil, err
+	}
+	algorithm, err := uintField(tokens[0], "algorithm", 8)
+	if err != nil {
+		return nil, err
+	}
+	fpType, err := uintIn(tokens[1], "fingerprint type", 1, 2)
+	if err != nil {
+		return nil, err
+	}
+	fingerprint, err := hexField(tokens[2:], "fingerprint", map[uint64]int{1: 20, 2: 32}[fpType])
+	if err != nil {
+		return nil, err
+	}
+	return []string{algorithm, strconv.FormatUint(fpType, 10), fingerprint}, nil
+}
+
+// dsDigestLengths are the digest lengths of the DS digest types, RFC 4509 and RFC 6605
+var dsDigestLengths = map[string]int{"1": 20, "2": 32, "3": 32, "4": 48}
+
+// parseDS parses a delegation signer, RFC 4034 section 5.3
+func parseDS(tokens []token) ([]string, error) {
+	if err := minTokens(tokens, "key tag", "algorithm", "digest type", "digest"); err != nil {
+		return nil, err
+	}
+	keyTag, err := uintField(tokens[0], "key tag", 16)
+	if err != nil {
+		return nil, err
+	}
+	algorithm, err := uintField(tokens[1], "algorithm", 8)
+	if err != nil {
+		return nil, err
+	}
+	digestType, err := uintField(tokens[2], "digest type", 8)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := hexField(tokens[3:], "digest", dsDigestLengths[digestType])
+	if err != nil {
+		return nil, err
+	}
+	return []string{keyTag, algorithm, digestType, digest}, nil
+}
+
+// parseDNSKEY parses a DNS public key, RFC 4034 section 2.2
+func parseDNSKEY(tokens []token) ([]string, error) {
+	if err := minTokens(tokens, "flags", "protocol", "algorithm", "public key"); err != nil {
+		return nil, err
+	}
+	flags, err := uintField(tokens[0], "flags", 16)
+	if err != nil {
+		return nil, err
+	}
+	if tokens[1].value != "3" {
+		return nil, fmt.Errorf("protocol must be 3, got %q", tokens[1].value)
+	}
+	algorithm, err := uintField(tokens[2], "algorithm", 8)
+	if err != nil {
+		return nil, err
+	}
+	key, err := base64Field(tokens[3:], "public key")
+	if err != nil {
+		return nil, err
+	}
+	return []string{flags, "3", algorithm, key}, nil
+}
+
+// certTypes are the certificate type mnemonics, RFC 4398 section 2.1
+var certTypes = map[string]string{
+	"PKIX": "1", "SPKI": "2", "PGP": "3", "IPKIX": "4", "ISPKI": "5", "IPGP": "6", "ACPKIX": "7", "IACPKIX": "8",
+	"URI": "253", "OID": "254",
+}
+
+// parseCERT parses a certificate, RFC 4398 section 2.2. Mnemonic types are canonicalized to their numbers.
+func parseCERT(tokens []token) ([]string, error) {
+	if err := minTokens(tokens, "type", "key tag", "algorithm", "certificate"); err != nil {
+		return nil, err
+	}
+	certType, ok := certTypes[strings.ToUpper(tokens[0].value)]
+	if !ok {
+		var err error
+		if certType, err = uintField(tokens[0], "type", 16); err != nil {
+			return nil, err
+		}
+	}
+	keyTag, err := uintField(tokens[1], "key tag", 16)
+	if err != nil {
+		return nil, err
+	}
+	algorithm, err := uintField(tokens[2], "algorithm", 8)
+	if err != nil {
+		return nil, err
+	}
+	certificate, err := base64Field(tokens[3:], "certificate")
+	if err != nil {
+		return nil, err
+	}
+	return []string{certType, keyTag, algorithm, certificate}, nil
+}
+
+// parseNSEC3PARAM parses NSEC3 parameters, RFC 5155 section 4.3
+func parseNSEC3PARAM(tokens []token) ([]string, error) {
+	if err := expectTokens(tokens, "hash algorithm", "flags", "iterations", "salt"); err != nil {
+		return nil, err
+	}
+	return nsec3Params(tokens)
+}
+
+func nsec3Params(tokens []token) ([]string, error) {
+	fields := make([]string, 0, 4)
+	for i, name := range []string{"hash algorithm", "flags"} {
+		value, err := uintField(tokens[i], name, 8)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, value)
+	}
+	iterations, err := uintField(tokens[2], "iterations", 16)
+	if err != nil {
+		return nil, err
+	}
+	fields = append(fields, iterations)
+	// "-" stands for an empty salt
+	if tokens[3].value == "-" {
+		return append(fields, "-"), nil
+	}
+	salt, err := hexField(tokens[3:4], "salt", 0)
+	if err != nil {
+		return nil, err
+	}
+	return append(fields, salt), nil
+}
+
+// parseNSEC3 parses hashed authenticated denial of existence, RFC 5155 section 3.3
+func parseNSEC3(tokens []token) ([]string, error) {
+	if err := minTokens(tokens, "hash algorithm", "flags", "iterations", "salt", "next hashed owner name"); err != nil {
+		return nil, err
+	}
+	fields, err := nsec3Params(tokens[:4])
+	if err != nil {
+		return nil, err
+	}
+	next, err := base32HexField(tokens[4], "next hashed owner name")
+	if err != nil {
+		return nil, err
+	}
+	fields = append(fields, next)
+	types, err := typeList(tokens[5:])
+	if err != nil {
+		return nil, err
+	}
+	return append(fields, types...), nil
+}
+
+var typeMnemonic = regexp.MustCompile(`^([A-Z][A-Z0-9-]*|TYPE[0-9]+)$`)
+
+func typeList(tokens []token) ([]string, error) {
+	types := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		value := strings.ToUpper(t.value)
+		if !typeMnemonic.MatchString(value) {
+			return nil, fmt.Errorf("%q is not a record type", t.value)
+		}
+		types = append(types, value)
+	}
+	return types, nil
+}
+
+var signatureTime = regexp.MustCompile(`^[0-9]{14}$`)
+
+// parseRRSIG parses a resource record signature, RFC 4034 section 3.2
+func parseRRSIG(tokens []token) ([]string, error) {
+	if err := minTokens(tokens, "type covered", "algorithm", "labels", "original TTL", "expiration", "inception", "key tag", "signer", "signature"); err != nil {
+		return nil, err
+	}
+	fields, err := typeList(tokens[:1])
+	if err != nil {
+		return nil, err
+	}
+	for i, f := range []struct {
+		name string
+		bits int
+	}{{"algorithm", 8}, {"labels", 8}, {"original TTL", 32}} {
+		value, err := uintField(tokens[i+1], f.name, f.bits)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, value)
+	}
+	// times are either YYYYMMDDHHmmSS or seconds since the epoch
+	for i, name := range []string{"expiration", "inception"} {
+		t := tokens[i+4]
+		if signatureTime.MatchString(t.value) {
+			fields = append(fields, t.value)
+			continue
+		}
+		value, err := uintField(t, name, 32)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, value)
+	}
+	keyTag, err := uintField(tokens[6], "key tag", 16)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := nameField(tokens[7])
+	if err != nil {
+		return nil, err
+	}
+	signature, err := base64Field(tokens[8:], "signature")
+	if err != nil {
+		return nil, err
+	}
+	return append(fields, keyTag, signer, signature), nil
+}
@@ -0,0 +1,588 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var (
+	bulkZonePollMinimum  = 5 * time.Second
+	bulkZonePollInterval = bulkZonePollMinimum
+
+	// ErrBulkZoneRequest is returned when zones of a bulk request could not be created or deleted
+	ErrBulkZoneRequest = errors.New("bulk zone request")
+)
+
+func resourceDNSBulkZones() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDNSBulkZonesCreate,
+		ReadContext:   resourceDNSBulkZonesRead,
+		UpdateContext: resourceDNSBulkZonesUpdate,
+		DeleteContext: resourceDNSBulkZonesDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(time.Hour),
+			Update: schema.DefaultTimeout(time.Hour),
+			Delete: schema.DefaultTimeout(time.Hour),
+		},
+		Schema: map[string]*schema.Schema{
+			"contract": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: tools.FieldPrefixSuppress("ctr_"),
+			},
+			"group": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: tools.FieldPrefixSuppress("grp_"),
+			},
+			"zone": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "The zones created by bulk requests",
+				Elem:        bulkZoneSchema(),
+			},
+			"allow_zone_deletion": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Delete zones removed from the configuration, and all zones on destroy, instead of only forgetting them",
+			},
+			"bypass_safety_checks": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Delete zones even when they still hold records or are referenced by aliases",
+			},
+			"request_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the last bulk request",
+			},
+		},
+	}
+}
+
+func bulkZoneSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateZoneType,
+				StateFunc: func(val interface{}) string {
+					return strings.ToUpper(val.(string))
+				},
+			},
+			"masters": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+				Set:      schema.HashString,
+			},
+			"comment": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Managed by Terraform",
+			},
+			"sign_and_serve": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"sign_and_serve_algorithm": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"end_customer_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"target": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceDNSBulkZonesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSBulkZonesCreate")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	query, err := bulkZoneQueryString(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	zones, err := expandBulkZones(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.WithField("zones", len(zones)).Info("Bulk Zone Create")
+
+	requestID, created, createErr := createBulkZones(ctx, inst.Client(meta), zones, query, logger)
+	if requestID == "" {
+		return diag.FromErr(createErr)
+	}
+	d.SetId(requestID)
+	if err := d.Set("request_id", requestID); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	if createErr != nil {
+		// keep only the zones which were actually created, so the next plan retries the others
+		if err := d.Set("zone", flattenBulkZones(filterBulkZones(zones, created))); err != nil {
+			return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+		}
+		return diag.FromErr(createErr)
+	}
+	return resourceDNSBulkZonesRead(ctx, d, meta)
+}
+
+func resourceDNSBulkZonesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSBulkZonesRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	contract, err := tools.GetStringValue("contract", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	zones, err := expandBulkZones(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.WithField("zones", len(zones)).Info("Bulk Zone Read")
+
+	resp, err := inst.Client(meta).ListZones(ctx, dns.ZoneListQueryArgs{
+		ContractIDs: strings.TrimPrefix(contract, "ctr_"),
+		ShowAll:     true,
+	})
+	if err != nil {
+		return diag.Errorf("listing zones of contract %s: %s", contract, err)
+	}
+	live := make(map[string]*dns.ZoneResponse, len(resp.Zones))
+	for _, zone := range resp.Zones {
+		live[strings.ToLower(zone.Zone)] = zone
+	}
+
+	current := make([]*dns.ZoneCreate, 0, len(zones))
+	for _, zone := range zones {
+		found, ok := live[strings.ToLower(zone.Zone)]
+		if !ok {
+			logger.Warnf("Zone %s no longer exists; removing it from state", zone.Zone)
+			continue
+		}
+		current = append(current, &dns.ZoneCreate{
+			Zone:                  zone.Zone,
+			Type:                  found.Type,
+			Masters:               found.Masters,
+			Comment:               found.Comment,
+			SignAndServe:          found.SignAndServe,
+			SignAndServeAlgorithm: found.SignAndServeAlgorithm,
+			Target:                found.Target,
+			EndCustomerID:         found.EndCustomerID,
+		})
+	}
+	if len(current) == 0 {
+		logger.Warn("None of the zones exist anymore; removing bulk zones from state")
+		d.SetId("")
+		return nil
+	}
+	if err := d.Set("zone", flattenBulkZones(current)); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	return nil
+}
+
+func resourceDNSBulkZonesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSBulkZonesUpdate")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+	client := inst.Client(meta)
+
+	if !d.HasChange("zone") {
+		return resourceDNSBulkZonesRead(ctx, d, meta)
+	}
+	query, err := bulkZoneQueryString(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	allowDeletion, err := tools.GetBoolValue("allow_zone_deletion", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	bypass, err := tools.GetBoolValue("bypass_safety_checks", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	oldSet, newSet := d.GetChange("zone")
+	oldZones := bulkZonesByName(expandBulkZoneSet(oldSet.(*schema.Set)))
+	newZones := expandBulkZoneSet(newSet.(*schema.Set))
+
+	var added, changed []*dns.ZoneCreate
+	for _, zone := range newZones {
+		old, ok := oldZones[strings.ToLower(zone.Zone)]
+		switch {
+		case !ok:
+			added = append(added, zone)
+		case !strings.EqualFold(old.Type, zone.Type):
+			return diag.Errorf("type of zone %s can not be changed from %s to %s", zone.Zone, old.Type, zone.Type)
+		case !bulkZonesEqual(old, zone):
+			changed = append(changed, zone)
+		}
+		delete(oldZones, strings.ToLower(zone.Zone))
+	}
+	removed := make([]string, 0, len(oldZones))
+	for _, zone := range oldZones {
+		removed = append(removed, zone.Zone)
+	}
+	sort.Strings(removed)
+	logger.WithFields(log.Fields{
+		"added":   len(added),
+		"changed": len(changed),
+		"removed": len(removed),
+	}).Info("Bulk Zone Update")
+
+	for _, zone := range changed {
+		// keep settings which are not managed here, such as the TSIG key of secondary zones
+		live, err := client.GetZone(ctx, zone.Zone)
+		if err != nil {
+			return diag.Errorf("reading zone %s: %s", zone.Zone, err)
+		}
+		zone.TsigKey = live.TsigKey
+		zone.ContractID = live.ContractID
+		if err := client.UpdateZone(ctx, zone, query); err != nil {
+			return diag.Errorf("updating zone %s: %s", zone.Zone, err)
+		}
+	}
+
+	if len(removed) > 0 {
+		if allowDeletion {
+			requestID, err := deleteBulkZones(ctx, client, removed, bypass, logger)
+			if requestID != "" {
+				if err := d.Set("request_id", requestID); err != nil {
+					return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+				}
+			}
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		} else {
+			logger.Warnf("Zones %s were removed from the configuration but are not deleted", strings.Join(removed, ", "))
+		}
+	}
+
+	if len(added) > 0 {
+		requestID, created, err := createBulkZones(ctx, client, added, query, logger)
+		if requestID != "" {
+			if err := d.Set("request_id", requestID); err != nil {
+				return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+			}
+		}
+		if err != nil {
+			// added zones which failed are left out of state, so the next plan retries them
+			current := newZones
+			for _, zone := range added {
+				if !containsZone(created, zone.Zone) {
+					current = removeZone(current, zone.Zone)
+				}
+			}
+			if err := d.Set("zone", flattenBulkZones(current)); err != nil {
+				return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+			}
+			return diag.FromErr(err)
+		}
+	}
+	return resourceDNSBulkZonesRead(ctx, d, meta)
+}
+
+func resourceDNSBulkZonesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSBulkZonesDelete")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	allowDeletion, err := tools.GetBoolValue("allow_zone_deletion", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	bypass, err := tools.GetBoolValue("bypass_safety_checks", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	zones, err := expandBulkZones(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	names := make([]string, 0, len(zones))
+	for _, zone := range zones {
+		names = append(names, zone.Zone)
+	}
+	sort.Strings(names)
+	logger.WithField("zones", len(names)).Info("Bulk Zone Delete")
+
+	if !allowDeletion {
+		d.SetId("")
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "Zones were not deleted",
+			Detail:   fmt.Sprintf("allow_zone_deletion is not set; zones %s were removed from state only", strings.Join(names, ", ")),
+		}}
+	}
+	if _, err := deleteBulkZones(ctx, inst.Client(meta), names, bypass, logger); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return nil
+}
+
+// createBulkZones submits a bulk create request, waits for it to complete and returns the request ID and the names
+// of the created zones. An error wrapping ErrBulkZoneRequest is returned along with them when some zones failed.
+func createBulkZones(ctx context.Context, client dns.DNS, zones []*dns.ZoneCreate, query dns.ZoneQueryString, logger log.Interface) (string, []string, error) {
+	resp, err := client.CreateBulkZones(ctx, &dns.BulkZonesCreate{Zones: zones}, query)
+	if err != nil {
+		return "", nil, fmt.Errorf("submitting bulk zone create request: %w", err)
+	}
+	logger.WithFields(log.Fields{
+		"requestid":  resp.RequestId,
+		"expiration": resp.ExpirationDate,
+	}).Debug("Bulk zone create submitted")
+
+	if err := waitForBulkZoneRequest(ctx, resp.RequestId, client.GetBulkZoneCreateStatus); err != nil {
+		return resp.RequestId, nil, err
+	}
+	result, err := client.GetBulkZoneCreateResult(ctx, resp.RequestId)
+	if err != nil {
+		return resp.RequestId, nil, fmt.Errorf("reading result of bulk zone create request %s: %w", resp.RequestId, err)
+	}
+	return resp.RequestId, result.SuccessfullyCreatedZones, bulkZoneFailures("create", resp.RequestId, result.FailedZones)
+}
+
+// deleteBulkZones submits a bulk delete request, waits for it to complete and returns the request ID
+func deleteBulkZones(ctx context.Context, client dns.DNS, zones []string, bypassSafetyChecks bool, logger log.Interface) (string, error) {
+	resp, err := client.DeleteBulkZones(ctx, &dns.ZoneNameListResponse{Zones: zones}, bypassSafetyChecks)
+	if err != nil {
+		return "", fmt.Errorf("submitting bulk zone delete request: %w", err)
+	}
+	logger.WithFields(log.Fields{
+		"requestid":  resp.RequestId,
+		"expiration": resp.ExpirationDate,
+	}).Debug("Bulk zone delete submitted")
+
+	if err := waitForBulkZoneRequest(ctx, resp.RequestId, client.GetBulkZoneDeleteStatus); err != nil {
+		return resp.RequestId, err
+	}
+	result, err := client.GetBulkZoneDeleteResult(ctx, resp.RequestId)
+	if err != nil {
+		return resp.RequestId, fmt.Errorf("reading result of bulk zone delete request %s: %w", resp.RequestId, err)
+	}
+	return resp.RequestId, bulkZoneFailures("delete", resp.RequestId, result.FailedZones)
+}
+
+// waitForBulkZoneRequest polls the status of a bulk request until it is complete
+func waitForBulkZoneRequest(ctx context.Context, requestID string, getStatus func(context.Context, string) (*dns.BulkStatusResponse, error)) error {
+	for {
+		status, err := getStatus(ctx, requestID)
+		if err != nil {
+			return fmt.Errorf("reading status of bulk zone request %s: %w", requestID, err)
+		}
+		if status.IsComplete {
+			return nil
+		}
+		select {
+		case <-time.After(tools.MaxDuration(bulkZonePollInterval, bulkZonePollMinimum)):
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for bulk zone request %s (%d of %d zones processed): %w",
+				requestID, status.SuccessCount+status.FailureCount, status.ZonesSubmitted, ctx.Err())
+		}
+	}
+}
+
+func bulkZoneFailures(operation, requestID string, failed []*dns.BulkFailedZone) error {
+	if len(failed) == 0 {
+		return nil
+	}
+	reasons := make([]string, 0, len(failed))
+	for _, zone := range failed {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", zone.Zone, zone.FailureReason))
+	}
+	sort.Strings(reasons)
+	return fmt.Errorf("%w %s: failed to %s %d zone(s):\n%s", ErrBulkZoneRequest, requestID, operation, len(failed), strings.Join(reasons, "\n"))
+}
+
+func bulkZoneQueryString(d *schema.ResourceData) (dns.ZoneQueryString, error) {
+	contract, err := tools.GetStringValue("contract", d)
+	if err != nil {
+		return dns.ZoneQueryString{}, err
+	}
+	group, err := tools.GetStringValue("group", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return dns.ZoneQueryString{}, err
+	}
+	return dns.ZoneQueryString{
+		Contract: strings.TrimPrefix(contract, "ctr_"),
+		Group:    strings.TrimPrefix(group, "grp_"),
+	}, nil
+}
+
+func expandBulkZones(d *schema.ResourceData) ([]*dns.ZoneCreate, error) {
+	set, err := tools.GetSetValue("zone", d)
+	if err != nil {
+		return nil, err
+	}
+	zones := expandBulkZoneSet(set)
+	for _, zone := range zones {
+		if err := checkBulkZone(zone); err != nil {
+			return nil, err
+		}
+	}
+	return zones, nil
+}
+
+func expandBulkZoneSet(set *schema.Set) []*dns.ZoneCreate {
+	zones := make([]*dns.ZoneCreate, 0, set.Len())
+	for _, item := range set.List() {
+		raw := item.(map[string]interface{})
+		zone := &dns.ZoneCreate{
+			Zone:                  raw["zone"].(string),
+			Type:                  strings.ToUpper(raw["type"].(string)),
+			Comment:               raw["comment"].(string),
+			SignAndServe:          raw["sign_and_serve"].(bool),
+			SignAndServeAlgorithm: raw["sign_and_serve_algorithm"].(string),
+			Target:                raw["target"].(string),
+			EndCustomerID:         raw["end_customer_id"].(string),
+		}
+		if masters, ok := raw["masters"].(*schema.Set); ok {
+			for _, master := range masters.List() {
+				zone.Masters = append(zone.Masters, master.(string))
+			}
+			sort.Strings(zone.Masters)
+		}
+		zones = append(zones, zone)
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].Zone < zones[j].Zone })
+	return zones
+}
+
+// checkBulkZone validates the zone attributes against the zone type, as checkDNSv2Zone does for single zones
+func checkBulkZone(zone *dns.ZoneCreate) error {
+	switch zone.Type {
+	case "SECONDARY":
+		if len(zone.Masters) == 0 {
+			return fmt.Errorf("zone %s: masters is required for SECONDARY zones", zone.Zone)
+		}
+	case "ALIAS":
+		if zone.Target == "" {
+			return fmt.Errorf("zone %s: target is required for ALIAS zones", zone.Zone)
+		}
+	}
+	if zone.Type != "SECONDARY" && len(zone.Masters) > 0 {
+		return fmt.Errorf("zone %s: masters is only allowed for SECONDARY zones", zone.Zone)
+	}
+	if zone.Type != "ALIAS" && zone.Target != "" {
+		return fmt.Errorf("zone %s: target is only allowed for ALIAS zones", zone.Zone)
+	}
+	return nil
+}
+
+func flattenBulkZones(zones []*dns.ZoneCreate) []interface{} {
+	result := make([]interface{}, 0, len(zones))
+	for _, zone := range zones {
+		masters := make([]interface{}, 0, len(zone.Masters))
+		for _, master := range zone.Masters {
+			masters = append(masters, master)
+		}
+		result = append(result, map[string]interface{}{
+			"zone":                     zone.Zone,
+			"type":                     strings.ToUpper(zone.Type),
+			"masters":                  schema.NewSet(schema.HashString, masters),
+			"comment":                  zone.Comment,
+			"sign_and_serve":           zone.SignAndServe,
+			"sign_and_serve_algorithm": zone.SignAndServeAlgorithm,
+			"target":                   zone.Target,
+			"end_customer_id":          zone.EndCustomerID,
+		})
+	}
+	return result
+}
+
+// filterBulkZones returns the zones whose names are in names
+func filterBulkZones(zones []*dns.ZoneCreate, names []string) []*dns.ZoneCreate {
+	result := make([]*dns.ZoneCreate, 0, len(names))
+	for _, zone := range zones {
+		if containsZone(names, zone.Zone) {
+			result = append(result, zone)
+		}
+	}
+	return result
+}
+
+func bulkZonesByName(zones []*dns.ZoneCreate) map[string]*dns.ZoneCreate {
+	result := make(map[string]*dns.ZoneCreate, len(zones))
+	for _, zone := range zones {
+		result[strings.ToLower(zone.Zone)] = zone
+	}
+	return result
+}
+
+func bulkZonesEqual(a, b *dns.ZoneCreate) bool {
+	return strings.Join(a.Masters, ",") == strings.Join(b.Masters, ",") &&
+		a.Comment == b.Comment &&
+		a.SignAndServe == b.SignAndServe &&
+		a.SignAndServeAlgorithm == b.SignAndServeAlgorithm &&
+		a.Target == b.Target &&
+		a.EndCustomerID == b.EndCustomerID
+}
+
+func containsZone(names []string, zone string) bool {
+	for _, name := range names {
+		if strings.EqualFold(strings.TrimSuffix(name, "."), strings.TrimSuffix(zone, ".")) {
+			return true
+		}
+	}
+	return false
+}
+
+func removeZone(zones []*dns.ZoneCreate, name string) []*dns.ZoneCreate {
+	result := make([]*dns.ZoneCreate, 0, len(zones))
+	for _, zone := range zones {
+		if !strings.EqualFold(zone.Zone, name) {
+			result = append(result, zone)
+		}
+	}
+	return result
+}
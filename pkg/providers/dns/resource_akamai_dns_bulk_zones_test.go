@@ -0,0 +1,225 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/dns"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResDnsBulkZones(t *testing.T) {
+	bulkZonePollMinimum = time.Millisecond
+	bulkZonePollInterval = bulkZonePollMinimum
+
+	secondary := func(name string, masters ...string) *dns.ZoneCreate {
+		return &dns.ZoneCreate{Zone: name, Type: "SECONDARY", Masters: masters, Comment: "Managed by Terraform"}
+	}
+	live := func(zone *dns.ZoneCreate) *dns.ZoneResponse {
+		return &dns.ZoneResponse{Zone: zone.Zone, Type: zone.Type, Masters: zone.Masters, Comment: zone.Comment, ContractID: "1-3CV382"}
+	}
+
+	t.Run("lifecycle test", func(t *testing.T) {
+		client := &dns.Mock{}
+		query := dns.ZoneQueryString{Contract: "1-3CV382", Group: "1-2CW482"}
+		list := &dns.ZoneListResponse{}
+
+		client.On("ListZones",
+			mock.Anything, // ctx is irrelevant for this test
+			dns.ZoneListQueryArgs{ContractIDs: "1-3CV382", ShowAll: true},
+		).Return(list, nil)
+
+		// create
+		one, two := secondary("one.exampleterraform.io", "1.2.3.4"), secondary("two.exampleterraform.io", "1.2.3.4")
+		client.On("CreateBulkZones",
+			mock.Anything, // ctx is irrelevant for this test
+			&dns.BulkZonesCreate{Zones: []*dns.ZoneCreate{one, two}},
+			query,
+		).Return(&dns.BulkZonesResponse{RequestId: "create-1"}, nil).Once()
+		client.On("GetBulkZoneCreateStatus",
+			mock.Anything, // ctx is irrelevant for this test
+			"create-1",
+		).Return(&dns.BulkStatusResponse{RequestId: "create-1", ZonesSubmitted: 2}, nil).Once()
+		client.On("GetBulkZoneCreateStatus",
+			mock.Anything, // ctx is irrelevant for this test
+			"create-1",
+		).Return(&dns.BulkStatusResponse{RequestId: "create-1", ZonesSubmitted: 2, SuccessCount: 2, IsComplete: true}, nil).Once()
+		client.On("GetBulkZoneCreateResult",
+			mock.Anything, // ctx is irrelevant for this test
+			"create-1",
+		).Return(&dns.BulkCreateResultResponse{
+			RequestId:                "create-1",
+			SuccessfullyCreatedZones: []string{one.Zone, two.Zone},
+		}, nil).Run(func(mock.Arguments) {
+			list.Zones = []*dns.ZoneResponse{live(one), live(two)}
+		}).Once()
+
+		// update: one changes masters, two is deleted, three is created
+		oneUpdated, three := secondary("one.exampleterraform.io", "1.2.3.4", "5.6.7.8"), secondary("three.exampleterraform.io", "1.2.3.4")
+		client.On("GetZone",
+			mock.Anything, // ctx is irrelevant for this test
+			one.Zone,
+		).Return(live(one), nil).Once()
+		client.On("UpdateZone",
+			mock.Anything, // ctx is irrelevant for this test
+			&dns.ZoneCreate{Zone: oneUpdated.Zone, Type: "SECONDARY", Masters: oneUpdated.Masters, Comment: oneUpdated.Comment, ContractID: "1-3CV382"},
+			query,
+		).Return(nil).Once()
+		client.On("DeleteBulkZones",
+			mock.Anything, // ctx is irrelevant for this test
+			&dns.ZoneNameListResponse{Zones: []string{two.Zone}},
+			false,
+		).Return(&dns.BulkZonesResponse{RequestId: "delete-1"}, nil).Once()
+		client.On("GetBulkZoneDeleteStatus",
+			mock.Anything, // ctx is irrelevant for this test
+			"delete-1",
+		).Return(&dns.BulkStatusResponse{RequestId: "delete-1", ZonesSubmitted: 1, SuccessCount: 1, IsComplete: true}, nil).Once()
+		client.On("GetBulkZoneDeleteResult",
+			mock.Anything, // ctx is irrelevant for this test
+			"delete-1",
+		).Return(&dns.BulkDeleteResultResponse{
+			RequestId:                "delete-1",
+			SuccessfullyDeletedZones: []string{two.Zone},
+		}, nil).Once()
+		client.On("CreateBulkZones",
+			mock.Anything, // ctx is irrelevant for this test
+			&dns.BulkZonesCreate{Zones: []*dns.ZoneCreate{three}},
+			query,
+		).Return(&dns.BulkZonesResponse{RequestId: "create-2"}, nil).Once()
+		client.On("GetBulkZoneCreateStatus",
+			mock.Anything, // ctx is irrelevant for this test
+			"create-2",
+		).Return(&dns.BulkStatusResponse{RequestId: "create-2", ZonesSubmitted: 1, SuccessCount: 1, IsComplete: true}, nil).Once()
+		client.On("GetBulkZoneCreateResult",
+			mock.Anything, // ctx is irrelevant for this test
+			"create-2",
+		).Return(&dns.BulkCreateResultResponse{
+			RequestId:                "create-2",
+			SuccessfullyCreatedZones: []string{three.Zone},
+		}, nil).Run(func(mock.Arguments) {
+			list.Zones = []*dns.ZoneResponse{live(oneUpdated), live(three)}
+		}).Once()
+
+		// destroy
+		client.On("DeleteBulkZones",
+			mock.Anything, // ctx is irrelevant for this test
+			&dns.ZoneNameListResponse{Zones: []string{one.Zone, three.Zone}},
+			false,
+		).Return(&dns.BulkZonesResponse{RequestId: "delete-2"}, nil).Once()
+		client.On("GetBulkZoneDeleteStatus",
+			mock.Anything, // ctx is irrelevant for this test
+			"delete-2",
+		).Return(&dns.BulkStatusResponse{RequestId: "delete-2", ZonesSubmitted: 2, SuccessCount: 2, IsComplete: true}, nil).Once()
+		client.On("GetBulkZoneDeleteResult",
+			mock.Anything, // ctx is irrelevant for this test
+			"delete-2",
+		).Return(&dns.BulkDeleteResultResponse{
+			RequestId:                "delete-2",
+			SuccessfullyDeletedZones: []string{one.Zone, three.Zone},
+		}, nil).Once()
+
+		resourceName := "akamai_dns_bulk_zones.test"
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:          func() { testAccPreCheck(t) },
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResDnsBulkZones/create.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(resourceName, "id", "create-1"),
+							resource.TestCheckResourceAttr(resourceName, "request_id", "create-1"),
+							resource.TestCheckResourceAttr(resourceName, "zone.#", "2"),
+						),
+					},
+					{
+						Config: loadFixtureString("testdata/TestResDnsBulkZones/update.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(resourceName, "request_id", "create-2"),
+							resource.TestCheckResourceAttr(resourceName, "zone.#", "2"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
+
+func TestCreateBulkZones(t *testing.T) {
+	bulkZonePollMinimum = time.Millisecond
+	bulkZonePollInterval = bulkZonePollMinimum
+
+	zones := []*dns.ZoneCreate{
+		{Zone: "one.exampleterraform.io", Type: "PRIMARY"},
+		{Zone: "two.exampleterraform.io", Type: "PRIMARY"},
+	}
+	query := dns.ZoneQueryString{Contract: "1-3CV382"}
+
+	t.Run("partial failure", func(t *testing.T) {
+		client := &dns.Mock{}
+		client.On("CreateBulkZones", mock.Anything, &dns.BulkZonesCreate{Zones: zones}, query).
+			Return(&dns.BulkZonesResponse{RequestId: "req"}, nil).Once()
+		client.On("GetBulkZoneCreateStatus", mock.Anything, "req").
+			Return(&dns.BulkStatusResponse{IsComplete: true, SuccessCount: 1, FailureCount: 1}, nil).Once()
+		client.On("GetBulkZoneCreateResult", mock.Anything, "req").
+			Return(&dns.BulkCreateResultResponse{
+				SuccessfullyCreatedZones: []string{"one.exampleterraform.io"},
+				FailedZones:              []*dns.BulkFailedZone{{Zone: "two.exampleterraform.io", FailureReason: "ZONE_ALREADY_EXISTS"}},
+			}, nil).Once()
+
+		requestID, created, err := createBulkZones(context.Background(), client, zones, query, log.Log)
+		assert.Equal(t, "req", requestID)
+		assert.Equal(t, []string{"one.exampleterraform.io"}, created)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrBulkZoneRequest))
+		assert.Contains(t, err.Error(), "two.exampleterraform.io: ZONE_ALREADY_EXISTS")
+		client.AssertExpectations(t)
+	})
+
+	t.Run("context cancelled while polling", func(t *testing.T) {
+		client := &dns.Mock{}
+		client.On("CreateBulkZones", mock.Anything, &dns.BulkZonesCreate{Zones: zones}, query).
+			Return(&dns.BulkZonesResponse{RequestId: "req"}, nil).Once()
+		client.On("GetBulkZoneCreateStatus", mock.Anything, "req").
+			Return(&dns.BulkStatusResponse{ZonesSubmitted: 2}, nil)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		requestID, _, err := createBulkZones(ctx, client, zones, query, log.Log)
+		assert.Equal(t, "req", requestID)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+}
+
+func TestCheckBulkZone(t *testing.T) {
+	tests := map[string]struct {
+		zone    dns.ZoneCreate
+		withErr bool
+	}{
+		"primary":                 {zone: dns.ZoneCreate{Zone: "a.io", Type: "PRIMARY"}},
+		"secondary":               {zone: dns.ZoneCreate{Zone: "a.io", Type: "SECONDARY", Masters: []string{"1.2.3.4"}}},
+		"alias":                   {zone: dns.ZoneCreate{Zone: "a.io", Type: "ALIAS", Target: "b.io"}},
+		"secondary no masters":    {zone: dns.ZoneCreate{Zone: "a.io", Type: "SECONDARY"}, withErr: true},
+		"alias no target":         {zone: dns.ZoneCreate{Zone: "a.io", Type: "ALIAS"}, withErr: true},
+		"primary with masters":    {zone: dns.ZoneCreate{Zone: "a.io", Type: "PRIMARY", Masters: []string{"1.2.3.4"}}, withErr: true},
+		"secondary with a target": {zone: dns.ZoneCreate{Zone: "a.io", Type: "SECONDARY", Masters: []string{"1.2.3.4"}, Target: "b.io"}, withErr: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := checkBulkZone(&test.zone)
+			if test.withErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -2,13 +2,11 @@ package dns
 
 import (
 	"context"
-	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
-	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -19,9 +17,11 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/session"
 
 	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/providers/dns/rdata"
 	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
 	"github.com/apex/log"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -38,6 +38,9 @@ func resourceDNSv2Record() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceDNSRecordImport,
 		},
+		CustomizeDiff: customdiff.All(
+			validateRecordRdata,
+		),
 		Schema: map[string]*schema.Schema{
 			"zone": {
 				Type:             schema.TypeString,
@@ -317,8 +320,9 @@ func resourceDNSv2Record() *schema.Resource {
 				Optional: true,
 			},
 			"svc_params": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: dnsRecordSvcParamsSuppress,
 			},
 			"target_name": {
 				Type:     schema.TypeString,
@@ -413,6 +417,114 @@ func dnsRecordTypeValueSuppress(_, _, _ string, d *schema.ResourceData) bool {
 	return false
 }
 
+// Suppress check for svc_params which only differ in key order or value notation
+func dnsRecordSvcParamsSuppress(_, old, new string, _ *schema.ResourceData) bool {
+	oldParams, err := rdata.SvcParams(old)
+	if err != nil {
+		return false
+	}
+	newParams, err := rdata.SvcParams(new)
+	if err != nil {
+		return false
+	}
+	return oldParams == newParams
+}
+
+// rdataTargetTypes are the record types whose target holds complete records
+var rdataTargetTypes = map[string]bool{
+	RRTypeA:     true,
+	RRTypeAaaa:  true,
+	RRTypeCaa:   true,
+	RRTypeCname: true,
+	RRTypeLoc:   true,
+	RRTypeNs:    true,
+	RRTypePtr:   true,
+}
+
+// rdataValidatedTypes are the record types whose record data is validated by the rdata package. The
+// record data of other types is left to the API.
+var rdataValidatedTypes = map[string]bool{
+	RRTypeA:     true,
+	RRTypeAaaa:  true,
+	RRTypeCaa:   true,
+	RRTypeCname: true,
+	RRTypeDs:    true,
+	RRTypeHTTPS: true,
+	RRTypeLoc:   true,
+	RRTypeNs:    true,
+	RRTypePtr:   true,
+	RRTypeSshfp: true,
+	RRTypeSvcb:  true,
+	RRTypeTlsa:  true,
+}
+
+// validateRecordRdata is a CustomizeDiff which validates record data at plan time, so invalid records
+// fail before any zone is changed
+func validateRecordRdata(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	recordType, err := tools.GetStringValue("recordtype", diff)
+	if err != nil {
+		return nil
+	}
+
+	switch {
+	case rdataTargetTypes[recordType]:
+		if !diff.NewValueKnown("target") {
+			return nil
+		}
+		target, err := tools.GetListValue("target", diff)
+		if err != nil {
+			return nil
+		}
+		for _, t := range target {
+			value, ok := t.(string)
+			if !ok {
+				continue
+			}
+			if err := rdata.Validate(recordType, value); err != nil {
+				return fmt.Errorf("target: %w", err)
+			}
+		}
+	case recordType == RRTypeSvcb || recordType == RRTypeHTTPS:
+		if !diff.NewValueKnown("svc_params") {
+			return nil
+		}
+		params, err := tools.GetStringValue("svc_params", diff)
+		if err != nil {
+			return nil
+		}
+		if _, err := rdata.SvcParams(params); err != nil {
+			return fmt.Errorf("svc_params: %w", err)
+		}
+	case recordType == RRTypeTlsa:
+		return validateRecordFields(diff, recordType, "usage", "selector", "match_type", "certificate")
+	case recordType == RRTypeSshfp:
+		return validateRecordFields(diff, recordType, "algorithm", "fingerprint_type", "fingerprint")
+	case recordType == RRTypeDs:
+		return validateRecordFields(diff, recordType, "keytag", "algorithm", "digest_type", "digest")
+	}
+	return nil
+}
+
+// validateRecordFields joins the fields a record is built from and validates the result. Records with unknown
+// or unset fields are left to validateRecord at apply time.
+func validateRecordFields(diff *schema.ResourceDiff, recordType string, fields ...string) error {
+	values := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if !diff.NewValueKnown(field) {
+			return nil
+		}
+		value, ok := diff.GetOk(field)
+		if !ok {
+			return nil
+		}
+		values = append(values, fmt.Sprint(value))
+	}
+	if err := rdata.Validate(recordType, strings.Join(values, " ")); err != nil {
+		return fmt.Errorf("%s: %w", strings.Join(fields, ", "), err)
+	}
+	return nil
+}
+
 // DiffSuppresFunc to handle quoted TXT Rdata strings possibly containing escaped quotes
 func dnsRecordTargetSuppress(_, old, new string, d *schema.ResourceData) bool {
 	logger := akamai.Log("[Akamai DNS]", "dnsRecordTargetSuppress")
@@ -483,27 +595,19 @@ func diffQuotedDNSRecord(oldTargetList []string, newTargetList []string, old str
 		compList = newTargetList
 	}
 
-	// for AAAA record type, we want to compare IPv6 values
-	if recordType == RRTypeAaaa {
-		logger.Debugf("AAAA Suppress. baseval: [%v]", baseVal)
-		fullBaseval := FullIPv6(net.ParseIP(baseVal))
-		for _, compval := range compList {
-			logger.Debugf("AAAA Suppress. compval: [%v]", compval)
-			fullCompval := FullIPv6(net.ParseIP(compval))
-			if fullBaseval == fullCompval {
-				return true
-			}
+	// compare the canonical forms of record types where the API may return a different notation
+	if recordType == RRTypeAaaa || recordType == RRTypeCaa || recordType == RRTypeLoc {
+		if recordType == RRTypeCaa {
+			// quotes around the value may be escaped in state
+			baseVal = strings.ReplaceAll(baseVal, singleQuote, "")
 		}
-		return false
-	}
-
-	if recordType == RRTypeCaa {
-		baseVal = strings.ReplaceAll(baseVal, singleQuote, "")
+		logger.Debugf("%s Suppress. baseval: [%v]", recordType, baseVal)
 		for _, compval := range compList {
-			compval = strings.ReplaceAll(compval, singleQuote, "")
-			logger.Debugf("updated baseVal: %v", baseVal)
-			logger.Debugf("compval: %v", compval)
-			if baseVal == compval {
+			if recordType == RRTypeCaa {
+				compval = strings.ReplaceAll(compval, singleQuote, "")
+			}
+			logger.Debugf("%s Suppress. compval: [%v]", recordType, compval)
+			if rdata.Equal(recordType, baseVal, compval) {
 				return true
 			}
 		}
@@ -523,12 +627,10 @@ func diffQuotedDNSRecord(oldTargetList []string, newTargetList []string, old str
 	}
 
 	if recordType == RRTypeAfsdb || recordType == RRTypeCname || recordType == RRTypePtr || recordType == RRTypeSrv || recordType == RRTypeNs {
-		baseVal = strings.TrimRight(baseVal, ".")
 		for _, compval := range compList {
-			compval = strings.TrimRight(compval, ".")
 			logger.Debugf("updated baseVal: %v", baseVal)
 			logger.Debugf("compval: %v", compval)
-			if baseVal == compval {
+			if rdata.Equal(recordType, baseVal, compval) {
 				return true
 			}
 		}
@@ -704,14 +806,6 @@ func resourceDNSRecordCreate(ctx context.Context, d *schema.ResourceData, m inte
 
 	logger.Infof("Record Create. zone: %s, host: %s, recordtype: %s", zone, host, recordType)
 
-	if err := validateRecord(d); err != nil {
-		return append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("DNS record validation failure for recordset %s", host),
-			Detail:   err.Error(),
-		})
-	}
-
 	// serialize record creates of same type
 	getRecordLock(recordType).Lock()
 	defer getRecordLock(recordType).Unlock()
@@ -747,6 +841,13 @@ func resourceDNSRecordCreate(ctx context.Context, d *schema.ResourceData, m inte
 			Detail:   err.Error(),
 		})
 	}
+	if err := validateRecord(recordCreate); err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("DNS record validation failure for recordset %s", host),
+			Detail:   err.Error(),
+		})
+	}
 
 	logger.WithField("bind-object", recordCreate).Debug("Record Create")
 
@@ -875,14 +976,6 @@ func resourceDNSRecordUpdate(ctx context.Context, d *schema.ResourceData, m inte
 		"recordtype": recordType,
 	}).Info("record Update")
 
-	if err := validateRecord(d); err != nil {
-		return append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("DNS record validation failure for %s", host),
-			Detail:   err.Error(),
-		})
-	}
-
 	// serialize record updates of same type
 	getRecordLock(recordType).Lock()
 	defer getRecordLock(recordType).Unlock()
@@ -921,6 +1014,13 @@ func resourceDNSRecordUpdate(ctx context.Context, d *schema.ResourceData, m inte
 			Detail:   err.Error(),
 		})
 	}
+	if err := validateRecord(recordCreate); err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("DNS record validation failure for %s", host),
+			Detail:   err.Error(),
+		})
+	}
 	extractString := strings.Join(recordCreate.Target, " ")
 	sha1hash := tools.GetSHAString(extractString)
 
@@ -1349,41 +1449,10 @@ func resourceDNSRecordDelete(ctx context.Context, d *schema.ResourceData, m inte
 }
 
 // FullIPv6 encodes IPV6 as a full string
+//
+// Deprecated: use rdata.FullIPv6 instead.
 func FullIPv6(ip net.IP) string {
-	dst := make([]byte, hex.EncodedLen(len(ip)))
-	_ = hex.Encode(dst, ip)
-	return string(dst[0:4]) + ":" +
-		string(dst[4:8]) + ":" +
-		string(dst[8:12]) + ":" +
-		string(dst[12:16]) + ":" +
-		string(dst[16:20]) + ":" +
-		string(dst[20:24]) + ":" +
-		string(dst[24:28]) + ":" +
-		string(dst[28:])
-}
-
-func padvalue(str string, logger log.Interface) string {
-	vstr := strings.ReplaceAll(str, "m", "")
-	logger.WithField("padvalue", str).Debug("[Akamai DNSv2]")
-	vfloat, err := strconv.ParseFloat(vstr, 32)
-	if err != nil {
-		logger.Errorf("padvalue. Parse error: %s", vstr)
-	}
-	vresult := fmt.Sprintf("%.2f", vfloat)
-	logger.Debugf("padvalue. Padded v_result %s", vresult)
-	return vresult
-}
-
-// Used to pad coordinates to x.xxm format
-func padCoordinates(str string, logger log.Interface) string {
-
-	s := strings.Split(str, " ")
-	if len(s) < 12 {
-		logger.Debug("coordinates string is too short")
-		return ""
-	}
-	latD, latM, latS, latDir, longD, longM, longS, longDir, altitude, size, horizPrecision, vertPrecision := s[0], s[1], s[2], s[3], s[4], s[5], s[6], s[7], s[8], s[9], s[10], s[11]
-	return fmt.Sprintf("%s %s %s %s %s %s %s %s %sm %sm %sm %sm", latD, latM, latS, latDir, longD, longM, longS, longDir, padvalue(altitude, logger), padvalue(size, logger), padvalue(horizPrecision, logger), padvalue(vertPrecision, logger))
+	return rdata.FullIPv6(ip)
 }
 
 func bindRecord(ctx context.Context, meta akamai.OperationMeta, d *schema.ResourceData, logger log.Interface) (dns.RecordBody, error) {
@@ -2035,12 +2104,18 @@ func buildRecordsList(target []interface{}, recordType string, logger log.Interf
 		switch recordType {
 		case RRTypeAaaa:
 			addr := net.ParseIP(recContentStr)
-			result := FullIPv6(addr)
+			result := rdata.FullIPv6(addr)
 			logger.Debugf("IPV6 full %s", result)
 			records = append(records, result)
 		case RRTypeLoc:
 			logger.Debugf("LOC code format %s", recContentStr)
-			str := padCoordinates(recContentStr, logger)
+			str := rdata.PadCoordinates(recContentStr)
+			if str == "" {
+				// fill in the optional fields of short notations
+				if canonical, err := rdata.Canonical(RRTypeLoc, recContentStr); err == nil {
+					str = canonical
+				}
+			}
 			records = append(records, str)
 		case RRTypeSpf:
 			if !strings.HasPrefix(recContentStr, "\"") {
@@ -2077,765 +2152,27 @@ func buildRecordsList(target []interface{}, recordType string, logger log.Interf
 	return records, nil
 }
 
-func validateRecord(d *schema.ResourceData) error {
-	recordType, err := tools.GetStringValue("recordtype", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-
-	switch recordType {
-	case RRTypeA, RRTypeAaaa, RRTypeAkamaiCdn, RRTypeCname, RRTypeLoc, RRTypeNs, RRTypePtr, RRTypeSpf, RRTypeTxt:
-		if err := checkBasicRecordTypes(d); err != nil {
-			return err
-		}
-		return checkTargets(d)
-	case RRTypeAfsdb:
-		return checkAsdfRecord(d)
-	case RRTypeDnskey:
-		return checkDnskeyRecord(d)
-	case RRTypeDs:
-		return checkDsRecord(d)
-	case RRTypeHinfo:
-		return checkHinfoRecord(d)
-	case RRTypeMx:
-		return checkMxRecord(d)
-	case RRTypeNaptr:
-		return checkNaptrRecord(d)
-	case RRTypeNsec3:
-		return checkNsec3Record(d)
-	case RRTypeNsec3Param:
-		return checkNsec3ParamRecord(d)
-	case RRTypeRp:
-		return checkRpRecord(d)
-	case RRTypeRrsig:
-		return checkRrsigRecord(d)
-	case RRTypeSrv:
-		return checkSrvRecord(d)
-	case RRTypeSshfp:
-		return checkSshfpRecord(d)
-	case RRTypeAkamaiTlc:
-		return checkAkamaiTlcRecord(d)
-	case RRTypeSoa:
-		return checkSoaRecord(d)
-	case RRTypeCaa:
-		return checkCaaRecord(d)
-	case RRTypeCert:
-		return checkCertRecord(d)
-	case RRTypeTlsa:
-		return checkTlsaRecord(d)
-	case RRTypeSvcb:
-		return checkSvcbRecord(d)
-	case RRTypeHTTPS:
-		return checkHTTPSRecord(d)
-	default:
-		return fmt.Errorf("invalid recordtype %v", recordType)
+// validateRecord validates the record data built from the configuration. The record types validated at plan
+// time by validateRecordRdata are validated again by their parser in the rdata package, as values that
+// weren't known when planning aren't checked there.
+func validateRecord(rec dns.RecordBody) error {
+	if rec.RecordType == RRTypeAkamaiTlc {
+		return fmt.Errorf("AKAMAITLC is a READ ONLY record")
 	}
-}
-
-func checkBasicRecordTypes(d *schema.ResourceData) error {
-	_, err := tools.GetStringValue("name", d)
-	if err != nil {
-		if !errors.Is(err, tools.ErrNotFound) {
-			return err
-		}
-		return fmt.Errorf("configuration argument host must be set")
-	}
-	_, err = tools.GetStringValue("recordtype", d)
-	if err != nil {
-		if !errors.Is(err, tools.ErrNotFound) {
-			return err
-		}
-		return fmt.Errorf("configuration argument recordtype must be set")
-	}
-	_, err = tools.GetIntValue("ttl", d)
-	if err != nil {
-		if !errors.Is(err, tools.ErrNotFound) {
-			return err
-		}
-		return fmt.Errorf("configuration argument ttl must be set")
-	}
-	return nil
-}
-
-func checkTargets(d *schema.ResourceData) error {
-	target, err := tools.GetListValue("target", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	if len(target) == 0 {
+	if len(rec.Target) == 0 {
 		return fmt.Errorf("configuration argument target must be set")
 	}
-	for _, recContent := range target {
-		_, ok := recContent.(string)
-		if !ok {
-			return fmt.Errorf("target record is of invalid type; should be 'string'")
-		}
-	}
-	return nil
-}
-
-func checkAsdfRecord(d *schema.ResourceData) error {
-	subtype, err := tools.GetIntValue("subtype", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	if subtype == 0 {
-		return fmt.Errorf("configuration argument subtype must be set for ASDF")
-	}
-
-	return checkTargets(d)
-}
-
-func checkDnskeyRecord(d *schema.ResourceData) error {
-	flags, err := tools.GetIntValue("flags", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	protocol, err := tools.GetIntValue("protocol", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	algorithm, err := tools.GetIntValue("algorithm", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	key, err := tools.GetStringValue("key", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	ttl, err := tools.GetIntValue("ttl", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-
-	if !(flags == 0 || flags == 256 || flags == 257) {
-		return fmt.Errorf("configuration argument flags must not be %v for DNSKEY", flags)
-	}
-
-	if ttl == 0 {
-		return fmt.Errorf("configuration argument ttl must be set for DNSKEY")
-	}
-
-	if protocol == 0 {
-		return fmt.Errorf("configuration argument protocol must be set for DNSKEY")
-	}
-
-	// FIXME this logic seems to be flawed, assertion will fail only if algorithm == 10
-	if !((algorithm >= 1 && algorithm <= 8) || algorithm != 10) {
-		return fmt.Errorf("configuration argument algorithm must not be %v for DNSKEY", algorithm)
-	}
-
-	if key == "" {
-		return fmt.Errorf("configuration argument key must be set for DNSKEY")
-	}
-
-	return nil
-}
-
-func checkDsRecord(d *schema.ResourceData) error {
-	digestType, err := tools.GetIntValue("digest_type", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	keytag, err := tools.GetIntValue("keytag", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	algorithm, err := tools.GetIntValue("algorithm", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	digest, err := tools.GetStringValue("digest", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-
-	if digestType == 0 {
-		return fmt.Errorf("configuration argument digest_type must be set for DS")
-	}
-
-	if keytag == 0 {
-		return fmt.Errorf("configuration argument keytag must be set for DS")
-	}
-
-	if algorithm == 0 {
-		return fmt.Errorf("configuration argument algorithm must be set for DS")
-	}
-
-	if digest == "" {
-		return fmt.Errorf("configuration argument digest must be set for DS")
-	}
-
-	return nil
-}
-
-func checkHinfoRecord(d *schema.ResourceData) error {
-	hardware, err := tools.GetStringValue("hardware", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	software, err := tools.GetStringValue("software", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-
-	if hardware == "" {
-		return fmt.Errorf("configuration argument hardware must be set for HINFO")
-	}
-
-	if software == "" {
-		return fmt.Errorf("configuration argument software must be set for HINFO")
-	}
-
-	return nil
-}
-
-func checkMxRecord(d *schema.ResourceData) error {
-	priority, err := tools.GetIntValue("priority", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-
-	if err := checkBasicRecordTypes(d); err != nil {
-		return err
-	}
-
-	if priority < 0 || priority > 65535 {
-		return fmt.Errorf("configuration argument priority must be set for MX")
-	}
-
-	return checkTargets(d)
-}
-
-func checkNaptrRecord(d *schema.ResourceData) error {
-	flagsnaptr, err := tools.GetStringValue("flagsnaptr", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	order, err := tools.GetIntValue("order", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	preference, err := tools.GetIntValue("preference", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	regexp, err := tools.GetStringValue("regexp", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	replacement, err := tools.GetStringValue("replacement", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	service, err := tools.GetStringValue("service", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-
-	if err := checkBasicRecordTypes(d); err != nil {
-		return err
-	}
-
-	if flagsnaptr == "" {
-		return fmt.Errorf("configuration argument flagsnaptr must be set for NAPTR")
-	}
-
-	if order < 0 || order > 65535 {
-		return fmt.Errorf("configuration argument order must not be %v for NAPTR", order)
-	}
-
-	if preference == 0 {
-		return fmt.Errorf("configuration argument preference must be set for NAPTR")
-	}
-
-	if regexp == "" {
-		return fmt.Errorf("configuration argument regexp must be set for NAPTR")
-	}
-
-	if replacement == "" {
-		return fmt.Errorf("configuration argument replacement must be set for NAPTR")
-	}
-
-	if service == "" {
-		return fmt.Errorf("configuration argument service must be set for NAPTR")
-	}
-
-	return nil
-}
-
-func checkNsec3Record(d *schema.ResourceData) error {
-	flags, err := tools.GetIntValue("flags", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	algorithm, err := tools.GetIntValue("algorithm", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	iterations, err := tools.GetIntValue("iterations", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	nextHashedOwnerName, err := tools.GetStringValue("next_hashed_owner_name", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	salt, err := tools.GetStringValue("salt", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	typeBitmaps, err := tools.GetStringValue("type_bitmaps", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-
-	if err := checkBasicRecordTypes(d); err != nil {
-		return err
-	}
-
-	if !(flags == 0 || flags == 1) {
-		return fmt.Errorf("configuration argument flags must be set for NSEC3")
-	}
-
-	if algorithm != 1 {
-		return fmt.Errorf("configuration argument flags must be set for NSEC3")
-	}
-	if iterations == 0 {
-		return fmt.Errorf("configuration argument iterations must be set for NSEC3")
-	}
-	if nextHashedOwnerName == "" {
-		return fmt.Errorf("configuration argument nextHashedOwnerName must be set for NSEC3")
-	}
-	if salt == "" {
-		return fmt.Errorf("configuration argument salt must be set for NSEC3")
-	}
-	if typeBitmaps == "" {
-		return fmt.Errorf("configuration argument typeBitMaps must be set for NSEC3")
-	}
-	return nil
-}
-
-func checkNsec3ParamRecord(d *schema.ResourceData) error {
-	flags, err := tools.GetIntValue("flags", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	algorithm, err := tools.GetIntValue("algorithm", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	iterations, err := tools.GetIntValue("iterations", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	salt, err := tools.GetStringValue("salt", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-
-	if err := checkBasicRecordTypes(d); err != nil {
-		return err
-	}
-
-	if !(flags == 0 || flags == 1) {
-		return fmt.Errorf("configuration argument flags must be set for NSEC3PARAM")
-	}
-
-	if algorithm != 1 {
-		return fmt.Errorf("configuration argument algorithm must be set for NSEC3PARAM")
-	}
-
-	if iterations == 0 {
-		return fmt.Errorf("configuration argument iterations must be set for NSEC3PARAM")
-	}
-
-	if salt == "" {
-		return fmt.Errorf("configuration argument salt must be set for NSEC3PARAM")
-	}
-
-	return nil
-}
-
-func checkRpRecord(d *schema.ResourceData) error {
-	mailbox, err := tools.GetStringValue("mailbox", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	txt, err := tools.GetStringValue("txt", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-
-	if err := checkBasicRecordTypes(d); err != nil {
-		return err
-	}
-
-	if mailbox == "" {
-		return fmt.Errorf("configuration argument mailbox must be set for RP")
-	}
-
-	if txt == "" {
-		return fmt.Errorf("configuration argument txt must be set for RP")
-	}
-
-	return nil
-}
-
-func checkRrsigRecord(d *schema.ResourceData) error {
-	expiration, err := tools.GetStringValue("expiration", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	inception, err := tools.GetStringValue("inception", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	originalTTL, err := tools.GetIntValue("original_ttl", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	algorithm, err := tools.GetIntValue("algorithm", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	labels, err := tools.GetIntValue("labels", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	keytag, err := tools.GetIntValue("keytag", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	signature, err := tools.GetStringValue("signature", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	signer, err := tools.GetStringValue("signer", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	typeCovered, err := tools.GetStringValue("type_covered", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-
-	if err := checkBasicRecordTypes(d); err != nil {
-		return err
-	}
-
-	if expiration == "" {
-		return fmt.Errorf("configuration argument expiration must be set for RRSIG")
-	}
-
-	if inception == "" {
-		return fmt.Errorf("configuration argument inception must be set for RRSIG")
-	}
-
-	if originalTTL == 0 {
-		return fmt.Errorf("configuration argument originalTTL must be set for RRSIG")
-	}
-
-	if algorithm == 0 {
-		return fmt.Errorf("configuration argument algorithm must be set for RRSIG")
-	}
-
-	if labels == 0 {
-		return fmt.Errorf("configuration argument labels must be set for RRSIG")
-	}
-
-	if keytag == 0 {
-		return fmt.Errorf("configuration argument keytag must be set for RRSIG")
-	}
-
-	if signature == "" {
-		return fmt.Errorf("configuration argument signature must be set for RRSIG")
-	}
-
-	if signer == "" {
-		return fmt.Errorf("configuration argument signer must be set for RRSIG")
-	}
-
-	if typeCovered == "" {
-		return fmt.Errorf("configuration argument typeCovered must be set for RRSIG")
-	}
-
-	return nil
-}
-
-func checkSrvRecord(d *schema.ResourceData) error {
-	priority, err := tools.GetIntValue("priority", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	weight, err := tools.GetIntValue("weight", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	port, err := tools.GetIntValue("port", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-
-	if err := checkBasicRecordTypes(d); err != nil {
-		return err
-	}
-
-	if err := checkTargets(d); err != nil {
-		return err
-	}
-
-	if priority < 0 || priority > 65535 {
-		return fmt.Errorf("configuration argument priority must be set for SRV")
-	}
-
-	if weight < 0 || weight > 65535 {
-		return fmt.Errorf("configuration argument weight must not be %v for SRV", weight)
-	}
-
-	if port == 0 {
-		return fmt.Errorf("configuration argument port must be set for SRV")
-	}
-
-	return nil
-}
-
-func checkSshfpRecord(d *schema.ResourceData) error {
-	algorithm, err := tools.GetIntValue("algorithm", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
+	if !rdataValidatedTypes[rec.RecordType] {
+		return nil
 	}
-	fingerprintType, err := tools.GetIntValue("fingerprint_type", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	fingerprint, err := tools.GetStringValue("fingerprint", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-
-	if err := checkBasicRecordTypes(d); err != nil {
-		return err
-	}
-
-	if algorithm == 0 {
-		return fmt.Errorf("configuration argument algorithm must be set for SSHFP")
-	}
-
-	if fingerprintType == 0 {
-		return fmt.Errorf("configuration argument fingerprintType must be set for SSHFP")
-	}
-
-	if fingerprint == "null" {
-		return fmt.Errorf("configuration argument fingerprint must be set for SSHFP")
-	}
-
-	return nil
-}
-
-func checkSoaRecord(d *schema.ResourceData) error {
-
-	nameserver, err := tools.GetStringValue("name_server", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	emailaddr, err := tools.GetStringValue("email_address", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	refresh, err := tools.GetIntValue("refresh", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	retry, err := tools.GetIntValue("retry", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	expiry, err := tools.GetIntValue("expiry", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	nxdomainttl, err := tools.GetIntValue("nxdomain_ttl", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-
-	if nameserver == "" {
-		return fmt.Errorf("configuration argument %s must be specified in SOA record", "nameserver")
-	}
-
-	if emailaddr == "" {
-		return fmt.Errorf("configuration argument %s must be specified in SOA record", "emailaddr")
-	}
-
-	if refresh == 0 {
-		return fmt.Errorf("configuration argument %s must be specified in SOA record", "refresh")
-	}
-
-	if retry == 0 {
-		return fmt.Errorf("configuration argument %s must be specified in SOA record", "retry")
-	}
-
-	if expiry == 0 {
-		return fmt.Errorf("configuration argument %s must be specified in SOA record", "expiry")
-	}
-
-	if nxdomainttl == 0 {
-		return fmt.Errorf("configuration argument %s must be specified in SOA record", "nxdomainttl")
-	}
-
-	return nil
-}
-
-func checkAkamaiTlcRecord(*schema.ResourceData) error {
-
-	return fmt.Errorf("AKAMAITLC is a READ ONLY record")
-}
-
-func checkCaaRecord(d *schema.ResourceData) error {
-
-	if err := checkBasicRecordTypes(d); err != nil {
-		return err
-	}
-
-	if err := checkTargets(d); err != nil {
-		return err
-	}
-
-	caatarget := d.Get("target").([]interface{})
-	for _, caa := range caatarget {
-		caaStr, ok := caa.(string)
-		if !ok {
-			return fmt.Errorf("CAA is of invalid type; should be 'string'")
-		}
-		caaparts := strings.Split(caaStr, " ")
-		if len(caaparts) != 3 {
-			return fmt.Errorf("configuration argument CAA target %s is invalid", caaStr)
-		}
-
-		flag, err := strconv.Atoi(caaparts[0])
-		if err != nil || flag < 0 || flag > 255 {
-			return fmt.Errorf("configuration argument CAA target %s is invalid. flag value must be <= 0 and >= 255", caaStr)
-		}
-		re := regexp.MustCompile(`[^a-zA-Z0-9]+`)
-		submatchall := re.FindAllString(caaparts[1], -1)
-		if len(submatchall) > 0 {
-			return fmt.Errorf("configuration argument  CAA target %s is invalid. tag contains invalid characters", caaStr)
+	for _, target := range rec.Target {
+		if err := rdata.Validate(rec.RecordType, target); err != nil {
+			return fmt.Errorf("%s record %q: %w", rec.RecordType, target, err)
 		}
 	}
-
 	return nil
 }
 
-func checkCertRecord(d *schema.ResourceData) error {
-	typemnemonic, err := tools.GetStringValue("type_mnemonic", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	typevalue, err := tools.GetIntValue("type_value", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	certificate, err := tools.GetStringValue("certificate", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-
-	if err := checkBasicRecordTypes(d); err != nil {
-		return err
-	}
-
-	if typemnemonic == "" && typevalue == 0 {
-		return fmt.Errorf("configuration arguments type_value and type_mnemonic are not set. Invalid CERT configuration")
-	}
-
-	if typemnemonic != "" && typevalue != 0 {
-		return fmt.Errorf("configuration arguments type_value and type_mnemonic are both set. Invalid CERT configuration")
-	}
-
-	if certificate == "" {
-		return fmt.Errorf("configuration argument certificate must be set for CERT")
-	}
-	return nil
-
-}
-
-func checkTlsaRecord(d *schema.ResourceData) error {
-
-	usage, err := tools.GetIntValue("usage", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	certificate, err := tools.GetStringValue("certificate", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-
-	if err := checkBasicRecordTypes(d); err != nil {
-		return err
-	}
-
-	if certificate == "" {
-		return fmt.Errorf("configuration argument certificate must be set for TLSA")
-	}
-
-	if usage == 0 {
-		return fmt.Errorf("configuration argument usage must be set for TLSA")
-	}
-
-	return nil
-
-}
-
-func checkSvcbRecord(d *schema.ResourceData) error {
-
-	return checkServiceRecord(d, "SVCB")
-}
-
-func checkHTTPSRecord(d *schema.ResourceData) error {
-
-	return checkServiceRecord(d, "HTTPS")
-}
-
-func checkServiceRecord(d *schema.ResourceData, rtype string) error {
-
-	pri, err := tools.GetIntValue("svc_priority", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	tname, err := tools.GetStringValue("target_name", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-	params, err := tools.GetStringValue("svc_params", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
-		return err
-	}
-
-	if err := checkBasicRecordTypes(d); err != nil {
-		return err
-	}
-
-	if pri < 0 || pri > 65535 {
-		return fmt.Errorf("configuration argument svc_priority must be positive int for %s", rtype)
-	}
-
-	if tname == "" {
-		return fmt.Errorf("configuration argument target_name must be set for %s", rtype)
-	}
-
-	if params == "" && pri > 0 {
-		return fmt.Errorf("configuration argument svc_params must be set for %s", rtype)
-	}
-
-	if pri == 0 && params != "" {
-		return fmt.Errorf("configuration argument svc_params cannot be set for %s if svc_priority is zero", rtype)
-	}
-
-	return nil
-
-}
-
 // Resource record types supported by the Akamai Edge DNS API
 const (
 	RRTypeA          = "A"
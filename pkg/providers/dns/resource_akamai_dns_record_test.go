@@ -7,8 +7,12 @@ import (
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/dns"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/session"
+	"github.com/apex/log"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResDnsRecord(t *testing.T) {
@@ -105,3 +109,198 @@ func TestResDnsRecord(t *testing.T) {
 		client.AssertExpectations(t)
 	})
 }
+
+func TestDiffQuotedDNSRecord(t *testing.T) {
+	tests := map[string]struct {
+		recordType string
+		old, new   string
+		expected   bool
+	}{
+		"AAAA full and short": {
+			recordType: RRTypeAaaa,
+			old:        "2001:0db8:0000:0000:0000:0000:0000:0001",
+			new:        "2001:db8::1",
+			expected:   true,
+		},
+		"AAAA different": {
+			recordType: RRTypeAaaa,
+			old:        "2001:db8::1",
+			new:        "2001:db8::2",
+			expected:   false,
+		},
+		"CAA escaped quotes": {
+			recordType: RRTypeCaa,
+			old:        `0 issue \"ca.example.net\"`,
+			new:        `0 issue "ca.example.net"`,
+			expected:   true,
+		},
+		"CAA tag case": {
+			recordType: RRTypeCaa,
+			old:        `0 issue \"ca.example.net\"`,
+			new:        `0 ISSUE "ca.example.net"`,
+			expected:   true,
+		},
+		"LOC padded": {
+			recordType: RRTypeLoc,
+			old:        "52 22 23.000 N 4 53 32.000 E -2.00m 0.00m 10000.00m 10.00m",
+			new:        "52 22 23 N 4 53 32 E -2m 0m",
+			expected:   true,
+		},
+		"CNAME trailing dot and case": {
+			recordType: RRTypeCname,
+			old:        "www.example.com.",
+			new:        "WWW.example.com",
+			expected:   true,
+		},
+		"SRV trailing dot": {
+			recordType: RRTypeSrv,
+			old:        "10 60 5060 sip.example.com.",
+			new:        "10 60 5060 sip.example.com",
+			expected:   true,
+		},
+		"SRV different port": {
+			recordType: RRTypeSrv,
+			old:        "10 60 5060 sip.example.com.",
+			new:        "10 60 5061 sip.example.com",
+			expected:   false,
+		},
+		"AFSDB trailing dot": {
+			recordType: RRTypeAfsdb,
+			old:        "1 afsdb.example.com.",
+			new:        "1 afsdb.example.com",
+			expected:   true,
+		},
+		"TXT escaped quotes": {
+			recordType: RRTypeTxt,
+			old:        `\"v=spf1 -all\"`,
+			new:        `v=spf1 -all`,
+			expected:   true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			suppressed := diffQuotedDNSRecord([]string{test.old}, []string{test.new}, test.old, test.new, test.recordType, log.Log)
+			assert.Equal(t, test.expected, suppressed)
+		})
+	}
+}
+
+func TestValidateRecord(t *testing.T) {
+	tests := map[string]struct {
+		config    map[string]interface{}
+		withError string
+	}{
+		"A": {
+			config: map[string]interface{}{"recordtype": "A", "target": []interface{}{"10.0.0.2", "10.0.0.3"}},
+		},
+		"AAAA": {
+			config: map[string]interface{}{"recordtype": "AAAA", "target": []interface{}{"2001:db8::1"}},
+		},
+		"AFSDB": {
+			config: map[string]interface{}{"recordtype": "AFSDB", "subtype": 1, "target": []interface{}{"afsdb.example.com"}},
+		},
+		"CAA": {
+			config: map[string]interface{}{"recordtype": "CAA", "target": []interface{}{`0 issue "ca.example.net"`}},
+		},
+		"CERT": {
+			config: map[string]interface{}{"recordtype": "CERT", "type_mnemonic": "PGP", "keytag": 1, "algorithm": 3,
+				"certificate": "TUlJQ1hEQ0NBaDJnQXdJQkFnSUpBTVVX"},
+		},
+		"CNAME": {
+			config: map[string]interface{}{"recordtype": "CNAME", "target": []interface{}{"www.example.com"}},
+		},
+		"DNSKEY": {
+			config: map[string]interface{}{"recordtype": "DNSKEY", "flags": 257, "protocol": 3, "algorithm": 8,
+				"key": "AwEAAcVKSpdUxmm4YC0Ei6RVQGsOJjpL"},
+		},
+		"DS": {
+			config: map[string]interface{}{"recordtype": "DS", "keytag": 30336, "algorithm": 7, "digest_type": 1,
+				"digest": "909FF0B4DD66F91F56524C4F968D13083BE42380"},
+		},
+		"HINFO": {
+			config: map[string]interface{}{"recordtype": "HINFO", "hardware": "INTEL-386", "software": "UNIX"},
+		},
+		"NAPTR": {
+			config: map[string]interface{}{"recordtype": "NAPTR", "order": 100, "preference": 10, "flagsnaptr": "S",
+				"service": "SIP+D2U", "regexp": "!^.*$!sip:customer-service@example.com!", "replacement": "."},
+		},
+		"NSEC3": {
+			config: map[string]interface{}{"recordtype": "NSEC3", "algorithm": 1, "flags": 0, "iterations": 1,
+				"salt": "ABCDEF", "next_hashed_owner_name": "R2NUSMGFSEUHT195P59KOU2AI30JR96P", "type_bitmaps": "A NS SOA"},
+		},
+		"NSEC3PARAM": {
+			config: map[string]interface{}{"recordtype": "NSEC3PARAM", "algorithm": 1, "flags": 0, "iterations": 1, "salt": "ABCDEF"},
+		},
+		"RP": {
+			config: map[string]interface{}{"recordtype": "RP", "mailbox": "admin.example.com", "txt": "txt.example.com"},
+		},
+		"RRSIG": {
+			config: map[string]interface{}{"recordtype": "RRSIG", "type_covered": "A", "algorithm": 7, "labels": 3,
+				"original_ttl": 3600, "expiration": "20120318104101", "inception": "20120315094101", "keytag": 63761,
+				"signer": "example.com.", "signature": "toCy19QnAb86vRlQjf5LGuM6RbYuVFj1e0L0sH3xQ6T0jq7p4K4="},
+		},
+		"SOA": {
+			config: map[string]interface{}{"recordtype": "SOA", "name_server": "a1-118.akam.net.", "email_address": "hostmaster.example.com",
+				"serial": 1, "refresh": 3600, "retry": 600, "expiry": 604800, "nxdomain_ttl": 300},
+		},
+		"SPF": {
+			config: map[string]interface{}{"recordtype": "SPF", "target": []interface{}{"v=spf1 -all"}},
+		},
+		"SRV": {
+			config: map[string]interface{}{"recordtype": "SRV", "priority": 10, "weight": 60, "port": 5060, "target": []interface{}{"sip.example.com"}},
+		},
+		"SSHFP": {
+			config: map[string]interface{}{"recordtype": "SSHFP", "algorithm": 2, "fingerprint_type": 1,
+				"fingerprint": "123456789ABCDEF67890123456789ABCDEF67890"},
+		},
+		"SVCB": {
+			config: map[string]interface{}{"recordtype": "SVCB", "svc_priority": 1, "target_name": "svc.example.com.", "svc_params": "port=8443"},
+		},
+		"TLSA": {
+			config: map[string]interface{}{"recordtype": "TLSA", "usage": 3, "selector": 1, "match_type": 1,
+				"certificate": "D2ABDE240D7CD3EE6B4B28C54DF034B97983A1D16E8A410E4561CB106618E971"},
+		},
+		"TXT": {
+			config: map[string]interface{}{"recordtype": "TXT", "target": []interface{}{"v=DKIM1; k=rsa; p=MIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQC"}},
+		},
+		"invalid address": {
+			config:    map[string]interface{}{"recordtype": "A", "target": []interface{}{"10.0.0.256"}},
+			withError: `A record "10.0.0.256"`,
+		},
+		"DS digest length": {
+			config: map[string]interface{}{"recordtype": "DS", "keytag": 30336, "algorithm": 7, "digest_type": 1,
+				"digest": "909FF0B4DD66F91F"},
+			withError: `DS record "30336 7 1 909FF0B4DD66F91F"`,
+		},
+		"type left to the API": {
+			config: map[string]interface{}{"recordtype": "DNSKEY", "flags": 257, "protocol": 2, "algorithm": 8,
+				"key": "AwEAAcVKSpdUxmm4YC0Ei6RVQGsOJjpL"},
+		},
+		"no target": {
+			config:    map[string]interface{}{"recordtype": "CNAME"},
+			withError: "configuration argument target must be set",
+		},
+		"read only": {
+			config:    map[string]interface{}{"recordtype": "AKAMAITLC", "answer_type": "A", "dns_name": "example.com"},
+			withError: "AKAMAITLC is a READ ONLY record",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.config["zone"] = "example.com"
+			test.config["name"] = "www.example.com"
+			test.config["ttl"] = 300
+			d := schema.TestResourceDataRaw(t, resourceDNSv2Record().Schema, test.config)
+			rec, err := bindRecord(context.Background(), nil, d, log.Log)
+			require.NoError(t, err)
+
+			err = validateRecord(rec)
+			if test.withError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.withError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
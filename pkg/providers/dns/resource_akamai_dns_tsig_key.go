@@ -0,0 +1,281 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceDNSTSIGKey() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDNSTSIGKeyCreate,
+		ReadContext:   resourceDNSTSIGKeyRead,
+		UpdateContext: resourceDNSTSIGKeyUpdate,
+		DeleteContext: resourceDNSTSIGKeyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDNSTSIGKeyImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the key",
+			},
+			"algorithm": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The hashing algorithm, such as hmac-sha256",
+			},
+			"secret": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The base64 encoded shared secret",
+			},
+			"zones": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Required:    true,
+				MinItems:    1,
+				Description: "The zones which use the key for zone transfers",
+			},
+		},
+	}
+}
+
+func resourceDNSTSIGKeyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSTSIGKeyCreate")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	key, err := expandTSIGKey(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	zones, err := tsigKeyZones(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.WithFields(log.Fields{
+		"key":   key.Name,
+		"zones": len(zones),
+	}).Info("TSIG Key Create")
+
+	if err := inst.Client(meta).TsigKeyBulkUpdate(ctx, &dns.TSIGKeyBulkPost{Key: key, Zones: zones}); err != nil {
+		return diag.Errorf("assigning TSIG key %s: %s", key.Name, err)
+	}
+	d.SetId(key.Name)
+	return resourceDNSTSIGKeyRead(ctx, d, meta)
+}
+
+func resourceDNSTSIGKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSTSIGKeyRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	zones, err := tsigKeyZones(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.WithField("key", d.Id()).Info("TSIG Key Read")
+
+	client := inst.Client(meta)
+	key, err := readAssignedTSIGKey(ctx, client, d.Id(), zones)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if key == nil {
+		logger.Warnf("TSIG key %s is not used by any of its zones; removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	resp, err := client.GetTsigKeyZones(ctx, key)
+	if err != nil {
+		return diag.Errorf("reading zones of TSIG key %s: %s", key.Name, err)
+	}
+	if len(resp.Zones) == 0 {
+		logger.Warnf("TSIG key %s is not used by any zone; removing it from state", key.Name)
+		d.SetId("")
+		return nil
+	}
+	sort.Strings(resp.Zones)
+	if err := tools.SetAttrs(d, map[string]interface{}{
+		"name":      key.Name,
+		"algorithm": key.Algorithm,
+		"secret":    key.Secret,
+		"zones":     resp.Zones,
+	}); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// readAssignedTSIGKey reads the key with the given name from the first of the zones that still uses it,
+// or returns nil if none does
+func readAssignedTSIGKey(ctx context.Context, client dns.DNS, name string, zones []string) (*dns.TSIGKey, error) {
+	for _, zone := range zones {
+		resp, err := client.GetTsigKey(ctx, zone)
+		if err != nil {
+			var apiError *dns.Error
+			if errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return nil, fmt.Errorf("reading TSIG key of zone %s: %w", zone, err)
+		}
+		if resp.Name == name {
+			return &resp.TSIGKey, nil
+		}
+	}
+	return nil, nil
+}
+
+func resourceDNSTSIGKeyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSTSIGKeyUpdate")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+	client := inst.Client(meta)
+
+	key, err := expandTSIGKey(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	oldSet, newSet := d.GetChange("zones")
+	added := newSet.(*schema.Set).Difference(oldSet.(*schema.Set))
+	removed := oldSet.(*schema.Set).Difference(newSet.(*schema.Set))
+
+	// a changed secret or algorithm has to be pushed to every zone, otherwise only to the new ones
+	assign := added
+	if d.HasChanges("algorithm", "secret") {
+		assign = newSet.(*schema.Set)
+	}
+	logger.WithFields(log.Fields{
+		"key":      key.Name,
+		"assigned": assign.Len(),
+		"removed":  removed.Len(),
+	}).Info("TSIG Key Update")
+
+	if assign.Len() > 0 {
+		zones := setToSortedStrings(assign)
+		if err := client.TsigKeyBulkUpdate(ctx, &dns.TSIGKeyBulkPost{Key: key, Zones: zones}); err != nil {
+			return diag.Errorf("assigning TSIG key %s: %s", key.Name, err)
+		}
+	}
+	for _, zone := range setToSortedStrings(removed) {
+		if err := client.DeleteTsigKey(ctx, zone); err != nil {
+			return diag.Errorf("removing TSIG key %s from zone %s: %s", key.Name, zone, err)
+		}
+	}
+	return resourceDNSTSIGKeyRead(ctx, d, meta)
+}
+
+func resourceDNSTSIGKeyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSTSIGKeyDelete")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	zones, err := tsigKeyZones(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.WithFields(log.Fields{
+		"key":   d.Id(),
+		"zones": len(zones),
+	}).Info("TSIG Key Delete")
+
+	for _, zone := range zones {
+		if err := inst.Client(meta).DeleteTsigKey(ctx, zone); err != nil {
+			return diag.Errorf("removing TSIG key %s from zone %s: %s", d.Id(), zone, err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+// Import TSIG key. Id is the name of a zone which uses the key
+func resourceDNSTSIGKeyImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSTSIGKeyImport")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	zone := d.Id()
+	logger.WithField("zone", zone).Info("TSIG Key Import")
+	resp, err := inst.Client(meta).GetTsigKey(ctx, zone)
+	if err != nil {
+		return nil, fmt.Errorf("reading TSIG key of zone %s: %w", zone, err)
+	}
+	if err := tools.SetAttrs(d, map[string]interface{}{
+		"name":      resp.Name,
+		"algorithm": resp.Algorithm,
+		"secret":    resp.Secret,
+		"zones":     []string{zone},
+	}); err != nil {
+		return nil, err
+	}
+	d.SetId(resp.Name)
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandTSIGKey(d *schema.ResourceData) (*dns.TSIGKey, error) {
+	name, err := tools.GetStringValue("name", d)
+	if err != nil {
+		return nil, err
+	}
+	algorithm, err := tools.GetStringValue("algorithm", d)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := tools.GetStringValue("secret", d)
+	if err != nil {
+		return nil, err
+	}
+	return &dns.TSIGKey{Name: name, Algorithm: algorithm, Secret: secret}, nil
+}
+
+func tsigKeyZones(d *schema.ResourceData) ([]string, error) {
+	zones, err := tools.GetSetValue("zones", d)
+	if err != nil {
+		return nil, err
+	}
+	return setToSortedStrings(zones), nil
+}
+
+func setToSortedStrings(set *schema.Set) []string {
+	result := make([]string, 0, set.Len())
+	for _, item := range set.List() {
+		result = append(result, item.(string))
+	}
+	sort.Strings(result)
+	return result
+}
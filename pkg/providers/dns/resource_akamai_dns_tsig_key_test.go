@@ -0,0 +1,141 @@
+package dns
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/dns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResDnsTSIGKey(t *testing.T) {
+	t.Run("lifecycle test", func(t *testing.T) {
+		client := &dns.Mock{}
+		key := &dns.TSIGKey{
+			Name:      "transfer.exampleterraform.io",
+			Algorithm: "hmac-sha256",
+			Secret:    "Ym9ndXMgc2VjcmV0IGZvciB0ZXN0aW5n",
+		}
+		used := &dns.ZoneNameListResponse{}
+
+		client.On("GetTsigKey",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.Anything,
+		).Return(&dns.TSIGKeyResponse{TSIGKey: *key}, nil)
+
+		client.On("GetTsigKeyZones",
+			mock.Anything, // ctx is irrelevant for this test
+			key,
+		).Return(used, nil)
+
+		// create
+		client.On("TsigKeyBulkUpdate",
+			mock.Anything, // ctx is irrelevant for this test
+			&dns.TSIGKeyBulkPost{Key: key, Zones: []string{"one.exampleterraform.io", "two.exampleterraform.io"}},
+		).Return(nil).Run(func(mock.Arguments) {
+			used.Zones = []string{"one.exampleterraform.io", "two.exampleterraform.io"}
+		}).Once()
+
+		// update: the key is assigned to three and removed from two
+		client.On("TsigKeyBulkUpdate",
+			mock.Anything, // ctx is irrelevant for this test
+			&dns.TSIGKeyBulkPost{Key: key, Zones: []string{"three.exampleterraform.io"}},
+		).Return(nil).Once()
+		client.On("DeleteTsigKey",
+			mock.Anything, // ctx is irrelevant for this test
+			"two.exampleterraform.io",
+		).Return(nil).Run(func(mock.Arguments) {
+			used.Zones = []string{"one.exampleterraform.io", "three.exampleterraform.io"}
+		}).Once()
+
+		// destroy
+		client.On("DeleteTsigKey",
+			mock.Anything, // ctx is irrelevant for this test
+			"one.exampleterraform.io",
+		).Return(nil).Once()
+		client.On("DeleteTsigKey",
+			mock.Anything, // ctx is irrelevant for this test
+			"three.exampleterraform.io",
+		).Return(nil).Once()
+
+		resourceName := "akamai_dns_tsig_key.test"
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:          func() { testAccPreCheck(t) },
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResDnsTSIGKey/create.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(resourceName, "id", "transfer.exampleterraform.io"),
+							resource.TestCheckResourceAttr(resourceName, "zones.#", "2"),
+						),
+					},
+					{
+						Config: loadFixtureString("testdata/TestResDnsTSIGKey/update.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(resourceName, "zones.#", "2"),
+							resource.TestCheckTypeSetElemAttr(resourceName, "zones.*", "three.exampleterraform.io"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+	t.Run("secret changed outside terraform", func(t *testing.T) {
+		client := &dns.Mock{}
+		key := &dns.TSIGKey{
+			Name:      "transfer.exampleterraform.io",
+			Algorithm: "hmac-sha256",
+			Secret:    "Ym9ndXMgc2VjcmV0IGZvciB0ZXN0aW5n",
+		}
+		rotated := &dns.TSIGKey{
+			Name:      "transfer.exampleterraform.io",
+			Algorithm: "hmac-sha512",
+			Secret:    "cm90YXRlZCBzZWNyZXQ=",
+		}
+
+		client.On("TsigKeyBulkUpdate",
+			mock.Anything, // ctx is irrelevant for this test
+			&dns.TSIGKeyBulkPost{Key: key, Zones: []string{"one.exampleterraform.io", "two.exampleterraform.io"}},
+		).Return(nil).Once()
+		client.On("GetTsigKey",
+			mock.Anything, // ctx is irrelevant for this test
+			"one.exampleterraform.io",
+		).Return(&dns.TSIGKeyResponse{TSIGKey: *rotated}, nil)
+		client.On("GetTsigKeyZones",
+			mock.Anything, // ctx is irrelevant for this test
+			rotated,
+		).Return(&dns.ZoneNameListResponse{Zones: []string{"one.exampleterraform.io", "two.exampleterraform.io"}}, nil)
+		client.On("DeleteTsigKey",
+			mock.Anything, // ctx is irrelevant for this test
+			"one.exampleterraform.io",
+		).Return(nil).Once()
+		client.On("DeleteTsigKey",
+			mock.Anything, // ctx is irrelevant for this test
+			"two.exampleterraform.io",
+		).Return(nil).Once()
+
+		resourceName := "akamai_dns_tsig_key.test"
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:          func() { testAccPreCheck(t) },
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResDnsTSIGKey/create.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(resourceName, "algorithm", "hmac-sha512"),
+							resource.TestCheckResourceAttr(resourceName, "secret", "cm90YXRlZCBzZWNyZXQ="),
+						),
+						ExpectNonEmptyPlan: true,
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
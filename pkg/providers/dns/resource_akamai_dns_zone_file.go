@@ -0,0 +1,240 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceDNSZoneFile() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDNSZoneFileCreate,
+		ReadContext:   resourceDNSZoneFileRead,
+		UpdateContext: resourceDNSZoneFileUpdate,
+		DeleteContext: resourceDNSZoneFileDelete,
+		CustomizeDiff: validateZoneFileContent,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDNSZoneFileImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The primary zone whose content is replaced by the master file",
+			},
+			"content": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: zoneFileContentSuppress,
+				Description:      "The content of an RFC 1035 master file",
+			},
+			"recordset_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of recordsets in the live zone",
+			},
+		},
+	}
+}
+
+func resourceDNSZoneFileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSZoneFileCreate")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	zone, err := tools.GetStringValue("zone", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.WithField("zone", zone).Info("Zone File Create")
+	if err := uploadZoneFile(ctx, meta, d, zone, logger); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(zone)
+	return resourceDNSZoneFileRead(ctx, d, meta)
+}
+
+func resourceDNSZoneFileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSZoneFileRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	zone := d.Id()
+	logger.WithField("zone", zone).Info("Zone File Read")
+	liveContent, err := inst.Client(meta).GetMasterZoneFile(ctx, zone)
+	if err != nil {
+		var apiError *dns.Error
+		if errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound {
+			logger.Warnf("Zone %s no longer exists; removing zone file from state", zone)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("retrieving master file for zone %s: %s", zone, err)
+	}
+	live, err := parseZoneFile(liveContent, zone)
+	if err != nil {
+		return diag.Errorf("parsing live master file for zone %s: %s", zone, err)
+	}
+
+	// Keep the configured content as long as it still describes the live zone, so that formatting,
+	// comments and relative names in the checked-in file do not show up as a diff
+	content, err := tools.GetStringValue("content", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	expected, err := parseZoneFile(content, zone)
+	if err != nil || len(diffRecordsets(expected, withoutManagedRecords(zone, expected, live))) > 0 {
+		logger.Debug("Live zone differs from configured master file")
+		content = renderZoneFile(zone, live)
+	}
+
+	if err := d.Set("zone", zone); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	if err := d.Set("content", content); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	if err := d.Set("recordset_count", len(live)); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	return nil
+}
+
+func resourceDNSZoneFileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSZoneFileUpdate")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	zone := d.Id()
+	logger.WithField("zone", zone).Info("Zone File Update")
+	if err := uploadZoneFile(ctx, meta, d, zone, logger); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceDNSZoneFileRead(ctx, d, meta)
+}
+
+func resourceDNSZoneFileDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSZoneFileDelete")
+	zone := d.Id()
+	logger.WithField("zone", zone).Info("Zone File Delete")
+
+	// Edge DNS has no operation to empty a zone; the records stay in place
+	d.SetId("")
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Zone content was not removed",
+		Detail:   fmt.Sprintf("Zone file resource for %s was removed from state only. The zone keeps its records.", zone),
+	}}
+}
+
+func resourceDNSZoneFileImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSZoneFileImport")
+	zone := d.Id()
+	logger.WithField("zone", zone).Info("Zone File Import")
+
+	if diags := resourceDNSZoneFileRead(ctx, d, meta); diags.HasError() {
+		return nil, fmt.Errorf("importing master file for zone %s: %s", zone, diags[0].Summary)
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("zone %s not found", zone)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// uploadZoneFile parses the configured master file and posts it in canonical form. The apex SOA and NS
+// sets of the live zone are carried over when the master file does not declare them.
+func uploadZoneFile(ctx context.Context, meta akamai.OperationMeta, d *schema.ResourceData, zone string, logger log.Interface) error {
+	content, err := tools.GetStringValue("content", d)
+	if err != nil {
+		return err
+	}
+	recordsets, err := parseZoneFile(content, zone)
+	if err != nil {
+		return fmt.Errorf("parsing master file for zone %s: %w", zone, err)
+	}
+
+	liveContent, err := inst.Client(meta).GetMasterZoneFile(ctx, zone)
+	if err != nil {
+		logger.Debugf("Unable to retrieve master file for zone %s: %s", zone, err)
+	} else if live, err := parseZoneFile(liveContent, zone); err == nil {
+		declared := make(map[string]struct{}, len(recordsets))
+		for _, rs := range recordsets {
+			declared[recordsetKey(rs.Name, rs.Type)] = struct{}{}
+		}
+		for _, rs := range live {
+			key := recordsetKey(rs.Name, rs.Type)
+			if _, ok := declared[key]; ok {
+				continue
+			}
+			if key == recordsetKey(zone, RRTypeSoa) || key == recordsetKey(zone, RRTypeNs) {
+				recordsets = append(recordsets, rs)
+			}
+		}
+	}
+
+	logger.WithField("recordsets", len(recordsets)).Debug("Uploading master file")
+	if err := inst.Client(meta).PostMasterZoneFile(ctx, zone, renderZoneFile(zone, recordsets)); err != nil {
+		return fmt.Errorf("uploading master file for zone %s: %w", zone, err)
+	}
+	return nil
+}
+
+// zoneFileContentSuppress suppresses differences between master files describing the same recordsets
+func zoneFileContentSuppress(_, old, new string, d *schema.ResourceData) bool {
+	zone, ok := d.Get("zone").(string)
+	if !ok || old == "" || new == "" {
+		return false
+	}
+	oldRecordsets, err := parseZoneFile(old, zone)
+	if err != nil {
+		return false
+	}
+	newRecordsets, err := parseZoneFile(new, zone)
+	if err != nil {
+		return false
+	}
+	return len(diffRecordsets(newRecordsets, withoutManagedRecords(zone, newRecordsets, oldRecordsets))) == 0
+}
+
+// validateZoneFileContent reports master file syntax errors at plan time
+func validateZoneFileContent(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("content") || !diff.NewValueKnown("zone") {
+		return nil
+	}
+	zone, ok := diff.Get("zone").(string)
+	if !ok {
+		return fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "zone", "string")
+	}
+	content, ok := diff.Get("content").(string)
+	if !ok {
+		return fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "content", "string")
+	}
+	if _, err := parseZoneFile(content, zone); err != nil {
+		return fmt.Errorf("invalid master file for zone %s: %w", zone, err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package dns
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/dns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResDnsZoneFile(t *testing.T) {
+	const managed = `$ORIGIN exampleterraform.io.
+exampleterraform.io. 86400 IN SOA a1-1.akam.net. hostmaster.exampleterraform.io. 12 3600 600 604800 300
+exampleterraform.io. 86400 IN NS a1-1.akam.net.
+`
+
+	t.Run("lifecycle test", func(t *testing.T) {
+		client := &dns.Mock{}
+		liveZone := managed
+
+		getCall := client.On("GetMasterZoneFile",
+			mock.Anything, // ctx is irrelevant for this test
+			"exampleterraform.io",
+		)
+		getCall.Run(func(args mock.Arguments) {
+			getCall.ReturnArguments = mock.Arguments{liveZone, nil}
+		})
+
+		client.On("PostMasterZoneFile",
+			mock.Anything, // ctx is irrelevant for this test
+			"exampleterraform.io",
+			mock.AnythingOfType("string"),
+		).Return(nil).Run(func(args mock.Arguments) {
+			uploaded := args.String(2)
+			// the managed SOA and NS records are carried over from the live zone
+			if !strings.Contains(uploaded, " SOA ") || !strings.Contains(uploaded, " NS ") {
+				t.Errorf("uploaded master file lacks SOA and NS records:\n%s", uploaded)
+			}
+			liveZone = uploaded
+		})
+
+		resourceName := "akamai_dns_zone_file.test"
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:          func() { testAccPreCheck(t) },
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResDnsZoneFile/create.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(resourceName, "id", "exampleterraform.io"),
+							resource.TestCheckResourceAttr(resourceName, "recordset_count", "5"),
+						),
+					},
+					{
+						Config: loadFixtureString("testdata/TestResDnsZoneFile/update.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(resourceName, "id", "exampleterraform.io"),
+							resource.TestCheckResourceAttr(resourceName, "recordset_count", "5"),
+						),
+					},
+					{
+						ImportState:       true,
+						ImportStateId:     "exampleterraform.io",
+						ResourceName:      resourceName,
+						ImportStateVerify: false,
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
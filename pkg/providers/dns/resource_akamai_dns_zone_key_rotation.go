@@ -0,0 +1,125 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceDNSZoneKeyRotation() *schema.Resource {
+	attributes := map[string]*schema.Schema{
+		"zone": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "The Sign and Serve zone to rotate the keys of",
+		},
+		"comment": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "A comment stored with the key rotation request",
+		},
+		"triggers": {
+			Type:        schema.TypeMap,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Arbitrary values which start a new key rotation whenever they change",
+		},
+		"request_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the key rotation request",
+		},
+	}
+	for name, attr := range dnssecStatusSchema() {
+		attributes[name] = attr
+	}
+	return &schema.Resource{
+		CreateContext: resourceDNSZoneKeyRotationCreate,
+		ReadContext:   resourceDNSZoneKeyRotationRead,
+		DeleteContext: resourceDNSZoneKeyRotationDelete,
+		Schema:        attributes,
+	}
+}
+
+func resourceDNSZoneKeyRotationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSZoneKeyRotationCreate")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	zone, err := tools.GetStringValue("zone", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	comment, err := tools.GetStringValue("comment", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+
+	logger.WithField("zone", zone).Info("Zone Key Rotation Create")
+	resp, err := inst.DNSSECClient(meta).RotateKeys(ctx, RotateKeysRequest{Zones: []string{zone}, Comment: comment})
+	if err != nil {
+		return diag.Errorf("rotating keys of zone %s: %s", zone, err)
+	}
+	logger.WithFields(log.Fields{
+		"requestid":  resp.RequestID,
+		"expiration": resp.ExpirationDate,
+	}).Debug("Key rotation requested")
+
+	if err := d.Set("request_id", resp.RequestID); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(zone)
+	return resourceDNSZoneKeyRotationRead(ctx, d, meta)
+}
+
+func resourceDNSZoneKeyRotationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSZoneKeyRotationRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	zone := d.Id()
+	logger.WithField("zone", zone).Info("Zone Key Rotation Read")
+	status, err := getZoneDNSSECStatus(ctx, meta, zone)
+	if err != nil {
+		var apiError *dns.Error
+		if errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound {
+			logger.Warnf("Zone %s no longer exists; removing key rotation from state", zone)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	if err := setZoneDNSSECStatus(d, status); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceDNSZoneKeyRotationDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSZoneKeyRotationDelete")
+	logger.WithField("zone", d.Id()).Info("Zone Key Rotation Delete")
+
+	// A key rotation can not be undone; removing the resource only forgets it
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,63 @@
+package dns
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResDnsZoneKeyRotation(t *testing.T) {
+	t.Run("lifecycle test", func(t *testing.T) {
+		client := &mockDNSSEC{}
+		status := &DNSSECStatusResponse{DNSSecStatuses: []DNSSECStatus{{
+			Zone: "exampleterraform.io",
+			CurrentRecords: DNSSECRecords{
+				DNSKeyRecord: rfcDNSKeyRecord,
+				DSRecord:     rfcDSRecord,
+				ExpectedTTL:  86400,
+			},
+		}}}
+
+		client.On("RotateKeys",
+			mock.Anything, // ctx is irrelevant for this test
+			RotateKeysRequest{Zones: []string{"exampleterraform.io"}, Comment: "yearly rotation"},
+		).Return(&RotateKeysResponse{RequestID: "req-1"}, nil).Once()
+		client.On("RotateKeys",
+			mock.Anything, // ctx is irrelevant for this test
+			RotateKeysRequest{Zones: []string{"exampleterraform.io"}, Comment: "yearly rotation"},
+		).Return(&RotateKeysResponse{RequestID: "req-2"}, nil).Once()
+		client.On("GetDNSSECStatus",
+			mock.Anything, // ctx is irrelevant for this test
+			DNSSECStatusRequest{Zones: []string{"exampleterraform.io"}},
+		).Return(status, nil)
+
+		resourceName := "akamai_dns_zone_key_rotation.test"
+		useDNSSECClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:          func() { testAccPreCheck(t) },
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResDnsZoneKeyRotation/create.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(resourceName, "id", "exampleterraform.io"),
+							resource.TestCheckResourceAttr(resourceName, "request_id", "req-1"),
+							resource.TestCheckResourceAttr(resourceName, "ds_records.0.key_tag", "60485"),
+							resource.TestCheckResourceAttr(resourceName, "rollover_in_progress", "false"),
+						),
+					},
+					{
+						// changing the triggers starts a new rotation
+						Config: loadFixtureString("testdata/TestResDnsZoneKeyRotation/update.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(resourceName, "request_id", "req-2"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
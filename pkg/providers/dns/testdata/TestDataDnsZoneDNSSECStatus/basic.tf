@@ -0,0 +1,7 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_dns_zone_dnssec_status" "test" {
+  zone = "exampleterraform.io"
+}
@@ -0,0 +1,8 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_dns_zone_export" "test" {
+  zone      = "exampleterraform.io"
+  page_size = 2
+}
@@ -0,0 +1,8 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_dns_zone_file" "test" {
+  zone    = "exampleterraform.io"
+  content = "www IN A 10.0.0.1"
+}
@@ -0,0 +1,21 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+resource "akamai_dns_bulk_zones" "test" {
+  contract            = "ctr_1-3CV382"
+  group               = "grp_1-2CW482"
+  allow_zone_deletion = true
+
+  zone {
+    zone    = "one.exampleterraform.io"
+    type    = "secondary"
+    masters = ["1.2.3.4"]
+  }
+
+  zone {
+    zone    = "two.exampleterraform.io"
+    type    = "SECONDARY"
+    masters = ["1.2.3.4"]
+  }
+}
@@ -0,0 +1,10 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+resource "akamai_dns_tsig_key" "test" {
+  name      = "transfer.exampleterraform.io"
+  algorithm = "hmac-sha256"
+  secret    = "Ym9ndXMgc2VjcmV0IGZvciB0ZXN0aW5n"
+  zones     = ["one.exampleterraform.io", "two.exampleterraform.io"]
+}
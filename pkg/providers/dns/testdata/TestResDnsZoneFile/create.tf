@@ -0,0 +1,8 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+resource "akamai_dns_zone_file" "test" {
+  zone    = "exampleterraform.io"
+  content = file("testdata/TestResDnsZoneFile/create.zone")
+}
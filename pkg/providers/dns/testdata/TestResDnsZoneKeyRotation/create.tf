@@ -0,0 +1,11 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+resource "akamai_dns_zone_key_rotation" "test" {
+  zone    = "exampleterraform.io"
+  comment = "yearly rotation"
+  triggers = {
+    year = "2023"
+  }
+}
@@ -0,0 +1,476 @@
+package dns
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/dns"
+	canonicalrdata "github.com/akamai/terraform-provider-akamai/v3/pkg/providers/dns/rdata"
+)
+
+var (
+	// ErrZoneFileSyntax is returned when a master file cannot be parsed
+	ErrZoneFileSyntax = errors.New("zone file syntax error")
+	// ErrZoneFileUnsupported is returned when a master file uses a directive or record type not supported by Edge DNS
+	ErrZoneFileUnsupported = errors.New("zone file unsupported content")
+)
+
+// zoneFileRecordTypes lists the record types which can be read from a master file. It mirrors the types
+// accepted by the akamai_dns_record resource.
+var zoneFileRecordTypes = map[string]struct{}{
+	RRTypeA:          {},
+	RRTypeAaaa:       {},
+	RRTypeAfsdb:      {},
+	RRTypeAkamaiCdn:  {},
+	RRTypeAkamaiTlc:  {},
+	RRTypeCaa:        {},
+	RRTypeCert:       {},
+	RRTypeCname:      {},
+	RRTypeDnskey:     {},
+	RRTypeDs:         {},
+	RRTypeHinfo:      {},
+	RRTypeHTTPS:      {},
+	RRTypeLoc:        {},
+	RRTypeMx:         {},
+	RRTypeNaptr:      {},
+	RRTypeNs:         {},
+	RRTypeNsec3:      {},
+	RRTypeNsec3Param: {},
+	RRTypePtr:        {},
+	RRTypeRp:         {},
+	RRTypeRrsig:      {},
+	RRTypeSoa:        {},
+	RRTypeSpf:        {},
+	RRTypeSrv:        {},
+	RRTypeSshfp:      {},
+	RRTypeSvcb:       {},
+	RRTypeTlsa:       {},
+	RRTypeTxt:        {},
+}
+
+// zoneFileNameFields holds, per record type, the positions of rdata fields which are domain names
+// and therefore have to be made absolute against the current origin
+var zoneFileNameFields = map[string][]int{
+	RRTypeAfsdb: {1},
+	RRTypeCname: {0},
+	RRTypeMx:    {1},
+	RRTypeNaptr: {5},
+	RRTypeNs:    {0},
+	RRTypePtr:   {0},
+	RRTypeRp:    {0, 1},
+	RRTypeSoa:   {0, 1},
+	RRTypeSrv:   {3},
+	RRTypeSvcb:  {1},
+	RRTypeHTTPS: {1},
+}
+
+// zoneFileLine is a single logical entry of a master file, with parentheses already joined
+type zoneFileLine struct {
+	number       int
+	tokens       []string
+	leadingBlank bool
+}
+
+// parseZoneFile parses an RFC 1035 master file into a list of recordsets, in the order of first appearance.
+// Owner names and domain names in rdata are returned fully qualified without the trailing dot for owners and
+// with the trailing dot for rdata, which is the form the Edge DNS API returns them in.
+func parseZoneFile(content, origin string) ([]dns.Recordset, error) {
+	lines, err := splitZoneFileLines(content)
+	if err != nil {
+		return nil, err
+	}
+
+	origin = strings.TrimSuffix(origin, ".")
+	var (
+		defaultTTL = -1
+		lastTTL    = -1
+		lastOwner  string
+		order      []string
+		recordsets = make(map[string]*dns.Recordset)
+	)
+	for _, line := range lines {
+		tokens := line.tokens
+		if strings.HasPrefix(tokens[0], "$") && !line.leadingBlank {
+			switch strings.ToUpper(tokens[0]) {
+			case "$ORIGIN":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("%w: line %d: $ORIGIN requires exactly one argument", ErrZoneFileSyntax, line.number)
+				}
+				origin = strings.TrimSuffix(absoluteName(tokens[1], origin), ".")
+			case "$TTL":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("%w: line %d: $TTL requires exactly one argument", ErrZoneFileSyntax, line.number)
+				}
+				ttl, err := parseZoneFileTTL(tokens[1])
+				if err != nil {
+					return nil, fmt.Errorf("%w: line %d: %s", ErrZoneFileSyntax, line.number, err)
+				}
+				defaultTTL = ttl
+			default:
+				return nil, fmt.Errorf("%w: line %d: directive %s", ErrZoneFileUnsupported, line.number, tokens[0])
+			}
+			continue
+		}
+
+		owner := lastOwner
+		if !line.leadingBlank {
+			owner = strings.TrimSuffix(absoluteName(tokens[0], origin), ".")
+			tokens = tokens[1:]
+		}
+		if owner == "" {
+			return nil, fmt.Errorf("%w: line %d: record without owner name", ErrZoneFileSyntax, line.number)
+		}
+		lastOwner = owner
+
+		ttl := -1
+		// TTL and class may appear in either order before the type
+		for i := 0; i < 2 && len(tokens) > 0; i++ {
+			if strings.EqualFold(tokens[0], "IN") {
+				tokens = tokens[1:]
+				continue
+			}
+			if isZoneFileClass(tokens[0]) {
+				return nil, fmt.Errorf("%w: line %d: class %s", ErrZoneFileUnsupported, line.number, tokens[0])
+			}
+			if v, err := parseZoneFileTTL(tokens[0]); err == nil {
+				ttl = v
+				tokens = tokens[1:]
+			}
+		}
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("%w: line %d: missing record type", ErrZoneFileSyntax, line.number)
+		}
+		recordType := strings.ToUpper(tokens[0])
+		if _, ok := zoneFileRecordTypes[recordType]; !ok {
+			return nil, fmt.Errorf("%w: line %d: record type %s", ErrZoneFileUnsupported, line.number, tokens[0])
+		}
+		rdata := tokens[1:]
+		if len(rdata) == 0 {
+			return nil, fmt.Errorf("%w: line %d: %s record has no data", ErrZoneFileSyntax, line.number, recordType)
+		}
+		for _, idx := range zoneFileNameFields[recordType] {
+			if idx < len(rdata) {
+				rdata[idx] = absoluteName(rdata[idx], origin)
+			}
+		}
+
+		switch {
+		case ttl >= 0:
+		case defaultTTL >= 0:
+			ttl = defaultTTL
+		case lastTTL >= 0:
+			ttl = lastTTL
+		default:
+			return nil, fmt.Errorf("%w: line %d: no TTL given and no $TTL directive", ErrZoneFileSyntax, line.number)
+		}
+		lastTTL = ttl
+
+		key := recordsetKey(owner, recordType)
+		rs, ok := recordsets[key]
+		if !ok {
+			rs = &dns.Recordset{Name: owner, Type: recordType, TTL: ttl}
+			recordsets[key] = rs
+			order = append(order, key)
+		}
+		// RFC 2181 5.2: differing TTLs within one set are treated as the lowest of them
+		if ttl < rs.TTL {
+			rs.TTL = ttl
+		}
+		rs.Rdata = append(rs.Rdata, strings.Join(rdata, " "))
+	}
+
+	result := make([]dns.Recordset, 0, len(order))
+	for _, key := range order {
+		result = append(result, *recordsets[key])
+	}
+	return result, nil
+}
+
+// splitZoneFileLines strips comments and joins parenthesized continuation lines
+func splitZoneFileLines(content string) ([]zoneFileLine, error) {
+	var (
+		lines   []zoneFileLine
+		current *zoneFileLine
+		depth   int
+	)
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	number := 0
+	for scanner.Scan() {
+		number++
+		text := scanner.Text()
+		if current == nil {
+			current = &zoneFileLine{
+				number:       number,
+				leadingBlank: len(text) > 0 && (text[0] == ' ' || text[0] == '\t'),
+			}
+		}
+
+		var (
+			token    strings.Builder
+			inQuotes bool
+			escaped  bool
+			hasToken bool
+		)
+		flush := func() {
+			if hasToken {
+				current.tokens = append(current.tokens, token.String())
+				token.Reset()
+				hasToken = false
+			}
+		}
+	scan:
+		for _, r := range text {
+			switch {
+			case escaped:
+				token.WriteRune(r)
+				escaped = false
+			case r == '\\':
+				token.WriteRune(r)
+				escaped = true
+				hasToken = true
+			case r == '"':
+				token.WriteRune(r)
+				inQuotes = !inQuotes
+				hasToken = true
+			case inQuotes:
+				token.WriteRune(r)
+			case r == ';':
+				break scan
+			case r == '(':
+				flush()
+				depth++
+			case r == ')':
+				flush()
+				if depth == 0 {
+					return nil, fmt.Errorf("%w: line %d: unbalanced parentheses", ErrZoneFileSyntax, number)
+				}
+				depth--
+			case unicode.IsSpace(r):
+				flush()
+			default:
+				token.WriteRune(r)
+				hasToken = true
+			}
+		}
+		if inQuotes {
+			return nil, fmt.Errorf("%w: line %d: unterminated quoted string", ErrZoneFileSyntax, number)
+		}
+		flush()
+
+		if depth > 0 {
+			continue
+		}
+		if len(current.tokens) > 0 {
+			lines = append(lines, *current)
+		}
+		current = nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrZoneFileSyntax, err)
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("%w: line %d: unbalanced parentheses", ErrZoneFileSyntax, current.number)
+	}
+	return lines, nil
+}
+
+// absoluteName makes the given name fully qualified against origin, returning it with a trailing dot
+func absoluteName(name, origin string) string {
+	if name == "@" {
+		return origin + "."
+	}
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	if origin == "" {
+		return name + "."
+	}
+	return name + "." + origin + "."
+}
+
+// parseZoneFileTTL parses a TTL value given either in seconds or in BIND unit notation such as 1h30m
+func parseZoneFileTTL(value string) (int, error) {
+	if ttl, err := strconv.Atoi(value); err == nil {
+		if ttl < 0 {
+			return 0, fmt.Errorf("invalid TTL %q", value)
+		}
+		return ttl, nil
+	}
+	units := map[rune]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	var total, current int
+	var digits bool
+	for _, r := range strings.ToLower(value) {
+		if unicode.IsDigit(r) {
+			current = current*10 + int(r-'0')
+			digits = true
+			continue
+		}
+		multiplier, ok := units[r]
+		if !ok || !digits {
+			return 0, fmt.Errorf("invalid TTL %q", value)
+		}
+		total += current * multiplier
+		current, digits = 0, false
+	}
+	if digits {
+		return 0, fmt.Errorf("invalid TTL %q", value)
+	}
+	return total, nil
+}
+
+func isZoneFileClass(token string) bool {
+	switch strings.ToUpper(token) {
+	case "CH", "CS", "HS", "ANY", "NONE":
+		return true
+	}
+	return false
+}
+
+func recordsetKey(name, recordType string) string {
+	return strings.ToLower(strings.TrimSuffix(name, ".")) + " " + strings.ToUpper(recordType)
+}
+
+// renderZoneFile renders recordsets as a canonical master file. Records are sorted by name and type,
+// and rdata within a set is sorted, so the output of equal zones is byte for byte identical.
+func renderZoneFile(origin string, recordsets []dns.Recordset) string {
+	sorted := make([]dns.Recordset, len(recordsets))
+	copy(sorted, recordsets)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ni, nj := strings.ToLower(sorted[i].Name), strings.ToLower(sorted[j].Name)
+		if ni != nj {
+			// SOA and the apex go first
+			if strings.EqualFold(ni, origin) || strings.EqualFold(nj, origin) {
+				return strings.EqualFold(ni, origin)
+			}
+			return ni < nj
+		}
+		if sorted[i].Type == RRTypeSoa || sorted[j].Type == RRTypeSoa {
+			return sorted[i].Type == RRTypeSoa
+		}
+		return sorted[i].Type < sorted[j].Type
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "$ORIGIN %s.\n", strings.TrimSuffix(origin, "."))
+	for _, rs := range sorted {
+		rdata := make([]string, len(rs.Rdata))
+		copy(rdata, rs.Rdata)
+		sort.Strings(rdata)
+		for _, r := range rdata {
+			fmt.Fprintf(&b, "%s. %d IN %s %s\n", strings.TrimSuffix(rs.Name, "."), rs.TTL, rs.Type, r)
+		}
+	}
+	return b.String()
+}
+
+// zoneFileDrift describes the difference between an expected and a live recordset
+type zoneFileDrift struct {
+	Name     string
+	Type     string
+	Status   string
+	Expected *dns.Recordset
+	Live     *dns.Recordset
+}
+
+const (
+	driftMissing = "missing"
+	driftExtra   = "extra"
+	driftChanged = "changed"
+)
+
+// diffRecordsets compares expected recordsets against live ones. The SOA serial is ignored, since
+// Edge DNS increments it on every change.
+func diffRecordsets(expected, live []dns.Recordset) []zoneFileDrift {
+	liveByKey := make(map[string]dns.Recordset, len(live))
+	for _, rs := range live {
+		liveByKey[recordsetKey(rs.Name, rs.Type)] = rs
+	}
+
+	var drift []zoneFileDrift
+	seen := make(map[string]struct{}, len(expected))
+	for i := range expected {
+		exp := expected[i]
+		key := recordsetKey(exp.Name, exp.Type)
+		seen[key] = struct{}{}
+		liveRs, ok := liveByKey[key]
+		if !ok {
+			drift = append(drift, zoneFileDrift{Name: exp.Name, Type: exp.Type, Status: driftMissing, Expected: &exp})
+			continue
+		}
+		if !recordsetsEqual(exp, liveRs) {
+			drift = append(drift, zoneFileDrift{Name: exp.Name, Type: exp.Type, Status: driftChanged, Expected: &exp, Live: &liveRs})
+		}
+	}
+	for i := range live {
+		liveRs := live[i]
+		if _, ok := seen[recordsetKey(liveRs.Name, liveRs.Type)]; !ok {
+			drift = append(drift, zoneFileDrift{Name: liveRs.Name, Type: liveRs.Type, Status: driftExtra, Live: &liveRs})
+		}
+	}
+	sort.SliceStable(drift, func(i, j int) bool {
+		return recordsetKey(drift[i].Name, drift[i].Type) < recordsetKey(drift[j].Name, drift[j].Type)
+	})
+	return drift
+}
+
+// withoutManagedRecords drops the apex SOA and NS sets from live when expected does not declare them.
+// Edge DNS maintains those itself, so a master file is not required to list them.
+func withoutManagedRecords(zone string, expected, live []dns.Recordset) []dns.Recordset {
+	declared := make(map[string]struct{}, len(expected))
+	for _, rs := range expected {
+		declared[recordsetKey(rs.Name, rs.Type)] = struct{}{}
+	}
+	result := make([]dns.Recordset, 0, len(live))
+	for _, rs := range live {
+		key := recordsetKey(rs.Name, rs.Type)
+		_, ok := declared[key]
+		if !ok && (key == recordsetKey(zone, RRTypeSoa) || key == recordsetKey(zone, RRTypeNs)) {
+			continue
+		}
+		result = append(result, rs)
+	}
+	return result
+}
+
+func recordsetsEqual(a, b dns.Recordset) bool {
+	if a.TTL != b.TTL || len(a.Rdata) != len(b.Rdata) {
+		return false
+	}
+	left, right := normalizeRdata(a.Type, a.Rdata), normalizeRdata(b.Type, b.Rdata)
+	for i := range left {
+		if left[i] != right[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeRdata returns a sorted copy of rdata in canonical form, falling back to collapsed whitespace and lower
+// cased names for records which can not be parsed, so that it can be compared regardless of the formatting used
+// in the master file
+func normalizeRdata(recordType string, rdata []string) []string {
+	normalized := make([]string, 0, len(rdata))
+	for _, r := range rdata {
+		if canonical, err := canonicalrdata.Canonical(recordType, r); err == nil {
+			r = canonical
+		}
+		fields := strings.Fields(r)
+		for _, idx := range zoneFileNameFields[strings.ToUpper(recordType)] {
+			if idx < len(fields) {
+				fields[idx] = strings.ToLower(fields[idx])
+			}
+		}
+		if strings.ToUpper(recordType) == RRTypeSoa && len(fields) > 2 {
+			fields[2] = "0"
+		}
+		normalized = append(normalized, strings.Join(fields, " "))
+	}
+	sort.Strings(normalized)
+	return normalized
+}
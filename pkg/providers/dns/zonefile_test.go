@@ -0,0 +1,177 @@
+package dns
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseZoneFile(t *testing.T) {
+	tests := map[string]struct {
+		content  string
+		origin   string
+		expected []dns.Recordset
+		withErr  error
+	}{
+		"origin, ttl and relative names": {
+			content: `$ORIGIN example.com.
+$TTL 1h
+@       IN  SOA ns1 hostmaster ( 2023010101 ; serial
+                                 7200       ; refresh
+                                 3600 1209600 300 )
+        IN  NS  ns1
+        IN  NS  ns2.example.net.
+www 300 IN  A   10.0.0.1
+            IN  A   10.0.0.2
+mail    IN 600 MX 10 mx1
+`,
+			origin: "example.com",
+			expected: []dns.Recordset{
+				{Name: "example.com", Type: "SOA", TTL: 3600, Rdata: []string{"ns1.example.com. hostmaster.example.com. 2023010101 7200 3600 1209600 300"}},
+				{Name: "example.com", Type: "NS", TTL: 3600, Rdata: []string{"ns1.example.com.", "ns2.example.net."}},
+				{Name: "www.example.com", Type: "A", TTL: 300, Rdata: []string{"10.0.0.1", "10.0.0.2"}},
+				{Name: "mail.example.com", Type: "MX", TTL: 600, Rdata: []string{"10 mx1.example.com."}},
+			},
+		},
+		"nested origin and quoted strings": {
+			content: `$TTL 300
+$ORIGIN sub
+txt TXT "v=spf1 include:example.net ~all" "second; string"
+alias CNAME www.example.com.
+`,
+			origin: "example.com.",
+			expected: []dns.Recordset{
+				{Name: "txt.sub.example.com", Type: "TXT", TTL: 300, Rdata: []string{`"v=spf1 include:example.net ~all" "second; string"`}},
+				{Name: "alias.sub.example.com", Type: "CNAME", TTL: 300, Rdata: []string{"www.example.com."}},
+			},
+		},
+		"escaped quotes": {
+			content: `txt 60 TXT "say \"hi\""`,
+			origin:  "example.com",
+			expected: []dns.Recordset{
+				{Name: "txt.example.com", Type: "TXT", TTL: 60, Rdata: []string{`"say \"hi\""`}},
+			},
+		},
+		"lowest ttl wins within a set": {
+			content: `a 300 A 10.0.0.1
+a 60 A 10.0.0.2`,
+			origin: "example.com",
+			expected: []dns.Recordset{
+				{Name: "a.example.com", Type: "A", TTL: 60, Rdata: []string{"10.0.0.1", "10.0.0.2"}},
+			},
+		},
+		"missing ttl": {
+			content: `www A 10.0.0.1`,
+			origin:  "example.com",
+			withErr: ErrZoneFileSyntax,
+		},
+		"unbalanced parentheses": {
+			content: `$TTL 300
+@ SOA ns1 hostmaster ( 1 2 3 4 5`,
+			origin:  "example.com",
+			withErr: ErrZoneFileSyntax,
+		},
+		"unsupported type": {
+			content: `$TTL 300
+www WKS 10.0.0.1 TCP ( smtp )`,
+			origin:  "example.com",
+			withErr: ErrZoneFileUnsupported,
+		},
+		"unsupported include": {
+			content: `$INCLUDE other.zone`,
+			origin:  "example.com",
+			withErr: ErrZoneFileUnsupported,
+		},
+		"unsupported class": {
+			content: `$TTL 300
+www CH A 10.0.0.1`,
+			origin:  "example.com",
+			withErr: ErrZoneFileUnsupported,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			recordsets, err := parseZoneFile(test.content, test.origin)
+			if test.withErr != nil {
+				assert.True(t, errors.Is(err, test.withErr), "want: %s; got: %s", test.withErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, recordsets)
+		})
+	}
+}
+
+func TestParseZoneFileTTL(t *testing.T) {
+	tests := map[string]struct {
+		value    string
+		expected int
+		withErr  bool
+	}{
+		"seconds":     {value: "3600", expected: 3600},
+		"units":       {value: "1h30m", expected: 5400},
+		"upper case":  {value: "1W", expected: 604800},
+		"trailing":    {value: "1h30", withErr: true},
+		"bad unit":    {value: "1y", withErr: true},
+		"not a value": {value: "IN", withErr: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ttl, err := parseZoneFileTTL(test.value)
+			if test.withErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, ttl)
+		})
+	}
+}
+
+func TestRenderZoneFile(t *testing.T) {
+	recordsets := []dns.Recordset{
+		{Name: "www.example.com", Type: "A", TTL: 300, Rdata: []string{"10.0.0.2", "10.0.0.1"}},
+		{Name: "example.com", Type: "NS", TTL: 86400, Rdata: []string{"ns1.example.com."}},
+		{Name: "example.com", Type: "SOA", TTL: 86400, Rdata: []string{"ns1.example.com. hostmaster.example.com. 1 2 3 4 5"}},
+	}
+	expected := `$ORIGIN example.com.
+example.com. 86400 IN SOA ns1.example.com. hostmaster.example.com. 1 2 3 4 5
+example.com. 86400 IN NS ns1.example.com.
+www.example.com. 300 IN A 10.0.0.1
+www.example.com. 300 IN A 10.0.0.2
+`
+	assert.Equal(t, expected, renderZoneFile("example.com", recordsets))
+
+	parsed, err := parseZoneFile(expected, "example.com")
+	require.NoError(t, err)
+	assert.Empty(t, diffRecordsets(recordsets, parsed))
+}
+
+func TestDiffRecordsets(t *testing.T) {
+	expected := []dns.Recordset{
+		{Name: "example.com", Type: "SOA", TTL: 86400, Rdata: []string{"ns1.example.com. hostmaster.example.com. 1 2 3 4 5"}},
+		{Name: "www.example.com", Type: "A", TTL: 300, Rdata: []string{"10.0.0.1"}},
+		{Name: "mail.example.com", Type: "MX", TTL: 300, Rdata: []string{"10 MX1.example.com."}},
+		{Name: "old.example.com", Type: "A", TTL: 300, Rdata: []string{"10.0.0.9"}},
+	}
+	live := []dns.Recordset{
+		{Name: "example.com", Type: "SOA", TTL: 86400, Rdata: []string{"ns1.example.com.  hostmaster.example.com. 42 2 3 4 5"}},
+		{Name: "example.com", Type: "NS", TTL: 86400, Rdata: []string{"ns1.example.com."}},
+		{Name: "WWW.example.com", Type: "A", TTL: 300, Rdata: []string{"10.0.0.2"}},
+		{Name: "mail.example.com", Type: "MX", TTL: 300, Rdata: []string{"10 mx1.example.com."}},
+		{Name: "new.example.com", Type: "A", TTL: 300, Rdata: []string{"10.0.0.3"}},
+	}
+
+	drift := diffRecordsets(expected, withoutManagedRecords("example.com", expected, live))
+	require.Len(t, drift, 3)
+	assert.Equal(t, "new.example.com", drift[0].Name)
+	assert.Equal(t, driftExtra, drift[0].Status)
+	assert.Equal(t, "old.example.com", drift[1].Name)
+	assert.Equal(t, driftMissing, drift[1].Status)
+	assert.Equal(t, "www.example.com", drift[2].Name)
+	assert.Equal(t, driftChanged, drift[2].Status)
+	assert.Equal(t, []string{"10.0.0.2"}, drift[2].Live.Rdata)
+}
@@ -0,0 +1,201 @@
+package gtm
+
+import (
+	"context"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/gtm"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGTMDomain() *schema.Resource {
+	s := computedSchema(resourceGTMv1Domain().Schema, "contract", "group", "wait_on_complete")
+	s["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	s["last_modified"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	s["last_modified_by"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	s["status"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"change_id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"message": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"passing_validation": {
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+				"propagation_status": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"propagation_status_date": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+	return &schema.Resource{
+		ReadContext: dataSourceGTMDomainRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceGTMDomainRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("Akamai GTM", "dataSourceGTMDomainRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	name, err := tools.GetStringValue("name", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	dom, err := inst.Client(meta).GetDomain(ctx, name)
+	if err != nil {
+		logger.Errorf("Domain Read failed: %s", err.Error())
+		return diag.Errorf("domain Read failed: %s", err.Error())
+	}
+	// the current status reflects the propagation of the last change
+	status, err := inst.Client(meta).GetDomainStatus(ctx, name)
+	if err != nil {
+		logger.Errorf("Domain Status Read failed: %s", err.Error())
+		return diag.Errorf("domain status Read failed: %s", err.Error())
+	}
+
+	populateTerraformState(d, dom, m)
+	if err := tools.SetAttrs(d, map[string]interface{}{
+		"last_modified":    dom.LastModified,
+		"last_modified_by": dom.LastModifiedBy,
+		"status":           flattenResponseStatus(status),
+	}); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(dom.Name)
+	return nil
+}
+
+func dataSourceGTMDomains() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGTMDomainsRead,
+		Schema: map[string]*schema.Schema{
+			"domains": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"acg_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_modified": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGTMDomainsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("Akamai GTM", "dataSourceGTMDomainsRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	domains, err := inst.Client(meta).ListDomains(ctx)
+	if err != nil {
+		logger.Errorf("Domains List failed: %s", err.Error())
+		return diag.Errorf("domains List failed: %s", err.Error())
+	}
+	items := make([]interface{}, 0, len(domains))
+	names := ""
+	for _, dom := range domains {
+		items = append(items, map[string]interface{}{
+			"name":          dom.Name,
+			"status":        dom.Status,
+			"acg_id":        dom.AcgId,
+			"last_modified": dom.LastModified,
+		})
+		names += dom.Name + ";"
+	}
+	if err := d.Set("domains", items); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet.Error(), err.Error())
+	}
+	d.SetId(tools.GetSHAString(names))
+	return nil
+}
+
+func flattenResponseStatus(status *gtm.ResponseStatus) []interface{} {
+	if status == nil {
+		return []interface{}{}
+	}
+	return []interface{}{map[string]interface{}{
+		"change_id":               status.ChangeId,
+		"message":                 status.Message,
+		"passing_validation":      status.PassingValidation,
+		"propagation_status":      status.PropagationStatus,
+		"propagation_status_date": status.PropagationStatusDate,
+	}}
+}
+
+// computedSchema returns a copy of a resource schema with all attributes computed, leaving out the given attributes
+func computedSchema(s map[string]*schema.Schema, exclude ...string) map[string]*schema.Schema {
+	excluded := make(map[string]bool, len(exclude))
+	for _, name := range exclude {
+		excluded[name] = true
+	}
+	computed := make(map[string]*schema.Schema, len(s))
+	for name, attr := range s {
+		if excluded[name] {
+			continue
+		}
+		c := &schema.Schema{
+			Type:      attr.Type,
+			Computed:  true,
+			Sensitive: attr.Sensitive,
+		}
+		switch elem := attr.Elem.(type) {
+		case *schema.Resource:
+			c.Elem = &schema.Resource{Schema: computedSchema(elem.Schema)}
+		case *schema.Schema:
+			c.Elem = &schema.Schema{Type: elem.Type}
+		}
+		computed[name] = c
+	}
+	return computed
+}
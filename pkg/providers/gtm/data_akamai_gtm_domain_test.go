@@ -0,0 +1,142 @@
+package gtm
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/gtm"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDataGtmDomain(t *testing.T) {
+	t.Run("domain with status", func(t *testing.T) {
+		client := &gtm.Mock{}
+
+		client.On("GetDomain",
+			mock.Anything, // ctx is irrelevant for this test
+			gtmTestDomain,
+		).Return(&dom, nil)
+
+		client.On("GetDomainStatus",
+			mock.Anything, // ctx is irrelevant for this test
+			gtmTestDomain,
+		).Return(&pendingResponseStatus, nil)
+
+		dataSourceName := "data.akamai_gtm_domain.test"
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestDataGtmDomain/domain.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(dataSourceName, "id", gtmTestDomain),
+							resource.TestCheckResourceAttr(dataSourceName, "type", "weighted"),
+							resource.TestCheckResourceAttr(dataSourceName, "load_imbalance_percentage", "10"),
+							resource.TestCheckResourceAttr(dataSourceName, "last_modified_by", "operator"),
+							resource.TestCheckResourceAttr(dataSourceName, "status.0.propagation_status", "PENDING"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("domain not found", func(t *testing.T) {
+		client := &gtm.Mock{}
+
+		client.On("GetDomain",
+			mock.Anything, // ctx is irrelevant for this test
+			gtmTestDomain,
+		).Return(nil, &gtm.Error{StatusCode: 404, Title: "Not Found"})
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config:      loadFixtureString("testdata/TestDataGtmDomain/domain.tf"),
+						ExpectError: regexp.MustCompile("domain Read failed"),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
+
+func TestDataGtmDomains(t *testing.T) {
+	client := &gtm.Mock{}
+
+	client.On("ListDomains",
+		mock.Anything, // ctx is irrelevant for this test
+	).Return([]*gtm.DomainItem{
+		{Name: gtmTestDomain, Status: "2023-02-01 09:47 GMT: Current configuration has been propagated to all GTM nameservers", AcgId: "1-2ABCDEF"},
+		{Name: "second.akadns.net", Status: "PENDING"},
+	}, nil)
+
+	dataSourceName := "data.akamai_gtm_domains.test"
+
+	useClient(client, func() {
+		resource.UnitTest(t, resource.TestCase{
+			ProviderFactories: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: loadFixtureString("testdata/TestDataGtmDomain/domains.tf"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(dataSourceName, "domains.#", "2"),
+						resource.TestCheckResourceAttr(dataSourceName, "domains.0.name", gtmTestDomain),
+						resource.TestCheckResourceAttr(dataSourceName, "domains.0.acg_id", "1-2ABCDEF"),
+						resource.TestCheckResourceAttr(dataSourceName, "domains.1.name", "second.akadns.net"),
+					),
+				},
+			},
+		})
+	})
+
+	client.AssertExpectations(t)
+}
+
+func TestComputedSchema(t *testing.T) {
+	s := computedSchema(map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"secret": {
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+		},
+		"block": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"value": {
+						Type:     schema.TypeInt,
+						Optional: true,
+						Default:  1,
+					},
+				},
+			},
+		},
+	}, "name")
+
+	assert.NotContains(t, s, "name")
+	assert.True(t, s["secret"].Computed)
+	assert.False(t, s["secret"].Optional)
+	assert.True(t, s["secret"].Sensitive)
+	assert.Equal(t, 0, s["block"].MaxItems)
+	value := s["block"].Elem.(*schema.Resource).Schema["value"]
+	assert.True(t, value.Computed)
+	assert.Nil(t, value.Default)
+}
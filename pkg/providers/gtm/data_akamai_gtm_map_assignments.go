@@ -0,0 +1,191 @@
+package gtm
+
+import (
+	"context"
+	"errors"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/providers/gtm/mapfile"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// getMapAssignments retrieves the assignments of an existing map
+type getMapAssignments func(ctx context.Context, meta akamai.OperationMeta, name, domain string) ([]mapfile.Assignment, error)
+
+func dataSourceGTMGeomapAssignments() *schema.Resource {
+	return dataSourceGTMMapAssignments(mapfile.Geographic, "countries", &schema.Schema{Type: schema.TypeString},
+		func(ctx context.Context, meta akamai.OperationMeta, name, domain string) ([]mapfile.Assignment, error) {
+			geoMap, err := inst.Client(meta).GetGeoMap(ctx, name, domain)
+			if err != nil {
+				return nil, err
+			}
+			assignments := make([]mapfile.Assignment, 0, len(geoMap.Assignments))
+			for _, a := range geoMap.Assignments {
+				assignments = append(assignments, mapfile.Assignment{
+					DatacenterID: a.DatacenterId,
+					Nickname:     a.Nickname,
+					Countries:    append([]string(nil), a.Countries...),
+				})
+			}
+			return assignments, nil
+		})
+}
+
+func dataSourceGTMASmapAssignments() *schema.Resource {
+	return dataSourceGTMMapAssignments(mapfile.AS, "as_numbers", &schema.Schema{Type: schema.TypeInt},
+		func(ctx context.Context, meta akamai.OperationMeta, name, domain string) ([]mapfile.Assignment, error) {
+			asMap, err := inst.Client(meta).GetAsMap(ctx, name, domain)
+			if err != nil {
+				return nil, err
+			}
+			assignments := make([]mapfile.Assignment, 0, len(asMap.Assignments))
+			for _, a := range asMap.Assignments {
+				assignments = append(assignments, mapfile.Assignment{
+					DatacenterID: a.DatacenterId,
+					Nickname:     a.Nickname,
+					ASNumbers:    append([]int64(nil), a.AsNumbers...),
+				})
+			}
+			return assignments, nil
+		})
+}
+
+// dataSourceGTMMapAssignments returns a data source reading map assignments from CSV or JSON content, or from an
+// existing map, and exporting them to both formats
+func dataSourceGTMMapAssignments(kind mapfile.Kind, valuesAttr string, valueSchema *schema.Schema, get getMapAssignments) *schema.Resource {
+	return &schema.Resource{
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			meta := akamai.Meta(m)
+			logger := meta.Log("Akamai GTM", "dataSourceGTMMapAssignmentsRead")
+			// create a context with logging for api calls
+			ctx = session.ContextWithOptions(
+				ctx,
+				session.WithContextLog(logger),
+			)
+
+			var assignments []mapfile.Assignment
+			if content, err := tools.GetStringValue("content", d); err == nil {
+				format, err := tools.GetStringValue("format", d)
+				if err != nil && !errors.Is(err, tools.ErrNotFound) {
+					return diag.FromErr(err)
+				}
+				if assignments, err = mapfile.Parse(kind, format, []byte(content)); err != nil {
+					return diag.Errorf("invalid %s map assignments: %s", kind, err.Error())
+				}
+			} else {
+				domain, err := tools.GetStringValue("domain", d)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				name, err := tools.GetStringValue("map_name", d)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				logger.Debugf("Reading assignments of %s map [%s] in domain [%s]", kind, name, domain)
+				if assignments, err = get(ctx, meta, name, domain); err != nil {
+					logger.Errorf("%s map Read failed: %s", kind, err.Error())
+					return diag.Errorf("%s map Read failed: %s", kind, err.Error())
+				}
+				mapfile.Sort(assignments)
+			}
+
+			csv, err := mapfile.WriteCSV(kind, assignments)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			json, err := mapfile.WriteJSON(kind, assignments)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			hash := mapfile.Hash(assignments)
+			if err := tools.SetAttrs(d, map[string]interface{}{
+				"assignment": flattenMapAssignments(valuesAttr, assignments),
+				"hash":       hash,
+				"csv":        string(csv),
+				"json":       string(json),
+			}); err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(hash)
+			return nil
+		},
+		Schema: map[string]*schema.Schema{
+			"content": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"content", "map_name"},
+			},
+			"format": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"csv", "json"}, false)),
+			},
+			"domain": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"map_name"},
+			},
+			"map_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"domain"},
+			},
+			"assignment": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datacenter_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"nickname": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						valuesAttr: {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem:     valueSchema,
+						},
+					},
+				},
+			},
+			"hash": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"csv": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func flattenMapAssignments(valuesAttr string, assignments []mapfile.Assignment) []interface{} {
+	result := make([]interface{}, 0, len(assignments))
+	for _, a := range assignments {
+		values := make([]interface{}, 0, len(a.Countries)+len(a.ASNumbers))
+		for _, c := range a.Countries {
+			values = append(values, c)
+		}
+		for _, n := range a.ASNumbers {
+			values = append(values, int(n))
+		}
+		result = append(result, map[string]interface{}{
+			"datacenter_id": a.DatacenterID,
+			"nickname":      a.Nickname,
+			valuesAttr:      values,
+		})
+	}
+	return result
+}
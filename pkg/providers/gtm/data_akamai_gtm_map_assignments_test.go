@@ -0,0 +1,81 @@
+package gtm
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/gtm"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/providers/gtm/mapfile"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDataGtmMapAssignments(t *testing.T) {
+	t.Run("import from content", func(t *testing.T) {
+		useClient(&gtm.Mock{}, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestDataGtmMapAssignments/import.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr("data.akamai_gtm_asmap_assignments.test", "assignment.#", "2"),
+							resource.TestCheckResourceAttr("data.akamai_gtm_asmap_assignments.test", "assignment.0.datacenter_id", "3131"),
+							resource.TestCheckResourceAttr("data.akamai_gtm_asmap_assignments.test", "assignment.0.as_numbers.#", "3"),
+							resource.TestCheckResourceAttr("data.akamai_gtm_asmap_assignments.test", "assignment.1.nickname", "tfexample_dc_2"),
+							resource.TestCheckResourceAttr("data.akamai_gtm_asmap_assignments.test", "csv",
+								"datacenter_id,nickname,as_number\n3131,tfexample_dc_1,64512-64514\n3132,tfexample_dc_2,12229\n"),
+							resource.TestCheckResourceAttr("data.akamai_gtm_geomap_assignments.test", "assignment.0.countries.#", "2"),
+							resource.TestCheckResourceAttr("data.akamai_gtm_geomap_assignments.test", "hash", mapfile.Hash([]mapfile.Assignment{
+								{DatacenterID: 3131, Nickname: "tfexample_dc_1", Countries: []string{"BE", "NL"}},
+							})),
+						),
+					},
+				},
+			})
+		})
+	})
+
+	t.Run("export existing map", func(t *testing.T) {
+		client := &gtm.Mock{}
+		client.On("GetAsMap",
+			mock.Anything, // ctx is irrelevant for this test
+			"tfexample_as_1",
+			gtmTestDomain,
+		).Return(&asmap, nil)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestDataGtmMapAssignments/export.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr("data.akamai_gtm_asmap_assignments.test", "assignment.#", "2"),
+							resource.TestCheckResourceAttr("data.akamai_gtm_asmap_assignments.test", "csv",
+								"datacenter_id,nickname,as_number\n3131,tfexample_dc_1,12222\n3131,tfexample_dc_1,16702\n3131,tfexample_dc_1,17334\n"+
+									"3132,tfexample_dc_2,12229\n3132,tfexample_dc_2,16703\n3132,tfexample_dc_2,17335\n"),
+							resource.TestCheckResourceAttrSet("data.akamai_gtm_asmap_assignments.test", "json"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("invalid country code", func(t *testing.T) {
+		useClient(&gtm.Mock{}, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config:      loadFixtureString("testdata/TestDataGtmMapAssignments/invalid.tf"),
+						ExpectError: regexp.MustCompile(`invalid geographic map assignments: data center 3131: invalid country code "XX"`),
+					},
+				},
+			})
+		})
+	})
+}
@@ -0,0 +1,153 @@
+package gtm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// getGTMObject retrieves one GTM object by name
+type getGTMObject func(ctx context.Context, meta akamai.OperationMeta, name, domain string) (interface{}, error)
+
+func dataSourceGTMProperty() *schema.Resource {
+	return dataSourceGTMObject(propertyUnitKind, func(ctx context.Context, meta akamai.OperationMeta, name, domain string) (interface{}, error) {
+		return inst.Client(meta).GetProperty(ctx, name, domain)
+	})
+}
+
+func dataSourceGTMGeomap() *schema.Resource {
+	return dataSourceGTMObject(geographicMapUnitKind, func(ctx context.Context, meta akamai.OperationMeta, name, domain string) (interface{}, error) {
+		return inst.Client(meta).GetGeoMap(ctx, name, domain)
+	})
+}
+
+func dataSourceGTMASmap() *schema.Resource {
+	return dataSourceGTMObject(asMapUnitKind, func(ctx context.Context, meta akamai.OperationMeta, name, domain string) (interface{}, error) {
+		return inst.Client(meta).GetAsMap(ctx, name, domain)
+	})
+}
+
+func dataSourceGTMCidrmap() *schema.Resource {
+	return dataSourceGTMObject(cidrMapUnitKind, func(ctx context.Context, meta akamai.OperationMeta, name, domain string) (interface{}, error) {
+		return inst.Client(meta).GetCidrMap(ctx, name, domain)
+	})
+}
+
+func dataSourceGTMProperties() *schema.Resource {
+	return dataSourceGTMObjects(propertyUnitKind, "properties")
+}
+
+func dataSourceGTMDatacenters() *schema.Resource {
+	return dataSourceGTMObjects(datacenterUnitKind, "datacenters")
+}
+
+func dataSourceGTMResources() *schema.Resource {
+	return dataSourceGTMObjects(resourceUnitKind, "resources")
+}
+
+// dataSourceGTMObject returns a data source reading one named object of a domain. The attributes are
+// the ones of the resource managing objects of that kind.
+func dataSourceGTMObject(kind domainUnitKind, get getGTMObject) *schema.Resource {
+	block := kind.block
+	s := computedSchema(domainUnitElemSchema(kind))
+	s["domain"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	s["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+
+	return &schema.Resource{
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			meta := akamai.Meta(m)
+			logger := meta.Log("Akamai GTM", "dataSourceGTMObjectRead")
+			// create a context with logging for api calls
+			ctx = session.ContextWithOptions(
+				ctx,
+				session.WithContextLog(logger),
+			)
+
+			domain, err := tools.GetStringValue("domain", d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			name, err := tools.GetStringValue("name", d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			logger.Debugf("Reading %s [%s] in domain [%s]", block, name, domain)
+			obj, err := get(ctx, meta, name, domain)
+			if err != nil {
+				logger.Errorf("%s Read failed: %s", block, err.Error())
+				return diag.Errorf("%s Read failed: %s", block, err.Error())
+			}
+			attrs, err := flattenDomainUnitObject(kind, map[string]interface{}{}, obj, m)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if err := tools.SetAttrs(d, attrs); err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(fmt.Sprintf("%s:%s", domain, name))
+			return nil
+		},
+		Schema: s,
+	}
+}
+
+// dataSourceGTMObjects returns a data source listing all objects of a kind in a domain
+func dataSourceGTMObjects(kind domainUnitKind, attr string) *schema.Resource {
+	return &schema.Resource{
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			meta := akamai.Meta(m)
+			logger := meta.Log("Akamai GTM", "dataSourceGTMObjectsRead")
+			// create a context with logging for api calls
+			ctx = session.ContextWithOptions(
+				ctx,
+				session.WithContextLog(logger),
+			)
+
+			domain, err := tools.GetStringValue("domain", d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			logger.Debugf("Listing %s in domain [%s]", attr, domain)
+			dom, err := inst.Client(meta).GetDomain(ctx, domain)
+			if err != nil {
+				logger.Errorf("Domain Read failed: %s", err.Error())
+				return diag.Errorf("domain Read failed: %s", err.Error())
+			}
+			objects := make([]interface{}, 0)
+			for _, obj := range kind.objects(dom) {
+				flattened, err := flattenDomainUnitObject(kind, map[string]interface{}{}, obj, m)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				objects = append(objects, flattened)
+			}
+			if err := d.Set(attr, objects); err != nil {
+				return diag.Errorf("%s: %s", tools.ErrValueSet.Error(), err.Error())
+			}
+			d.SetId(fmt.Sprintf("%s:%s", domain, attr))
+			return nil
+		},
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			attr: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Resource{Schema: computedSchema(domainUnitElemSchema(kind))},
+			},
+		},
+	}
+}
@@ -0,0 +1,77 @@
+package gtm
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/gtm"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDataGtmObjects(t *testing.T) {
+	client := &gtm.Mock{}
+
+	objectsDomain := gtm.Domain{
+		Name:        gtmTestDomain,
+		Type:        "weighted",
+		Datacenters: datacenters,
+		Properties:  properties,
+		Resources:   []*gtm.Resource{&rsrc},
+	}
+	client.On("GetDomain",
+		mock.Anything, // ctx is irrelevant for this test
+		gtmTestDomain,
+	).Return(&objectsDomain, nil)
+
+	client.On("GetProperty",
+		mock.Anything, // ctx is irrelevant for this test
+		"test_property",
+		gtmTestDomain,
+	).Return(properties[0], nil)
+
+	client.On("GetGeoMap",
+		mock.Anything, // ctx is irrelevant for this test
+		"tfexample_geomap_1",
+		gtmTestDomain,
+	).Return(&geo, nil)
+
+	client.On("GetAsMap",
+		mock.Anything, // ctx is irrelevant for this test
+		"tfexample_as_1",
+		gtmTestDomain,
+	).Return(&asmap, nil)
+
+	client.On("GetCidrMap",
+		mock.Anything, // ctx is irrelevant for this test
+		"tfexample_cidrmap_1",
+		gtmTestDomain,
+	).Return(&cidr, nil)
+
+	useClient(client, func() {
+		resource.UnitTest(t, resource.TestCase{
+			ProviderFactories: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: loadFixtureString("testdata/TestDataGtmObjects/objects.tf"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("data.akamai_gtm_property.test", "id", gtmTestDomain+":test_property"),
+						resource.TestCheckResourceAttr("data.akamai_gtm_property.test", "type", "weighted-round-robin"),
+						resource.TestCheckResourceAttr("data.akamai_gtm_property.test", "traffic_target.0.datacenter_id", "3131"),
+						resource.TestCheckResourceAttr("data.akamai_gtm_property.test", "liveness_test.0.name", "health check"),
+						resource.TestCheckResourceAttr("data.akamai_gtm_properties.test", "properties.#", "1"),
+						resource.TestCheckResourceAttr("data.akamai_gtm_properties.test", "properties.0.name", "test_property"),
+						resource.TestCheckResourceAttr("data.akamai_gtm_datacenters.test", "datacenters.#", "1"),
+						resource.TestCheckResourceAttr("data.akamai_gtm_datacenters.test", "datacenters.0.datacenter_id", "3132"),
+						resource.TestCheckResourceAttr("data.akamai_gtm_resources.test", "resources.0.name", "tfexample_resource_1"),
+						resource.TestCheckResourceAttr("data.akamai_gtm_resources.test", "resources.0.resource_instance.0.load_object", "/test1"),
+						resource.TestCheckResourceAttr("data.akamai_gtm_geomap.test", "assignment.0.countries.#", "1"),
+						resource.TestCheckResourceAttr("data.akamai_gtm_asmap.test", "assignment.#", "2"),
+						resource.TestCheckResourceAttr("data.akamai_gtm_cidrmap.test", "default_datacenter.0.datacenter_id", "5400"),
+					),
+				},
+			},
+		})
+	})
+
+	client.AssertExpectations(t)
+}
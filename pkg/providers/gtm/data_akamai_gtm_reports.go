@@ -0,0 +1,578 @@
+package gtm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// reportSchema returns the arguments shared by all report data sources merged with the given attributes
+func reportSchema(attrs map[string]*schema.Schema) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"domain": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"property": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"start": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ExactlyOneOf:     []string{"start", "duration"},
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+		},
+		"end": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ConflictsWith:    []string{"duration"},
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+		},
+		"duration": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ValidateDiagFunc: func(v interface{}, _ cty.Path) diag.Diagnostics {
+				if _, err := time.ParseDuration(v.(string)); err != nil {
+					return diag.Errorf("invalid duration %q: %s", v, err)
+				}
+				return nil
+			},
+		},
+		"window_start": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"window_end": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+	for name, attr := range attrs {
+		s[name] = attr
+	}
+	return s
+}
+
+// reportWindow resolves the report window from either start and end, or a duration ending at now
+func reportWindow(d tools.ResourceDataFetcher, now time.Time) (ReportWindow, error) {
+	window := ReportWindow{End: now}
+	if end, err := tools.GetStringValue("end", d); err == nil {
+		if window.End, err = time.Parse(time.RFC3339, end); err != nil {
+			return ReportWindow{}, fmt.Errorf("invalid end: %w", err)
+		}
+	}
+	if start, err := tools.GetStringValue("start", d); err == nil {
+		if window.Start, err = time.Parse(time.RFC3339, start); err != nil {
+			return ReportWindow{}, fmt.Errorf("invalid start: %w", err)
+		}
+	} else {
+		duration, err := tools.GetStringValue("duration", d)
+		if err != nil {
+			return ReportWindow{}, err
+		}
+		length, err := time.ParseDuration(duration)
+		if err != nil {
+			return ReportWindow{}, fmt.Errorf("invalid duration: %w", err)
+		}
+		window.Start = window.End.Add(-length)
+	}
+	if !window.End.After(window.Start) {
+		return ReportWindow{}, fmt.Errorf("end of the report window %s must be after its start %s",
+			window.End.Format(time.RFC3339), window.Start.Format(time.RFC3339))
+	}
+	return window, nil
+}
+
+// reportRequest reads the domain, property and window shared by all report data sources
+func reportRequest(d *schema.ResourceData) (string, string, ReportWindow, error) {
+	domain, err := tools.GetStringValue("domain", d)
+	if err != nil {
+		return "", "", ReportWindow{}, err
+	}
+	property, err := tools.GetStringValue("property", d)
+	if err != nil {
+		return "", "", ReportWindow{}, err
+	}
+	window, err := reportWindow(d, time.Now())
+	if err != nil {
+		return "", "", ReportWindow{}, err
+	}
+	return domain, property, window, nil
+}
+
+// setReportWindow stores the resolved window and sets an id unique to the report requested
+func setReportWindow(d *schema.ResourceData, report, domain, property string, window ReportWindow) error {
+	start, end := window.Start.UTC().Format(time.RFC3339), window.End.UTC().Format(time.RFC3339)
+	if err := tools.SetAttrs(d, map[string]interface{}{
+		"window_start": start,
+		"window_end":   end,
+	}); err != nil {
+		return err
+	}
+	d.SetId(tools.GetSHAString(fmt.Sprintf("%s:%s:%s:%s:%s", report, domain, property, start, end)))
+	return nil
+}
+
+func dataSourceGTMLivenessReport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGTMLivenessReportRead,
+		Schema: reportSchema(map[string]*schema.Schema{
+			"datacenter_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"agent_ip": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"target_ip": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"results": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"timestamp":           {Type: schema.TypeString, Computed: true},
+						"datacenter_id":       {Type: schema.TypeInt, Computed: true},
+						"nickname":            {Type: schema.TypeString, Computed: true},
+						"traffic_target_name": {Type: schema.TypeString, Computed: true},
+						"agent_ip":            {Type: schema.TypeString, Computed: true},
+						"target_ip":           {Type: schema.TypeString, Computed: true},
+						"test_name":           {Type: schema.TypeString, Computed: true},
+						"error_code":          {Type: schema.TypeInt, Computed: true},
+						"duration":            {Type: schema.TypeFloat, Computed: true},
+					},
+				},
+			},
+			"datacenters": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datacenter_id": {Type: schema.TypeInt, Computed: true},
+						"nickname":      {Type: schema.TypeString, Computed: true},
+						"tests":         {Type: schema.TypeInt, Computed: true},
+						"failures":      {Type: schema.TypeInt, Computed: true},
+						"healthy":       {Type: schema.TypeBool, Computed: true},
+					},
+				},
+			},
+			"healthy": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		}),
+	}
+}
+
+func dataSourceGTMLivenessReportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("Akamai GTM", "dataSourceGTMLivenessReportRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	domain, property, window, err := reportRequest(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	datacenterID, err := tools.GetIntValue("datacenter_id", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	agentIP, err := tools.GetStringValue("agent_ip", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	targetIP, err := tools.GetStringValue("target_ip", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+
+	logger.Debugf("Reading liveness tests of property [%s] in domain [%s]", property, domain)
+	report, err := inst.ReportsClient(meta).GetLivenessTestReport(ctx, LivenessTestReportRequest{
+		Domain:       domain,
+		Property:     property,
+		Window:       window,
+		DatacenterID: datacenterID,
+		AgentIP:      agentIP,
+		TargetIP:     targetIP,
+	})
+	if err != nil {
+		logger.Errorf("Liveness Test Report Read failed: %s", err.Error())
+		return diag.Errorf("liveness test report Read failed: %s", err.Error())
+	}
+
+	results, datacenters, healthy := flattenLivenessTestReport(report)
+	if err := tools.SetAttrs(d, map[string]interface{}{
+		"results":     results,
+		"datacenters": datacenters,
+		"healthy":     healthy,
+	}); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := setReportWindow(d, "liveness-tests", domain, property, window); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// flattenLivenessTestReport returns the test results, a summary per data center and whether all tests passed
+func flattenLivenessTestReport(report *LivenessTestReport) ([]interface{}, []interface{}, bool) {
+	results := make([]interface{}, 0)
+	summaries := make(map[int]map[string]interface{})
+	healthy := true
+	for _, row := range report.DataRows {
+		for _, dc := range row.Datacenters {
+			results = append(results, map[string]interface{}{
+				"timestamp":           row.Timestamp,
+				"datacenter_id":       dc.DatacenterID,
+				"nickname":            dc.Nickname,
+				"traffic_target_name": dc.TrafficTargetName,
+				"agent_ip":            dc.AgentIP,
+				"target_ip":           dc.TargetIP,
+				"test_name":           dc.TestName,
+				"error_code":          dc.ErrorCode,
+				"duration":            dc.Duration,
+			})
+			summary, ok := summaries[dc.DatacenterID]
+			if !ok {
+				summary = map[string]interface{}{
+					"datacenter_id": dc.DatacenterID,
+					"nickname":      dc.Nickname,
+					"tests":         0,
+					"failures":      0,
+					"healthy":       true,
+				}
+				summaries[dc.DatacenterID] = summary
+			}
+			summary["tests"] = summary["tests"].(int) + 1
+			// a zero error code is a passed test
+			if dc.ErrorCode != 0 {
+				summary["failures"] = summary["failures"].(int) + 1
+				summary["healthy"] = false
+				healthy = false
+			}
+		}
+	}
+	return results, sortedDatacenterSummaries(summaries), healthy
+}
+
+func dataSourceGTMTrafficReport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGTMTrafficReportRead,
+		Schema: reportSchema(map[string]*schema.Schema{
+			"rows": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"timestamp": {Type: schema.TypeString, Computed: true},
+						"datacenters": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"datacenter_id":       {Type: schema.TypeInt, Computed: true},
+									"nickname":            {Type: schema.TypeString, Computed: true},
+									"traffic_target_name": {Type: schema.TypeString, Computed: true},
+									"requests":            {Type: schema.TypeInt, Computed: true},
+									"status":              {Type: schema.TypeString, Computed: true},
+								},
+							},
+						},
+					},
+				},
+			},
+			"datacenters": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datacenter_id": {Type: schema.TypeInt, Computed: true},
+						"nickname":      {Type: schema.TypeString, Computed: true},
+						"requests":      {Type: schema.TypeInt, Computed: true},
+						"percentage":    {Type: schema.TypeFloat, Computed: true},
+					},
+				},
+			},
+			"total_requests": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		}),
+	}
+}
+
+func dataSourceGTMTrafficReportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("Akamai GTM", "dataSourceGTMTrafficReportRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	domain, property, window, err := reportRequest(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	logger.Debugf("Reading traffic of property [%s] in domain [%s]", property, domain)
+	report, err := inst.ReportsClient(meta).GetTrafficReport(ctx, TrafficReportRequest{
+		Domain:   domain,
+		Property: property,
+		Window:   window,
+	})
+	if err != nil {
+		logger.Errorf("Traffic Report Read failed: %s", err.Error())
+		return diag.Errorf("traffic report Read failed: %s", err.Error())
+	}
+
+	rows, datacenters, total := flattenTrafficReport(report)
+	if err := tools.SetAttrs(d, map[string]interface{}{
+		"rows":           rows,
+		"datacenters":    datacenters,
+		"total_requests": total,
+	}); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := setReportWindow(d, "traffic", domain, property, window); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// flattenTrafficReport returns the report rows, the share of requests per data center and the total requests
+func flattenTrafficReport(report *TrafficReport) ([]interface{}, []interface{}, int64) {
+	rows := make([]interface{}, 0, len(report.DataRows))
+	summaries := make(map[int]map[string]interface{})
+	var total int64
+	for _, row := range report.DataRows {
+		datacenters := make([]interface{}, 0, len(row.Datacenters))
+		for _, dc := range row.Datacenters {
+			datacenters = append(datacenters, map[string]interface{}{
+				"datacenter_id":       dc.DatacenterID,
+				"nickname":            dc.Nickname,
+				"traffic_target_name": dc.TrafficTargetName,
+				"requests":            dc.Requests,
+				"status":              dc.Status,
+			})
+			summary, ok := summaries[dc.DatacenterID]
+			if !ok {
+				summary = map[string]interface{}{
+					"datacenter_id": dc.DatacenterID,
+					"nickname":      dc.Nickname,
+					"requests":      int64(0),
+				}
+				summaries[dc.DatacenterID] = summary
+			}
+			summary["requests"] = summary["requests"].(int64) + dc.Requests
+			total += dc.Requests
+		}
+		rows = append(rows, map[string]interface{}{
+			"timestamp":   row.Timestamp,
+			"datacenters": datacenters,
+		})
+	}
+	for _, summary := range summaries {
+		summary["percentage"] = 0.0
+		if total > 0 {
+			summary["percentage"] = float64(summary["requests"].(int64)) * 100 / float64(total)
+		}
+	}
+	return rows, sortedDatacenterSummaries(summaries), total
+}
+
+func dataSourceGTMIPAvailability() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGTMIPAvailabilityRead,
+		Schema: reportSchema(map[string]*schema.Schema{
+			"datacenter_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"ip_address": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"most_recent": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"rows": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"timestamp": {Type: schema.TypeString, Computed: true},
+						"cut_off":   {Type: schema.TypeFloat, Computed: true},
+						"datacenters": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"datacenter_id":       {Type: schema.TypeInt, Computed: true},
+									"nickname":            {Type: schema.TypeString, Computed: true},
+									"traffic_target_name": {Type: schema.TypeString, Computed: true},
+									"ips": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"ip":         {Type: schema.TypeString, Computed: true},
+												"score":      {Type: schema.TypeFloat, Computed: true},
+												"handed_out": {Type: schema.TypeBool, Computed: true},
+												"alive":      {Type: schema.TypeBool, Computed: true},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"datacenters": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datacenter_id": {Type: schema.TypeInt, Computed: true},
+						"nickname":      {Type: schema.TypeString, Computed: true},
+						"ips":           {Type: schema.TypeInt, Computed: true},
+						"alive_ips":     {Type: schema.TypeInt, Computed: true},
+						"alive":         {Type: schema.TypeBool, Computed: true},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func dataSourceGTMIPAvailabilityRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("Akamai GTM", "dataSourceGTMIPAvailabilityRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	domain, property, window, err := reportRequest(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	datacenterID, err := tools.GetIntValue("datacenter_id", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	ipAddress, err := tools.GetStringValue("ip_address", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	mostRecent, err := tools.GetBoolValue("most_recent", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	logger.Debugf("Reading IP availability of property [%s] in domain [%s]", property, domain)
+	report, err := inst.ReportsClient(meta).GetIPAvailabilityReport(ctx, IPAvailabilityReportRequest{
+		Domain:       domain,
+		Property:     property,
+		Window:       window,
+		DatacenterID: datacenterID,
+		IPAddress:    ipAddress,
+		MostRecent:   mostRecent,
+	})
+	if err != nil {
+		logger.Errorf("IP Availability Report Read failed: %s", err.Error())
+		return diag.Errorf("IP availability report Read failed: %s", err.Error())
+	}
+
+	rows, datacenters := flattenIPAvailabilityReport(report)
+	if err := tools.SetAttrs(d, map[string]interface{}{
+		"rows":        rows,
+		"datacenters": datacenters,
+	}); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := setReportWindow(d, "ip-availability", domain, property, window); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// flattenIPAvailabilityReport returns the report rows and a summary per data center of the latest row it appears in
+func flattenIPAvailabilityReport(report *IPAvailabilityReport) ([]interface{}, []interface{}) {
+	rows := make([]interface{}, 0, len(report.DataRows))
+	summaries := make(map[int]map[string]interface{})
+	for _, row := range report.DataRows {
+		datacenters := make([]interface{}, 0, len(row.Datacenters))
+		for _, dc := range row.Datacenters {
+			ips := make([]interface{}, 0, len(dc.IPs))
+			alive := 0
+			for _, ip := range dc.IPs {
+				ips = append(ips, map[string]interface{}{
+					"ip":         ip.IP,
+					"score":      ip.Score,
+					"handed_out": ip.HandedOut,
+					"alive":      ip.Alive,
+				})
+				if ip.Alive {
+					alive++
+				}
+			}
+			datacenters = append(datacenters, map[string]interface{}{
+				"datacenter_id":       dc.DatacenterID,
+				"nickname":            dc.Nickname,
+				"traffic_target_name": dc.TrafficTargetName,
+				"ips":                 ips,
+			})
+			// rows are ordered by time, so later rows overwrite the summary
+			summaries[dc.DatacenterID] = map[string]interface{}{
+				"datacenter_id": dc.DatacenterID,
+				"nickname":      dc.Nickname,
+				"ips":           len(dc.IPs),
+				"alive_ips":     alive,
+				"alive":         alive > 0,
+			}
+		}
+		rows = append(rows, map[string]interface{}{
+			"timestamp":   row.Timestamp,
+			"cut_off":     row.CutOff,
+			"datacenters": datacenters,
+		})
+	}
+	return rows, sortedDatacenterSummaries(summaries)
+}
+
+// sortedDatacenterSummaries returns the summaries ordered by data center id
+func sortedDatacenterSummaries(summaries map[int]map[string]interface{}) []interface{} {
+	ids := make([]int, 0, len(summaries))
+	for id := range summaries {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	result := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, summaries[id])
+	}
+	return result
+}
@@ -0,0 +1,225 @@
+package gtm
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataGtmLivenessReport(t *testing.T) {
+	client := &mockReports{}
+	client.On("GetLivenessTestReport",
+		mock.Anything, // ctx is irrelevant for this test
+		LivenessTestReportRequest{
+			Domain:       gtmTestDomain,
+			Property:     "test_property",
+			Window:       reportTestWindow,
+			DatacenterID: 3131,
+		},
+	).Return(&LivenessTestReport{DataRows: []LivenessTestReportRow{
+		{
+			Timestamp: "2023-03-01T10:05:00Z",
+			Datacenters: []LivenessTestDatacenterData{
+				{DatacenterID: 3131, Nickname: "tfexample_dc_1", TestName: "health check", AgentIP: "10.0.0.1", TargetIP: "1.2.3.4"},
+			},
+		},
+		{
+			Timestamp: "2023-03-01T10:10:00Z",
+			Datacenters: []LivenessTestDatacenterData{
+				{DatacenterID: 3131, Nickname: "tfexample_dc_1", TestName: "health check", AgentIP: "10.0.0.1", TargetIP: "1.2.3.4", ErrorCode: 3101},
+			},
+		},
+	}}, nil)
+
+	dataSourceName := "data.akamai_gtm_liveness_report.test"
+
+	useReportsClient(client, func() {
+		resource.UnitTest(t, resource.TestCase{
+			ProviderFactories: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: loadFixtureString("testdata/TestDataGtmReports/liveness.tf"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(dataSourceName, "window_start", "2023-03-01T10:00:00Z"),
+						resource.TestCheckResourceAttr(dataSourceName, "results.#", "2"),
+						resource.TestCheckResourceAttr(dataSourceName, "results.1.error_code", "3101"),
+						resource.TestCheckResourceAttr(dataSourceName, "datacenters.0.tests", "2"),
+						resource.TestCheckResourceAttr(dataSourceName, "datacenters.0.failures", "1"),
+						resource.TestCheckResourceAttr(dataSourceName, "datacenters.0.healthy", "false"),
+						resource.TestCheckResourceAttr(dataSourceName, "healthy", "false"),
+					),
+				},
+			},
+		})
+	})
+
+	client.AssertExpectations(t)
+}
+
+func TestDataGtmTrafficReport(t *testing.T) {
+	t.Run("traffic distribution", func(t *testing.T) {
+		client := &mockReports{}
+		client.On("GetTrafficReport",
+			mock.Anything, // ctx is irrelevant for this test
+			TrafficReportRequest{
+				Domain:   gtmTestDomain,
+				Property: "test_property",
+				Window:   reportTestWindow,
+			},
+		).Return(&TrafficReport{DataRows: []TrafficReportRow{
+			{
+				Timestamp: "2023-03-01T10:05:00Z",
+				Datacenters: []TrafficDatacenterData{
+					{DatacenterID: 3132, Nickname: "tfexample_dc_2", Requests: 30},
+					{DatacenterID: 3131, Nickname: "tfexample_dc_1", Requests: 50},
+				},
+			},
+			{
+				Timestamp: "2023-03-01T10:10:00Z",
+				Datacenters: []TrafficDatacenterData{
+					{DatacenterID: 3131, Nickname: "tfexample_dc_1", Requests: 25},
+					{DatacenterID: 3132, Nickname: "tfexample_dc_2", Requests: 20},
+				},
+			},
+		}}, nil)
+
+		dataSourceName := "data.akamai_gtm_traffic_report.test"
+
+		useReportsClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestDataGtmReports/traffic.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(dataSourceName, "rows.#", "2"),
+							resource.TestCheckResourceAttr(dataSourceName, "rows.0.datacenters.0.requests", "30"),
+							resource.TestCheckResourceAttr(dataSourceName, "total_requests", "125"),
+							resource.TestCheckResourceAttr(dataSourceName, "datacenters.0.datacenter_id", "3131"),
+							resource.TestCheckResourceAttr(dataSourceName, "datacenters.0.requests", "75"),
+							resource.TestCheckResourceAttr(dataSourceName, "datacenters.0.percentage", "60"),
+							resource.TestCheckResourceAttr(dataSourceName, "datacenters.1.percentage", "40"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("end before start", func(t *testing.T) {
+		client := &mockReports{}
+
+		useReportsClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config:      loadFixtureString("testdata/TestDataGtmReports/invalid_window.tf"),
+						ExpectError: regexp.MustCompile("must be after its start"),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
+
+func TestDataGtmIPAvailability(t *testing.T) {
+	client := &mockReports{}
+	client.On("GetIPAvailabilityReport",
+		mock.Anything, // ctx is irrelevant for this test
+		IPAvailabilityReportRequest{
+			Domain:     gtmTestDomain,
+			Property:   "test_property",
+			Window:     reportTestWindow,
+			MostRecent: true,
+		},
+	).Return(&IPAvailabilityReport{DataRows: []IPAvailabilityReportRow{
+		{
+			Timestamp: "2023-03-01T10:25:00Z",
+			CutOff:    112.5,
+			Datacenters: []IPAvailabilityDatacenterData{
+				{
+					DatacenterID: 3131,
+					Nickname:     "tfexample_dc_1",
+					IPs: []IPScoring{
+						{IP: "1.2.3.4", Score: 75, HandedOut: true, Alive: true},
+						{IP: "1.2.3.5", Score: 150, Alive: false},
+					},
+				},
+			},
+		},
+	}}, nil)
+
+	dataSourceName := "data.akamai_gtm_ip_availability.test"
+
+	useReportsClient(client, func() {
+		resource.UnitTest(t, resource.TestCase{
+			ProviderFactories: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: loadFixtureString("testdata/TestDataGtmReports/ip_availability.tf"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(dataSourceName, "rows.0.cut_off", "112.5"),
+						resource.TestCheckResourceAttr(dataSourceName, "rows.0.datacenters.0.ips.#", "2"),
+						resource.TestCheckResourceAttr(dataSourceName, "rows.0.datacenters.0.ips.0.handed_out", "true"),
+						resource.TestCheckResourceAttr(dataSourceName, "datacenters.0.ips", "2"),
+						resource.TestCheckResourceAttr(dataSourceName, "datacenters.0.alive_ips", "1"),
+						resource.TestCheckResourceAttr(dataSourceName, "datacenters.0.alive", "true"),
+					),
+				},
+			},
+		})
+	})
+
+	client.AssertExpectations(t)
+}
+
+func TestReportWindow(t *testing.T) {
+	now := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	s := dataSourceGTMTrafficReport().Schema
+
+	tests := map[string]struct {
+		raw       map[string]interface{}
+		expected  ReportWindow
+		withError bool
+	}{
+		"start and end": {
+			raw:      map[string]interface{}{"start": "2023-03-01T10:00:00Z", "end": "2023-03-01T10:30:00Z"},
+			expected: reportTestWindow,
+		},
+		"start until now": {
+			raw:      map[string]interface{}{"start": "2023-03-01T10:00:00Z"},
+			expected: ReportWindow{Start: reportTestWindow.Start, End: now},
+		},
+		"duration ending now": {
+			raw:      map[string]interface{}{"duration": "30m"},
+			expected: ReportWindow{Start: now.Add(-30 * time.Minute), End: now},
+		},
+		"empty window": {
+			raw:       map[string]interface{}{"start": "2023-03-01T10:00:00Z", "end": "2023-03-01T10:00:00Z"},
+			withError: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			window, err := reportWindow(schema.TestResourceDataRaw(t, s, test.raw), now)
+			if test.withError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, test.expected.Start.Equal(window.Start), window.Start)
+			assert.True(t, test.expected.End.Equal(window.End), window.End)
+		})
+	}
+}
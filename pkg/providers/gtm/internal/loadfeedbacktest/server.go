@@ -0,0 +1,130 @@
+// Package loadfeedbacktest provides a local stand-in for the endpoints GTM load objects are uploaded to.
+package loadfeedbacktest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/providers/gtm/loadfeedback"
+)
+
+const (
+	netStorageActionHeader   = "X-Akamai-ACS-Action"
+	netStorageAuthDataHeader = "X-Akamai-ACS-Auth-Data"
+	netStorageAuthSignHeader = "X-Akamai-ACS-Auth-Sign"
+)
+
+// Server is a local HTTP stand-in for load object endpoints, for use in tests. It stores uploaded objects in
+// memory and accepts both plain HTTP requests, like an origin, and NetStorage HTTP API requests, whose
+// signatures are verified against the keys it was created with.
+type Server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	keys    map[string]string
+	objects map[string][]byte
+	uploads map[string]int
+}
+
+// NewServer starts a stand-in server. The keys map NetStorage key names to keys; NetStorage requests signed
+// with any other key are rejected.
+func NewServer(keys map[string]string) *Server {
+	s := &Server{
+		keys:    keys,
+		objects: make(map[string][]byte),
+		uploads: make(map[string]int),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Object returns the object stored at the given path
+func (s *Server) Object(path string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	body, ok := s.objects[path]
+	return body, ok
+}
+
+// Uploads returns how many times an object was uploaded to the given path
+func (s *Server) Uploads(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.uploads[path]
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	method := r.Method
+	if action := r.Header.Get(netStorageActionHeader); action != "" {
+		var err error
+		if method, err = s.netStorageMethod(r, action); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch method {
+	case http.MethodPut:
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.objects[r.URL.Path] = body
+		s.uploads[r.URL.Path]++
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		body, ok := s.objects[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(body)
+	case http.MethodDelete:
+		if _, ok := s.objects[r.URL.Path]; !ok {
+			http.NotFound(w, r)
+			return
+		}
+		delete(s.objects, r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.Error(w, fmt.Sprintf("method %s not allowed", method), http.StatusMethodNotAllowed)
+	}
+}
+
+// netStorageMethod verifies the signature of a NetStorage request and returns the plain HTTP method of its action
+func (s *Server) netStorageMethod(r *http.Request, action string) (string, error) {
+	authData := r.Header.Get(netStorageAuthDataHeader)
+	fields := strings.Split(authData, ", ")
+	if len(fields) != 6 {
+		return "", fmt.Errorf("invalid %s header", netStorageAuthDataHeader)
+	}
+	key, ok := s.keys[fields[5]]
+	if !ok {
+		return "", fmt.Errorf("unknown key name %q", fields[5])
+	}
+	if r.Header.Get(netStorageAuthSignHeader) != loadfeedback.SignNetStorageRequest(key, authData, r.URL.Path, action) {
+		return "", fmt.Errorf("invalid signature")
+	}
+	values, err := url.ParseQuery(action)
+	if err != nil {
+		return "", err
+	}
+	switch values.Get("action") {
+	case "upload":
+		return http.MethodPut, nil
+	case "download":
+		return http.MethodGet, nil
+	case "delete":
+		return http.MethodDelete, nil
+	}
+	return "", fmt.Errorf("unsupported action %q", values.Get("action"))
+}
@@ -0,0 +1,214 @@
+package loadfeedback
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when a load object does not exist on the endpoint
+var ErrNotFound = errors.New("load object not found")
+
+type (
+	// Client publishes load objects to an endpoint GTM polls them from
+	Client interface {
+		// Upload stores the load object at the given path
+		Upload(ctx context.Context, path string, body []byte) error
+		// Download returns the load object stored at the given path
+		Download(ctx context.Context, path string) ([]byte, error)
+		// Delete removes the load object stored at the given path
+		Delete(ctx context.Context, path string) error
+		// URL returns the URL of the given path on the endpoint
+		URL(path string) string
+	}
+
+	origin struct {
+		baseURL string
+		headers map[string]string
+		client  *http.Client
+	}
+
+	netStorage struct {
+		endpoint string
+		cpCode   int
+		keyName  string
+		key      string
+		client   *http.Client
+		now      func() time.Time
+	}
+)
+
+const (
+	netStorageActionHeader   = "X-Akamai-ACS-Action"
+	netStorageAuthDataHeader = "X-Akamai-ACS-Auth-Data"
+	netStorageAuthSignHeader = "X-Akamai-ACS-Auth-Sign"
+)
+
+// NewOriginClient returns a client uploading load objects with HTTP PUT requests to an origin. The headers are
+// sent with every request, for example to authenticate.
+func NewOriginClient(baseURL string, headers map[string]string, client *http.Client) Client {
+	return &origin{baseURL: strings.TrimSuffix(baseURL, "/"), headers: headers, client: client}
+}
+
+// NewNetStorageClient returns a client uploading load objects to a NetStorage upload account with the
+// NetStorage HTTP API
+func NewNetStorageClient(endpoint string, cpCode int, keyName, key string, client *http.Client) Client {
+	return &netStorage{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		cpCode:   cpCode,
+		keyName:  keyName,
+		key:      key,
+		client:   client,
+		now:      time.Now,
+	}
+}
+
+func (o *origin) URL(path string) string {
+	return o.baseURL + cleanPath(path)
+}
+
+func (o *origin) Upload(ctx context.Context, path string, body []byte) error {
+	req, err := o.request(ctx, http.MethodPut, path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/xml")
+	_, err = do(o.client, req, http.StatusOK, http.StatusCreated, http.StatusNoContent)
+	return err
+}
+
+func (o *origin) Download(ctx context.Context, path string) ([]byte, error) {
+	req, err := o.request(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return do(o.client, req, http.StatusOK)
+}
+
+func (o *origin) Delete(ctx context.Context, path string) error {
+	req, err := o.request(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+	_, err = do(o.client, req, http.StatusOK, http.StatusAccepted, http.StatusNoContent)
+	return err
+}
+
+func (o *origin) request(ctx context.Context, method, path string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, o.URL(path), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range o.headers {
+		req.Header.Set(name, value)
+	}
+	return req, nil
+}
+
+func (n *netStorage) URL(path string) string {
+	return n.endpoint + n.objectPath(path)
+}
+
+func (n *netStorage) Upload(ctx context.Context, path string, body []byte) error {
+	sum := sha256.Sum256(body)
+	action := fmt.Sprintf("version=1&action=upload&upload-type=binary&sha256=%s", hex.EncodeToString(sum[:]))
+	req, err := n.request(ctx, http.MethodPut, path, action, body)
+	if err != nil {
+		return err
+	}
+	_, err = do(n.client, req, http.StatusOK)
+	return err
+}
+
+func (n *netStorage) Download(ctx context.Context, path string) ([]byte, error) {
+	req, err := n.request(ctx, http.MethodGet, path, "version=1&action=download", nil)
+	if err != nil {
+		return nil, err
+	}
+	return do(n.client, req, http.StatusOK)
+}
+
+func (n *netStorage) Delete(ctx context.Context, path string) error {
+	req, err := n.request(ctx, http.MethodPut, path, "version=1&action=delete", nil)
+	if err != nil {
+		return err
+	}
+	_, err = do(n.client, req, http.StatusOK)
+	return err
+}
+
+func (n *netStorage) objectPath(path string) string {
+	return fmt.Sprintf("/%d%s", n.cpCode, cleanPath(path))
+}
+
+func (n *netStorage) request(ctx context.Context, method, path, action string, body []byte) (*http.Request, error) {
+	objectPath := n.objectPath(path)
+	req, err := http.NewRequestWithContext(ctx, method, n.endpoint+objectPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	uniqueID, err := randomID()
+	if err != nil {
+		return nil, err
+	}
+	authData := fmt.Sprintf("5, 0.0.0.0, 0.0.0.0, %d, %s, %s", n.now().Unix(), uniqueID, n.keyName)
+	req.Header.Set(netStorageActionHeader, action)
+	req.Header.Set(netStorageAuthDataHeader, authData)
+	req.Header.Set(netStorageAuthSignHeader, SignNetStorageRequest(n.key, authData, objectPath, action))
+	return req, nil
+}
+
+// SignNetStorageRequest returns the signature of a NetStorage HTTP API request, version 5 (HMAC-SHA256)
+func SignNetStorageRequest(key, authData, path, action string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(authData + path + "\n" + strings.ToLower(netStorageActionHeader) + ":" + action + "\n"))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func do(client *http.Client, req *http.Request, expected ...int) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s %s", ErrNotFound, req.Method, req.URL.Path)
+	}
+	for _, status := range expected {
+		if resp.StatusCode == status {
+			return body, nil
+		}
+	}
+	return nil, fmt.Errorf("%s %s failed with status %d: %s", req.Method, req.URL.Path, resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+func cleanPath(path string) string {
+	if !strings.HasPrefix(path, "/") {
+		return "/" + path
+	}
+	return path
+}
+
+func randomID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,60 @@
+package loadfeedback_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/providers/gtm/internal/loadfeedbacktest"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/providers/gtm/loadfeedback"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOriginClient(t *testing.T) {
+	server := loadfeedbacktest.NewServer(nil)
+	defer server.Close()
+
+	client := loadfeedback.NewOriginClient(server.URL+"/", map[string]string{"Authorization": "Bearer token"}, server.Client())
+	assert.Equal(t, server.URL+"/load/dc1.xml", client.URL("load/dc1.xml"))
+
+	ctx := context.Background()
+	require.NoError(t, client.Upload(ctx, "/load/dc1.xml", []byte("<LoadFeedback/>")))
+	require.NoError(t, client.Upload(ctx, "/load/dc1.xml", []byte("<LoadFeedback></LoadFeedback>")))
+	assert.Equal(t, 2, server.Uploads("/load/dc1.xml"))
+
+	body, err := client.Download(ctx, "/load/dc1.xml")
+	require.NoError(t, err)
+	assert.Equal(t, "<LoadFeedback></LoadFeedback>", string(body))
+
+	require.NoError(t, client.Delete(ctx, "/load/dc1.xml"))
+	_, err = client.Download(ctx, "/load/dc1.xml")
+	assert.True(t, errors.Is(err, loadfeedback.ErrNotFound))
+}
+
+func TestNetStorageClient(t *testing.T) {
+	server := loadfeedbacktest.NewServer(map[string]string{"upload1": "secret"})
+	defer server.Close()
+	ctx := context.Background()
+
+	client := loadfeedback.NewNetStorageClient(server.URL, 123456, "upload1", "secret", server.Client())
+	assert.Equal(t, server.URL+"/123456/load/dc1.xml", client.URL("/load/dc1.xml"))
+
+	require.NoError(t, client.Upload(ctx, "/load/dc1.xml", []byte("<LoadFeedback/>")))
+	stored, ok := server.Object("/123456/load/dc1.xml")
+	require.True(t, ok)
+	assert.Equal(t, "<LoadFeedback/>", string(stored))
+
+	body, err := client.Download(ctx, "/load/dc1.xml")
+	require.NoError(t, err)
+	assert.Equal(t, "<LoadFeedback/>", string(body))
+
+	require.NoError(t, client.Delete(ctx, "/load/dc1.xml"))
+	_, ok = server.Object("/123456/load/dc1.xml")
+	assert.False(t, ok)
+
+	wrongKey := loadfeedback.NewNetStorageClient(server.URL, 123456, "upload1", "wrong", server.Client())
+	err = wrongKey.Upload(ctx, "/load/dc1.xml", []byte("<LoadFeedback/>"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 403")
+}
@@ -0,0 +1,27 @@
+package loadfeedback
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignNetStorageRequest(t *testing.T) {
+	client := NewNetStorageClient("https://example-nsu.akamaihd.net", 123456, "upload1", "secret", http.DefaultClient).(*netStorage)
+	client.now = func() time.Time { return time.Unix(1677664800, 0) }
+
+	req, err := client.request(context.Background(), http.MethodGet, "/load/dc1.xml", "version=1&action=download", nil)
+	require.NoError(t, err)
+	authData := req.Header.Get(netStorageAuthDataHeader)
+	assert.Regexp(t, `^5, 0\.0\.0\.0, 0\.0\.0\.0, 1677664800, [0-9a-f]{16}, upload1$`, authData)
+	assert.Equal(t, "version=1&action=download", req.Header.Get(netStorageActionHeader))
+	assert.Equal(t, SignNetStorageRequest("secret", authData, "/123456/load/dc1.xml", "version=1&action=download"),
+		req.Header.Get(netStorageAuthSignHeader))
+	// the signature covers the data, path and action
+	assert.NotEqual(t, SignNetStorageRequest("secret", authData, "/123456/load/dc2.xml", "version=1&action=download"),
+		req.Header.Get(netStorageAuthSignHeader))
+}
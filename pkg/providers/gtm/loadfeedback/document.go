@@ -0,0 +1,64 @@
+// Package loadfeedback builds GTM load feedback documents and publishes them to the endpoints GTM polls them from
+package loadfeedback
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+)
+
+// Document is a GTM XML load object reporting the load of one resource on one server
+type Document struct {
+	XMLName      xml.Name `xml:"LoadFeedback"`
+	ServerName   string   `xml:"ServerName"`
+	ResourceName string   `xml:"ResourceName"`
+	Timestamp    int64    `xml:"Timestamp"`
+	CurrentLoad  float64  `xml:"Current-Load"`
+	TargetLoad   float64  `xml:"Target-Load"`
+	MaxLoad      *float64 `xml:"Max-Load,omitempty"`
+}
+
+// Validate checks the document has the fields GTM requires and consistent load values
+func (d Document) Validate() error {
+	if d.ServerName == "" {
+		return fmt.Errorf("server name is required")
+	}
+	if d.ResourceName == "" {
+		return fmt.Errorf("resource name is required")
+	}
+	if d.Timestamp <= 0 {
+		return fmt.Errorf("timestamp must be a positive unix time")
+	}
+	if d.CurrentLoad < 0 || d.TargetLoad < 0 {
+		return fmt.Errorf("current and target load must not be negative")
+	}
+	if d.MaxLoad != nil && *d.MaxLoad < d.TargetLoad {
+		return fmt.Errorf("max load %g must not be lower than target load %g", *d.MaxLoad, d.TargetLoad)
+	}
+	return nil
+}
+
+// Marshal returns the document as indented XML with a declaration
+func (d Document) Marshal() ([]byte, error) {
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	body, err := xml.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" standalone="yes"?>` + "\n")
+	buf.Write(body)
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
+
+// Unmarshal parses an XML load object
+func Unmarshal(data []byte) (*Document, error) {
+	var d Document
+	if err := xml.Unmarshal(data, &d); err != nil {
+		return nil, fmt.Errorf("invalid load object: %w", err)
+	}
+	return &d, nil
+}
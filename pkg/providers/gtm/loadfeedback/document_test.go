@@ -0,0 +1,55 @@
+package loadfeedback
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentMarshal(t *testing.T) {
+	maxLoad := 90.0
+	doc := Document{
+		ServerName:   "origin1.example.com",
+		ResourceName: "bandwidth",
+		Timestamp:    1677664800,
+		CurrentLoad:  37.5,
+		TargetLoad:   70,
+		MaxLoad:      &maxLoad,
+	}
+	body, err := doc.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" standalone="yes"?>
+<LoadFeedback>
+  <ServerName>origin1.example.com</ServerName>
+  <ResourceName>bandwidth</ResourceName>
+  <Timestamp>1677664800</Timestamp>
+  <Current-Load>37.5</Current-Load>
+  <Target-Load>70</Target-Load>
+  <Max-Load>90</Max-Load>
+</LoadFeedback>
+`, string(body))
+
+	parsed, err := Unmarshal(body)
+	require.NoError(t, err)
+	assert.Equal(t, doc.ServerName, parsed.ServerName)
+	assert.Equal(t, doc.CurrentLoad, parsed.CurrentLoad)
+	assert.Equal(t, maxLoad, *parsed.MaxLoad)
+}
+
+func TestDocumentValidate(t *testing.T) {
+	lowMax := 50.0
+	tests := map[string]Document{
+		"missing server name":   {ResourceName: "cpu", Timestamp: 1, TargetLoad: 70},
+		"missing resource name": {ServerName: "s", Timestamp: 1, TargetLoad: 70},
+		"missing timestamp":     {ServerName: "s", ResourceName: "cpu", TargetLoad: 70},
+		"negative load":         {ServerName: "s", ResourceName: "cpu", Timestamp: 1, CurrentLoad: -1},
+		"max below target":      {ServerName: "s", ResourceName: "cpu", Timestamp: 1, TargetLoad: 70, MaxLoad: &lowMax},
+	}
+	for name, doc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := doc.Marshal()
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+// Package mapfile reads and writes the assignments of GTM geographic and AS maps as CSV and JSON files
+package mapfile
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Kind is the kind of map the assignments belong to
+type Kind string
+
+const (
+	// Geographic assignments hand out data centers by country code
+	Geographic Kind = "geographic"
+	// AS assignments hand out data centers by autonomous system number
+	AS Kind = "as"
+
+	// MaxASNumber is the highest 32-bit AS number
+	MaxASNumber int64 = 4294967295
+)
+
+var (
+	// reservedASNumbers can't be assigned to data centers
+	reservedASNumbers = map[int64]string{
+		0:           "reserved",
+		23456:       "AS_TRANS",
+		65535:       "reserved",
+		MaxASNumber: "reserved",
+	}
+)
+
+// Assignment is the list of countries or AS numbers handed out a data center
+type Assignment struct {
+	DatacenterID int      `json:"datacenter_id"`
+	Nickname     string   `json:"nickname"`
+	Countries    []string `json:"countries,omitempty"`
+	ASNumbers    []int64  `json:"as_numbers,omitempty"`
+}
+
+// Normalize validates assignments and returns them merged per data center, sorted by data center id, with
+// sorted values. A country or AS number can only be assigned to one data center.
+func Normalize(kind Kind, assignments []Assignment) ([]Assignment, error) {
+	if kind != Geographic && kind != AS {
+		return nil, fmt.Errorf("unsupported map kind %q", kind)
+	}
+	byDatacenter := make(map[int]*Assignment, len(assignments))
+	owners := make(map[string]int)
+	for _, a := range assignments {
+		if a.DatacenterID <= 0 {
+			return nil, fmt.Errorf("invalid data center id %d", a.DatacenterID)
+		}
+		merged, ok := byDatacenter[a.DatacenterID]
+		if !ok {
+			merged = &Assignment{DatacenterID: a.DatacenterID, Nickname: a.Nickname}
+			byDatacenter[a.DatacenterID] = merged
+		}
+		if merged.Nickname == "" {
+			merged.Nickname = a.Nickname
+		}
+		if a.Nickname != "" && a.Nickname != merged.Nickname {
+			return nil, fmt.Errorf("data center %d has nicknames %q and %q", a.DatacenterID, merged.Nickname, a.Nickname)
+		}
+
+		if kind == AS && len(a.Countries) > 0 || kind == Geographic && len(a.ASNumbers) > 0 {
+			return nil, fmt.Errorf("data center %d: %s maps only assign %s", a.DatacenterID, kind, valueName(kind))
+		}
+		for _, c := range a.Countries {
+			code := strings.ToUpper(strings.TrimSpace(c))
+			if !countryCodes[code] {
+				return nil, fmt.Errorf("data center %d: invalid country code %q", a.DatacenterID, c)
+			}
+			if err := claim(owners, code, a.DatacenterID); err != nil {
+				return nil, err
+			}
+			merged.Countries = append(merged.Countries, code)
+		}
+		for _, n := range a.ASNumbers {
+			if err := ValidateASNumber(n); err != nil {
+				return nil, fmt.Errorf("data center %d: %w", a.DatacenterID, err)
+			}
+			if err := claim(owners, fmt.Sprint(n), a.DatacenterID); err != nil {
+				return nil, err
+			}
+			merged.ASNumbers = append(merged.ASNumbers, n)
+		}
+	}
+
+	result := make([]Assignment, 0, len(byDatacenter))
+	for _, a := range byDatacenter {
+		if a.Nickname == "" {
+			return nil, fmt.Errorf("data center %d: nickname is required", a.DatacenterID)
+		}
+		result = append(result, *a)
+	}
+	Sort(result)
+	return result, nil
+}
+
+// Sort orders assignments by data center id and their values, without validating them, so assignments
+// read from an existing map hash and export the same way as normalized ones
+func Sort(assignments []Assignment) {
+	for i := range assignments {
+		a := &assignments[i]
+		sort.Strings(a.Countries)
+		sort.Slice(a.ASNumbers, func(i, j int) bool { return a.ASNumbers[i] < a.ASNumbers[j] })
+	}
+	sort.Slice(assignments, func(i, j int) bool { return assignments[i].DatacenterID < assignments[j].DatacenterID })
+}
+
+// ValidateASNumber checks an AS number is in the 32-bit range and not reserved
+func ValidateASNumber(n int64) error {
+	if n < 0 || n > MaxASNumber {
+		return fmt.Errorf("AS number %d out of range 1-%d", n, MaxASNumber)
+	}
+	if reason, ok := reservedASNumbers[n]; ok {
+		return fmt.Errorf("AS number %d is %s", n, reason)
+	}
+	return nil
+}
+
+// Hash returns a hash of normalized assignments which only changes when the assignments change
+func Hash(assignments []Assignment) string {
+	canonical, _ := json.Marshal(assignments)
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:])
+}
+
+// Parse reads assignments in the given format, "csv" or "json", and normalizes them. An empty format
+// is detected from the content.
+func Parse(kind Kind, format string, content []byte) ([]Assignment, error) {
+	if format == "" {
+		format = DetectFormat(content)
+	}
+	var assignments []Assignment
+	var err error
+	switch format {
+	case "csv":
+		assignments, err = ParseCSV(kind, content)
+	case "json":
+		assignments, err = ParseJSON(kind, content)
+	default:
+		return nil, fmt.Errorf("unsupported format %q, should be csv or json", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return Normalize(kind, assignments)
+}
+
+// DetectFormat returns "json" for content starting with a JSON array or object, "csv" otherwise
+func DetectFormat(content []byte) string {
+	trimmed := strings.TrimSpace(string(content))
+	if strings.HasPrefix(trimmed, "[") || strings.HasPrefix(trimmed, "{") {
+		return "json"
+	}
+	return "csv"
+}
+
+func claim(owners map[string]int, value string, datacenterID int) error {
+	if owner, ok := owners[value]; ok && owner != datacenterID {
+		return fmt.Errorf("%s is assigned to both data center %d and %d", value, owner, datacenterID)
+	}
+	owners[value] = datacenterID
+	return nil
+}
+
+func valueName(kind Kind) string {
+	if kind == AS {
+		return "AS numbers"
+	}
+	return "countries"
+}
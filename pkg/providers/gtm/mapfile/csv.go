@@ -0,0 +1,163 @@
+package mapfile
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// MaxRangeSize is the highest number of AS numbers a single range may expand to
+const MaxRangeSize = 100000
+
+// ParseCSV reads assignments from CSV with one country or AS number per row. The header names the columns
+// datacenter_id, nickname and country for geographic maps or as_number for AS maps. AS numbers may be
+// given as inclusive ranges like 64512-64520. Lines starting with # are skipped.
+func ParseCSV(kind Kind, content []byte) ([]Assignment, error) {
+	r := csv.NewReader(bytes.NewReader(content))
+	r.Comment = '#'
+	r.TrimLeadingSpace = true
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	valueColumn := csvValueColumn(kind)
+	for _, name := range []string{"datacenter_id", "nickname", valueColumn} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("CSV header must have the columns datacenter_id, nickname and %s", valueColumn)
+		}
+	}
+
+	var assignments []Assignment
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return assignments, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading CSV: %w", err)
+		}
+		line, _ := r.FieldPos(0)
+		field := func(name string) string {
+			if i := columns[name]; i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		datacenterID, err := strconv.Atoi(field("datacenter_id"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid datacenter_id %q", line, field("datacenter_id"))
+		}
+		a := Assignment{DatacenterID: datacenterID, Nickname: field("nickname")}
+		value := field(valueColumn)
+		if value == "" {
+			return nil, fmt.Errorf("line %d: %s is required", line, valueColumn)
+		}
+		if kind == AS {
+			if a.ASNumbers, err = ParseASRange(value); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		} else {
+			a.Countries = []string{value}
+		}
+		assignments = append(assignments, a)
+	}
+}
+
+// WriteCSV writes normalized assignments as CSV, with consecutive AS numbers written as ranges
+func WriteCSV(kind Kind, assignments []Assignment) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write([]string{"datacenter_id", "nickname", csvValueColumn(kind)}); err != nil {
+		return nil, err
+	}
+	for _, a := range assignments {
+		values := a.Countries
+		if kind == AS {
+			values = FormatASRanges(a.ASNumbers)
+		}
+		for _, v := range values {
+			if err := w.Write([]string{strconv.Itoa(a.DatacenterID), a.Nickname, v}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ParseASRange parses an AS number or an inclusive range of AS numbers like 64512-64520
+func ParseASRange(value string) ([]int64, error) {
+	first, last := value, value
+	if i := strings.Index(value, "-"); i > 0 {
+		first, last = strings.TrimSpace(value[:i]), strings.TrimSpace(value[i+1:])
+	}
+	from, err := parseASNumber(first)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseASNumber(last)
+	if err != nil {
+		return nil, err
+	}
+	if to < from {
+		return nil, fmt.Errorf("invalid AS number range %q: end is lower than start", value)
+	}
+	if to-from >= MaxRangeSize {
+		return nil, fmt.Errorf("AS number range %q has more than %d numbers", value, MaxRangeSize)
+	}
+	numbers := make([]int64, 0, to-from+1)
+	for n := from; n <= to; n++ {
+		numbers = append(numbers, n)
+	}
+	return numbers, nil
+}
+
+// FormatASRanges returns sorted AS numbers with consecutive numbers collapsed into ranges
+func FormatASRanges(numbers []int64) []string {
+	var ranges []string
+	for i := 0; i < len(numbers); {
+		j := i
+		for j+1 < len(numbers) && numbers[j+1] == numbers[j]+1 {
+			j++
+		}
+		if i == j {
+			ranges = append(ranges, strconv.FormatInt(numbers[i], 10))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", numbers[i], numbers[j]))
+		}
+		i = j + 1
+	}
+	return ranges
+}
+
+func parseASNumber(value string) (int64, error) {
+	n, err := strconv.ParseInt(strings.TrimPrefix(strings.ToUpper(value), "AS"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid AS number %q", value)
+	}
+	return n, nil
+}
+
+func csvValueColumn(kind Kind) string {
+	if kind == AS {
+		return "as_number"
+	}
+	return "country"
+}
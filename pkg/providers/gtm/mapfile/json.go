@@ -0,0 +1,79 @@
+package mapfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+type jsonAssignment struct {
+	DatacenterID int               `json:"datacenter_id"`
+	Nickname     string            `json:"nickname"`
+	Countries    []string          `json:"countries,omitempty"`
+	ASNumbers    []json.RawMessage `json:"as_numbers,omitempty"`
+}
+
+// ParseJSON reads assignments from a JSON array of objects with the datacenter_id, nickname and countries
+// or as_numbers fields. AS numbers are numbers or strings holding ranges like "64512-64520". The array may
+// also be wrapped in an object as its assignments field.
+func ParseJSON(_ Kind, content []byte) ([]Assignment, error) {
+	var list []jsonAssignment
+	if bytes.HasPrefix(bytes.TrimSpace(content), []byte("{")) {
+		var wrapped struct {
+			Assignments []jsonAssignment `json:"assignments"`
+		}
+		if err := json.Unmarshal(content, &wrapped); err != nil {
+			return nil, fmt.Errorf("reading JSON: %w", err)
+		}
+		list = wrapped.Assignments
+	} else if err := json.Unmarshal(content, &list); err != nil {
+		return nil, fmt.Errorf("reading JSON: %w", err)
+	}
+
+	assignments := make([]Assignment, 0, len(list))
+	for i, item := range list {
+		a := Assignment{DatacenterID: item.DatacenterID, Nickname: item.Nickname, Countries: item.Countries}
+		for _, raw := range item.ASNumbers {
+			var n int64
+			if err := json.Unmarshal(raw, &n); err == nil {
+				a.ASNumbers = append(a.ASNumbers, n)
+				continue
+			}
+			var r string
+			if err := json.Unmarshal(raw, &r); err != nil {
+				return nil, fmt.Errorf("assignment %d: invalid AS number %s", i, raw)
+			}
+			numbers, err := ParseASRange(r)
+			if err != nil {
+				return nil, fmt.Errorf("assignment %d: %w", i, err)
+			}
+			a.ASNumbers = append(a.ASNumbers, numbers...)
+		}
+		assignments = append(assignments, a)
+	}
+	return assignments, nil
+}
+
+// WriteJSON writes normalized assignments as an indented JSON array, with consecutive AS numbers written as ranges
+func WriteJSON(kind Kind, assignments []Assignment) ([]byte, error) {
+	list := make([]jsonAssignment, 0, len(assignments))
+	for _, a := range assignments {
+		item := jsonAssignment{DatacenterID: a.DatacenterID, Nickname: a.Nickname}
+		if kind == AS {
+			for _, r := range FormatASRanges(a.ASNumbers) {
+				raw, err := json.Marshal(r)
+				if err != nil {
+					return nil, err
+				}
+				if n, err := parseASNumber(r); err == nil {
+					raw, _ = json.Marshal(n)
+				}
+				item.ASNumbers = append(item.ASNumbers, raw)
+			}
+		} else {
+			item.Countries = a.Countries
+		}
+		list = append(list, item)
+	}
+	return json.MarshalIndent(list, "", "  ")
+}
@@ -0,0 +1,180 @@
+package mapfile
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		kind      Kind
+		format    string
+		content   string
+		expected  []Assignment
+		withError *regexp.Regexp
+	}{
+		"geographic CSV": {
+			kind: Geographic,
+			content: `# network team export
+datacenter_id,nickname,country
+3132,frankfurt,de
+3131,amsterdam,NL
+3132,frankfurt,AT
+3131,,BE
+`,
+			expected: []Assignment{
+				{DatacenterID: 3131, Nickname: "amsterdam", Countries: []string{"BE", "NL"}},
+				{DatacenterID: 3132, Nickname: "frankfurt", Countries: []string{"AT", "DE"}},
+			},
+		},
+		"AS CSV with ranges and reordered columns": {
+			kind: AS,
+			content: `as_number,datacenter_id,nickname
+AS64512,3131,amsterdam
+64514-64516,3131,amsterdam
+13335,3132,frankfurt
+`,
+			expected: []Assignment{
+				{DatacenterID: 3131, Nickname: "amsterdam", ASNumbers: []int64{64512, 64514, 64515, 64516}},
+				{DatacenterID: 3132, Nickname: "frankfurt", ASNumbers: []int64{13335}},
+			},
+		},
+		"AS JSON": {
+			kind: AS,
+			content: `[
+  {"datacenter_id": 3132, "nickname": "frankfurt", "as_numbers": [13335]},
+  {"datacenter_id": 3131, "nickname": "amsterdam", "as_numbers": ["64514-64515", 64512]}
+]`,
+			expected: []Assignment{
+				{DatacenterID: 3131, Nickname: "amsterdam", ASNumbers: []int64{64512, 64514, 64515}},
+				{DatacenterID: 3132, Nickname: "frankfurt", ASNumbers: []int64{13335}},
+			},
+		},
+		"wrapped geographic JSON": {
+			kind:    Geographic,
+			format:  "json",
+			content: `{"assignments": [{"datacenter_id": 3131, "nickname": "amsterdam", "countries": ["nl"]}]}`,
+			expected: []Assignment{
+				{DatacenterID: 3131, Nickname: "amsterdam", Countries: []string{"NL"}},
+			},
+		},
+		"invalid country code": {
+			kind:      Geographic,
+			content:   "datacenter_id,nickname,country\n3131,amsterdam,XX\n",
+			withError: regexp.MustCompile(`data center 3131: invalid country code "XX"`),
+		},
+		"country in two data centers": {
+			kind:      Geographic,
+			content:   "datacenter_id,nickname,country\n3131,amsterdam,NL\n3132,frankfurt,NL\n",
+			withError: regexp.MustCompile(`NL is assigned to both data center 3131 and 3132`),
+		},
+		"reserved AS number": {
+			kind:      AS,
+			content:   "datacenter_id,nickname,as_number\n3131,amsterdam,23456\n",
+			withError: regexp.MustCompile(`data center 3131: AS number 23456 is AS_TRANS`),
+		},
+		"AS number out of range": {
+			kind:      AS,
+			content:   `[{"datacenter_id": 3131, "nickname": "amsterdam", "as_numbers": [4294967296]}]`,
+			withError: regexp.MustCompile(`AS number 4294967296 out of range 1-4294967295`),
+		},
+		"reversed range": {
+			kind:      AS,
+			content:   "datacenter_id,nickname,as_number\n3131,amsterdam,64520-64512\n",
+			withError: regexp.MustCompile(`line 2: invalid AS number range "64520-64512": end is lower than start`),
+		},
+		"range too large": {
+			kind:      AS,
+			content:   "datacenter_id,nickname,as_number\n3131,amsterdam,1-200000\n",
+			withError: regexp.MustCompile(`has more than 100000 numbers`),
+		},
+		"missing column": {
+			kind:      AS,
+			content:   "datacenter_id,nickname,country\n3131,amsterdam,NL\n",
+			withError: regexp.MustCompile(`CSV header must have the columns datacenter_id, nickname and as_number`),
+		},
+		"conflicting nicknames": {
+			kind:      Geographic,
+			content:   "datacenter_id,nickname,country\n3131,amsterdam,NL\n3131,rotterdam,BE\n",
+			withError: regexp.MustCompile(`data center 3131 has nicknames "amsterdam" and "rotterdam"`),
+		},
+		"countries in AS map": {
+			kind:      AS,
+			content:   `[{"datacenter_id": 3131, "nickname": "amsterdam", "countries": ["NL"]}]`,
+			withError: regexp.MustCompile(`data center 3131: as maps only assign AS numbers`),
+		},
+		"unsupported format": {
+			kind:      AS,
+			format:    "yaml",
+			withError: regexp.MustCompile(`unsupported format "yaml", should be csv or json`),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assignments, err := Parse(test.kind, test.format, []byte(test.content))
+			if test.withError != nil {
+				require.Error(t, err)
+				assert.Regexp(t, test.withError, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, assignments)
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	assignments := []Assignment{
+		{DatacenterID: 3131, Nickname: "amsterdam", ASNumbers: []int64{64512, 64514, 64515, 64516}},
+		{DatacenterID: 3132, Nickname: "frankfurt", ASNumbers: []int64{13335}},
+	}
+
+	csv, err := WriteCSV(AS, assignments)
+	require.NoError(t, err)
+	assert.Equal(t, `datacenter_id,nickname,as_number
+3131,amsterdam,64512
+3131,amsterdam,64514-64516
+3132,frankfurt,13335
+`, string(csv))
+
+	json, err := WriteJSON(AS, assignments)
+	require.NoError(t, err)
+	assert.Equal(t, `[
+  {
+    "datacenter_id": 3131,
+    "nickname": "amsterdam",
+    "as_numbers": [
+      64512,
+      "64514-64516"
+    ]
+  },
+  {
+    "datacenter_id": 3132,
+    "nickname": "frankfurt",
+    "as_numbers": [
+      13335
+    ]
+  }
+]`, string(json))
+
+	for format, content := range map[string][]byte{"csv": csv, "json": json} {
+		parsed, err := Parse(AS, format, content)
+		require.NoError(t, err)
+		assert.Equal(t, assignments, parsed, format)
+	}
+}
+
+func TestHash(t *testing.T) {
+	first, err := Parse(Geographic, "", []byte("datacenter_id,nickname,country\n3131,amsterdam,NL\n3131,amsterdam,BE\n"))
+	require.NoError(t, err)
+	second, err := Parse(Geographic, "", []byte(`[{"datacenter_id": 3131, "nickname": "amsterdam", "countries": ["be", "nl"]}]`))
+	require.NoError(t, err)
+	assert.Equal(t, Hash(first), Hash(second))
+
+	third, err := Parse(Geographic, "", []byte("datacenter_id,nickname,country\n3131,amsterdam,NL\n"))
+	require.NoError(t, err)
+	assert.NotEqual(t, Hash(first), Hash(third))
+}
@@ -0,0 +1,35 @@
+package gtm
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type mockReports struct {
+	mock.Mock
+}
+
+func (m *mockReports) GetLivenessTestReport(ctx context.Context, params LivenessTestReportRequest) (*LivenessTestReport, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*LivenessTestReport), args.Error(1)
+}
+
+func (m *mockReports) GetTrafficReport(ctx context.Context, params TrafficReportRequest) (*TrafficReport, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*TrafficReport), args.Error(1)
+}
+
+func (m *mockReports) GetIPAvailabilityReport(ctx context.Context, params IPAvailabilityReportRequest) (*IPAvailabilityReport, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*IPAvailabilityReport), args.Error(1)
+}
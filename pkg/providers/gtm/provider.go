@@ -19,7 +19,8 @@ type (
 	provider struct {
 		*schema.Provider
 
-		client gtm.GTM
+		client  gtm.GTM
+		reports Reports
 	}
 
 	// Option is a gtm provider option
@@ -62,15 +63,33 @@ func Provider() *schema.Provider {
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"akamai_gtm_default_datacenter": dataSourceGTMDefaultDatacenter(),
+			"akamai_gtm_domain":             dataSourceGTMDomain(),
+			"akamai_gtm_domains":            dataSourceGTMDomains(),
+			"akamai_gtm_property":           dataSourceGTMProperty(),
+			"akamai_gtm_properties":         dataSourceGTMProperties(),
+			"akamai_gtm_datacenters":        dataSourceGTMDatacenters(),
+			"akamai_gtm_resources":          dataSourceGTMResources(),
+			"akamai_gtm_geomap":             dataSourceGTMGeomap(),
+			"akamai_gtm_asmap":              dataSourceGTMASmap(),
+			"akamai_gtm_cidrmap":            dataSourceGTMCidrmap(),
+			"akamai_gtm_geomap_assignments": dataSourceGTMGeomapAssignments(),
+			"akamai_gtm_asmap_assignments":  dataSourceGTMASmapAssignments(),
+			"akamai_gtm_liveness_report":    dataSourceGTMLivenessReport(),
+			"akamai_gtm_traffic_report":     dataSourceGTMTrafficReport(),
+			"akamai_gtm_ip_availability":    dataSourceGTMIPAvailability(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"akamai_gtm_domain":     resourceGTMv1Domain(),
-			"akamai_gtm_property":   resourceGTMv1Property(),
-			"akamai_gtm_datacenter": resourceGTMv1Datacenter(),
-			"akamai_gtm_resource":   resourceGTMv1Resource(),
-			"akamai_gtm_asmap":      resourceGTMv1ASmap(),
-			"akamai_gtm_geomap":     resourceGTMv1Geomap(),
-			"akamai_gtm_cidrmap":    resourceGTMv1Cidrmap(),
+			"akamai_gtm_domain":        resourceGTMv1Domain(),
+			"akamai_gtm_domain_unit":   resourceGTMv1DomainUnit(),
+			"akamai_gtm_domain_clone":  resourceGTMv1DomainClone(),
+			"akamai_gtm_load_object":   resourceGTMv1LoadObject(),
+			"akamai_gtm_traffic_shift": resourceGTMv1TrafficShift(),
+			"akamai_gtm_property":      resourceGTMv1Property(),
+			"akamai_gtm_datacenter":    resourceGTMv1Datacenter(),
+			"akamai_gtm_resource":      resourceGTMv1Resource(),
+			"akamai_gtm_asmap":         resourceGTMv1ASmap(),
+			"akamai_gtm_geomap":        resourceGTMv1Geomap(),
+			"akamai_gtm_cidrmap":       resourceGTMv1Cidrmap(),
 		},
 	}
 	return provider
@@ -83,6 +102,13 @@ func WithClient(c gtm.GTM) Option {
 	}
 }
 
+// WithReportsClient sets the Reports client interface, used for mocking and testing
+func WithReportsClient(c Reports) Option {
+	return func(p *provider) {
+		p.reports = c
+	}
+}
+
 // Client returns the DNS interface
 func (p *provider) Client(meta akamai.OperationMeta) gtm.GTM {
 	if p.client != nil {
@@ -91,6 +117,14 @@ func (p *provider) Client(meta akamai.OperationMeta) gtm.GTM {
 	return gtm.Client(meta.Session())
 }
 
+// ReportsClient returns the Reports interface
+func (p *provider) ReportsClient(meta akamai.OperationMeta) Reports {
+	if p.reports != nil {
+		return p.reports
+	}
+	return newReports(meta.Session())
+}
+
 func getConfigGTMV1Service(d *schema.ResourceData) error {
 	var inlineConfig *schema.Set
 	for _, key := range []string{"gtm", "config"} {
@@ -56,6 +56,34 @@ func useClient(client gtm.GTM, f func()) {
 	f()
 }
 
+// useReportsClient swaps out the Reports client on the global instance for the duration of the given func
+func useReportsClient(client Reports, f func()) {
+	clientLock.Lock()
+	orig := inst.reports
+	inst.reports = client
+
+	defer func() {
+		inst.reports = orig
+		clientLock.Unlock()
+	}()
+
+	f()
+}
+
+// useClients swaps out both the client and the Reports client on the global instance for the duration of the given func
+func useClients(client gtm.GTM, reports Reports, f func()) {
+	clientLock.Lock()
+	origClient, origReports := inst.client, inst.reports
+	inst.client, inst.reports = client, reports
+
+	defer func() {
+		inst.client, inst.reports = origClient, origReports
+		clientLock.Unlock()
+	}()
+
+	f()
+}
+
 // loadFixtureBytes returns the entire contents of the given file as a byte slice
 func loadFixtureBytes(path string) []byte {
 	contents, err := ioutil.ReadFile(path)
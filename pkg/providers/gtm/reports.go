@@ -0,0 +1,283 @@
+package gtm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/gtm"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/session"
+)
+
+type (
+	// Reports covers the GTM Reporting API, which is not part of the edgegrid gtm client
+	Reports interface {
+		// GetLivenessTestReport returns the liveness test results of a property per data center.
+		//
+		// See: https://techdocs.akamai.com/gtm-reporting/reference/get-liveness-tests-domain-property
+		GetLivenessTestReport(context.Context, LivenessTestReportRequest) (*LivenessTestReport, error)
+		// GetTrafficReport returns the number of requests handed out to each data center of a property.
+		//
+		// See: https://techdocs.akamai.com/gtm-reporting/reference/get-traffic-domain-property
+		GetTrafficReport(context.Context, TrafficReportRequest) (*TrafficReport, error)
+		// GetIPAvailabilityReport returns the liveness scores of the servers of a property per data center.
+		//
+		// See: https://techdocs.akamai.com/gtm-reporting/reference/get-ip-availability-domain-property
+		GetIPAvailabilityReport(context.Context, IPAvailabilityReportRequest) (*IPAvailabilityReport, error)
+	}
+
+	// ReportWindow is the time window a report covers
+	ReportWindow struct {
+		Start time.Time
+		End   time.Time
+	}
+
+	// ReportMetadata describes the report returned
+	ReportMetadata struct {
+		Domain   string `json:"domain"`
+		Property string `json:"property"`
+		Start    string `json:"start"`
+		End      string `json:"end"`
+		URI      string `json:"uri"`
+	}
+
+	// LivenessTestReportRequest contains the property and filters of a liveness test report
+	LivenessTestReportRequest struct {
+		Domain       string
+		Property     string
+		Window       ReportWindow
+		DatacenterID int
+		AgentIP      string
+		TargetIP     string
+	}
+
+	// LivenessTestReport contains the liveness test results of a property
+	LivenessTestReport struct {
+		Metadata ReportMetadata          `json:"metadata"`
+		DataRows []LivenessTestReportRow `json:"dataRows"`
+	}
+
+	// LivenessTestReportRow contains the liveness test results at a point in time
+	LivenessTestReportRow struct {
+		Timestamp   string                       `json:"timestamp"`
+		Datacenters []LivenessTestDatacenterData `json:"datacenters"`
+	}
+
+	// LivenessTestDatacenterData is the result of a liveness test of one data center
+	LivenessTestDatacenterData struct {
+		DatacenterID      int     `json:"datacenterId"`
+		Nickname          string  `json:"nickname"`
+		TrafficTargetName string  `json:"trafficTargetName"`
+		AgentIP           string  `json:"agentIp"`
+		TargetIP          string  `json:"targetIp"`
+		TestName          string  `json:"testName"`
+		ErrorCode         int     `json:"errorCode"`
+		Duration          float64 `json:"duration"`
+	}
+
+	// TrafficReportRequest contains the property a traffic report is requested for
+	TrafficReportRequest struct {
+		Domain   string
+		Property string
+		Window   ReportWindow
+	}
+
+	// TrafficReport contains the requests handed out to the data centers of a property
+	TrafficReport struct {
+		Metadata ReportMetadata     `json:"metadata"`
+		DataRows []TrafficReportRow `json:"dataRows"`
+	}
+
+	// TrafficReportRow contains the requests handed out during one interval
+	TrafficReportRow struct {
+		Timestamp   string                  `json:"timestamp"`
+		Datacenters []TrafficDatacenterData `json:"datacenters"`
+	}
+
+	// TrafficDatacenterData is the number of requests handed out to one data center
+	TrafficDatacenterData struct {
+		DatacenterID      int    `json:"datacenterId"`
+		Nickname          string `json:"nickname"`
+		TrafficTargetName string `json:"trafficTargetName"`
+		Requests          int64  `json:"requests"`
+		Status            string `json:"status"`
+	}
+
+	// IPAvailabilityReportRequest contains the property and filters of an IP availability report
+	IPAvailabilityReportRequest struct {
+		Domain       string
+		Property     string
+		Window       ReportWindow
+		DatacenterID int
+		IPAddress    string
+		MostRecent   bool
+	}
+
+	// IPAvailabilityReport contains the liveness scores of the servers of a property
+	IPAvailabilityReport struct {
+		Metadata ReportMetadata            `json:"metadata"`
+		DataRows []IPAvailabilityReportRow `json:"dataRows"`
+	}
+
+	// IPAvailabilityReportRow contains the liveness scores at a point in time
+	IPAvailabilityReportRow struct {
+		Timestamp   string                         `json:"timestamp"`
+		CutOff      float64                        `json:"cutOff"`
+		Datacenters []IPAvailabilityDatacenterData `json:"datacenters"`
+	}
+
+	// IPAvailabilityDatacenterData contains the liveness scores of the servers of one data center
+	IPAvailabilityDatacenterData struct {
+		DatacenterID      int         `json:"datacenterId"`
+		Nickname          string      `json:"nickname"`
+		TrafficTargetName string      `json:"trafficTargetName"`
+		IPs               []IPScoring `json:"IPs"`
+	}
+
+	// IPScoring is the liveness score of a single server
+	IPScoring struct {
+		IP        string  `json:"ip"`
+		Score     float64 `json:"score"`
+		HandedOut bool    `json:"handedOut"`
+		Alive     bool    `json:"alive"`
+	}
+
+	reports struct {
+		session.Session
+	}
+)
+
+// newReports returns a Reports client for the given session
+func newReports(sess session.Session) Reports {
+	return &reports{Session: sess}
+}
+
+func (p *reports) GetLivenessTestReport(ctx context.Context, params LivenessTestReportRequest) (*LivenessTestReport, error) {
+	logger := p.Log(ctx)
+	logger.Debug("GetLivenessTestReport")
+
+	if err := validateReportRequest(params.Domain, params.Property, params.Window); err != nil {
+		return nil, fmt.Errorf("GetLivenessTestReport: %w", err)
+	}
+
+	query := params.Window.query()
+	if params.DatacenterID != 0 {
+		query.Set("datacenterId", strconv.Itoa(params.DatacenterID))
+	}
+	if params.AgentIP != "" {
+		query.Set("agentIp", params.AgentIP)
+	}
+	if params.TargetIP != "" {
+		query.Set("targetIp", params.TargetIP)
+	}
+
+	var result LivenessTestReport
+	if err := p.get(ctx, reportPath("liveness-tests", params.Domain, params.Property), query, &result); err != nil {
+		return nil, fmt.Errorf("GetLivenessTestReport request failed: %w", err)
+	}
+	return &result, nil
+}
+
+func (p *reports) GetTrafficReport(ctx context.Context, params TrafficReportRequest) (*TrafficReport, error) {
+	logger := p.Log(ctx)
+	logger.Debug("GetTrafficReport")
+
+	if err := validateReportRequest(params.Domain, params.Property, params.Window); err != nil {
+		return nil, fmt.Errorf("GetTrafficReport: %w", err)
+	}
+
+	var result TrafficReport
+	if err := p.get(ctx, reportPath("traffic", params.Domain, params.Property), params.Window.query(), &result); err != nil {
+		return nil, fmt.Errorf("GetTrafficReport request failed: %w", err)
+	}
+	return &result, nil
+}
+
+func (p *reports) GetIPAvailabilityReport(ctx context.Context, params IPAvailabilityReportRequest) (*IPAvailabilityReport, error) {
+	logger := p.Log(ctx)
+	logger.Debug("GetIPAvailabilityReport")
+
+	if err := validateReportRequest(params.Domain, params.Property, params.Window); err != nil {
+		return nil, fmt.Errorf("GetIPAvailabilityReport: %w", err)
+	}
+
+	query := params.Window.query()
+	if params.DatacenterID != 0 {
+		query.Set("datacenterId", strconv.Itoa(params.DatacenterID))
+	}
+	if params.IPAddress != "" {
+		query.Set("ipAddress", params.IPAddress)
+	}
+	if params.MostRecent {
+		query.Set("mostRecent", "true")
+	}
+
+	var result IPAvailabilityReport
+	if err := p.get(ctx, reportPath("ip-availability", params.Domain, params.Property), query, &result); err != nil {
+		return nil, fmt.Errorf("GetIPAvailabilityReport request failed: %w", err)
+	}
+	return &result, nil
+}
+
+func (p *reports) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	uri := url.URL{Path: path, RawQuery: query.Encode()}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := p.Exec(req, out)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return p.error(resp)
+	}
+	return nil
+}
+
+// error decodes an API problem response into the same error type the edgegrid gtm client returns
+func (p *reports) error(r *http.Response) error {
+	e := &gtm.Error{StatusCode: r.StatusCode}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		e.Title = "Failed to read error body"
+		e.Detail = err.Error()
+		return e
+	}
+	if err := json.Unmarshal(body, e); err != nil {
+		e.Title = "Failed to unmarshal error body"
+		e.Detail = err.Error()
+	}
+	return e
+}
+
+// query returns the window as the start and end query parameters of a report
+func (w ReportWindow) query() url.Values {
+	query := url.Values{}
+	query.Set("start", w.Start.UTC().Format(time.RFC3339))
+	query.Set("end", w.End.UTC().Format(time.RFC3339))
+	return query
+}
+
+func reportPath(report, domain, property string) string {
+	return fmt.Sprintf("/gtm-api/v1/reports/%s/domains/%s/properties/%s", report, url.PathEscape(domain), url.PathEscape(property))
+}
+
+func validateReportRequest(domain, property string, window ReportWindow) error {
+	if domain == "" || property == "" {
+		return fmt.Errorf("%w: domain and property are required", gtm.ErrBadRequest)
+	}
+	if window.Start.IsZero() || window.End.IsZero() {
+		return fmt.Errorf("%w: start and end of the report window are required", gtm.ErrBadRequest)
+	}
+	if !window.End.After(window.Start) {
+		return fmt.Errorf("%w: end of the report window must be after its start", gtm.ErrBadRequest)
+	}
+	return nil
+}
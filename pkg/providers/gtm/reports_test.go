@@ -0,0 +1,109 @@
+package gtm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/gtm"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var reportTestWindow = ReportWindow{
+	Start: time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC),
+	End:   time.Date(2023, 3, 1, 10, 30, 0, 0, time.UTC),
+}
+
+func TestReportsClient(t *testing.T) {
+	newClient := func(t *testing.T, handler http.HandlerFunc) Reports {
+		server := httptest.NewTLSServer(handler)
+		t.Cleanup(server.Close)
+		serverURL, err := url.Parse(server.URL)
+		require.NoError(t, err)
+		sess, err := session.New(
+			session.WithClient(server.Client()),
+			session.WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+		)
+		require.NoError(t, err)
+		return newReports(sess)
+	}
+
+	t.Run("liveness tests", func(t *testing.T) {
+		client := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/gtm-api/v1/reports/liveness-tests/domains/example.akadns.net/properties/www", r.URL.Path)
+			assert.Equal(t, url.Values{
+				"start":        {"2023-03-01T10:00:00Z"},
+				"end":          {"2023-03-01T10:30:00Z"},
+				"datacenterId": {"3131"},
+			}, r.URL.Query())
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"metadata":{"domain":"example.akadns.net","property":"www"},"dataRows":[{"timestamp":"2023-03-01T10:05:00Z","datacenters":[{"datacenterId":3131,"nickname":"dc1","testName":"http","errorCode":3101,"duration":1.5}]}]}`))
+			require.NoError(t, err)
+		})
+		report, err := client.GetLivenessTestReport(context.Background(), LivenessTestReportRequest{
+			Domain:       "example.akadns.net",
+			Property:     "www",
+			Window:       reportTestWindow,
+			DatacenterID: 3131,
+		})
+		require.NoError(t, err)
+		require.Len(t, report.DataRows, 1)
+		assert.Equal(t, 3101, report.DataRows[0].Datacenters[0].ErrorCode)
+		assert.Equal(t, "www", report.Metadata.Property)
+	})
+
+	t.Run("ip availability", func(t *testing.T) {
+		client := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/gtm-api/v1/reports/ip-availability/domains/example.akadns.net/properties/www", r.URL.Path)
+			assert.Equal(t, "true", r.URL.Query().Get("mostRecent"))
+			assert.Equal(t, "1.2.3.4", r.URL.Query().Get("ipAddress"))
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"dataRows":[{"timestamp":"2023-03-01T10:05:00Z","cutOff":112.5,"datacenters":[{"datacenterId":3131,"IPs":[{"ip":"1.2.3.4","score":75,"handedOut":true,"alive":true}]}]}]}`))
+			require.NoError(t, err)
+		})
+		report, err := client.GetIPAvailabilityReport(context.Background(), IPAvailabilityReportRequest{
+			Domain:     "example.akadns.net",
+			Property:   "www",
+			Window:     reportTestWindow,
+			IPAddress:  "1.2.3.4",
+			MostRecent: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 112.5, report.DataRows[0].CutOff)
+		assert.True(t, report.DataRows[0].Datacenters[0].IPs[0].Alive)
+	})
+
+	t.Run("traffic error", func(t *testing.T) {
+		client := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/gtm-api/v1/reports/traffic/domains/example.akadns.net/properties/www", r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+			_, err := w.Write([]byte(`{"title":"Bad Request","detail":"report window exceeds 48 hours"}`))
+			require.NoError(t, err)
+		})
+		_, err := client.GetTrafficReport(context.Background(), TrafficReportRequest{
+			Domain:   "example.akadns.net",
+			Property: "www",
+			Window:   reportTestWindow,
+		})
+		var apiError *gtm.Error
+		require.True(t, errors.As(err, &apiError))
+		assert.Equal(t, http.StatusBadRequest, apiError.StatusCode)
+		assert.Equal(t, "report window exceeds 48 hours", apiError.Detail)
+	})
+
+	t.Run("missing window", func(t *testing.T) {
+		_, err := newReports(session.Must(session.New())).GetTrafficReport(context.Background(), TrafficReportRequest{
+			Domain:   "example.akadns.net",
+			Property: "www",
+		})
+		assert.True(t, errors.Is(err, gtm.ErrBadRequest))
+	})
+}
@@ -0,0 +1,606 @@
+package gtm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/gtm"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// domainCloneSubstitutions replace values of the source domain with the values used in the target domain
+type domainCloneSubstitutions struct {
+	DatacenterNicknames map[string]string
+	ServerIPs           map[string]string
+	HandoutCNames       map[string]string
+}
+
+// domainCloneIgnoredFields are object fields set by the API, which differ between domains without being drift
+var domainCloneIgnoredFields = []string{"links", "lastModified", "cloneOf"}
+
+func resourceGTMv1DomainClone() *schema.Resource {
+	substitutionMap := &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+	return &schema.Resource{
+		CreateContext: resourceGTMv1DomainCloneCreate,
+		ReadContext:   resourceGTMv1DomainCloneRead,
+		UpdateContext: resourceGTMv1DomainCloneUpdate,
+		DeleteContext: resourceGTMv1DomainCloneDelete,
+		CustomizeDiff: markDomainCloneDrift,
+		Schema: map[string]*schema.Schema{
+			"source_domain": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"target_domain": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"substitution": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datacenter_nicknames": substitutionMap,
+						"server_ips":           substitutionMap,
+						"handout_cnames":       substitutionMap,
+					},
+				},
+			},
+			"prune": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"wait_on_complete": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"comment": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"drift": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"extra_objects": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"in_sync": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// markDomainCloneDrift is a CustomizeDiff which plans an update when the last read found drift between the domains
+func markDomainCloneDrift(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+	if inSync, ok := diff.Get("in_sync").(bool); ok && !inSync {
+		if err := diff.SetNewComputed("drift"); err != nil {
+			return err
+		}
+		return diff.SetNewComputed("in_sync")
+	}
+	return nil
+}
+
+// Create clones the source domain into the target domain
+func resourceGTMv1DomainCloneCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("Akamai GTM", "resourceGTMv1DomainCloneCreate")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	if err := submitDomainClone(ctx, d, m, logger); err != nil {
+		logger.Errorf("Domain clone failed: %s", err.Error())
+		return diag.Errorf("domain clone failed: %s", err.Error())
+	}
+	target, err := tools.GetStringValue("target_domain", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(target)
+	return resourceGTMv1DomainCloneRead(ctx, d, m)
+}
+
+// Read compares the source domain, after substitutions, with the target domain
+func resourceGTMv1DomainCloneRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("Akamai GTM", "resourceGTMv1DomainCloneRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	source, err := tools.GetStringValue("source_domain", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	subs, err := expandDomainCloneSubstitutions(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	prune, err := tools.GetBoolValue("prune", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	logger.Debugf("Reading domain clone [%s] of [%s]", d.Id(), source)
+	targetDom, err := inst.Client(meta).GetDomain(ctx, d.Id())
+	if err != nil {
+		var apiError *gtm.Error
+		if errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound && !d.IsNewResource() {
+			logger.Warnf("Target domain [%s] not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		logger.Errorf("Target domain Read failed: %s", err.Error())
+		return diag.Errorf("target domain Read failed: %s", err.Error())
+	}
+	sourceDom, err := inst.Client(meta).GetDomain(ctx, source)
+	if err != nil {
+		logger.Errorf("Source domain Read failed: %s", err.Error())
+		return diag.Errorf("source domain Read failed: %s", err.Error())
+	}
+
+	expected, _, err := buildDomainClone(sourceDom, targetDom, subs)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	drift, extra := domainCloneDrift(expected, targetDom)
+	if prune {
+		drift = append(drift, extra...)
+	}
+	if err := tools.SetAttrs(d, map[string]interface{}{
+		"drift":         drift,
+		"extra_objects": extra,
+		"in_sync":       len(drift) == 0,
+	}); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// Update clones the source domain again
+func resourceGTMv1DomainCloneUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("Akamai GTM", "resourceGTMv1DomainCloneUpdate")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	if err := submitDomainClone(ctx, d, m, logger); err != nil {
+		logger.Errorf("Domain clone failed: %s", err.Error())
+		return diag.Errorf("domain clone failed: %s", err.Error())
+	}
+	return resourceGTMv1DomainCloneRead(ctx, d, m)
+}
+
+// Delete only removes the clone from the state, the target domain and its objects are left in place
+func resourceGTMv1DomainCloneDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("Akamai GTM", "resourceGTMv1DomainCloneDelete")
+	logger.Infof("Removing domain clone [%s] from state, target domain objects are kept", d.Id())
+	d.SetId("")
+	return nil
+}
+
+// submitDomainClone creates the data centers missing in the target domain, then submits the clone of the
+// source domain objects with a single domain update
+func submitDomainClone(ctx context.Context, d *schema.ResourceData, m interface{}, logger log.Interface) error {
+	meta := akamai.Meta(m)
+	client := inst.Client(meta)
+
+	source, err := tools.GetStringValue("source_domain", d)
+	if err != nil {
+		return err
+	}
+	target, err := tools.GetStringValue("target_domain", d)
+	if err != nil {
+		return err
+	}
+	if source == target {
+		return fmt.Errorf("source and target domain must differ")
+	}
+	subs, err := expandDomainCloneSubstitutions(d)
+	if err != nil {
+		return err
+	}
+	prune, err := tools.GetBoolValue("prune", d)
+	if err != nil {
+		return err
+	}
+
+	sourceDom, err := client.GetDomain(ctx, source)
+	if err != nil {
+		return fmt.Errorf("reading source domain: %w", err)
+	}
+	targetDom, err := client.GetDomain(ctx, target)
+	if err != nil {
+		return fmt.Errorf("reading target domain: %w", err)
+	}
+	if !strings.EqualFold(sourceDom.Type, targetDom.Type) {
+		return fmt.Errorf("source domain type %s differs from target domain type %s", sourceDom.Type, targetDom.Type)
+	}
+
+	_, missing, err := buildDomainClone(sourceDom, targetDom, subs)
+	if err != nil {
+		return err
+	}
+	for _, dc := range missing {
+		logger.Infof("Creating data center [%s] in domain [%s]", dc.Nickname, target)
+		resp, err := client.CreateDatacenter(ctx, dc, target)
+		if err != nil {
+			return fmt.Errorf("creating data center %s: %w", dc.Nickname, err)
+		}
+		if resp.Status != nil && resp.Status.PropagationStatus == "DENIED" {
+			return fmt.Errorf("creating data center %s: %s", dc.Nickname, resp.Status.Message)
+		}
+		targetDom.Datacenters = append(targetDom.Datacenters, resp.Resource)
+	}
+
+	expected, missing, err := buildDomainClone(sourceDom, targetDom, subs)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("data center %s is still missing in the target domain", missing[0].Nickname)
+	}
+
+	for _, kind := range domainUnitKinds {
+		removed := make(map[string]bool)
+		if prune {
+			for _, obj := range kind.objects(targetDom) {
+				removed[kind.keyOf(obj)] = true
+			}
+		}
+		staged, err := stageDomainUnitObjects(kind.objects(targetDom), kind.objects(expected), removed, kind.keyOf)
+		if err != nil {
+			return fmt.Errorf("%s: %w", kind.block, err)
+		}
+		kind.store(targetDom, staged)
+	}
+	comment, err := tools.GetStringValue("comment", d)
+	if err != nil {
+		comment = fmt.Sprintf("Cloned from %s", source)
+	}
+	targetDom.ModificationComments = comment
+
+	logger.Debugf("Updating Domain PROPOSED: %v", targetDom)
+	uStat, err := client.UpdateDomain(ctx, targetDom, map[string]string{})
+	if err != nil {
+		return err
+	}
+	logger.Debugf("Update status: %v", uStat)
+	if uStat.PropagationStatus == "DENIED" {
+		return fmt.Errorf(uStat.Message)
+	}
+
+	waitOnComplete, err := tools.GetBoolValue("wait_on_complete", d)
+	if err != nil {
+		return err
+	}
+	if waitOnComplete {
+		done, err := waitForCompletion(ctx, target, m)
+		if err != nil {
+			return err
+		}
+		if done {
+			logger.Infof("Domain clone completed")
+		} else {
+			logger.Infof("Domain clone pending")
+		}
+	}
+	return nil
+}
+
+// buildDomainClone returns the objects of the source domain as they should be in the target domain. Data centers
+// are matched by their substituted nickname; the ones missing in the target domain are returned without an id and
+// the objects referring to them keep a data center id of 0.
+func buildDomainClone(source, target *gtm.Domain, subs domainCloneSubstitutions) (*gtm.Domain, []*gtm.Datacenter, error) {
+	clone, err := deepCopyDomainObjects(source)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	targetIDs := make(map[string]int, len(target.Datacenters))
+	for _, dc := range target.Datacenters {
+		targetIDs[dc.Nickname] = dc.DatacenterId
+	}
+	ids := make(map[int]int, len(clone.Datacenters))
+	nicknames := make(map[int]string, len(clone.Datacenters))
+	var missing []*gtm.Datacenter
+	datacenters := make([]*gtm.Datacenter, 0, len(clone.Datacenters))
+	for _, dc := range clone.Datacenters {
+		sourceID := dc.DatacenterId
+		dc.Nickname = substitute(subs.DatacenterNicknames, dc.Nickname)
+		dc.Links = nil
+		dc.CloneOf = 0
+		nicknames[sourceID] = dc.Nickname
+		if isDefaultDatacenter(sourceID) {
+			ids[sourceID] = sourceID
+			datacenters = append(datacenters, dc)
+			continue
+		}
+		id, ok := targetIDs[dc.Nickname]
+		if !ok {
+			dc.DatacenterId = 0
+			missing = append(missing, dc)
+			continue
+		}
+		ids[sourceID] = id
+		dc.DatacenterId = id
+		datacenters = append(datacenters, dc)
+	}
+	clone.Datacenters = datacenters
+
+	remapID := func(what string, id int) (int, error) {
+		if isDefaultDatacenter(id) {
+			return id, nil
+		}
+		if _, ok := nicknames[id]; !ok {
+			return 0, fmt.Errorf("%s refers to data center %d, which is not in the source domain", what, id)
+		}
+		return ids[id], nil
+	}
+	remap := func(what string, base *gtm.DatacenterBase) error {
+		if base == nil {
+			return nil
+		}
+		if nickname, ok := nicknames[base.DatacenterId]; ok {
+			base.Nickname = nickname
+		} else {
+			base.Nickname = substitute(subs.DatacenterNicknames, base.Nickname)
+		}
+		id, err := remapID(what, base.DatacenterId)
+		base.DatacenterId = id
+		return err
+	}
+	for _, prop := range clone.Properties {
+		prop.Links = nil
+		for _, tt := range prop.TrafficTargets {
+			if tt.DatacenterId, err = remapID(fmt.Sprintf("property %s", prop.Name), tt.DatacenterId); err != nil {
+				return nil, nil, err
+			}
+			tt.Servers = substituteAll(subs.ServerIPs, tt.Servers)
+			tt.HandoutCName = substitute(subs.HandoutCNames, tt.HandoutCName)
+		}
+		for _, set := range prop.StaticRRSets {
+			set.Rdata = substituteAll(subs.ServerIPs, set.Rdata)
+		}
+	}
+	for _, rsrc := range clone.Resources {
+		rsrc.Links = nil
+		for _, instance := range rsrc.ResourceInstances {
+			if instance.DatacenterId, err = remapID(fmt.Sprintf("resource %s", rsrc.Name), instance.DatacenterId); err != nil {
+				return nil, nil, err
+			}
+			instance.LoadServers = substituteAll(subs.ServerIPs, instance.LoadServers)
+		}
+	}
+	for _, geo := range clone.GeographicMaps {
+		geo.Links = nil
+		if err := remap(fmt.Sprintf("geographic map %s", geo.Name), geo.DefaultDatacenter); err != nil {
+			return nil, nil, err
+		}
+		for _, a := range geo.Assignments {
+			if err := remap(fmt.Sprintf("geographic map %s", geo.Name), &a.DatacenterBase); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	for _, as := range clone.AsMaps {
+		as.Links = nil
+		if err := remap(fmt.Sprintf("AS map %s", as.Name), as.DefaultDatacenter); err != nil {
+			return nil, nil, err
+		}
+		for _, a := range as.Assignments {
+			if err := remap(fmt.Sprintf("AS map %s", as.Name), &a.DatacenterBase); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	for _, cidr := range clone.CidrMaps {
+		cidr.Links = nil
+		if err := remap(fmt.Sprintf("CIDR map %s", cidr.Name), cidr.DefaultDatacenter); err != nil {
+			return nil, nil, err
+		}
+		for _, a := range cidr.Assignments {
+			if err := remap(fmt.Sprintf("CIDR map %s", cidr.Name), &a.DatacenterBase); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return clone, missing, nil
+}
+
+// domainCloneDrift lists the objects of the expected clone missing or different in the target domain, and the
+// objects of the target domain which are not in the clone. Data centers are compared by nickname, other objects
+// by name.
+func domainCloneDrift(expected, target *gtm.Domain) ([]string, []string) {
+	drift := make([]string, 0)
+	extra := make([]string, 0)
+	for _, kind := range domainUnitKinds {
+		keyOf := kind.keyOf
+		if kind.block == "datacenter" {
+			keyOf = func(obj interface{}) string { return obj.(*gtm.Datacenter).Nickname }
+		}
+		targetObjects := make(map[string]interface{})
+		for _, obj := range kind.objects(target) {
+			targetObjects[keyOf(obj)] = obj
+		}
+		expectedKeys := make(map[string]bool)
+		for _, obj := range kind.objects(expected) {
+			key := keyOf(obj)
+			expectedKeys[key] = true
+			actual, ok := targetObjects[key]
+			if !ok {
+				drift = append(drift, fmt.Sprintf("%s %s: missing", kind.block, key))
+				continue
+			}
+			if fields := domainCloneChangedFields(obj, actual); len(fields) > 0 {
+				drift = append(drift, fmt.Sprintf("%s %s: %s differ", kind.block, key, strings.Join(fields, ", ")))
+			}
+		}
+		for _, obj := range kind.objects(target) {
+			if key := keyOf(obj); !expectedKeys[key] {
+				extra = append(extra, fmt.Sprintf("%s %s: not in source domain", kind.block, key))
+			}
+		}
+	}
+	return drift, extra
+}
+
+// domainCloneChangedFields returns the sorted JSON fields which differ between two objects
+func domainCloneChangedFields(expected, actual interface{}) []string {
+	expectedFields, err := domainCloneFields(expected)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	actualFields, err := domainCloneFields(actual)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	var changed []string
+	for field, value := range expectedFields {
+		if !reflect.DeepEqual(value, actualFields[field]) {
+			changed = append(changed, field)
+		}
+	}
+	for field := range actualFields {
+		if _, ok := expectedFields[field]; !ok {
+			changed = append(changed, field)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func domainCloneFields(obj interface{}) (map[string]interface{}, error) {
+	body, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, err
+	}
+	for _, field := range domainCloneIgnoredFields {
+		delete(fields, field)
+	}
+	if _, ok := obj.(*gtm.Datacenter); ok {
+		delete(fields, "datacenterId")
+	}
+	return fields, nil
+}
+
+// deepCopyDomainObjects returns a domain holding copies of the objects of a domain, so substitutions do not
+// change the domain read
+func deepCopyDomainObjects(from *gtm.Domain) (*gtm.Domain, error) {
+	type domainObjects struct {
+		Datacenters    []*gtm.Datacenter `json:"datacenters"`
+		Properties     []*gtm.Property   `json:"properties"`
+		Resources      []*gtm.Resource   `json:"resources"`
+		GeographicMaps []*gtm.GeoMap     `json:"geographicMaps"`
+		AsMaps         []*gtm.AsMap      `json:"asMaps"`
+		CidrMaps       []*gtm.CidrMap    `json:"cidrMaps"`
+	}
+	body, err := json.Marshal(domainObjects{from.Datacenters, from.Properties, from.Resources, from.GeographicMaps, from.AsMaps, from.CidrMaps})
+	if err != nil {
+		return nil, err
+	}
+	var objects domainObjects
+	if err := json.Unmarshal(body, &objects); err != nil {
+		return nil, err
+	}
+	return &gtm.Domain{
+		Datacenters:    objects.Datacenters,
+		Properties:     objects.Properties,
+		Resources:      objects.Resources,
+		GeographicMaps: objects.GeographicMaps,
+		AsMaps:         objects.AsMaps,
+		CidrMaps:       objects.CidrMaps,
+	}, nil
+}
+
+func expandDomainCloneSubstitutions(d *schema.ResourceData) (domainCloneSubstitutions, error) {
+	subs := domainCloneSubstitutions{}
+	list, err := tools.GetListValue("substitution", d)
+	if err != nil {
+		if errors.Is(err, tools.ErrNotFound) {
+			return subs, nil
+		}
+		return subs, err
+	}
+	if len(list) == 0 || list[0] == nil {
+		return subs, nil
+	}
+	block := list[0].(map[string]interface{})
+	subs.DatacenterNicknames = expandStringMap(block["datacenter_nicknames"])
+	subs.ServerIPs = expandStringMap(block["server_ips"])
+	subs.HandoutCNames = expandStringMap(block["handout_cnames"])
+	return subs, nil
+}
+
+func expandStringMap(value interface{}) map[string]string {
+	result := make(map[string]string)
+	m, _ := value.(map[string]interface{})
+	for k, v := range m {
+		result[k], _ = v.(string)
+	}
+	return result
+}
+
+// substitute returns the replacement of a value, or the value when it has none
+func substitute(replacements map[string]string, value string) string {
+	if replacement, ok := replacements[value]; ok {
+		return replacement
+	}
+	return value
+}
+
+func substituteAll(replacements map[string]string, values []string) []string {
+	if values == nil {
+		return nil
+	}
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		result = append(result, substitute(replacements, v))
+	}
+	return result
+}
+
+func isDefaultDatacenter(id int) bool {
+	return id == gtm.MapDefaultDC || id == gtm.Ipv4DefaultDC || id == gtm.Ipv6DefaultDC
+}
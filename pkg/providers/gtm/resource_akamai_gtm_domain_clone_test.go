@@ -0,0 +1,193 @@
+package gtm
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/gtm"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const cloneTargetDomain = "gtm_terra_testdomain_prod.akadns.net"
+
+func cloneSourceDomain() *gtm.Domain {
+	return &gtm.Domain{
+		Name: gtmTestDomain,
+		Type: "weighted",
+		Datacenters: []*gtm.Datacenter{
+			{DatacenterId: 3131, Nickname: "stage_ams", City: "Amsterdam"},
+			{DatacenterId: 3132, Nickname: "stage_fra", City: "Frankfurt"},
+			{DatacenterId: gtm.MapDefaultDC, Nickname: "Default Datacenter"},
+		},
+		Properties: []*gtm.Property{
+			{
+				Name:                 "www",
+				Type:                 "weighted-round-robin",
+				ScoreAggregationType: "mean",
+				HandoutLimit:         8,
+				HandoutMode:          "normal",
+				TrafficTargets: []*gtm.TrafficTarget{
+					{DatacenterId: 3131, Enabled: true, Weight: 50, Servers: []string{"10.0.0.1"}, HandoutCName: "ams.stage.example.com"},
+					{DatacenterId: 3132, Enabled: true, Weight: 50, Servers: []string{"10.0.1.1"}},
+				},
+				LastModified: "2023-03-01T10:00:00.000+00:00",
+				Links:        []*gtm.Link{{Rel: "self", Href: "https://example.com/www"}},
+			},
+		},
+		GeographicMaps: []*gtm.GeoMap{
+			{
+				Name:              "geo",
+				DefaultDatacenter: &gtm.DatacenterBase{DatacenterId: gtm.MapDefaultDC, Nickname: "Default Datacenter"},
+				Assignments: []*gtm.GeoAssignment{
+					{DatacenterBase: gtm.DatacenterBase{DatacenterId: 3132, Nickname: "stage_fra"}, Countries: []string{"DE"}},
+				},
+			},
+		},
+	}
+}
+
+func cloneTargetDomainObject() *gtm.Domain {
+	return &gtm.Domain{
+		Name: cloneTargetDomain,
+		Type: "weighted",
+		Datacenters: []*gtm.Datacenter{
+			{DatacenterId: 4131, Nickname: "prod_ams", City: "Amsterdam"},
+			{DatacenterId: gtm.MapDefaultDC, Nickname: "Default Datacenter"},
+		},
+		Properties: []*gtm.Property{
+			{Name: "legacy", Type: "failover"},
+		},
+	}
+}
+
+var cloneSubstitutions = domainCloneSubstitutions{
+	DatacenterNicknames: map[string]string{"stage_ams": "prod_ams", "stage_fra": "prod_fra"},
+	ServerIPs:           map[string]string{"10.0.0.1": "192.0.2.1", "10.0.1.1": "192.0.2.2"},
+	HandoutCNames:       map[string]string{"ams.stage.example.com": "ams.example.com"},
+}
+
+func TestBuildDomainClone(t *testing.T) {
+	source := cloneSourceDomain()
+	clone, missing, err := buildDomainClone(source, cloneTargetDomainObject(), cloneSubstitutions)
+	require.NoError(t, err)
+
+	require.Len(t, missing, 1)
+	assert.Equal(t, "prod_fra", missing[0].Nickname)
+	assert.Equal(t, 0, missing[0].DatacenterId)
+	assert.Equal(t, []*gtm.Datacenter{
+		{DatacenterId: 4131, Nickname: "prod_ams", City: "Amsterdam"},
+		{DatacenterId: gtm.MapDefaultDC, Nickname: "Default Datacenter"},
+	}, clone.Datacenters)
+
+	require.Len(t, clone.Properties, 1)
+	assert.Nil(t, clone.Properties[0].Links)
+	assert.Equal(t, []*gtm.TrafficTarget{
+		{DatacenterId: 4131, Enabled: true, Weight: 50, Servers: []string{"192.0.2.1"}, HandoutCName: "ams.example.com"},
+		{DatacenterId: 0, Enabled: true, Weight: 50, Servers: []string{"192.0.2.2"}},
+	}, clone.Properties[0].TrafficTargets)
+	assert.Equal(t, gtm.DatacenterBase{DatacenterId: 0, Nickname: "prod_fra"}, clone.GeographicMaps[0].Assignments[0].DatacenterBase)
+	assert.Equal(t, gtm.MapDefaultDC, clone.GeographicMaps[0].DefaultDatacenter.DatacenterId)
+
+	// the source domain is left unchanged
+	assert.Equal(t, "10.0.0.1", source.Properties[0].TrafficTargets[0].Servers[0])
+	assert.Equal(t, "stage_ams", source.Datacenters[0].Nickname)
+
+	target := cloneTargetDomainObject()
+	target.Datacenters = append(target.Datacenters, &gtm.Datacenter{DatacenterId: 4132, Nickname: "prod_fra"})
+	clone, missing, err = buildDomainClone(source, target, cloneSubstitutions)
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+	assert.Equal(t, 4132, clone.Properties[0].TrafficTargets[1].DatacenterId)
+	assert.Equal(t, 4132, clone.GeographicMaps[0].Assignments[0].DatacenterId)
+
+	source.Properties[0].TrafficTargets[0].DatacenterId = 3999
+	_, _, err = buildDomainClone(source, target, cloneSubstitutions)
+	require.Error(t, err)
+	assert.Regexp(t, regexp.MustCompile(`property www refers to data center 3999, which is not in the source domain`), err.Error())
+}
+
+func TestDomainCloneDrift(t *testing.T) {
+	target := cloneTargetDomainObject()
+	target.Datacenters = append(target.Datacenters, &gtm.Datacenter{DatacenterId: 4132, Nickname: "prod_fra", City: "Frankfurt"})
+	expected, _, err := buildDomainClone(cloneSourceDomain(), target, cloneSubstitutions)
+	require.NoError(t, err)
+
+	drift, extra := domainCloneDrift(expected, target)
+	assert.Equal(t, []string{"property www: missing", "geographic_map geo: missing"}, drift)
+	assert.Equal(t, []string{"property legacy: not in source domain"}, extra)
+
+	synced, _, err := buildDomainClone(cloneSourceDomain(), target, cloneSubstitutions)
+	require.NoError(t, err)
+	synced.Properties[0].LastModified = "2023-03-02T10:00:00.000+00:00"
+	synced.Properties[0].Links = []*gtm.Link{{Rel: "self", Href: "https://example.com/prod/www"}}
+	synced.Properties[0].TrafficTargets[0].Weight = 100
+	target.Properties = append(target.Properties, synced.Properties[0])
+	target.GeographicMaps = synced.GeographicMaps
+
+	drift, _ = domainCloneDrift(expected, target)
+	assert.Equal(t, []string{"property www: trafficTargets differ"}, drift)
+
+	synced.Properties[0].TrafficTargets[0].Weight = 50
+	drift, _ = domainCloneDrift(expected, target)
+	assert.Empty(t, drift)
+}
+
+func TestResGtmDomainClone(t *testing.T) {
+	client := &gtm.Mock{}
+
+	source := cloneSourceDomain()
+	target := cloneTargetDomainObject()
+	client.On("GetDomain",
+		mock.Anything, // ctx is irrelevant for this test
+		gtmTestDomain,
+	).Return(source, nil)
+	client.On("GetDomain",
+		mock.Anything, // ctx is irrelevant for this test
+		cloneTargetDomain,
+	).Return(target, nil)
+
+	client.On("CreateDatacenter",
+		mock.Anything, // ctx is irrelevant for this test
+		mock.MatchedBy(func(dc *gtm.Datacenter) bool { return dc.Nickname == "prod_fra" && dc.DatacenterId == 0 }),
+		cloneTargetDomain,
+	).Return(&gtm.DatacenterResponse{
+		Resource: &gtm.Datacenter{DatacenterId: 4132, Nickname: "prod_fra", City: "Frankfurt"},
+		Status:   &completeResponseStatus,
+	}, nil).Once()
+
+	client.On("UpdateDomain",
+		mock.Anything, // ctx is irrelevant for this test
+		mock.AnythingOfType("*gtm.Domain"),
+		map[string]string{},
+	).Run(func(args mock.Arguments) {
+		updated := args.Get(1).(*gtm.Domain)
+		*target = *updated
+	}).Return(&completeResponseStatus, nil).Once()
+
+	useClient(client, func() {
+		resource.UnitTest(t, resource.TestCase{
+			ProviderFactories: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: loadFixtureString("testdata/TestResGtmDomainClone/create.tf"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_gtm_domain_clone.prod", "id", cloneTargetDomain),
+						resource.TestCheckResourceAttr("akamai_gtm_domain_clone.prod", "in_sync", "true"),
+						resource.TestCheckResourceAttr("akamai_gtm_domain_clone.prod", "drift.#", "0"),
+						resource.TestCheckResourceAttr("akamai_gtm_domain_clone.prod", "extra_objects.#", "1"),
+						resource.TestCheckResourceAttr("akamai_gtm_domain_clone.prod", "extra_objects.0", "property legacy: not in source domain"),
+					),
+				},
+			},
+		})
+	})
+
+	client.AssertExpectations(t)
+	require.Len(t, target.Properties, 2)
+	assert.Equal(t, "legacy", target.Properties[0].Name)
+	assert.Equal(t, []string{"192.0.2.1"}, target.Properties[1].TrafficTargets[0].Servers)
+	assert.Equal(t, 4132, target.Properties[1].TrafficTargets[1].DatacenterId)
+}
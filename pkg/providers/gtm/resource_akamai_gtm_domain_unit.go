@@ -0,0 +1,597 @@
+package gtm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/gtm"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// domainUnitKind describes how objects of one type are staged in a domain by akamai_gtm_domain_unit.
+// Each block reuses the schema and the populate functions of the standalone resource of that type.
+type domainUnitKind struct {
+	// block is the attribute of akamai_gtm_domain_unit holding the objects
+	block string
+	// key is the attribute identifying an object within the domain
+	key string
+	// resource returns the standalone resource the block schema is derived from
+	resource func() *schema.Resource
+	// expand creates a GTM object from the resource data of one block
+	expand func(context.Context, akamai.OperationMeta, *schema.ResourceData, interface{}) (interface{}, error)
+	// flatten writes a GTM object to the resource data of one block
+	flatten func(*schema.ResourceData, interface{}, interface{})
+	// keyOf returns the identifying key of a GTM object
+	keyOf func(interface{}) string
+	// objects returns the objects of this type in the domain
+	objects func(*gtm.Domain) []interface{}
+	// store replaces the objects of this type in the domain
+	store func(*gtm.Domain, []interface{})
+}
+
+var (
+	datacenterUnitKind = domainUnitKind{
+		block:    "datacenter",
+		key:      "datacenter_id",
+		resource: resourceGTMv1Datacenter,
+		expand: func(ctx context.Context, meta akamai.OperationMeta, d *schema.ResourceData, m interface{}) (interface{}, error) {
+			return populateNewDatacenterObject(ctx, meta, d, m)
+		},
+		flatten: func(d *schema.ResourceData, obj interface{}, m interface{}) {
+			populateTerraformDCState(d, obj.(*gtm.Datacenter), m)
+		},
+		keyOf: func(obj interface{}) string {
+			return strconv.Itoa(obj.(*gtm.Datacenter).DatacenterId)
+		},
+		objects: func(dom *gtm.Domain) []interface{} {
+			objs := make([]interface{}, 0, len(dom.Datacenters))
+			for _, dc := range dom.Datacenters {
+				objs = append(objs, dc)
+			}
+			return objs
+		},
+		store: func(dom *gtm.Domain, objs []interface{}) {
+			dom.Datacenters = make([]*gtm.Datacenter, 0, len(objs))
+			for _, obj := range objs {
+				dom.Datacenters = append(dom.Datacenters, obj.(*gtm.Datacenter))
+			}
+		},
+	}
+	propertyUnitKind = domainUnitKind{
+		block:    "property",
+		key:      "name",
+		resource: resourceGTMv1Property,
+		expand: func(ctx context.Context, meta akamai.OperationMeta, d *schema.ResourceData, m interface{}) (interface{}, error) {
+			return populateNewPropertyObject(ctx, meta, d, m)
+		},
+		flatten: func(d *schema.ResourceData, obj interface{}, m interface{}) {
+			populateTerraformPropertyState(d, obj.(*gtm.Property), m)
+		},
+		keyOf: func(obj interface{}) string {
+			return obj.(*gtm.Property).Name
+		},
+		objects: func(dom *gtm.Domain) []interface{} {
+			objs := make([]interface{}, 0, len(dom.Properties))
+			for _, prop := range dom.Properties {
+				objs = append(objs, prop)
+			}
+			return objs
+		},
+		store: func(dom *gtm.Domain, objs []interface{}) {
+			dom.Properties = make([]*gtm.Property, 0, len(objs))
+			for _, obj := range objs {
+				dom.Properties = append(dom.Properties, obj.(*gtm.Property))
+			}
+		},
+	}
+	resourceUnitKind = domainUnitKind{
+		block:    "resource",
+		key:      "name",
+		resource: resourceGTMv1Resource,
+		expand: func(ctx context.Context, meta akamai.OperationMeta, d *schema.ResourceData, m interface{}) (interface{}, error) {
+			return populateNewResourceObject(ctx, meta, d, m)
+		},
+		flatten: func(d *schema.ResourceData, obj interface{}, m interface{}) {
+			populateTerraformResourceState(d, obj.(*gtm.Resource), m)
+		},
+		keyOf: func(obj interface{}) string {
+			return obj.(*gtm.Resource).Name
+		},
+		objects: func(dom *gtm.Domain) []interface{} {
+			objs := make([]interface{}, 0, len(dom.Resources))
+			for _, rsrc := range dom.Resources {
+				objs = append(objs, rsrc)
+			}
+			return objs
+		},
+		store: func(dom *gtm.Domain, objs []interface{}) {
+			dom.Resources = make([]*gtm.Resource, 0, len(objs))
+			for _, obj := range objs {
+				dom.Resources = append(dom.Resources, obj.(*gtm.Resource))
+			}
+		},
+	}
+	geographicMapUnitKind = domainUnitKind{
+		block:    "geographic_map",
+		key:      "name",
+		resource: resourceGTMv1Geomap,
+		expand: func(ctx context.Context, meta akamai.OperationMeta, d *schema.ResourceData, m interface{}) (interface{}, error) {
+			return populateNewGeoMapObject(ctx, meta, d, m), nil
+		},
+		flatten: func(d *schema.ResourceData, obj interface{}, m interface{}) {
+			populateTerraformGeoMapState(d, obj.(*gtm.GeoMap), m)
+		},
+		keyOf: func(obj interface{}) string {
+			return obj.(*gtm.GeoMap).Name
+		},
+		objects: func(dom *gtm.Domain) []interface{} {
+			objs := make([]interface{}, 0, len(dom.GeographicMaps))
+			for _, geo := range dom.GeographicMaps {
+				objs = append(objs, geo)
+			}
+			return objs
+		},
+		store: func(dom *gtm.Domain, objs []interface{}) {
+			dom.GeographicMaps = make([]*gtm.GeoMap, 0, len(objs))
+			for _, obj := range objs {
+				dom.GeographicMaps = append(dom.GeographicMaps, obj.(*gtm.GeoMap))
+			}
+		},
+	}
+	asMapUnitKind = domainUnitKind{
+		block:    "as_map",
+		key:      "name",
+		resource: resourceGTMv1ASmap,
+		expand: func(ctx context.Context, meta akamai.OperationMeta, d *schema.ResourceData, m interface{}) (interface{}, error) {
+			return populateNewASmapObject(ctx, meta, d, m), nil
+		},
+		flatten: func(d *schema.ResourceData, obj interface{}, m interface{}) {
+			populateTerraformASmapState(d, obj.(*gtm.AsMap), m)
+		},
+		keyOf: func(obj interface{}) string {
+			return obj.(*gtm.AsMap).Name
+		},
+		objects: func(dom *gtm.Domain) []interface{} {
+			objs := make([]interface{}, 0, len(dom.AsMaps))
+			for _, as := range dom.AsMaps {
+				objs = append(objs, as)
+			}
+			return objs
+		},
+		store: func(dom *gtm.Domain, objs []interface{}) {
+			dom.AsMaps = make([]*gtm.AsMap, 0, len(objs))
+			for _, obj := range objs {
+				dom.AsMaps = append(dom.AsMaps, obj.(*gtm.AsMap))
+			}
+		},
+	}
+	cidrMapUnitKind = domainUnitKind{
+		block:    "cidr_map",
+		key:      "name",
+		resource: resourceGTMv1Cidrmap,
+		expand: func(ctx context.Context, meta akamai.OperationMeta, d *schema.ResourceData, m interface{}) (interface{}, error) {
+			return populateNewCidrMapObject(ctx, meta, d, m), nil
+		},
+		flatten: func(d *schema.ResourceData, obj interface{}, m interface{}) {
+			populateTerraformCidrMapState(d, obj.(*gtm.CidrMap), m)
+		},
+		keyOf: func(obj interface{}) string {
+			return obj.(*gtm.CidrMap).Name
+		},
+		objects: func(dom *gtm.Domain) []interface{} {
+			objs := make([]interface{}, 0, len(dom.CidrMaps))
+			for _, cidr := range dom.CidrMaps {
+				objs = append(objs, cidr)
+			}
+			return objs
+		},
+		store: func(dom *gtm.Domain, objs []interface{}) {
+			dom.CidrMaps = make([]*gtm.CidrMap, 0, len(objs))
+			for _, obj := range objs {
+				dom.CidrMaps = append(dom.CidrMaps, obj.(*gtm.CidrMap))
+			}
+		},
+	}
+
+	// domainUnitKinds lists the kinds of objects staged by akamai_gtm_domain_unit
+	domainUnitKinds = []domainUnitKind{
+		datacenterUnitKind,
+		propertyUnitKind,
+		resourceUnitKind,
+		geographicMapUnitKind,
+		asMapUnitKind,
+		cidrMapUnitKind,
+	}
+)
+
+func resourceGTMv1DomainUnit() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"domain": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"contract": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          "",
+			DiffSuppressFunc: tools.FieldPrefixSuppress("ctr_"),
+		},
+		"group": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          "",
+			DiffSuppressFunc: tools.FieldPrefixSuppress("grp_"),
+		},
+		"wait_on_complete": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"comment": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "Managed by Terraform",
+		},
+	}
+	for _, kind := range domainUnitKinds {
+		s[kind.block] = &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     &schema.Resource{Schema: domainUnitElemSchema(kind)},
+		}
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceGTMv1DomainUnitCreate,
+		ReadContext:   resourceGTMv1DomainUnitRead,
+		UpdateContext: resourceGTMv1DomainUnitUpdate,
+		DeleteContext: resourceGTMv1DomainUnitDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGTMv1DomainUnitImport,
+		},
+		Schema: s,
+	}
+}
+
+// domainUnitElemSchema derives the block schema of a kind from its standalone resource. The domain is given by
+// the unit, the identifying key is required, and diff suppress functions of nested lists are dropped
+// as they read the top level attributes of the standalone resource.
+func domainUnitElemSchema(kind domainUnitKind) map[string]*schema.Schema {
+	elem := make(map[string]*schema.Schema)
+	for name, attr := range kind.resource().Schema {
+		if name == "domain" || name == "wait_on_complete" {
+			continue
+		}
+		a := *attr
+		if name == kind.key {
+			a.Required, a.Optional, a.Computed = true, false, false
+		}
+		if a.Type == schema.TypeList || a.Type == schema.TypeSet {
+			a.DiffSuppressFunc = nil
+		}
+		elem[name] = &a
+	}
+	return elem
+}
+
+// Create GTM domain unit
+func resourceGTMv1DomainUnitCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("Akamai GTM", "resourceGTMv1DomainUnitCreate")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	domain, err := tools.GetStringValue("domain", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Infof("Creating domain unit [%s]", domain)
+	if err := submitDomainUnit(ctx, d, m, false); err != nil {
+		logger.Errorf("Domain Unit Create failed: %s", err.Error())
+		return diag.Errorf("domain unit Create failed: %s", err.Error())
+	}
+
+	d.SetId(domain)
+	return resourceGTMv1DomainUnitRead(ctx, d, m)
+}
+
+// Read GTM domain unit. Only objects staged by the unit are read; objects removed outside of terraform
+// disappear from the state.
+func resourceGTMv1DomainUnitRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("Akamai GTM", "resourceGTMv1DomainUnitRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	logger.Debugf("Reading Domain Unit: %s", d.Id())
+	dom, err := inst.Client(meta).GetDomain(ctx, d.Id())
+	if err != nil {
+		logger.Errorf("Domain Unit Read failed: %s", err.Error())
+		return diag.Errorf("domain unit Read failed: %s", err.Error())
+	}
+	if err := populateTerraformDomainUnitState(d, dom, m, false); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// Update GTM domain unit
+func resourceGTMv1DomainUnitUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("Akamai GTM", "resourceGTMv1DomainUnitUpdate")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	logger.Debugf("Updating Domain Unit: %s", d.Id())
+	if err := submitDomainUnit(ctx, d, m, false); err != nil {
+		logger.Errorf("Domain Unit Update failed: %s", err.Error())
+		return diag.Errorf("domain unit Update failed: %s", err.Error())
+	}
+	return resourceGTMv1DomainUnitRead(ctx, d, m)
+}
+
+// Delete GTM domain unit. All objects staged by the unit are removed from the domain with a single update;
+// the domain itself is kept.
+func resourceGTMv1DomainUnitDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("Akamai GTM", "resourceGTMv1DomainUnitDelete")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	logger.Debugf("Deleting Domain Unit: %s", d.Id())
+	if err := submitDomainUnit(ctx, d, m, true); err != nil {
+		logger.Errorf("Domain Unit Delete failed: %s", err.Error())
+		return diag.Errorf("domain unit Delete failed: %s", err.Error())
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Import GTM domain unit. All objects of the domain are imported.
+func resourceGTMv1DomainUnitImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	meta := akamai.Meta(m)
+	logger := meta.Log("Akamai GTM", "resourceGTMv1DomainUnitImport")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	logger.Infof("Domain Unit [%s] Import", d.Id())
+	dom, err := inst.Client(meta).GetDomain(ctx, d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if err := tools.SetAttrs(d, map[string]interface{}{
+		"domain":           dom.Name,
+		"wait_on_complete": true,
+		"comment":          "Managed by Terraform",
+	}); err != nil {
+		return nil, err
+	}
+	if err := populateTerraformDomainUnitState(d, dom, m, true); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// submitDomainUnit stages the configured objects in the domain, removes the objects no longer configured
+// (or all of them, when deleting) and submits the whole domain with a single update, waiting once for propagation.
+func submitDomainUnit(ctx context.Context, d *schema.ResourceData, m interface{}, deleting bool) error {
+	meta := akamai.Meta(m)
+	logger := meta.Log("Akamai GTM", "submitDomainUnit")
+
+	domain, err := tools.GetStringValue("domain", d)
+	if err != nil {
+		return err
+	}
+	dom, err := inst.Client(meta).GetDomain(ctx, domain)
+	if err != nil {
+		return err
+	}
+
+	for _, kind := range domainUnitKinds {
+		oldBlocks, newBlocks := d.GetChange(kind.block)
+		if deleting {
+			newBlocks = []interface{}{}
+		}
+		desired := make([]interface{}, 0)
+		for _, block := range newBlocks.([]interface{}) {
+			obj, err := expandDomainUnitBlock(ctx, meta, kind, block.(map[string]interface{}), m)
+			if err != nil {
+				return fmt.Errorf("%s: %w", kind.block, err)
+			}
+			desired = append(desired, obj)
+		}
+		removed := make(map[string]bool)
+		for _, block := range oldBlocks.([]interface{}) {
+			removed[domainUnitBlockKey(kind, block.(map[string]interface{}))] = true
+		}
+		staged, err := stageDomainUnitObjects(kind.objects(dom), desired, removed, kind.keyOf)
+		if err != nil {
+			return fmt.Errorf("%s: %w", kind.block, err)
+		}
+		kind.store(dom, staged)
+	}
+	comment, err := tools.GetStringValue("comment", d)
+	if err == nil {
+		dom.ModificationComments = comment
+	}
+
+	args, err := GetQueryArgs(d)
+	if err != nil {
+		return err
+	}
+	logger.Debugf("Updating Domain PROPOSED: %v", dom)
+	uStat, err := inst.Client(meta).UpdateDomain(ctx, dom, args)
+	if err != nil {
+		return err
+	}
+	logger.Debugf("Update status: %v", uStat)
+	if uStat.PropagationStatus == "DENIED" {
+		return fmt.Errorf(uStat.Message)
+	}
+
+	waitOnComplete, err := tools.GetBoolValue("wait_on_complete", d)
+	if err != nil {
+		return err
+	}
+	if waitOnComplete {
+		done, err := waitForCompletion(ctx, domain, m)
+		if err != nil {
+			return err
+		}
+		if done {
+			logger.Infof("Domain Unit submit completed")
+		} else {
+			logger.Infof("Domain Unit submit pending")
+		}
+	}
+	return nil
+}
+
+// stageDomainUnitObjects merges the desired objects into the objects of the domain. Existing objects keep their
+// position and are replaced, objects in removed which are not desired anymore are dropped and new objects are appended.
+func stageDomainUnitObjects(existing, desired []interface{}, removed map[string]bool, keyOf func(interface{}) string) ([]interface{}, error) {
+	desiredByKey := make(map[string]interface{}, len(desired))
+	for _, obj := range desired {
+		key := keyOf(obj)
+		if _, ok := desiredByKey[key]; ok {
+			return nil, fmt.Errorf("%q is configured more than once", key)
+		}
+		desiredByKey[key] = obj
+	}
+
+	staged := make([]interface{}, 0, len(existing)+len(desired))
+	for _, obj := range existing {
+		key := keyOf(obj)
+		if replacement, ok := desiredByKey[key]; ok {
+			staged = append(staged, replacement)
+			delete(desiredByKey, key)
+			continue
+		}
+		if !removed[key] {
+			staged = append(staged, obj)
+		}
+	}
+	for _, obj := range desired {
+		if _, ok := desiredByKey[keyOf(obj)]; ok {
+			staged = append(staged, obj)
+		}
+	}
+	return staged, nil
+}
+
+// expandDomainUnitBlock creates a GTM object of the given kind from a block of the unit
+func expandDomainUnitBlock(ctx context.Context, meta akamai.OperationMeta, kind domainUnitKind, block map[string]interface{}, m interface{}) (interface{}, error) {
+	rd, err := domainUnitResourceData(kind, block)
+	if err != nil {
+		return nil, err
+	}
+	return kind.expand(ctx, meta, rd, m)
+}
+
+// domainUnitResourceData returns resource data of the standalone resource of the kind holding the values of a block
+func domainUnitResourceData(kind domainUnitKind, block map[string]interface{}) (*schema.ResourceData, error) {
+	rd := kind.resource().Data(nil)
+	for name, value := range block {
+		if err := rd.Set(name, value); err != nil {
+			return nil, fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+		}
+	}
+	return rd, nil
+}
+
+func domainUnitBlockKey(kind domainUnitKind, block map[string]interface{}) string {
+	return fmt.Sprintf("%v", block[kind.key])
+}
+
+// populateTerraformDomainUnitState sets the blocks of the unit from the domain. Blocks keep the order of the state;
+// with all set, objects of the domain not in the state yet are appended.
+func populateTerraformDomainUnitState(d *schema.ResourceData, dom *gtm.Domain, m interface{}, all bool) error {
+	meta := akamai.Meta(m)
+	logger := meta.Log("Akamai GTM", "populateTerraformDomainUnitState")
+
+	for _, kind := range domainUnitKinds {
+		objects := make(map[string]interface{})
+		keys := make([]string, 0)
+		for _, obj := range kind.objects(dom) {
+			objects[kind.keyOf(obj)] = obj
+			keys = append(keys, kind.keyOf(obj))
+		}
+
+		blocks := make([]interface{}, 0)
+		stateBlocks, err := tools.GetListValue(kind.block, d)
+		if err != nil && !errors.Is(err, tools.ErrNotFound) {
+			return err
+		}
+		for _, b := range stateBlocks {
+			block := b.(map[string]interface{})
+			key := domainUnitBlockKey(kind, block)
+			obj, ok := objects[key]
+			if !ok {
+				logger.Warnf("%s %s NOT FOUND in domain %s", kind.block, key, dom.Name)
+				continue
+			}
+			flattened, err := flattenDomainUnitObject(kind, block, obj, m)
+			if err != nil {
+				return err
+			}
+			blocks = append(blocks, flattened)
+			delete(objects, key)
+		}
+		if all {
+			for _, key := range keys {
+				obj, ok := objects[key]
+				if !ok {
+					continue
+				}
+				flattened, err := flattenDomainUnitObject(kind, map[string]interface{}{}, obj, m)
+				if err != nil {
+					return err
+				}
+				blocks = append(blocks, flattened)
+			}
+		}
+		if err := d.Set(kind.block, blocks); err != nil {
+			return fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+		}
+	}
+	return nil
+}
+
+// flattenDomainUnitObject returns the block of a GTM object. The values of the current block are used as a base,
+// so the populate functions can keep the order of nested lists.
+func flattenDomainUnitObject(kind domainUnitKind, block map[string]interface{}, obj interface{}, m interface{}) (map[string]interface{}, error) {
+	rd, err := domainUnitResourceData(kind, block)
+	if err != nil {
+		return nil, err
+	}
+	kind.flatten(rd, obj, m)
+
+	flattened := make(map[string]interface{})
+	for name := range domainUnitElemSchema(kind) {
+		flattened[name] = rd.Get(name)
+	}
+	return flattened, nil
+}
@@ -0,0 +1,201 @@
+package gtm
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/gtm"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResGtmDomainUnit(t *testing.T) {
+	dataSourceName := "akamai_gtm_domain_unit.tfexample_unit"
+
+	t.Run("create and update domain unit", func(t *testing.T) {
+		client := getDomainUnitMocks()
+		updateCall := client.On("UpdateDomain",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("*gtm.Domain"),
+			mock.AnythingOfType("map[string]string"),
+		).Return(&completeResponseStatus, nil)
+
+		var submitted []*gtm.Domain
+		updateCall.RunFn = func(args mock.Arguments) {
+			submitted = append(submitted, args.Get(1).(*gtm.Domain))
+		}
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResGtmDomainUnit/create_basic.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(dataSourceName, "id", gtmTestDomain),
+							resource.TestCheckResourceAttr(dataSourceName, "datacenter.0.datacenter_id", "3200"),
+							resource.TestCheckResourceAttr(dataSourceName, "property.0.name", "unit_property"),
+							resource.TestCheckResourceAttr(dataSourceName, "property.0.traffic_target.0.weight", "100"),
+						),
+					},
+					{
+						Config: loadFixtureString("testdata/TestResGtmDomainUnit/update_basic.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(dataSourceName, "property.0.traffic_target.0.weight", "50"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+		require.NotEmpty(t, submitted)
+		// objects of the domain not managed by the unit are submitted unchanged
+		assert.Equal(t, "test_property", submitted[0].Properties[0].Name)
+		assert.Equal(t, "unit_property", submitted[0].Properties[1].Name)
+		assert.Equal(t, "Staged by unit", submitted[0].ModificationComments)
+	})
+
+	t.Run("domain unit denied", func(t *testing.T) {
+		client := getDomainUnitMocks()
+		client.On("UpdateDomain",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("*gtm.Domain"),
+			mock.AnythingOfType("map[string]string"),
+		).Return(&deniedResponseStatus, nil)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config:      loadFixtureString("testdata/TestResGtmDomainUnit/create_basic.tf"),
+						ExpectError: regexp.MustCompile("Request could not be completed. Invalid credentials."),
+					},
+				},
+			})
+		})
+	})
+}
+
+func TestStageDomainUnitObjects(t *testing.T) {
+	keyOf := func(obj interface{}) string {
+		return obj.(*gtm.Property).Name
+	}
+	existing := []interface{}{
+		&gtm.Property{Name: "a", Comments: "old"},
+		&gtm.Property{Name: "b"},
+		&gtm.Property{Name: "c"},
+	}
+
+	tests := map[string]struct {
+		desired   []interface{}
+		removed   map[string]bool
+		expected  []string
+		withError bool
+	}{
+		"replace keeps position": {
+			desired:  []interface{}{&gtm.Property{Name: "b", Comments: "new"}},
+			removed:  map[string]bool{"b": true},
+			expected: []string{"a:old", "b:new", "c"},
+		},
+		"add appends in config order": {
+			desired:  []interface{}{&gtm.Property{Name: "e"}, &gtm.Property{Name: "d"}},
+			expected: []string{"a:old", "b", "c", "e", "d"},
+		},
+		"remove only owned objects": {
+			desired:  []interface{}{},
+			removed:  map[string]bool{"a": true, "x": true},
+			expected: []string{"b", "c"},
+		},
+		"unmanaged objects are kept": {
+			desired:  nil,
+			expected: []string{"a:old", "b", "c"},
+		},
+		"duplicate key": {
+			desired:   []interface{}{&gtm.Property{Name: "d"}, &gtm.Property{Name: "d"}},
+			withError: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			staged, err := stageDomainUnitObjects(existing, test.desired, test.removed, keyOf)
+			if test.withError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			names := make([]string, 0, len(staged))
+			for _, obj := range staged {
+				prop := obj.(*gtm.Property)
+				if prop.Comments != "" {
+					names = append(names, fmt.Sprintf("%s:%s", prop.Name, prop.Comments))
+					continue
+				}
+				names = append(names, prop.Name)
+			}
+			assert.Equal(t, test.expected, names)
+		})
+	}
+}
+
+func TestDomainUnitElemSchema(t *testing.T) {
+	for _, kind := range domainUnitKinds {
+		elem := domainUnitElemSchema(kind)
+		assert.NotContains(t, elem, "domain", kind.block)
+		assert.NotContains(t, elem, "wait_on_complete", kind.block)
+		require.Contains(t, elem, kind.key, kind.block)
+		assert.True(t, elem[kind.key].Required, kind.block)
+	}
+	// the standalone resources are left untouched
+	assert.True(t, resourceGTMv1Datacenter().Schema["datacenter_id"].Computed)
+}
+
+// getDomainUnitMocks returns a mock client holding a domain with one datacenter and property not managed by the unit
+func getDomainUnitMocks() *gtm.Mock {
+	client := &gtm.Mock{}
+
+	unitDomain := gtm.Domain{
+		Name:        gtmTestDomain,
+		Type:        "weighted",
+		Datacenters: datacenters,
+		Properties:  properties,
+	}
+	client.On("GetDomain",
+		mock.Anything, // ctx is irrelevant for this test
+		gtmTestDomain,
+	).Return(&unitDomain, nil)
+
+	client.On("GetDomainStatus",
+		mock.Anything, // ctx is irrelevant for this test
+		gtmTestDomain,
+	).Return(&completeResponseStatus, nil).Maybe()
+
+	// every call returns a new object, as the populate functions modify them
+	dcCall := client.On("NewDatacenter",
+		mock.Anything, // ctx is irrelevant for this test
+	)
+	dcCall.RunFn = func(mock.Arguments) {
+		dcCall.ReturnArguments = mock.Arguments{&gtm.Datacenter{}}
+	}
+
+	propCall := client.On("NewProperty",
+		mock.Anything, // ctx is irrelevant for this test
+		mock.AnythingOfType("string"),
+	)
+	propCall.RunFn = func(args mock.Arguments) {
+		propCall.ReturnArguments = mock.Arguments{&gtm.Property{Name: args.String(1)}}
+	}
+
+	ttCall := client.On("NewTrafficTarget",
+		mock.Anything, // ctx is irrelevant for this test
+	)
+	ttCall.RunFn = func(mock.Arguments) {
+		ttCall.ReturnArguments = mock.Arguments{&gtm.TrafficTarget{}}
+	}
+
+	return client
+}
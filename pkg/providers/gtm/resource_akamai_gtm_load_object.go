@@ -0,0 +1,384 @@
+package gtm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/providers/gtm/loadfeedback"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// loadObjectHTTPClient is the client load objects are published with
+var loadObjectHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// loadObjectDocumentFields are the fields the load object document is generated from
+var loadObjectDocumentFields = []string{"server_name", "resource_name", "current_load", "target_load", "max_load", "timestamp"}
+
+func resourceGTMv1LoadObject() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGTMv1LoadObjectCreate,
+		ReadContext:   resourceGTMv1LoadObjectRead,
+		UpdateContext: resourceGTMv1LoadObjectUpdate,
+		DeleteContext: resourceGTMv1LoadObjectDelete,
+		CustomizeDiff: markLoadObjectDocumentChanged,
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"server_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"resource_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"current_load": {
+				Type:     schema.TypeFloat,
+				Required: true,
+			},
+			"target_load": {
+				Type:     schema.TypeFloat,
+				Required: true,
+			},
+			"max_load": {
+				Type:     schema.TypeFloat,
+				Optional: true,
+			},
+			"timestamp": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"origin": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"origin", "netstorage"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"headers": {
+							Type:      schema.TypeMap,
+							Optional:  true,
+							Sensitive: true,
+							Elem:      &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"netstorage": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"endpoint": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"cp_code": {
+							Type:     schema.TypeInt,
+							Required: true,
+							ForceNew: true,
+						},
+						"key_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"key": {
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
+						},
+					},
+				},
+			},
+			"archive_versions": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"delete_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"document": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"content_hash": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"uploaded_timestamp": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// markLoadObjectDocumentChanged is a CustomizeDiff which marks the generated document and its version as changing
+// whenever a field the document is built from changes
+func markLoadObjectDocumentChanged(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" || !diff.HasChanges(loadObjectDocumentFields...) {
+		return nil
+	}
+	for _, key := range []string{"document", "content_hash", "version", "uploaded_timestamp"} {
+		if err := diff.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Create a new load object
+func resourceGTMv1LoadObjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("Akamai GTM", "resourceGTMv1LoadObjectCreate")
+
+	client, err := loadObjectClient(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	path, err := tools.GetStringValue("path", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	logger.Infof("Uploading load object [%s]", client.URL(path))
+	if err := uploadLoadObject(ctx, d, client, path, 1); err != nil {
+		logger.Errorf("Load object Create failed: %s", err.Error())
+		return diag.Errorf("load object Create failed: %s", err.Error())
+	}
+	d.SetId(client.URL(path))
+
+	return resourceGTMv1LoadObjectRead(ctx, d, m)
+}
+
+// Read a load object, detecting published documents which were removed or changed outside of terraform
+func resourceGTMv1LoadObjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("Akamai GTM", "resourceGTMv1LoadObjectRead")
+
+	client, err := loadObjectClient(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	path, err := tools.GetStringValue("path", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	logger.Debugf("Reading load object [%s]", d.Id())
+	body, err := client.Download(ctx, path)
+	if errors.Is(err, loadfeedback.ErrNotFound) {
+		logger.Warnf("Load object [%s] not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		logger.Errorf("Load object Read failed: %s", err.Error())
+		return diag.Errorf("load object Read failed: %s", err.Error())
+	}
+	if err := d.Set("url", client.URL(path)); err != nil {
+		return diag.Errorf("%s: %s", tools.ErrValueSet.Error(), err.Error())
+	}
+	if loadObjectHash(body) == d.Get("content_hash").(string) {
+		return nil
+	}
+	// a document changed outside of terraform shows up as a diff and is uploaded again with the next apply
+	logger.Warnf("Load object [%s] was changed outside of terraform", d.Id())
+	if err := populateTerraformLoadObjectState(d, body); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// populateTerraformLoadObjectState sets the document fields from a published document
+func populateTerraformLoadObjectState(d *schema.ResourceData, body []byte) error {
+	attrs := map[string]interface{}{
+		"document":     string(body),
+		"content_hash": loadObjectHash(body),
+	}
+	doc, err := loadfeedback.Unmarshal(body)
+	if err != nil {
+		// an unreadable document can not match the configuration
+		attrs["server_name"] = ""
+		return tools.SetAttrs(d, attrs)
+	}
+	attrs["server_name"] = doc.ServerName
+	attrs["resource_name"] = doc.ResourceName
+	attrs["current_load"] = doc.CurrentLoad
+	attrs["target_load"] = doc.TargetLoad
+	if doc.MaxLoad != nil {
+		attrs["max_load"] = *doc.MaxLoad
+	}
+	// the timestamp defaults to the upload time, so it is only compared when configured
+	if _, ok := d.GetOk("timestamp"); ok {
+		attrs["timestamp"] = int(doc.Timestamp)
+	}
+	return tools.SetAttrs(d, attrs)
+}
+
+// Update a load object, uploading a new version when its document changed
+func resourceGTMv1LoadObjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("Akamai GTM", "resourceGTMv1LoadObjectUpdate")
+
+	if !d.HasChanges(loadObjectDocumentFields...) {
+		logger.Debugf("Load object [%s] document unchanged", d.Id())
+		return resourceGTMv1LoadObjectRead(ctx, d, m)
+	}
+
+	client, err := loadObjectClient(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	path, err := tools.GetStringValue("path", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	oldVersion, _ := d.GetChange("version")
+
+	logger.Infof("Uploading load object [%s]", d.Id())
+	if err := uploadLoadObject(ctx, d, client, path, oldVersion.(int)+1); err != nil {
+		logger.Errorf("Load object Update failed: %s", err.Error())
+		return diag.Errorf("load object Update failed: %s", err.Error())
+	}
+
+	return resourceGTMv1LoadObjectRead(ctx, d, m)
+}
+
+// Delete a load object. The published object is only removed with delete_on_destroy.
+func resourceGTMv1LoadObjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("Akamai GTM", "resourceGTMv1LoadObjectDelete")
+
+	deleteOnDestroy, err := tools.GetBoolValue("delete_on_destroy", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !deleteOnDestroy {
+		logger.Infof("Leaving load object [%s] in place", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	client, err := loadObjectClient(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	path, err := tools.GetStringValue("path", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Infof("Deleting load object [%s]", d.Id())
+	if err := client.Delete(ctx, path); err != nil && !errors.Is(err, loadfeedback.ErrNotFound) {
+		logger.Errorf("Load object Delete failed: %s", err.Error())
+		return diag.Errorf("load object Delete failed: %s", err.Error())
+	}
+	d.SetId("")
+	return nil
+}
+
+// uploadLoadObject generates the document, uploads it and stores the given version. With archive_versions a copy
+// is also uploaded to <path>.<version>.
+func uploadLoadObject(ctx context.Context, d *schema.ResourceData, client loadfeedback.Client, path string, version int) error {
+	now := time.Now().Unix()
+	doc, err := loadObjectDocument(d, now)
+	if err != nil {
+		return err
+	}
+	body, err := doc.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := client.Upload(ctx, path, body); err != nil {
+		return err
+	}
+	archive, err := tools.GetBoolValue("archive_versions", d)
+	if err != nil {
+		return err
+	}
+	if archive {
+		if err := client.Upload(ctx, fmt.Sprintf("%s.%d", path, version), body); err != nil {
+			return fmt.Errorf("archiving version %d: %w", version, err)
+		}
+	}
+	return tools.SetAttrs(d, map[string]interface{}{
+		"document":           string(body),
+		"content_hash":       loadObjectHash(body),
+		"version":            version,
+		"uploaded_timestamp": int(now),
+	})
+}
+
+// loadObjectDocument builds the document from the resource. The timestamp defaults to the upload time.
+func loadObjectDocument(d *schema.ResourceData, now int64) (*loadfeedback.Document, error) {
+	doc := &loadfeedback.Document{Timestamp: now}
+	var err error
+	if doc.ServerName, err = tools.GetStringValue("server_name", d); err != nil {
+		return nil, err
+	}
+	if doc.ResourceName, err = tools.GetStringValue("resource_name", d); err != nil {
+		return nil, err
+	}
+	doc.CurrentLoad = d.Get("current_load").(float64)
+	doc.TargetLoad = d.Get("target_load").(float64)
+	if maxLoad, ok := d.GetOk("max_load"); ok {
+		value := maxLoad.(float64)
+		doc.MaxLoad = &value
+	}
+	if timestamp, ok := d.GetOk("timestamp"); ok {
+		doc.Timestamp = int64(timestamp.(int))
+	}
+	return doc, nil
+}
+
+// loadObjectClient returns the client for the origin or NetStorage endpoint configured
+func loadObjectClient(d *schema.ResourceData) (loadfeedback.Client, error) {
+	if origin, err := tools.GetListValue("origin", d); err == nil && len(origin) > 0 {
+		o := origin[0].(map[string]interface{})
+		headers := make(map[string]string)
+		for name, value := range o["headers"].(map[string]interface{}) {
+			headers[name] = value.(string)
+		}
+		return loadfeedback.NewOriginClient(o["url"].(string), headers, loadObjectHTTPClient), nil
+	}
+	netStorage, err := tools.GetListValue("netstorage", d)
+	if err != nil || len(netStorage) == 0 {
+		return nil, fmt.Errorf("either origin or netstorage is required")
+	}
+	ns := netStorage[0].(map[string]interface{})
+	return loadfeedback.NewNetStorageClient(ns["endpoint"].(string), ns["cp_code"].(int), ns["key_name"].(string), ns["key"].(string), loadObjectHTTPClient), nil
+}
+
+func loadObjectHash(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
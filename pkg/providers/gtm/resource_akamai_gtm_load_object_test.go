@@ -0,0 +1,132 @@
+package gtm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/providers/gtm/internal/loadfeedbacktest"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/providers/gtm/loadfeedback"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResGtmLoadObject(t *testing.T) {
+	resourceName := "akamai_gtm_load_object.tfexample_load_1"
+
+	t.Run("create and update origin load object", func(t *testing.T) {
+		server := loadfeedbacktest.NewServer(nil)
+		defer server.Close()
+
+		resource.UnitTest(t, resource.TestCase{
+			ProviderFactories: testAccProviders,
+			CheckDestroy: func(*terraform.State) error {
+				if _, ok := server.Object("/load/dc1.xml"); ok {
+					return fmt.Errorf("load object was not deleted")
+				}
+				return nil
+			},
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(loadFixtureString("testdata/TestResGtmLoadObject/create_origin.tf"), server.URL),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(resourceName, "id", server.URL+"/load/dc1.xml"),
+						resource.TestCheckResourceAttr(resourceName, "version", "1"),
+						resource.TestCheckResourceAttrSet(resourceName, "content_hash"),
+						func(*terraform.State) error {
+							body, ok := server.Object("/load/dc1.xml")
+							require.True(t, ok)
+							assert.Contains(t, string(body), "<Current-Load>40</Current-Load>")
+							_, ok = server.Object("/load/dc1.xml.1")
+							assert.True(t, ok)
+							return nil
+						},
+					),
+				},
+				{
+					Config: fmt.Sprintf(loadFixtureString("testdata/TestResGtmLoadObject/update_origin.tf"), server.URL),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(resourceName, "version", "2"),
+						func(*terraform.State) error {
+							body, ok := server.Object("/load/dc1.xml")
+							require.True(t, ok)
+							assert.Contains(t, string(body), "<Current-Load>65</Current-Load>")
+							assert.Contains(t, string(body), "<Timestamp>1677665100</Timestamp>")
+							assert.Equal(t, 2, server.Uploads("/load/dc1.xml"))
+							return nil
+						},
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("create netstorage load object", func(t *testing.T) {
+		server := loadfeedbacktest.NewServer(map[string]string{"upload1": "secret"})
+		defer server.Close()
+
+		resource.UnitTest(t, resource.TestCase{
+			ProviderFactories: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(loadFixtureString("testdata/TestResGtmLoadObject/create_netstorage.tf"), server.URL),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(resourceName, "url", server.URL+"/123456/load/dc1.xml"),
+						resource.TestCheckResourceAttr(resourceName, "version", "1"),
+						resource.TestCheckResourceAttrSet(resourceName, "uploaded_timestamp"),
+						func(*terraform.State) error {
+							_, ok := server.Object("/123456/load/dc1.xml")
+							assert.True(t, ok)
+							return nil
+						},
+					),
+				},
+			},
+		})
+
+		// objects are kept on destroy by default
+		_, ok := server.Object("/123456/load/dc1.xml")
+		assert.True(t, ok)
+	})
+}
+
+func TestLoadObjectDocument(t *testing.T) {
+	s := resourceGTMv1LoadObject().Schema
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		"path":          "/load/dc1.xml",
+		"server_name":   "origin1.example.com",
+		"resource_name": "cpu",
+		"current_load":  20.0,
+		"target_load":   60.0,
+	})
+
+	doc, err := loadObjectDocument(d, 1677664800)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1677664800), doc.Timestamp)
+	assert.Nil(t, doc.MaxLoad)
+	assert.Equal(t, 60.0, doc.TargetLoad)
+
+	// a document changed outside of terraform is read back into the document fields
+	maxLoad := 95.0
+	body, err := (loadfeedback.Document{
+		ServerName:   "origin1.example.com",
+		ResourceName: "cpu",
+		Timestamp:    1677665100,
+		CurrentLoad:  80,
+		TargetLoad:   60,
+		MaxLoad:      &maxLoad,
+	}).Marshal()
+	require.NoError(t, err)
+	require.NoError(t, populateTerraformLoadObjectState(d, body))
+	assert.Equal(t, 80.0, d.Get("current_load"))
+	assert.Equal(t, 95.0, d.Get("max_load"))
+	assert.Equal(t, loadObjectHash(body), d.Get("content_hash"))
+	// the timestamp is not configured, so it is left out
+	_, ok := d.GetOk("timestamp")
+	assert.False(t, ok)
+
+	require.NoError(t, populateTerraformLoadObjectState(d, []byte("not xml")))
+	assert.Equal(t, "", d.Get("server_name"))
+}
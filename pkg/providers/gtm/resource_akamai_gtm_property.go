@@ -13,6 +13,7 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -25,6 +26,11 @@ func resourceGTMv1Property() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceGTMv1PropertyImport,
 		},
+		CustomizeDiff: customdiff.All(
+			validatePropertyType,
+			validatePropertyLivenessTests,
+			validatePropertyDatacenters,
+		),
 		Schema: map[string]*schema.Schema{
 			"domain": {
 				Type:     schema.TypeString,
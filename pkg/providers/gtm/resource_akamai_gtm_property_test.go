@@ -81,6 +81,7 @@ func TestResGtmProperty(t *testing.T) {
 
 	t.Run("create property", func(t *testing.T) {
 		client := &gtm.Mock{}
+		mockPropertyDatacenters(client)
 
 		getCall := client.On("GetProperty",
 			mock.Anything, // ctx is irrelevant for this test
@@ -184,6 +185,7 @@ func TestResGtmProperty(t *testing.T) {
 
 	t.Run("create property failed", func(t *testing.T) {
 		client := &gtm.Mock{}
+		mockPropertyDatacenters(client)
 
 		client.On("CreateProperty",
 			mock.Anything, // ctx is irrelevant for this test
@@ -245,6 +247,7 @@ func TestResGtmProperty(t *testing.T) {
 
 	t.Run("create property denied", func(t *testing.T) {
 		client := &gtm.Mock{}
+		mockPropertyDatacenters(client)
 
 		dr := gtm.PropertyResponse{}
 		dr.Resource = &prop
@@ -427,6 +430,7 @@ func TestResourceGTMTrafficTargetOrder(t *testing.T) {
 
 func getMocks() *gtm.Mock {
 	client := &gtm.Mock{}
+	mockPropertyDatacenters(client)
 
 	// read
 	getPropertyCall := client.On("GetProperty", mock.Anything, "tfexample_prop_1", "gtm_terra_testdomain.akadns.net").
@@ -464,3 +468,16 @@ func getMocks() *gtm.Mock {
 
 	return client
 }
+
+// mockPropertyDatacenters mocks the data centers the traffic targets of the test properties are validated against
+func mockPropertyDatacenters(client *gtm.Mock) {
+	client.On("ListDatacenters",
+		mock.Anything, // ctx is irrelevant for this test
+		gtmTestDomain,
+	).Return([]*gtm.Datacenter{
+		{DatacenterId: 3131},
+		{DatacenterId: 3132},
+		{DatacenterId: 3133},
+		{DatacenterId: 3134},
+	}, nil)
+}
@@ -0,0 +1,286 @@
+package gtm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/gtm"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	propertyTypeStatic      = "static"
+	propertyTypeGeographic  = "geographic"
+	propertyTypeCidrMapping = "cidrmapping"
+	propertyTypeAsMapping   = "asmapping"
+)
+
+var (
+	// mapPropertyTypes are the property types handing out data centers by a geographic, CIDR or AS map
+	mapPropertyTypes = map[string]bool{
+		propertyTypeGeographic:  true,
+		propertyTypeCidrMapping: true,
+		propertyTypeAsMapping:   true,
+	}
+
+	// weightedPropertyTypes are the property types distributing requests by traffic target weight
+	weightedPropertyTypes = map[string]bool{
+		"weighted-round-robin":               true,
+		"weighted-hashed":                    true,
+		"weighted-round-robin-load-feedback": true,
+	}
+
+	// trafficTargetRecordTypes are the record types handed out from traffic targets, so static record sets
+	// of those types are only valid on static properties
+	trafficTargetRecordTypes = map[string]bool{
+		"A":     true,
+		"AAAA":  true,
+		"CNAME": true,
+	}
+
+	// livenessTestProtocolFields lists the liveness test fields which are only valid for some protocols
+	livenessTestProtocolFields = map[string][]string{
+		"http_error3xx":          {"HTTP", "HTTPS", "FTP"},
+		"http_error4xx":          {"HTTP", "HTTPS", "FTP"},
+		"http_error5xx":          {"HTTP", "HTTPS", "FTP"},
+		"http_header":            {"HTTP", "HTTPS"},
+		"ssl_client_certificate": {"HTTPS", "POPS", "SMTPS", "TCPS"},
+		"ssl_client_private_key": {"HTTPS", "POPS", "SMTPS", "TCPS"},
+		"request_string":         {"TCP", "TCPS"},
+		"response_string":        {"HTTP", "HTTPS", "TCP", "TCPS"},
+		"resource_type":          {"DNS"},
+		"answers_required":       {"DNS"},
+		"recursion_requested":    {"DNS"},
+	}
+
+	// livenessTestRequiredFields lists the liveness test fields each protocol requires
+	livenessTestRequiredFields = map[string][]string{
+		"DNS":  {"resource_type"},
+		"FTP":  {"test_object_username"},
+		"TCP":  {"request_string", "response_string"},
+		"TCPS": {"request_string", "response_string"},
+	}
+)
+
+// validatePropertyType is a CustomizeDiff which checks the fields required or forbidden by the property type
+func validatePropertyType(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("type") {
+		return nil
+	}
+	propertyType, err := tools.GetStringValue("type", diff)
+	if err != nil {
+		return nil
+	}
+	propertyType = strings.ToLower(propertyType)
+
+	if diff.NewValueKnown("map_name") {
+		_, hasMap := diff.GetOk("map_name")
+		if mapPropertyTypes[propertyType] && !hasMap {
+			return fmt.Errorf("map_name is required for %s properties", propertyType)
+		}
+		if !mapPropertyTypes[propertyType] && hasMap {
+			return fmt.Errorf("map_name is only supported for geographic, cidrmapping and asmapping properties, not %s", propertyType)
+		}
+	}
+
+	if diff.NewValueKnown("traffic_target") {
+		targets, _ := diff.Get("traffic_target").([]interface{})
+		if propertyType == propertyTypeStatic && len(targets) > 0 {
+			return fmt.Errorf("static properties cannot have traffic targets")
+		}
+		if propertyType != propertyTypeStatic && len(targets) == 0 {
+			return fmt.Errorf("%s properties must have one or more traffic targets", propertyType)
+		}
+		if weightedPropertyTypes[propertyType] {
+			if err := validateTrafficTargetWeights(diff, targets); err != nil {
+				return err
+			}
+		}
+	}
+
+	if diff.NewValueKnown("static_rr_set") {
+		sets, _ := diff.Get("static_rr_set").([]interface{})
+		if propertyType == propertyTypeStatic && len(sets) == 0 {
+			return fmt.Errorf("static properties must have one or more static_rr_set")
+		}
+		if propertyType != propertyTypeStatic {
+			for i, s := range sets {
+				set, ok := s.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				recordType, _ := set["type"].(string)
+				if trafficTargetRecordTypes[strings.ToUpper(recordType)] {
+					return fmt.Errorf("static_rr_set.%d: %s record sets are only supported for static properties, %s properties hand them out from traffic targets",
+						i, strings.ToUpper(recordType), propertyType)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateTrafficTargetWeights checks that the weights of the enabled targets are not negative and that at least one
+// of them gets traffic. Weights are relative, so they do not have to add up to 100, and disabled targets are ignored.
+func validateTrafficTargetWeights(diff *schema.ResourceDiff, targets []interface{}) error {
+	var positive bool
+	for i, t := range targets {
+		if !diff.NewValueKnown(fmt.Sprintf("traffic_target.%d.weight", i)) || !diff.NewValueKnown(fmt.Sprintf("traffic_target.%d.enabled", i)) {
+			return nil
+		}
+		target, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if enabled, _ := target["enabled"].(bool); !enabled {
+			continue
+		}
+		weight, _ := target["weight"].(float64)
+		if weight < 0 {
+			return fmt.Errorf("traffic_target.%d: weight must not be negative", i)
+		}
+		if weight > 0 {
+			positive = true
+		}
+	}
+	if !positive {
+		return fmt.Errorf("at least one enabled traffic target must have a positive weight")
+	}
+	return nil
+}
+
+// validatePropertyLivenessTests is a CustomizeDiff which checks the liveness test fields used with each test protocol
+func validatePropertyLivenessTests(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	tests, err := tools.GetListValue("liveness_test", diff)
+	if err != nil {
+		return nil
+	}
+	for i, lt := range tests {
+		test, ok := lt.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if !diff.NewValueKnown(fmt.Sprintf("liveness_test.%d.test_object_protocol", i)) {
+			continue
+		}
+		protocol, _ := test["test_object_protocol"].(string)
+		protocol = strings.ToUpper(protocol)
+
+		for _, field := range sortedKeys(livenessTestProtocolFields) {
+			if !livenessTestFieldSet(test[field]) || tools.ContainsString(livenessTestProtocolFields[field], protocol) {
+				continue
+			}
+			return fmt.Errorf("liveness_test.%d (%s): %s is only supported for %s tests",
+				i, test["name"], field, strings.Join(livenessTestProtocolFields[field], ", "))
+		}
+		for _, field := range livenessTestRequiredFields[protocol] {
+			if !diff.NewValueKnown(fmt.Sprintf("liveness_test.%d.%s", i, field)) || livenessTestFieldSet(test[field]) {
+				continue
+			}
+			return fmt.Errorf("liveness_test.%d (%s): %s is required for %s tests", i, test["name"], field, protocol)
+		}
+		if livenessTestFieldSet(test["ssl_client_certificate"]) != livenessTestFieldSet(test["ssl_client_private_key"]) {
+			return fmt.Errorf("liveness_test.%d (%s): ssl_client_certificate and ssl_client_private_key must be set together", i, test["name"])
+		}
+	}
+	return nil
+}
+
+// livenessTestFieldSet tells whether a liveness test field holds a value other than its zero value
+func livenessTestFieldSet(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return v != ""
+	case bool:
+		return v
+	case []interface{}:
+		return len(v) > 0
+	}
+	return false
+}
+
+// validatePropertyDatacenters is a CustomizeDiff which checks that traffic targets only use data centers of the
+// domain. Domains which do not exist yet and data center ids known only after apply are left to the API.
+func validatePropertyDatacenters(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if !diff.HasChange("traffic_target") || !diff.NewValueKnown("domain") {
+		return nil
+	}
+	targets, err := tools.GetListValue("traffic_target", diff)
+	if err != nil {
+		return nil
+	}
+	ids := make(map[int]int, len(targets))
+	for i, t := range targets {
+		if !diff.NewValueKnown(fmt.Sprintf("traffic_target.%d.datacenter_id", i)) {
+			return nil
+		}
+		target, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if id, _ := target["datacenter_id"].(int); id != 0 {
+			ids[id] = i
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	meta := akamai.Meta(m)
+	logger := meta.Log("Akamai GTM", "validatePropertyDatacenters")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	domain, err := tools.GetStringValue("domain", diff)
+	if err != nil {
+		return nil
+	}
+	datacenters, err := inst.Client(meta).ListDatacenters(ctx, domain)
+	if err != nil {
+		var apiError *gtm.Error
+		if errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound {
+			logger.Debugf("Domain [%s] not found, skipping traffic target validation", domain)
+			return nil
+		}
+		return fmt.Errorf("validating traffic targets: %s", err.Error())
+	}
+	if unknown := unknownTargetDatacenters(ids, datacenters); len(unknown) > 0 {
+		return fmt.Errorf("traffic_target.%d: data center %d does not exist in domain %s", ids[unknown[0]], unknown[0], domain)
+	}
+	return nil
+}
+
+// unknownTargetDatacenters returns the ordered data center ids not found in the domain data centers
+func unknownTargetDatacenters(ids map[int]int, datacenters []*gtm.Datacenter) []int {
+	known := make(map[int]bool, len(datacenters))
+	for _, dc := range datacenters {
+		known[dc.DatacenterId] = true
+	}
+	var unknown []int
+	for id := range ids {
+		if !known[id] {
+			unknown = append(unknown, id)
+		}
+	}
+	sort.Ints(unknown)
+	return unknown
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,299 @@
+package gtm
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/gtm"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// propertyValidationConfig returns a valid weighted round robin property config merged with the given attributes
+func propertyValidationConfig(attrs map[string]interface{}) map[string]interface{} {
+	config := map[string]interface{}{
+		"domain":                 gtmTestDomain,
+		"name":                   "tfexample_prop_1",
+		"type":                   "weighted-round-robin",
+		"score_aggregation_type": "median",
+		"handout_limit":          5,
+		"handout_mode":           "normal",
+		"traffic_target": []interface{}{
+			map[string]interface{}{"datacenter_id": 3131, "enabled": true, "weight": 200, "servers": []interface{}{"1.2.3.9"}},
+		},
+	}
+	for k, v := range attrs {
+		config[k] = v
+	}
+	return config
+}
+
+func httpLivenessTest(attrs map[string]interface{}) map[string]interface{} {
+	test := map[string]interface{}{
+		"name":                 "lt1",
+		"test_interval":        30,
+		"test_object":          "/health",
+		"test_object_protocol": "HTTP",
+		"test_timeout":         10,
+	}
+	for k, v := range attrs {
+		test[k] = v
+	}
+	return test
+}
+
+func TestValidatePropertyType(t *testing.T) {
+	tests := map[string]struct {
+		attrs     map[string]interface{}
+		withError string
+	}{
+		"valid weighted property": {
+			attrs: map[string]interface{}{
+				"static_rr_set": []interface{}{map[string]interface{}{"type": "MX", "ttl": 300, "rdata": []interface{}{"100 mail"}}},
+			},
+		},
+		"geographic without map": {
+			attrs:     map[string]interface{}{"type": "geographic"},
+			withError: "map_name is required for geographic properties",
+		},
+		"cidrmapping with map": {
+			attrs: map[string]interface{}{"type": "cidrmapping", "map_name": "tfexample_cidrmap_1"},
+		},
+		"map on weighted property": {
+			attrs:     map[string]interface{}{"map_name": "tfexample_geomap_1"},
+			withError: "map_name is only supported",
+		},
+		"static with traffic targets": {
+			attrs:     map[string]interface{}{"type": "static"},
+			withError: "static properties cannot have traffic targets",
+		},
+		"static without record sets": {
+			attrs:     map[string]interface{}{"type": "static", "traffic_target": []interface{}{}},
+			withError: "static properties must have one or more static_rr_set",
+		},
+		"static with record sets": {
+			attrs: map[string]interface{}{
+				"type":           "static",
+				"traffic_target": []interface{}{},
+				"static_rr_set":  []interface{}{map[string]interface{}{"type": "A", "ttl": 300, "rdata": []interface{}{"1.2.3.4"}}},
+			},
+		},
+		"failover without traffic targets": {
+			attrs:     map[string]interface{}{"type": "failover", "traffic_target": []interface{}{}},
+			withError: "failover properties must have one or more traffic targets",
+		},
+		"A record set on weighted property": {
+			attrs: map[string]interface{}{
+				"static_rr_set": []interface{}{map[string]interface{}{"type": "a", "ttl": 300, "rdata": []interface{}{"1.2.3.4"}}},
+			},
+			withError: "static_rr_set.0: A record sets are only supported for static properties",
+		},
+		"negative weight": {
+			attrs: map[string]interface{}{
+				"traffic_target": []interface{}{
+					map[string]interface{}{"datacenter_id": 3131, "enabled": true, "weight": 100},
+					map[string]interface{}{"datacenter_id": 3132, "enabled": true, "weight": -1},
+				},
+			},
+			withError: "traffic_target.1: weight must not be negative",
+		},
+		"no weight on enabled targets": {
+			attrs: map[string]interface{}{
+				"traffic_target": []interface{}{
+					map[string]interface{}{"datacenter_id": 3131, "enabled": true, "weight": 0},
+					map[string]interface{}{"datacenter_id": 3132, "enabled": false, "weight": 100},
+				},
+			},
+			withError: "at least one enabled traffic target must have a positive weight",
+		},
+		"negative weight on disabled target": {
+			attrs: map[string]interface{}{
+				"traffic_target": []interface{}{
+					map[string]interface{}{"datacenter_id": 3131, "enabled": true, "weight": 100},
+					map[string]interface{}{"datacenter_id": 3132, "enabled": false, "weight": -1},
+				},
+			},
+		},
+		"negative weight on weighted hashed property": {
+			attrs: map[string]interface{}{
+				"type": "weighted-hashed",
+				"traffic_target": []interface{}{
+					map[string]interface{}{"datacenter_id": 3131, "enabled": true, "weight": -5},
+					map[string]interface{}{"datacenter_id": 3132, "enabled": true, "weight": 100},
+				},
+			},
+			withError: "traffic_target.0: weight must not be negative",
+		},
+		"no weight on weighted hashed property": {
+			attrs: map[string]interface{}{
+				"type": "weighted-hashed",
+				"traffic_target": []interface{}{
+					map[string]interface{}{"datacenter_id": 3131, "enabled": true, "weight": 0},
+					map[string]interface{}{"datacenter_id": 3132, "enabled": true, "weight": 0},
+				},
+			},
+			withError: "at least one enabled traffic target must have a positive weight",
+		},
+		"valid weighted hashed property": {
+			attrs: map[string]interface{}{
+				"type": "weighted-hashed",
+				"traffic_target": []interface{}{
+					map[string]interface{}{"datacenter_id": 3131, "enabled": true, "weight": 0},
+					map[string]interface{}{"datacenter_id": 3132, "enabled": true, "weight": 0.5},
+				},
+			},
+		},
+		"no weight on load feedback property": {
+			attrs: map[string]interface{}{
+				"type": "weighted-round-robin-load-feedback",
+				"traffic_target": []interface{}{
+					map[string]interface{}{"datacenter_id": 3131, "enabled": false, "weight": 100},
+				},
+			},
+			withError: "at least one enabled traffic target must have a positive weight",
+		},
+		"mixed case weighted type": {
+			attrs: map[string]interface{}{
+				"type": "Weighted-Round-Robin",
+				"traffic_target": []interface{}{
+					map[string]interface{}{"datacenter_id": 3131, "enabled": true, "weight": -1},
+				},
+			},
+			withError: "traffic_target.0: weight must not be negative",
+		},
+		"zero weights on failover property": {
+			attrs: map[string]interface{}{
+				"type": "failover",
+				"traffic_target": []interface{}{
+					map[string]interface{}{"datacenter_id": 3131, "enabled": true, "weight": 0},
+				},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := diffProperty(t, validatePropertyType, propertyValidationConfig(test.attrs))
+			if test.withError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.withError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidatePropertyLivenessTests(t *testing.T) {
+	tests := map[string]struct {
+		livenessTest map[string]interface{}
+		withError    string
+	}{
+		"valid http test": {
+			livenessTest: httpLivenessTest(map[string]interface{}{
+				"http_error5xx": true,
+				"http_header":   []interface{}{map[string]interface{}{"name": "Host", "value": "example.com"}},
+			}),
+		},
+		"http errors on tcp test": {
+			livenessTest: httpLivenessTest(map[string]interface{}{
+				"test_object_protocol": "TCP",
+				"request_string":       "PING",
+				"response_string":      "PONG",
+				"http_error4xx":        true,
+			}),
+			withError: "liveness_test.0 (lt1): http_error4xx is only supported for HTTP, HTTPS, FTP tests",
+		},
+		"tcp test without response string": {
+			livenessTest: httpLivenessTest(map[string]interface{}{
+				"test_object_protocol": "TCP",
+				"request_string":       "PING",
+			}),
+			withError: "response_string is required for TCP tests",
+		},
+		"dns test without resource type": {
+			livenessTest: httpLivenessTest(map[string]interface{}{"test_object_protocol": "DNS"}),
+			withError:    "resource_type is required for DNS tests",
+		},
+		"valid dns test": {
+			livenessTest: httpLivenessTest(map[string]interface{}{
+				"test_object_protocol": "DNS",
+				"resource_type":        "A",
+				"recursion_requested":  true,
+			}),
+		},
+		"ftp test without username": {
+			livenessTest: httpLivenessTest(map[string]interface{}{"test_object_protocol": "FTP"}),
+			withError:    "test_object_username is required for FTP tests",
+		},
+		"client certificate on http test": {
+			livenessTest: httpLivenessTest(map[string]interface{}{
+				"ssl_client_certificate": "cert",
+				"ssl_client_private_key": "key",
+			}),
+			withError: "ssl_client_certificate is only supported",
+		},
+		"client certificate without key": {
+			livenessTest: httpLivenessTest(map[string]interface{}{
+				"test_object_protocol":   "HTTPS",
+				"ssl_client_certificate": "cert",
+			}),
+			withError: "must be set together",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := diffProperty(t, validatePropertyLivenessTests, propertyValidationConfig(map[string]interface{}{
+				"liveness_test": []interface{}{test.livenessTest},
+			}))
+			if test.withError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.withError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUnknownTargetDatacenters(t *testing.T) {
+	unknown := unknownTargetDatacenters(map[int]int{3200: 2, 3132: 0, 3133: 1}, datacenters)
+	assert.Equal(t, []int{3133, 3200}, unknown)
+	assert.Empty(t, unknownTargetDatacenters(map[int]int{3132: 0}, datacenters))
+}
+
+func TestResGtmPropertyDatacenterValidation(t *testing.T) {
+	client := &gtm.Mock{}
+	client.On("ListDatacenters",
+		mock.Anything, // ctx is irrelevant for this test
+		gtmTestDomain,
+	).Return([]*gtm.Datacenter{{DatacenterId: 3132}}, nil)
+
+	useClient(client, func() {
+		resource.UnitTest(t, resource.TestCase{
+			ProviderFactories: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config:      loadFixtureString("testdata/TestResGtmProperty/create_basic.tf"),
+					ExpectError: regexp.MustCompile("traffic_target.0: data center 3131 does not exist in domain"),
+				},
+			},
+		})
+	})
+
+	client.AssertExpectations(t)
+}
+
+// diffProperty plans a new property with the given config, running only the given CustomizeDiff
+func diffProperty(t *testing.T, customizeDiff schema.CustomizeDiffFunc, config map[string]interface{}) error {
+	res := &schema.Resource{
+		Schema:        resourceGTMv1Property().Schema,
+		CustomizeDiff: customizeDiff,
+	}
+	_, err := res.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+	return err
+}
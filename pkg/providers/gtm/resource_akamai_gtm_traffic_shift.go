@@ -0,0 +1,602 @@
+package gtm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/gtm"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/apex/log"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type (
+	// trafficShiftPlan moves the weight of the source data center traffic targets of properties to the target
+	// data center in steps
+	trafficShiftPlan struct {
+		Domain          string
+		Properties      []string
+		SourceID        int
+		TargetID        int
+		Steps           []float64
+		DwellTime       time.Duration
+		LivenessCheck   bool
+		MaxFailures     int
+		RevertOnFailure bool
+	}
+
+	// trafficShiftWeights are the weights of the source and target traffic targets of a property before the shift
+	trafficShiftWeights struct {
+		Property      string
+		SourceWeight  float64
+		SourceEnabled bool
+		TargetWeight  float64
+		TargetEnabled bool
+	}
+
+	// trafficShifter runs traffic shift plans
+	trafficShifter struct {
+		client  gtm.GTM
+		reports Reports
+		logger  log.Interface
+		// wait waits for the domain changes to propagate
+		wait func(context.Context) error
+		// sleep waits for the given time unless the context is done first
+		sleep func(context.Context, time.Duration) error
+		now   func() time.Time
+	}
+)
+
+const (
+	trafficShiftStatusComplete = "COMPLETE"
+
+	// trafficShiftStepTimeout is the time allowed for a step on top of its dwell time, covering the property
+	// updates and the wait for the domain changes to propagate
+	trafficShiftStepTimeout = 10 * time.Minute
+	// trafficShiftRevertTimeout is the time allowed to restore the original weights
+	trafficShiftRevertTimeout = 20 * time.Minute
+	// trafficShiftDefaultCreateTimeout bounds the create timeout when none is configured, in which case the
+	// shift gets the time its plan needs instead, see trafficShiftPlan.timeout
+	trafficShiftDefaultCreateTimeout = 24 * time.Hour
+)
+
+func resourceGTMv1TrafficShift() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGTMv1TrafficShiftCreate,
+		ReadContext:   resourceGTMv1TrafficShiftRead,
+		UpdateContext: resourceGTMv1TrafficShiftUpdate,
+		DeleteContext: resourceGTMv1TrafficShiftDelete,
+		CustomizeDiff: validateTrafficShiftSteps,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(trafficShiftDefaultCreateTimeout),
+			Delete: schema.DefaultTimeout(trafficShiftRevertTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"properties": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"source_datacenter_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"target_datacenter_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"steps": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:             schema.TypeFloat,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.FloatBetween(0, 100)),
+				},
+			},
+			"dwell_time": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "5m",
+				ValidateDiagFunc: validateTrafficShiftDwellTime,
+			},
+			"liveness_check": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_failures": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  0,
+						},
+					},
+				},
+			},
+			"revert_on_failure": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"revert_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"wait_on_complete": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"completed_steps": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"original_weights": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"property":       {Type: schema.TypeString, Computed: true},
+						"source_weight":  {Type: schema.TypeFloat, Computed: true},
+						"source_enabled": {Type: schema.TypeBool, Computed: true},
+						"target_weight":  {Type: schema.TypeFloat, Computed: true},
+						"target_enabled": {Type: schema.TypeBool, Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+// validateTrafficShiftSteps is a CustomizeDiff which checks the data centers differ and the steps only increase
+func validateTrafficShiftSteps(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.NewValueKnown("source_datacenter_id") && diff.NewValueKnown("target_datacenter_id") &&
+		diff.Get("source_datacenter_id").(int) == diff.Get("target_datacenter_id").(int) {
+		return fmt.Errorf("source_datacenter_id and target_datacenter_id must differ")
+	}
+	if !diff.NewValueKnown("steps") {
+		return nil
+	}
+	steps, err := tools.GetListValue("steps", diff)
+	if err != nil {
+		return nil
+	}
+	var previous float64
+	for i, s := range steps {
+		step, _ := s.(float64)
+		if step <= previous {
+			return fmt.Errorf("steps.%d: steps must be increasing percentages above 0, got %g after %g", i, step, previous)
+		}
+		previous = step
+	}
+	return nil
+}
+
+// validateTrafficShiftDwellTime checks that the dwell time parses as a duration which is not negative, so that a
+// bad value fails the plan rather than the shift
+func validateTrafficShiftDwellTime(v interface{}, _ cty.Path) diag.Diagnostics {
+	if d, err := time.ParseDuration(v.(string)); err != nil || d < 0 {
+		return diag.Errorf("invalid dwell_time %q: must be a duration like 5m which is not negative", v)
+	}
+	return nil
+}
+
+// trafficShiftCreateTimeoutConfigured tells whether the configuration sets the create timeout
+func trafficShiftCreateTimeoutConfigured(d *schema.ResourceData) bool {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() || !config.Type().HasAttribute(schema.TimeoutsConfigKey) {
+		return false
+	}
+	timeouts := config.GetAttr(schema.TimeoutsConfigKey)
+	if timeouts.IsNull() || !timeouts.IsKnown() || !timeouts.Type().IsObjectType() || !timeouts.Type().HasAttribute(schema.TimeoutCreate) {
+		return false
+	}
+	return !timeouts.GetAttr(schema.TimeoutCreate).IsNull()
+}
+
+// Create runs the traffic shift
+func resourceGTMv1TrafficShiftCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("Akamai GTM", "resourceGTMv1TrafficShiftCreate")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	plan, err := trafficShiftPlanFromSchema(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	shifter, err := newTrafficShifter(d, m, logger)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Without a configured timeout, the shift gets the time its steps need. A configured timeout is kept as is.
+	if !trafficShiftCreateTimeoutConfigured(d) {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, plan.timeout())
+		defer cancel()
+	} else if configured := d.Timeout(schema.TimeoutCreate); configured < plan.timeout() {
+		logger.Warnf("Create timeout %s is shorter than the %s the steps of the shift may take", configured, plan.timeout())
+	}
+
+	logger.Infof("Shifting traffic of %d properties in domain [%s] from data center %d to %d",
+		len(plan.Properties), plan.Domain, plan.SourceID, plan.TargetID)
+	original, err := shifter.run(ctx, plan)
+	if err != nil {
+		logger.Errorf("Traffic shift failed: %s", err.Error())
+		return diag.Errorf("traffic shift failed: %s", err.Error())
+	}
+
+	if err := tools.SetAttrs(d, map[string]interface{}{
+		"status":           trafficShiftStatusComplete,
+		"completed_steps":  len(plan.Steps),
+		"original_weights": flattenTrafficShiftWeights(original),
+	}); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%s:%d:%d", plan.Domain, plan.SourceID, plan.TargetID))
+	return resourceGTMv1TrafficShiftRead(ctx, d, m)
+}
+
+// Read a traffic shift. A completed shift has no remote state of its own; the weights belong to the properties.
+func resourceGTMv1TrafficShiftRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("Akamai GTM", "resourceGTMv1TrafficShiftRead")
+	logger.Debugf("Reading traffic shift [%s]", d.Id())
+	return nil
+}
+
+// Update a traffic shift. Only settings that do not change the shift itself can be updated.
+func resourceGTMv1TrafficShiftUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceGTMv1TrafficShiftRead(ctx, d, m)
+}
+
+// Delete a traffic shift, restoring the original weights with revert_on_destroy
+func resourceGTMv1TrafficShiftDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("Akamai GTM", "resourceGTMv1TrafficShiftDelete")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	revert, err := tools.GetBoolValue("revert_on_destroy", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !revert {
+		logger.Infof("Leaving the weights of traffic shift [%s] in place", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	plan, err := trafficShiftPlanFromSchema(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	shifter, err := newTrafficShifter(d, m, logger)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	original, err := expandTrafficShiftWeights(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Infof("Reverting traffic shift [%s]", d.Id())
+	if err := shifter.revert(ctx, plan, original); err != nil {
+		logger.Errorf("Traffic shift revert failed: %s", err.Error())
+		return diag.Errorf("traffic shift revert failed: %s", err.Error())
+	}
+	d.SetId("")
+	return nil
+}
+
+func trafficShiftPlanFromSchema(d *schema.ResourceData) (trafficShiftPlan, error) {
+	plan := trafficShiftPlan{
+		SourceID: d.Get("source_datacenter_id").(int),
+		TargetID: d.Get("target_datacenter_id").(int),
+	}
+	var err error
+	if plan.Domain, err = tools.GetStringValue("domain", d); err != nil {
+		return plan, err
+	}
+	properties, err := tools.GetListValue("properties", d)
+	if err != nil {
+		return plan, err
+	}
+	for _, p := range properties {
+		plan.Properties = append(plan.Properties, p.(string))
+	}
+	steps, err := tools.GetListValue("steps", d)
+	if err != nil {
+		return plan, err
+	}
+	for _, s := range steps {
+		plan.Steps = append(plan.Steps, s.(float64))
+	}
+	dwellTime, err := tools.GetStringValue("dwell_time", d)
+	if err != nil {
+		return plan, err
+	}
+	if plan.DwellTime, err = time.ParseDuration(dwellTime); err != nil {
+		return plan, fmt.Errorf("invalid dwell_time: %w", err)
+	}
+	if check, err := tools.GetListValue("liveness_check", d); err == nil && len(check) > 0 {
+		plan.LivenessCheck = true
+		if c, ok := check[0].(map[string]interface{}); ok {
+			plan.MaxFailures = c["max_failures"].(int)
+		}
+	}
+	if plan.RevertOnFailure, err = tools.GetBoolValue("revert_on_failure", d); err != nil {
+		return plan, err
+	}
+	return plan, nil
+}
+
+// timeout returns the time the steps of the plan may take
+func (p trafficShiftPlan) timeout() time.Duration {
+	return time.Duration(len(p.Steps)) * (p.DwellTime + trafficShiftStepTimeout)
+}
+
+func newTrafficShifter(d *schema.ResourceData, m interface{}, logger log.Interface) (*trafficShifter, error) {
+	meta := akamai.Meta(m)
+	waitOnComplete, err := tools.GetBoolValue("wait_on_complete", d)
+	if err != nil {
+		return nil, err
+	}
+	domain, err := tools.GetStringValue("domain", d)
+	if err != nil {
+		return nil, err
+	}
+	return &trafficShifter{
+		client:  inst.Client(meta),
+		reports: inst.ReportsClient(meta),
+		logger:  logger,
+		wait: func(ctx context.Context) error {
+			if !waitOnComplete {
+				return nil
+			}
+			done, err := waitForCompletion(ctx, domain, m)
+			if err != nil {
+				return err
+			}
+			if !done {
+				logger.Warnf("Domain [%s] propagation still pending", domain)
+			}
+			return nil
+		},
+		sleep: sleepContext,
+		now:   time.Now,
+	}, nil
+}
+
+// run shifts the traffic step by step and returns the weights before the shift. When a step fails, the original
+// weights are restored with RevertOnFailure.
+func (s *trafficShifter) run(ctx context.Context, plan trafficShiftPlan) ([]trafficShiftWeights, error) {
+	original := make([]trafficShiftWeights, 0, len(plan.Properties))
+	for _, name := range plan.Properties {
+		prop, err := s.client.GetProperty(ctx, name, plan.Domain)
+		if err != nil {
+			return nil, fmt.Errorf("reading property %s: %w", name, err)
+		}
+		source, target := trafficShiftTargets(prop, plan.SourceID, plan.TargetID)
+		if source == nil || target == nil {
+			return nil, fmt.Errorf("property %s must have traffic targets for both data center %d and %d", name, plan.SourceID, plan.TargetID)
+		}
+		if source.Weight+target.Weight <= 0 {
+			return nil, fmt.Errorf("property %s has no weight to shift on data centers %d and %d", name, plan.SourceID, plan.TargetID)
+		}
+		original = append(original, trafficShiftWeights{
+			Property:      name,
+			SourceWeight:  source.Weight,
+			SourceEnabled: source.Enabled,
+			TargetWeight:  target.Weight,
+			TargetEnabled: target.Enabled,
+		})
+	}
+
+	for i, step := range plan.Steps {
+		s.logger.Infof("Step %d: shifting %g%% of the traffic to data center %d", i+1, step, plan.TargetID)
+		err := s.step(ctx, plan, original, step)
+		if err == nil {
+			continue
+		}
+		err = fmt.Errorf("step %d (%g%%): %w", i+1, step, err)
+		if !plan.RevertOnFailure {
+			return nil, err
+		}
+		// The step may have failed because ctx is done, so the revert gets a context of its own
+		revertCtx, cancel := context.WithTimeout(session.ContextWithOptions(context.Background(), session.WithContextLog(s.logger)), trafficShiftRevertTimeout)
+		defer cancel()
+		if revertErr := s.revert(revertCtx, plan, original); revertErr != nil {
+			return nil, fmt.Errorf("%s; reverting failed: %w", err, revertErr)
+		}
+		return nil, fmt.Errorf("%w; original weights were restored", err)
+	}
+	return original, nil
+}
+
+// step applies the weights of a step, then waits for the dwell time and checks the liveness of the target data center
+func (s *trafficShifter) step(ctx context.Context, plan trafficShiftPlan, original []trafficShiftWeights, percentage float64) error {
+	for _, weights := range original {
+		total := weights.SourceWeight + weights.TargetWeight
+		targetWeight := total * percentage / 100
+		if err := s.setWeights(ctx, plan, trafficShiftWeights{
+			Property:      weights.Property,
+			SourceWeight:  total - targetWeight,
+			SourceEnabled: weights.SourceEnabled,
+			TargetWeight:  targetWeight,
+			TargetEnabled: true,
+		}); err != nil {
+			return err
+		}
+	}
+	if err := s.wait(ctx); err != nil {
+		return err
+	}
+
+	start := s.now()
+	if err := s.sleep(ctx, plan.DwellTime); err != nil {
+		return err
+	}
+	if !plan.LivenessCheck {
+		return nil
+	}
+	window := ReportWindow{Start: start.Truncate(time.Minute), End: s.now()}
+	if !window.End.After(window.Start) {
+		window.End = window.Start.Add(time.Minute)
+	}
+	for _, name := range plan.Properties {
+		report, err := s.reports.GetLivenessTestReport(ctx, LivenessTestReportRequest{
+			Domain:       plan.Domain,
+			Property:     name,
+			Window:       window,
+			DatacenterID: plan.TargetID,
+		})
+		if err != nil {
+			return fmt.Errorf("liveness check of property %s: %w", name, err)
+		}
+		if failures := livenessTestFailures(report, plan.TargetID); failures > plan.MaxFailures {
+			return fmt.Errorf("property %s failed %d liveness tests in data center %d, more than the %d allowed",
+				name, failures, plan.TargetID, plan.MaxFailures)
+		}
+	}
+	return nil
+}
+
+// revert restores the weights of all properties to the original ones
+func (s *trafficShifter) revert(ctx context.Context, plan trafficShiftPlan, original []trafficShiftWeights) error {
+	s.logger.Infof("Restoring the original weights of %d properties", len(original))
+	for _, weights := range original {
+		if err := s.setWeights(ctx, plan, weights); err != nil {
+			return err
+		}
+	}
+	return s.wait(ctx)
+}
+
+// setWeights updates the weights of the source and target traffic targets of a property
+func (s *trafficShifter) setWeights(ctx context.Context, plan trafficShiftPlan, weights trafficShiftWeights) error {
+	prop, err := s.client.GetProperty(ctx, weights.Property, plan.Domain)
+	if err != nil {
+		return fmt.Errorf("reading property %s: %w", weights.Property, err)
+	}
+	source, target := trafficShiftTargets(prop, plan.SourceID, plan.TargetID)
+	if source == nil || target == nil {
+		return fmt.Errorf("property %s no longer has traffic targets for both data center %d and %d", weights.Property, plan.SourceID, plan.TargetID)
+	}
+	source.Weight, source.Enabled = weights.SourceWeight, weights.SourceEnabled
+	target.Weight, target.Enabled = weights.TargetWeight, weights.TargetEnabled
+
+	s.logger.Debugf("Setting weights of property [%s] to %g (data center %d) and %g (data center %d)",
+		weights.Property, source.Weight, plan.SourceID, target.Weight, plan.TargetID)
+	status, err := s.client.UpdateProperty(ctx, prop, plan.Domain)
+	if err != nil {
+		return fmt.Errorf("updating property %s: %w", weights.Property, err)
+	}
+	if status.PropagationStatus == "DENIED" {
+		return fmt.Errorf("updating property %s: %s", weights.Property, status.Message)
+	}
+	return nil
+}
+
+// trafficShiftTargets returns the traffic targets of the source and target data centers of a property
+func trafficShiftTargets(prop *gtm.Property, sourceID, targetID int) (*gtm.TrafficTarget, *gtm.TrafficTarget) {
+	var source, target *gtm.TrafficTarget
+	for _, tt := range prop.TrafficTargets {
+		switch tt.DatacenterId {
+		case sourceID:
+			source = tt
+		case targetID:
+			target = tt
+		}
+	}
+	return source, target
+}
+
+// livenessTestFailures counts the failed liveness tests of a data center in a report
+func livenessTestFailures(report *LivenessTestReport, datacenterID int) int {
+	failures := 0
+	for _, row := range report.DataRows {
+		for _, dc := range row.Datacenters {
+			if dc.DatacenterID == datacenterID && dc.ErrorCode != 0 {
+				failures++
+			}
+		}
+	}
+	return failures
+}
+
+func flattenTrafficShiftWeights(weights []trafficShiftWeights) []interface{} {
+	result := make([]interface{}, 0, len(weights))
+	for _, w := range weights {
+		result = append(result, map[string]interface{}{
+			"property":       w.Property,
+			"source_weight":  w.SourceWeight,
+			"source_enabled": w.SourceEnabled,
+			"target_weight":  w.TargetWeight,
+			"target_enabled": w.TargetEnabled,
+		})
+	}
+	return result
+}
+
+func expandTrafficShiftWeights(d *schema.ResourceData) ([]trafficShiftWeights, error) {
+	list, err := tools.GetListValue("original_weights", d)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]trafficShiftWeights, 0, len(list))
+	for _, item := range list {
+		w := item.(map[string]interface{})
+		result = append(result, trafficShiftWeights{
+			Property:      w["property"].(string),
+			SourceWeight:  w["source_weight"].(float64),
+			SourceEnabled: w["source_enabled"].(bool),
+			TargetWeight:  w["target_weight"].(float64),
+			TargetEnabled: w["target_enabled"].(bool),
+		})
+	}
+	return result, nil
+}
+
+// sleepContext waits for the given time unless the context is done first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
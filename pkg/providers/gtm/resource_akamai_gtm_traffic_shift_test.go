@@ -0,0 +1,360 @@
+package gtm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v4/pkg/gtm"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func trafficShiftProperty() *gtm.Property {
+	return &gtm.Property{
+		Name: "test_property",
+		Type: "weighted-round-robin",
+		TrafficTargets: []*gtm.TrafficTarget{
+			{DatacenterId: 3131, Enabled: true, Weight: 100},
+			{DatacenterId: 3132, Enabled: false, Weight: 0},
+		},
+	}
+}
+
+// mockTrafficShiftUpdate expects an update of test_property with the given source and target weights
+func mockTrafficShiftUpdate(client *gtm.Mock, source, target float64, status *gtm.ResponseStatus) *mock.Call {
+	return client.On("UpdateProperty",
+		mock.Anything, // ctx is irrelevant for this test
+		mock.MatchedBy(func(p *gtm.Property) bool {
+			return p.TrafficTargets[0].Weight == source && p.TrafficTargets[1].Weight == target
+		}),
+		gtmTestDomain,
+	).Return(status, nil).Once()
+}
+
+func TestTrafficShifterRun(t *testing.T) {
+	now := time.Date(2023, 3, 1, 10, 0, 30, 0, time.UTC)
+	plan := trafficShiftPlan{
+		Domain:          gtmTestDomain,
+		Properties:      []string{"test_property"},
+		SourceID:        3131,
+		TargetID:        3132,
+		Steps:           []float64{25, 100},
+		DwellTime:       time.Minute,
+		LivenessCheck:   true,
+		RevertOnFailure: true,
+	}
+	livenessRequest := LivenessTestReportRequest{
+		Domain:       gtmTestDomain,
+		Property:     "test_property",
+		Window:       ReportWindow{Start: now.Truncate(time.Minute), End: now},
+		DatacenterID: 3132,
+	}
+	healthy := &LivenessTestReport{DataRows: []LivenessTestReportRow{
+		{Datacenters: []LivenessTestDatacenterData{{DatacenterID: 3132}, {DatacenterID: 3131, ErrorCode: 3101}}},
+	}}
+	failing := &LivenessTestReport{DataRows: []LivenessTestReportRow{
+		{Datacenters: []LivenessTestDatacenterData{{DatacenterID: 3132, ErrorCode: 3101}}},
+	}}
+
+	tests := map[string]struct {
+		plan      func(trafficShiftPlan) trafficShiftPlan
+		init      func(*gtm.Mock, *mockReports)
+		expected  []trafficShiftWeights
+		withError *regexp.Regexp
+	}{
+		"all steps applied": {
+			init: func(client *gtm.Mock, reports *mockReports) {
+				client.On("GetProperty", mock.Anything, "test_property", gtmTestDomain).Return(trafficShiftProperty(), nil)
+				mockTrafficShiftUpdate(client, 75, 25, &completeResponseStatus)
+				mockTrafficShiftUpdate(client, 0, 100, &completeResponseStatus)
+				reports.On("GetLivenessTestReport", mock.Anything, livenessRequest).Return(healthy, nil).Twice()
+			},
+			expected: []trafficShiftWeights{
+				{Property: "test_property", SourceWeight: 100, SourceEnabled: true},
+			},
+		},
+		"liveness failure reverts": {
+			init: func(client *gtm.Mock, reports *mockReports) {
+				client.On("GetProperty", mock.Anything, "test_property", gtmTestDomain).Return(trafficShiftProperty(), nil)
+				mockTrafficShiftUpdate(client, 75, 25, &completeResponseStatus)
+				mockTrafficShiftUpdate(client, 100, 0, &completeResponseStatus)
+				reports.On("GetLivenessTestReport", mock.Anything, livenessRequest).Return(failing, nil).Once()
+			},
+			withError: regexp.MustCompile(`step 1 \(25%\): property test_property failed 1 liveness tests in data center 3132, more than the 0 allowed; original weights were restored`),
+		},
+		"tolerated failures": {
+			plan: func(p trafficShiftPlan) trafficShiftPlan {
+				p.MaxFailures = 1
+				p.Steps = []float64{50}
+				return p
+			},
+			init: func(client *gtm.Mock, reports *mockReports) {
+				client.On("GetProperty", mock.Anything, "test_property", gtmTestDomain).Return(trafficShiftProperty(), nil)
+				mockTrafficShiftUpdate(client, 50, 50, &completeResponseStatus)
+				reports.On("GetLivenessTestReport", mock.Anything, livenessRequest).Return(failing, nil).Once()
+			},
+			expected: []trafficShiftWeights{
+				{Property: "test_property", SourceWeight: 100, SourceEnabled: true},
+			},
+		},
+		"denied update without revert": {
+			plan: func(p trafficShiftPlan) trafficShiftPlan {
+				p.RevertOnFailure = false
+				return p
+			},
+			init: func(client *gtm.Mock, _ *mockReports) {
+				client.On("GetProperty", mock.Anything, "test_property", gtmTestDomain).Return(trafficShiftProperty(), nil)
+				mockTrafficShiftUpdate(client, 75, 25, &deniedResponseStatus)
+			},
+			withError: regexp.MustCompile(`step 1 \(25%\): updating property test_property: ` + deniedResponseStatus.Message + `$`),
+		},
+		"missing target data center": {
+			plan: func(p trafficShiftPlan) trafficShiftPlan {
+				p.TargetID = 3133
+				return p
+			},
+			init: func(client *gtm.Mock, _ *mockReports) {
+				client.On("GetProperty", mock.Anything, "test_property", gtmTestDomain).Return(trafficShiftProperty(), nil)
+			},
+			withError: regexp.MustCompile(`property test_property must have traffic targets for both data center 3131 and 3133`),
+		},
+		"property not found": {
+			init: func(client *gtm.Mock, _ *mockReports) {
+				client.On("GetProperty", mock.Anything, "test_property", gtmTestDomain).Return(nil, &gtm.Error{StatusCode: 404})
+			},
+			withError: regexp.MustCompile(`reading property test_property`),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &gtm.Mock{}
+			reports := &mockReports{}
+			test.init(client, reports)
+			p := plan
+			if test.plan != nil {
+				p = test.plan(plan)
+			}
+			var slept time.Duration
+			shifter := &trafficShifter{
+				client:  client,
+				reports: reports,
+				logger:  log.Log,
+				wait:    func(context.Context) error { return nil },
+				sleep: func(_ context.Context, d time.Duration) error {
+					slept += d
+					return nil
+				},
+				now: func() time.Time { return now },
+			}
+
+			original, err := shifter.run(context.Background(), p)
+			client.AssertExpectations(t)
+			reports.AssertExpectations(t)
+			if test.withError != nil {
+				require.Error(t, err)
+				assert.Regexp(t, test.withError, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, original)
+			assert.Equal(t, time.Duration(len(p.Steps))*p.DwellTime, slept)
+		})
+	}
+}
+
+func TestTrafficShifterRunRevertsAfterTimeout(t *testing.T) {
+	plan := trafficShiftPlan{
+		Domain:          gtmTestDomain,
+		Properties:      []string{"test_property"},
+		SourceID:        3131,
+		TargetID:        3132,
+		Steps:           []float64{25, 100},
+		DwellTime:       time.Minute,
+		RevertOnFailure: true,
+	}
+	assert.Equal(t, 2*(time.Minute+trafficShiftStepTimeout), plan.timeout())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client := &gtm.Mock{}
+	client.On("GetProperty", mock.Anything, "test_property", gtmTestDomain).Return(trafficShiftProperty(), nil)
+	mockTrafficShiftUpdate(client, 75, 25, &completeResponseStatus)
+	client.On("UpdateProperty",
+		mock.MatchedBy(func(ctx context.Context) bool { return ctx.Err() == nil }),
+		mock.MatchedBy(func(p *gtm.Property) bool {
+			return p.TrafficTargets[0].Weight == 100 && p.TrafficTargets[1].Weight == 0
+		}),
+		gtmTestDomain,
+	).Return(&completeResponseStatus, nil).Once()
+
+	shifter := &trafficShifter{
+		client:  client,
+		reports: &mockReports{},
+		logger:  log.Log,
+		wait:    func(ctx context.Context) error { return ctx.Err() },
+		sleep: func(ctx context.Context, _ time.Duration) error {
+			// the deadline of the shift passes during the dwell time
+			cancel()
+			return ctx.Err()
+		},
+		now: time.Now,
+	}
+
+	_, err := shifter.run(ctx, plan)
+	require.Error(t, err)
+	assert.Equal(t, "step 1 (25%): context canceled; original weights were restored", err.Error())
+	client.AssertExpectations(t)
+}
+
+func TestLivenessTestFailures(t *testing.T) {
+	report := &LivenessTestReport{DataRows: []LivenessTestReportRow{
+		{Datacenters: []LivenessTestDatacenterData{{DatacenterID: 3131, ErrorCode: 3101}, {DatacenterID: 3132, ErrorCode: 3101}}},
+		{Datacenters: []LivenessTestDatacenterData{{DatacenterID: 3132}}},
+		{Datacenters: []LivenessTestDatacenterData{{DatacenterID: 3132, ErrorCode: 3102}}},
+	}}
+	assert.Equal(t, 2, livenessTestFailures(report, 3132))
+	assert.Equal(t, 1, livenessTestFailures(report, 3131))
+	assert.Equal(t, 0, livenessTestFailures(report, 3133))
+}
+
+func TestValidateTrafficShiftSteps(t *testing.T) {
+	tests := map[string]struct {
+		source, target int
+		steps          []interface{}
+		withError      *regexp.Regexp
+	}{
+		"increasing steps": {
+			source: 3131, target: 3132,
+			steps: []interface{}{10, 50, 100},
+		},
+		"same data center": {
+			source: 3131, target: 3131,
+			steps:     []interface{}{100},
+			withError: regexp.MustCompile(`source_datacenter_id and target_datacenter_id must differ`),
+		},
+		"decreasing steps": {
+			source: 3131, target: 3132,
+			steps:     []interface{}{50, 20},
+			withError: regexp.MustCompile(`steps.1: steps must be increasing percentages above 0, got 20 after 50`),
+		},
+		"zero step": {
+			source: 3131, target: 3132,
+			steps:     []interface{}{0, 100},
+			withError: regexp.MustCompile(`steps.0: steps must be increasing percentages above 0`),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res := resourceGTMv1TrafficShift()
+			_, err := (&schema.Resource{Schema: res.Schema, CustomizeDiff: res.CustomizeDiff}).Diff(
+				context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+					"domain":               gtmTestDomain,
+					"properties":           []interface{}{"test_property"},
+					"source_datacenter_id": test.source,
+					"target_datacenter_id": test.target,
+					"steps":                test.steps,
+				}), nil)
+			if test.withError != nil {
+				require.Error(t, err)
+				assert.Regexp(t, test.withError, err.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateTrafficShiftDwellTime(t *testing.T) {
+	for value, valid := range map[string]bool{
+		"5m":    true,
+		"1h30m": true,
+		"0s":    true,
+		"-1m":   false,
+		"5":     false,
+		"ten":   false,
+	} {
+		t.Run(value, func(t *testing.T) {
+			diags := validateTrafficShiftDwellTime(value, nil)
+			assert.Equal(t, !valid, diags.HasError())
+		})
+	}
+}
+
+func TestResGtmTrafficShift(t *testing.T) {
+	t.Run("create and revert on destroy", func(t *testing.T) {
+		client := &gtm.Mock{}
+		reports := &mockReports{}
+
+		client.On("GetProperty",
+			mock.Anything, // ctx is irrelevant for this test
+			"test_property",
+			gtmTestDomain,
+		).Return(trafficShiftProperty(), nil)
+		mockTrafficShiftUpdate(client, 50, 50, &completeResponseStatus)
+		mockTrafficShiftUpdate(client, 0, 100, &completeResponseStatus)
+		mockTrafficShiftUpdate(client, 100, 0, &completeResponseStatus)
+		reports.On("GetLivenessTestReport",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("LivenessTestReportRequest"),
+		).Return(&LivenessTestReport{}, nil)
+
+		useClients(client, reports, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResGtmTrafficShift/create.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_gtm_traffic_shift.tfexample_shift", "id", fmt.Sprintf("%s:3131:3132", gtmTestDomain)),
+							resource.TestCheckResourceAttr("akamai_gtm_traffic_shift.tfexample_shift", "status", trafficShiftStatusComplete),
+							resource.TestCheckResourceAttr("akamai_gtm_traffic_shift.tfexample_shift", "completed_steps", "2"),
+							resource.TestCheckResourceAttr("akamai_gtm_traffic_shift.tfexample_shift", "original_weights.0.source_weight", "100"),
+							resource.TestCheckResourceAttr("akamai_gtm_traffic_shift.tfexample_shift", "original_weights.0.target_weight", "0"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("liveness failure", func(t *testing.T) {
+		client := &gtm.Mock{}
+		reports := &mockReports{}
+
+		client.On("GetProperty",
+			mock.Anything, // ctx is irrelevant for this test
+			"test_property",
+			gtmTestDomain,
+		).Return(trafficShiftProperty(), nil)
+		mockTrafficShiftUpdate(client, 50, 50, &completeResponseStatus)
+		mockTrafficShiftUpdate(client, 100, 0, &completeResponseStatus)
+		reports.On("GetLivenessTestReport",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("LivenessTestReportRequest"),
+		).Return(nil, errors.New("report unavailable"))
+
+		useClients(client, reports, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProviderFactories: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config:      loadFixtureString("testdata/TestResGtmTrafficShift/create.tf"),
+						ExpectError: regexp.MustCompile(`step 1 \(50%\): liveness check of property test_property: report unavailable; original weights were restored`),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
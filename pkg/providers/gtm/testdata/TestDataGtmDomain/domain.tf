@@ -0,0 +1,7 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_gtm_domain" "test" {
+  name = "gtm_terra_testdomain.akadns.net"
+}
@@ -0,0 +1,8 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_gtm_asmap_assignments" "test" {
+  domain   = "gtm_terra_testdomain.akadns.net"
+  map_name = "tfexample_as_1"
+}
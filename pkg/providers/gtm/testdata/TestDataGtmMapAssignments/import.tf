@@ -0,0 +1,18 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_gtm_asmap_assignments" "test" {
+  content = <<-EOT
+    datacenter_id,nickname,as_number
+    3132,tfexample_dc_2,12229
+    3131,tfexample_dc_1,64512-64514
+  EOT
+}
+
+data "akamai_gtm_geomap_assignments" "test" {
+  format  = "json"
+  content = jsonencode([
+    { datacenter_id = 3131, nickname = "tfexample_dc_1", countries = ["nl", "be"] },
+  ])
+}
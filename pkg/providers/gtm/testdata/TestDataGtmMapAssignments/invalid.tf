@@ -0,0 +1,10 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_gtm_geomap_assignments" "test" {
+  content = <<-EOT
+    datacenter_id,nickname,country
+    3131,tfexample_dc_1,XX
+  EOT
+}
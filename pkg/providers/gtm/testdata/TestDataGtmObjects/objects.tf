@@ -0,0 +1,35 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_gtm_property" "test" {
+  domain = "gtm_terra_testdomain.akadns.net"
+  name   = "test_property"
+}
+
+data "akamai_gtm_properties" "test" {
+  domain = "gtm_terra_testdomain.akadns.net"
+}
+
+data "akamai_gtm_datacenters" "test" {
+  domain = "gtm_terra_testdomain.akadns.net"
+}
+
+data "akamai_gtm_resources" "test" {
+  domain = "gtm_terra_testdomain.akadns.net"
+}
+
+data "akamai_gtm_geomap" "test" {
+  domain = "gtm_terra_testdomain.akadns.net"
+  name   = "tfexample_geomap_1"
+}
+
+data "akamai_gtm_asmap" "test" {
+  domain = "gtm_terra_testdomain.akadns.net"
+  name   = "tfexample_as_1"
+}
+
+data "akamai_gtm_cidrmap" "test" {
+  domain = "gtm_terra_testdomain.akadns.net"
+  name   = "tfexample_cidrmap_1"
+}
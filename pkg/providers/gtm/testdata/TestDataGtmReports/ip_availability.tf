@@ -0,0 +1,11 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_gtm_ip_availability" "test" {
+  domain      = "gtm_terra_testdomain.akadns.net"
+  property    = "test_property"
+  start       = "2023-03-01T10:00:00Z"
+  end         = "2023-03-01T10:30:00Z"
+  most_recent = true
+}
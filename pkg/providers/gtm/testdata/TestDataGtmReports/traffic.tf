@@ -0,0 +1,10 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_gtm_traffic_report" "test" {
+  domain   = "gtm_terra_testdomain.akadns.net"
+  property = "test_property"
+  start    = "2023-03-01T10:00:00Z"
+  end      = "2023-03-01T10:30:00Z"
+}
@@ -0,0 +1,23 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+resource "akamai_gtm_domain_clone" "prod" {
+  source_domain    = "gtm_terra_testdomain.akadns.net"
+  target_domain    = "gtm_terra_testdomain_prod.akadns.net"
+  wait_on_complete = false
+
+  substitution {
+    datacenter_nicknames = {
+      stage_ams = "prod_ams"
+      stage_fra = "prod_fra"
+    }
+    server_ips = {
+      "10.0.0.1" = "192.0.2.1"
+      "10.0.1.1" = "192.0.2.2"
+    }
+    handout_cnames = {
+      "ams.stage.example.com" = "ams.example.com"
+    }
+  }
+}
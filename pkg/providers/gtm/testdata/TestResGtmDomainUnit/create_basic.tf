@@ -0,0 +1,30 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+resource "akamai_gtm_domain_unit" "tfexample_unit" {
+  domain  = "gtm_terra_testdomain.akadns.net"
+  comment = "Staged by unit"
+
+  datacenter {
+    datacenter_id = 3200
+    nickname      = "unit_dc"
+    city          = "Amsterdam"
+    country       = "NL"
+    continent     = "EU"
+  }
+
+  property {
+    name                   = "unit_property"
+    type                   = "weighted-round-robin"
+    score_aggregation_type = "median"
+    handout_limit          = 5
+    handout_mode           = "normal"
+    traffic_target {
+      datacenter_id = 3200
+      enabled       = true
+      weight        = 100
+      servers       = ["1.2.3.9"]
+    }
+  }
+}
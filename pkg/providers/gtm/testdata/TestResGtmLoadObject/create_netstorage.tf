@@ -0,0 +1,18 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+resource "akamai_gtm_load_object" "tfexample_load_1" {
+  path          = "/load/dc1.xml"
+  server_name   = "origin1.example.com"
+  resource_name = "cpu"
+  current_load  = 20
+  target_load   = 60
+
+  netstorage {
+    endpoint = "%s"
+    cp_code  = 123456
+    key_name = "upload1"
+    key      = "secret"
+  }
+}
@@ -0,0 +1,22 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+resource "akamai_gtm_load_object" "tfexample_load_1" {
+  path          = "/load/dc1.xml"
+  server_name   = "origin1.example.com"
+  resource_name = "bandwidth"
+  current_load  = 40
+  target_load   = 70
+  max_load      = 90
+  timestamp     = 1677664800
+
+  origin {
+    url = "%s"
+    headers = {
+      Authorization = "Bearer token"
+    }
+  }
+  archive_versions  = true
+  delete_on_destroy = true
+}
@@ -0,0 +1,18 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+resource "akamai_gtm_traffic_shift" "tfexample_shift" {
+  domain               = "gtm_terra_testdomain.akadns.net"
+  properties           = ["test_property"]
+  source_datacenter_id = 3131
+  target_datacenter_id = 3132
+  steps                = [50, 100]
+  dwell_time           = "0s"
+  wait_on_complete     = false
+  revert_on_destroy    = true
+
+  liveness_check {
+    max_failures = 0
+  }
+}